@@ -2,15 +2,15 @@ package main
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
 
 	"github.com/dreamer-zq/DKNet/internal/api"
 	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
@@ -20,16 +20,20 @@ import (
 // Global configuration
 var (
 	// Global variables for connection management
-	grpcConn   *grpc.ClientConn
+	grpcConn   *api.ClientConnPool
 	httpClient *http.Client
 	tssClient  tssv1.TSSServiceClient
 
 	// Command line flags
 	serverAddr   string
 	useGRPC      bool
+	grpcConns    int
 	timeout      time.Duration
 	outputFormat string
 
+	// operationTimeout is sent to the server as the requested timeout of started operations
+	operationTimeout time.Duration
+
 	// Authentication flags
 	jwtToken string
 )
@@ -69,8 +73,11 @@ Examples:
 func main() {
 	rootCmd.PersistentFlags().StringVarP(&serverAddr, "server", "s", "localhost:8080", "Server address (host:port)")
 	rootCmd.PersistentFlags().BoolVarP(&useGRPC, "grpc", "g", false, "Use gRPC instead of HTTP")
+	rootCmd.PersistentFlags().IntVar(&grpcConns, "grpc-conns", 1, "Number of gRPC connections to spread requests over")
 	rootCmd.PersistentFlags().DurationVarP(&timeout, "timeout", "t", 30*time.Second, "Request timeout")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text|json)")
+	rootCmd.PersistentFlags().DurationVar(&operationTimeout, "operation-timeout", 0,
+		"Requested timeout of started operations, the server's default applies if unset or out of its bounds")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text|json|yaml)")
 
 	// Authentication flags
 	rootCmd.PersistentFlags().StringVar(&jwtToken, "token", "", "JWT token for authentication (can also use DKNET_JWT_TOKEN env var)")
@@ -79,8 +86,18 @@ func main() {
 		createKeygenCommand(),
 		createSignCommand(),
 		createReshareCommand(),
+		createRefreshCommand(),
 		createGetOperationCommand(),
+		createListOperationsCommand(),
 		createGetKeyMetadataCommand(),
+		createHasKeyCommand(),
+		createKeyHoldersCommand(),
+		createKeyPolicyCommand(),
+		createNetworkCommand(),
+		createMaintenanceCommand(),
+		createNodeInfoCommand(),
+		createStatusCommand(),
+		createVerifyLocalCommand(),
 		version.NewCommand(),
 	)
 
@@ -90,6 +107,14 @@ func main() {
 	}
 }
 
+// outputStructured prints data in the selected structured output format (json or yaml)
+func outputStructured(data interface{}) error {
+	if outputFormat == outputFormatYAML {
+		return outputYAML(data)
+	}
+	return outputJSON(data)
+}
+
 func outputJSON(data interface{}) error {
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -99,15 +124,102 @@ func outputJSON(data interface{}) error {
 	return nil
 }
 
+// outputYAML prints data as YAML. Fields are named as in the JSON output,
+// timestamps are rendered as RFC 3339 strings.
+func outputYAML(data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(jsonData, &generic); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	yamlData, err := yaml.Marshal(formatTimestamps(generic))
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	fmt.Print(string(yamlData))
+	return nil
+}
+
+// formatTimestamps replaces JSON encoded protobuf timestamps ({"seconds": s, "nanos": n})
+// in fields named *_at with RFC 3339 strings
+func formatTimestamps(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if ts, ok := value.(map[string]interface{}); ok && strings.HasSuffix(key, "_at") {
+				if t, ok := parseTimestamp(ts); ok {
+					v[key] = t.Format(time.RFC3339Nano)
+					continue
+				}
+			}
+			v[key] = formatTimestamps(value)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = formatTimestamps(item)
+		}
+	}
+	return v
+}
+
+// parseTimestamp converts a JSON encoded protobuf timestamp to a UTC time
+func parseTimestamp(ts map[string]interface{}) (time.Time, bool) {
+	var seconds, nanos float64
+	for key, value := range ts {
+		n, ok := value.(float64)
+		if !ok {
+			return time.Time{}, false
+		}
+		switch key {
+		case "seconds":
+			seconds = n
+		case "nanos":
+			nanos = n
+		default:
+			return time.Time{}, false
+		}
+	}
+	return time.Unix(int64(seconds), int64(nanos)).UTC(), true
+}
+
 func createKeygenCommand() *cobra.Command {
 	var threshold int
 	var participants []string
+	var participantsFile string
+	var alias string
+	var chainFamily string
+	var labels map[string]string
+	var interactive bool
+	var policy keyPolicyFlags
 
 	cmd := &cobra.Command{
 		Use:   "keygen",
 		Short: "Start a key generation operation",
-		Long:  "Start a new threshold key generation operation with specified parameters.",
+		Long: `Start a new threshold key generation operation with specified parameters.
+
+With --interactive the participants and threshold are chosen from the nodes
+known to the server instead of being passed as flags. Large participant lists
+can be read from a file with --participants-file.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			participantsGiven := cmd.Flags().Changed("participants") || participantsFile != ""
+			if interactive {
+				if cmd.Flags().Changed("threshold") || participantsGiven {
+					return fmt.Errorf("--interactive cannot be combined with --threshold or --participants")
+				}
+				return runKeygenWizard(alias, chainFamily, labels, policy.policy())
+			}
+			if !cmd.Flags().Changed("threshold") || !participantsGiven {
+				return fmt.Errorf("--threshold and --participants (or --participants-file) are required unless --interactive is set")
+			}
+			participants, err := collectParticipants(participants, participantsFile)
+			if err != nil {
+				return err
+			}
 			if threshold < 0 {
 				return fmt.Errorf("threshold must be non-negative")
 			}
@@ -122,22 +234,21 @@ func createKeygenCommand() *cobra.Command {
 			defer cancel()
 
 			if useGRPC {
-				return keygenGRPC(ctx, threshold, participants)
+				return keygenGRPC(ctx, threshold, participants, alias, chainFamily, labels, policy.policy())
 			}
-			return keygenHTTP(ctx, threshold, participants)
+			return keygenHTTP(ctx, threshold, participants, alias, chainFamily, labels, policy.policy())
 		},
 	}
 
 	cmd.Flags().IntVarP(&threshold, "threshold", "r", 0,
 		"Fault tolerance threshold (t in (t+1)-of-n scheme). Max number of parties that can fail. Minimum signers required = t+1 (required)")
 	cmd.Flags().StringSliceVarP(&participants, "participants", "P", nil, "List of participant IDs (required)")
-
-	if err := cmd.MarkFlagRequired("threshold"); err != nil {
-		panic(fmt.Sprintf("Failed to mark threshold flag as required: %v", err))
-	}
-	if err := cmd.MarkFlagRequired("participants"); err != nil {
-		panic(fmt.Sprintf("Failed to mark participants flag as required: %v", err))
-	}
+	cmd.Flags().StringVar(&participantsFile, "participants-file", "", participantsFileUsage)
+	cmd.Flags().StringVar(&alias, "alias", "", "Optional human-readable alias usable instead of the key ID")
+	cmd.Flags().StringVar(&chainFamily, "chain-family", "", "Chain family of the key: ethereum (default) or bitcoin")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Choose participants and threshold interactively")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Labels to filter operations by later, e.g. team=payments")
+	policy.register(cmd)
 
 	return cmd
 }
@@ -145,7 +256,14 @@ func createKeygenCommand() *cobra.Command {
 func createSignCommand() *cobra.Command {
 	var message, keyID string
 	var messageHex bool
+	var messageEncoding string
 	var participants []string
+	var participantsFile string
+	var chainID uint64
+	var metadata map[string]string
+	var derivationPath string
+	var labels map[string]string
+	var contextBound bool
 
 	cmd := &cobra.Command{
 		Use:   "sign",
@@ -158,36 +276,61 @@ func createSignCommand() *cobra.Command {
 			if keyID == "" {
 				return fmt.Errorf("key-id is required")
 			}
+			participants, err := collectParticipants(participants, participantsFile)
+			if err != nil {
+				return err
+			}
 			if len(participants) == 0 {
 				return fmt.Errorf("participants list cannot be empty")
 			}
 
-			var messageBytes []byte
-			var err error
-
 			if messageHex {
-				messageBytes, err = hex.DecodeString(message)
-				if err != nil {
-					return fmt.Errorf("invalid hex message: %w", err)
+				if cmd.Flags().Changed("message-encoding") && messageEncoding != api.MessageEncodingHex {
+					return fmt.Errorf("--hex cannot be combined with --message-encoding %s", messageEncoding)
 				}
-			} else {
-				messageBytes = []byte(message)
+				messageEncoding = api.MessageEncodingHex
+			}
+			messageBytes, err := api.DecodeMessage(message, messageEncoding)
+			if err != nil {
+				return err
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), timeout)
 			defer cancel()
 
+			req := &tssv1.StartSigningRequest{
+				Message:        messageBytes,
+				KeyId:          keyID,
+				Participants:   participants,
+				Metadata:       metadata,
+				DerivationPath: derivationPath,
+				Labels:         labels,
+				ContextBound:   contextBound,
+			}
+			if chainID != 0 {
+				req.ChainId = &chainID
+			}
+
 			if useGRPC {
-				return signGRPC(ctx, messageBytes, keyID, participants)
+				return signGRPC(ctx, req)
 			}
-			return signHTTP(ctx, messageBytes, keyID, participants)
+			return signHTTP(ctx, req)
 		},
 	}
 
 	cmd.Flags().StringVarP(&message, "message", "m", "", "Message to sign (required)")
-	cmd.Flags().StringVarP(&keyID, "key-id", "k", "", "Key ID to use for signing (required)")
-	cmd.Flags().BoolVar(&messageHex, "hex", false, "Treat message as hex string")
+	cmd.Flags().StringVarP(&keyID, "key-id", "k", "", "Key ID or key alias to use for signing (required)")
+	cmd.Flags().BoolVar(&messageHex, "hex", false, "Treat message as hex string, same as --message-encoding hex")
+	cmd.Flags().StringVar(&messageEncoding, "message-encoding", api.MessageEncodingText,
+		"Encoding of the message: text, hex or base64")
 	cmd.Flags().StringSliceVarP(&participants, "participants", "P", nil, "List of participant IDs (required)")
+	cmd.Flags().StringVar(&participantsFile, "participants-file", "", participantsFileUsage)
+	cmd.Flags().Uint64Var(&chainID, "chain-id", 0, "EIP-155 chain ID used to compute v (default: legacy v=27/28)")
+	cmd.Flags().StringToStringVar(&metadata, "metadata", nil, "Metadata passed to the validation service, e.g. purpose=payout")
+	cmd.Flags().StringVar(&derivationPath, "derivation-path", "", "Non-hardened BIP32 path of the child key to sign with, e.g. m/0/1")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Labels to filter operations by later, e.g. team=payments")
+	cmd.Flags().BoolVar(&contextBound, "context-bound", false,
+		"Sign a digest bound to the key and session, only valid with its signing context")
 
 	if err := cmd.MarkFlagRequired("message"); err != nil {
 		panic(fmt.Sprintf("Failed to mark message flag as required: %v", err))
@@ -195,9 +338,7 @@ func createSignCommand() *cobra.Command {
 	if err := cmd.MarkFlagRequired("key-id"); err != nil {
 		panic(fmt.Sprintf("Failed to mark key-id flag as required: %v", err))
 	}
-	if err := cmd.MarkFlagRequired("participants"); err != nil {
-		panic(fmt.Sprintf("Failed to mark participants flag as required: %v", err))
-	}
+	cmd.MarkFlagsOneRequired("participants", "participants-file")
 
 	return cmd
 }
@@ -206,6 +347,8 @@ func createReshareCommand() *cobra.Command {
 	var keyID string
 	var newThreshold int
 	var newParticipants []string
+	var newParticipantsFile string
+	var labels map[string]string
 
 	cmd := &cobra.Command{
 		Use:   "reshare",
@@ -218,6 +361,10 @@ func createReshareCommand() *cobra.Command {
 			if newThreshold < 0 {
 				return fmt.Errorf("new-threshold must be a non-negative integer")
 			}
+			newParticipants, err := collectParticipants(newParticipants, newParticipantsFile)
+			if err != nil {
+				return err
+			}
 			if len(newParticipants) == 0 {
 				return fmt.Errorf("new-participants list cannot be empty")
 			}
@@ -229,16 +376,18 @@ func createReshareCommand() *cobra.Command {
 			defer cancel()
 
 			if useGRPC {
-				return reshareGRPC(ctx, keyID, newThreshold, newParticipants)
+				return reshareGRPC(ctx, keyID, newThreshold, newParticipants, labels)
 			}
-			return reshareHTTP(ctx, keyID, newThreshold, newParticipants)
+			return reshareHTTP(ctx, keyID, newThreshold, newParticipants, labels)
 		},
 	}
 
-	cmd.Flags().StringVarP(&keyID, "key-id", "k", "", "Key ID to reshare (required)")
+	cmd.Flags().StringVarP(&keyID, "key-id", "k", "", "Key ID or key alias to reshare (required)")
 	cmd.Flags().IntVar(&newThreshold, "new-threshold", 0,
 		"New fault tolerance threshold (t in (t+1)-of-n scheme). Max number of parties that can fail. Minimum signers required = t+1 (required)")
 	cmd.Flags().StringSliceVar(&newParticipants, "new-participants", nil, "List of new participant IDs (required)")
+	cmd.Flags().StringVar(&newParticipantsFile, "new-participants-file", "", participantsFileUsage)
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Labels to filter operations by later, e.g. team=payments")
 
 	if err := cmd.MarkFlagRequired("key-id"); err != nil {
 		panic(fmt.Sprintf("Failed to mark key-id flag as required: %v", err))
@@ -246,8 +395,41 @@ func createReshareCommand() *cobra.Command {
 	if err := cmd.MarkFlagRequired("new-threshold"); err != nil {
 		panic(fmt.Sprintf("Failed to mark new-threshold flag as required: %v", err))
 	}
-	if err := cmd.MarkFlagRequired("new-participants"); err != nil {
-		panic(fmt.Sprintf("Failed to mark new-participants flag as required: %v", err))
+	cmd.MarkFlagsOneRequired("new-participants", "new-participants-file")
+
+	return cmd
+}
+
+func createRefreshCommand() *cobra.Command {
+	var keyID string
+	var labels map[string]string
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Refresh the key shares of a key",
+		Long: `Start a resharing to the key's current participants and threshold. Every participant
+receives a fresh share of the same public key, shares from before the refresh can no
+longer be combined with the new ones.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyID == "" {
+				return fmt.Errorf("key-id is required")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return refreshGRPC(ctx, keyID, labels)
+			}
+			return refreshHTTP(ctx, keyID, labels)
+		},
+	}
+
+	cmd.Flags().StringVarP(&keyID, "key-id", "k", "", "Key ID or key alias to refresh (required)")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Labels to filter operations by later, e.g. team=payments")
+
+	if err := cmd.MarkFlagRequired("key-id"); err != nil {
+		panic(fmt.Sprintf("Failed to mark key-id flag as required: %v", err))
 	}
 
 	return cmd
@@ -271,15 +453,63 @@ func createGetOperationCommand() *cobra.Command {
 			return getOperationHTTP(ctx, operationID)
 		},
 	}
+	cmd.AddCommand(createWatchOperationCommand())
+
+	return cmd
+}
+
+func createListOperationsCommand() *cobra.Command {
+	var (
+		selector  map[string]string
+		pageSize  int
+		pageToken string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "operations [operation-id...]",
+		Short: "List operations",
+		Long: `List a page of the operations of the node in operation ID order. With --label
+only operations that have every given label are listed. Pass the printed next page
+token to --page-token to list the following page.
+
+Given operation IDs, look up the status of those operations in a single request
+instead. IDs of unknown operations are reported as not found.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && (len(selector) > 0 || pageSize != 0 || pageToken != "") {
+				return fmt.Errorf("--label, --page-size and --page-token cannot be combined with operation IDs")
+			}
+			if pageSize < 0 {
+				return fmt.Errorf("--page-size must not be negative")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if len(args) > 0 {
+				if useGRPC {
+					return getOperationsGRPC(ctx, args)
+				}
+				return getOperationsHTTP(ctx, args)
+			}
+			if useGRPC {
+				return listOperationsGRPC(ctx, selector, pageSize, pageToken)
+			}
+			return listOperationsHTTP(ctx, selector, pageSize, pageToken)
+		},
+	}
+
+	cmd.Flags().StringToStringVar(&selector, "label", nil, "Only list operations with these labels, e.g. team=payments")
+	cmd.Flags().IntVar(&pageSize, "page-size", 0, "Maximum number of operations to list, 0 for the server default")
+	cmd.Flags().StringVar(&pageToken, "page-token", "", "Next page token of a previous listing")
 
 	return cmd
 }
 
 func createGetKeyMetadataCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "key-metadata <key-id>",
+		Use:   "key-metadata <key-id|alias>",
 		Short: "Get key metadata",
-		Long:  "Retrieve the metadata of a specific key by its ID.",
+		Long:  "Retrieve the metadata of a specific key by its ID or alias.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			keyID := args[0]
@@ -297,14 +527,211 @@ func createGetKeyMetadataCommand() *cobra.Command {
 	return cmd
 }
 
+func createKeyPolicyCommand() *cobra.Command {
+	var policy keyPolicyFlags
+
+	cmd := &cobra.Command{
+		Use:   "key-policy <key-id|alias>",
+		Short: "Replace the signing policy of a key",
+		Long: `Replace the signing policy of a key on the node with the policy given by the flags.
+Without any policy flag the policy is removed. Every participant enforces its own copy
+of the policy, run the command against each of them. Requires the admin role when
+authentication is enabled.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return updateKeyPolicyGRPC(ctx, args[0], policy.policy())
+			}
+			return updateKeyPolicyHTTP(ctx, args[0], policy.policy())
+		},
+	}
+	policy.register(cmd)
+
+	return cmd
+}
+
+func createHasKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "has-key <key-id|alias>",
+		Short: "Check whether the node holds a key share",
+		Long: `Check whether the node knows a key and holds a share of it.
+Asking every node of a cluster shows which nodes can take part in signing with the key.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyID := args[0]
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return hasKeyGRPC(ctx, keyID)
+			}
+			return hasKeyHTTP(ctx, keyID)
+		},
+	}
+
+	return cmd
+}
+
+func createKeyHoldersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key-holders <key-id|alias>",
+		Short: "Find the nodes holding a key share",
+		Long: `Ask the nodes reachable from the node which of them hold a share of a key.
+Shows whether enough holders are reachable to sign with the key.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyID := args[0]
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return findKeyHoldersGRPC(ctx, keyID)
+			}
+			return findKeyHoldersHTTP(ctx, keyID)
+		},
+	}
+
+	return cmd
+}
+
+func createNetworkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Network management commands",
+	}
+
+	var nodeID string
+	infoCmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show the known addresses of a node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return getNodeAddressGRPC(ctx, nodeID)
+			}
+			return getNodeAddressHTTP(ctx, nodeID)
+		},
+	}
+	infoCmd.Flags().StringVar(&nodeID, "node-id", "", "Node ID (peer ID) to look up (required)")
+	if err := infoCmd.MarkFlagRequired("node-id"); err != nil {
+		panic(fmt.Sprintf("Failed to mark node-id flag as required: %v", err))
+	}
+	cmd.AddCommand(infoCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List this node and its connected peers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			nodes, err := getNetworkAddresses(ctx)
+			if err != nil {
+				return err
+			}
+			return outputGetNetworkAddressesResponse(&tssv1.GetNetworkAddressesResponse{Nodes: nodes})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "sync",
+		Short: "Trigger an immediate peer discovery round",
+		Long: `Ask the node to run peer discovery now instead of waiting for the periodic ticker.
+Useful after a node joins or the topology changes. Requests are rate limited by the server.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return syncPeersGRPC(ctx)
+			}
+			return syncPeersHTTP(ctx)
+		},
+	})
+
+	return cmd
+}
+
+func createMaintenanceCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "maintenance <on|off>",
+		Short: "Pause or resume accepting new operations on the node",
+		Long: `Turn maintenance mode of the node on or off. While it is on, the node rejects new
+keygen, signing and resharing requests with an unavailable error. Running operations finish,
+operations started by other nodes still run here, and operations can still be queried.
+The mode is stored with the node's data and survives a restart.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"on", "off"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var enabled bool
+			switch args[0] {
+			case "on":
+				enabled = true
+			case "off":
+			default:
+				return fmt.Errorf("invalid maintenance mode %q, expected on or off", args[0])
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return setMaintenanceModeGRPC(ctx, enabled)
+			}
+			return setMaintenanceModeHTTP(ctx, enabled)
+		},
+	}
+}
+
+func createNodeInfoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "node-info",
+		Short: "Show the identity and capabilities of the node",
+		Long: `Show the node ID, moniker, version and listen addresses of the running node, the
+curves, algorithms and chain families it supports, and whether authentication, access
+control, validation, TLS and maintenance mode are enabled.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return getNodeInfoGRPC(ctx)
+			}
+			return getNodeInfoHTTP(ctx)
+		},
+	}
+}
+
 // gRPC implementations
-func keygenGRPC(ctx context.Context, threshold int, participants []string) error {
+func keygenGRPC(
+	ctx context.Context,
+	threshold int,
+	participants []string,
+	alias, chainFamily string,
+	labels map[string]string,
+	policy *tssv1.KeyPolicy,
+) error {
 	// Add authentication to context
 	ctx = addAuthToContext(ctx)
 
 	req := &tssv1.StartKeygenRequest{
 		Threshold:    int32(threshold),
 		Participants: participants,
+		Alias:        alias,
+		ChainFamily:  chainFamily,
+		Labels:       labels,
+		Policy:       policy,
 	}
 
 	resp, err := tssClient.StartKeygen(ctx, req)
@@ -315,16 +742,10 @@ func keygenGRPC(ctx context.Context, threshold int, participants []string) error
 	return outputStartKeygenResponse(resp)
 }
 
-func signGRPC(ctx context.Context, message []byte, keyID string, participants []string) error {
+func signGRPC(ctx context.Context, req *tssv1.StartSigningRequest) error {
 	// Add authentication to context
 	ctx = addAuthToContext(ctx)
 
-	req := &tssv1.StartSigningRequest{
-		Message:      message,
-		KeyId:        keyID,
-		Participants: participants,
-	}
-
 	resp, err := tssClient.StartSigning(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to start signing: %w", err)
@@ -333,7 +754,7 @@ func signGRPC(ctx context.Context, message []byte, keyID string, participants []
 	return outputStartSigningResponse(resp)
 }
 
-func reshareGRPC(ctx context.Context, keyID string, newThreshold int, newParticipants []string) error {
+func reshareGRPC(ctx context.Context, keyID string, newThreshold int, newParticipants []string, labels map[string]string) error {
 	// Add authentication to context
 	ctx = addAuthToContext(ctx)
 
@@ -341,6 +762,7 @@ func reshareGRPC(ctx context.Context, keyID string, newThreshold int, newPartici
 		KeyId:           keyID,
 		NewThreshold:    int32(newThreshold),
 		NewParticipants: newParticipants,
+		Labels:          labels,
 	}
 
 	resp, err := tssClient.StartResharing(ctx, req)
@@ -351,6 +773,23 @@ func reshareGRPC(ctx context.Context, keyID string, newThreshold int, newPartici
 	return outputStartResharingResponse(resp)
 }
 
+func refreshGRPC(ctx context.Context, keyID string, labels map[string]string) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	req := &tssv1.RefreshSharesRequest{
+		KeyId:  keyID,
+		Labels: labels,
+	}
+
+	resp, err := tssClient.RefreshShares(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to start share refresh: %w", err)
+	}
+
+	return outputStartResharingResponse(resp)
+}
+
 func getOperationGRPC(ctx context.Context, operationID string) error {
 	// Add authentication to context
 	ctx = addAuthToContext(ctx)
@@ -367,6 +806,34 @@ func getOperationGRPC(ctx context.Context, operationID string) error {
 	return outputGetOperationResponse(resp)
 }
 
+func listOperationsGRPC(ctx context.Context, selector map[string]string, pageSize int, pageToken string) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.ListOperations(ctx, &tssv1.ListOperationsRequest{
+		LabelSelector: selector,
+		PageSize:      int32(pageSize),
+		PageToken:     pageToken,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list operations: %w", err)
+	}
+
+	return outputListOperationsResponse(resp)
+}
+
+func getOperationsGRPC(ctx context.Context, operationIDs []string) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.GetOperations(ctx, &tssv1.GetOperationsRequest{OperationIds: operationIDs})
+	if err != nil {
+		return fmt.Errorf("failed to get operations: %w", err)
+	}
+
+	return outputGetOperationsResponse(resp)
+}
+
 func getKeyMetadataGRPC(ctx context.Context, keyID string) error {
 	// Add authentication to context
 	ctx = addAuthToContext(ctx)
@@ -383,6 +850,147 @@ func getKeyMetadataGRPC(ctx context.Context, keyID string) error {
 	return outputGetKeyMetadataResponse(resp)
 }
 
+func updateKeyPolicyGRPC(ctx context.Context, keyID string, policy *tssv1.KeyPolicy) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.UpdateKeyPolicy(ctx, &tssv1.UpdateKeyPolicyRequest{KeyId: keyID, Policy: policy})
+	if err != nil {
+		return fmt.Errorf("failed to update key policy: %w", err)
+	}
+
+	return outputUpdateKeyPolicyResponse(resp)
+}
+
+func hasKeyGRPC(ctx context.Context, keyID string) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.HasKey(ctx, &tssv1.HasKeyRequest{KeyId: keyID})
+	if err != nil {
+		return fmt.Errorf("failed to check key: %w", err)
+	}
+
+	return outputHasKeyResponse(keyID, resp)
+}
+
+func findKeyHoldersGRPC(ctx context.Context, keyID string) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.FindKeyHolders(ctx, &tssv1.FindKeyHoldersRequest{KeyId: keyID})
+	if err != nil {
+		return fmt.Errorf("failed to find key holders: %w", err)
+	}
+
+	return outputFindKeyHoldersResponse(keyID, resp)
+}
+
+func syncPeersGRPC(ctx context.Context) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.SyncPeers(ctx, &tssv1.SyncPeersRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to sync peers: %w", err)
+	}
+
+	return outputSyncPeersResponse(resp)
+}
+
+func syncPeersHTTP(ctx context.Context) error {
+	resp, err := makeHTTPRequest(ctx, "POST", api.FullNetworkSyncPath, nil)
+	if err != nil {
+		return err
+	}
+
+	var syncResp tssv1.SyncPeersResponse
+	if err := parseHTTPResponse(resp, &syncResp); err != nil {
+		return err
+	}
+
+	return outputSyncPeersResponse(&syncResp)
+}
+
+func setMaintenanceModeGRPC(ctx context.Context, enabled bool) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.SetMaintenanceMode(ctx, &tssv1.SetMaintenanceModeRequest{Enabled: enabled})
+	if err != nil {
+		return fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+
+	return outputSetMaintenanceModeResponse(resp)
+}
+
+func setMaintenanceModeHTTP(ctx context.Context, enabled bool) error {
+	resp, err := makeHTTPRequest(ctx, "POST", api.FullMaintenancePath,
+		&tssv1.SetMaintenanceModeRequest{Enabled: enabled})
+	if err != nil {
+		return err
+	}
+
+	var maintenanceResp tssv1.SetMaintenanceModeResponse
+	if err := parseHTTPResponse(resp, &maintenanceResp); err != nil {
+		return err
+	}
+
+	return outputSetMaintenanceModeResponse(&maintenanceResp)
+}
+
+func getNodeInfoGRPC(ctx context.Context) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.GetNodeInfo(ctx, &tssv1.GetNodeInfoRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get node info: %w", err)
+	}
+
+	return outputGetNodeInfoResponse(resp)
+}
+
+func getNodeInfoHTTP(ctx context.Context) error {
+	resp, err := makeHTTPRequest(ctx, "GET", api.FullNodeInfoPath, nil)
+	if err != nil {
+		return err
+	}
+
+	var infoResp tssv1.GetNodeInfoResponse
+	if err := parseHTTPResponse(resp, &infoResp); err != nil {
+		return err
+	}
+
+	return outputGetNodeInfoResponse(&infoResp)
+}
+
+func getNodeAddressGRPC(ctx context.Context, nodeID string) error {
+	// Add authentication to context
+	ctx = addAuthToContext(ctx)
+
+	resp, err := tssClient.GetNodeAddress(ctx, &tssv1.GetNodeAddressRequest{NodeId: nodeID})
+	if err != nil {
+		return fmt.Errorf("failed to get node address: %w", err)
+	}
+
+	return outputGetNodeAddressResponse(resp)
+}
+
+func getNodeAddressHTTP(ctx context.Context, nodeID string) error {
+	resp, err := makeHTTPRequest(ctx, "GET", api.GetNodeAddressPath(nodeID), nil)
+	if err != nil {
+		return err
+	}
+
+	var addrResp tssv1.GetNodeAddressResponse
+	if err := parseHTTPResponse(resp, &addrResp); err != nil {
+		return err
+	}
+
+	return outputGetNodeAddressResponse(&addrResp)
+}
+
 func getKeyMetadataHTTP(ctx context.Context, keyID string) error {
 	req := &tssv1.GetKeyMetadataRequest{
 		KeyId: keyID,
@@ -394,18 +1002,79 @@ func getKeyMetadataHTTP(ctx context.Context, keyID string) error {
 	}
 
 	var opResp tssv1.GetKeyMetadataResponse
-	if err := json.Unmarshal(resp, &opResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	if err := parseHTTPResponse(resp, &opResp); err != nil {
+		return err
 	}
 
 	return outputGetKeyMetadataResponse(&opResp)
 }
 
+func updateKeyPolicyHTTP(ctx context.Context, keyID string, policy *tssv1.KeyPolicy) error {
+	if policy == nil {
+		policy = &tssv1.KeyPolicy{}
+	}
+	resp, err := makeHTTPRequest(ctx, "PUT", api.GetKeyPolicyPath(keyID), policy)
+	if err != nil {
+		return err
+	}
+
+	var policyResp tssv1.UpdateKeyPolicyResponse
+	if err := parseHTTPResponse(resp, &policyResp); err != nil {
+		return err
+	}
+
+	return outputUpdateKeyPolicyResponse(&policyResp)
+}
+
+func hasKeyHTTP(ctx context.Context, keyID string) error {
+	statusCode, header, err := makeHTTPHeadRequest(ctx, api.GetKeyPath(keyID))
+	if err != nil {
+		return err
+	}
+
+	resp := &tssv1.HasKeyResponse{}
+	switch statusCode {
+	case http.StatusOK:
+		resp.Known = true
+		resp.HasShare = header.Get(api.KeyShareHeader) == "true"
+	case http.StatusNotFound:
+	default:
+		return fmt.Errorf("HTTP %d", statusCode)
+	}
+
+	return outputHasKeyResponse(keyID, resp)
+}
+
+func findKeyHoldersHTTP(ctx context.Context, keyID string) error {
+	resp, err := makeHTTPRequest(ctx, "GET", api.GetKeyHoldersPath(keyID), nil)
+	if err != nil {
+		return err
+	}
+
+	var holdersResp tssv1.FindKeyHoldersResponse
+	if err := parseHTTPResponse(resp, &holdersResp); err != nil {
+		return err
+	}
+
+	return outputFindKeyHoldersResponse(keyID, &holdersResp)
+}
+
 // HTTP implementations
-func keygenHTTP(ctx context.Context, threshold int, participants []string) error {
+func keygenHTTP(
+	ctx context.Context,
+	threshold int,
+	participants []string,
+	alias, chainFamily string,
+	labels map[string]string,
+	policy *tssv1.KeyPolicy,
+) error {
 	req := &tssv1.StartKeygenRequest{
 		Threshold:    int32(threshold),
 		Participants: participants,
+		Alias:        alias,
+		ChainFamily:  chainFamily,
+		Labels:       labels,
+		Policy:       policy,
 	}
 
 	resp, err := makeHTTPRequest(ctx, "POST", api.FullKeygenPath, req)
@@ -414,38 +1083,33 @@ func keygenHTTP(ctx context.Context, threshold int, participants []string) error
 	}
 
 	var opResp tssv1.StartKeygenResponse
-	if err := json.Unmarshal(resp, &opResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	if err := parseHTTPResponse(resp, &opResp); err != nil {
+		return err
 	}
 
 	return outputStartKeygenResponse(&opResp)
 }
 
-func signHTTP(ctx context.Context, message []byte, keyID string, participants []string) error {
-	req := &tssv1.StartSigningRequest{
-		Message:      message,
-		KeyId:        keyID,
-		Participants: participants,
-	}
-
+func signHTTP(ctx context.Context, req *tssv1.StartSigningRequest) error {
 	resp, err := makeHTTPRequest(ctx, "POST", api.FullSignPath, req)
 	if err != nil {
 		return err
 	}
 
 	var opResp tssv1.StartSigningResponse
-	if err := json.Unmarshal(resp, &opResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	if err := parseHTTPResponse(resp, &opResp); err != nil {
+		return err
 	}
 
 	return outputStartSigningResponse(&opResp)
 }
 
-func reshareHTTP(ctx context.Context, keyID string, newThreshold int, newParticipants []string) error {
+func reshareHTTP(ctx context.Context, keyID string, newThreshold int, newParticipants []string, labels map[string]string) error {
 	req := &tssv1.StartResharingRequest{
 		KeyId:           keyID,
 		NewThreshold:    int32(newThreshold),
 		NewParticipants: newParticipants,
+		Labels:          labels,
 	}
 
 	resp, err := makeHTTPRequest(ctx, "POST", api.FullResharePath, req)
@@ -454,8 +1118,27 @@ func reshareHTTP(ctx context.Context, keyID string, newThreshold int, newPartici
 	}
 
 	var opResp tssv1.StartResharingResponse
-	if err := json.Unmarshal(resp, &opResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	if err := parseHTTPResponse(resp, &opResp); err != nil {
+		return err
+	}
+
+	return outputStartResharingResponse(&opResp)
+}
+
+func refreshHTTP(ctx context.Context, keyID string, labels map[string]string) error {
+	req := &tssv1.RefreshSharesRequest{
+		KeyId:  keyID,
+		Labels: labels,
+	}
+
+	resp, err := makeHTTPRequest(ctx, "POST", api.FullRefreshPath, req)
+	if err != nil {
+		return err
+	}
+
+	var opResp tssv1.StartResharingResponse
+	if err := parseHTTPResponse(resp, &opResp); err != nil {
+		return err
 	}
 
 	return outputStartResharingResponse(&opResp)
@@ -467,20 +1150,39 @@ func getOperationHTTP(ctx context.Context, operationID string) error {
 		return err
 	}
 
-	// For now, let's output the raw JSON response since there's a format mismatch
-	if outputFormat == "json" {
-		var rawResp map[string]interface{}
-		if err := json.Unmarshal(resp, &rawResp); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
-		}
-		return outputJSON(rawResp)
+	var opResp tssv1.GetOperationResponse
+	if err := parseHTTPResponse(resp, &opResp); err != nil {
+		return err
 	}
 
-	// Parse the raw response for text output
-	var rawResp map[string]interface{}
-	if err := json.Unmarshal(resp, &rawResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	return outputGetOperationResponse(&opResp)
+}
+
+func listOperationsHTTP(ctx context.Context, selector map[string]string, pageSize int, pageToken string) error {
+	resp, err := makeHTTPRequest(ctx, "GET", api.GetListOperationsPath(selector, pageSize, pageToken), nil)
+	if err != nil {
+		return err
+	}
+
+	var listResp tssv1.ListOperationsResponse
+	if err := parseHTTPResponse(resp, &listResp); err != nil {
+		return err
+	}
+
+	return outputListOperationsResponse(&listResp)
+}
+
+func getOperationsHTTP(ctx context.Context, operationIDs []string) error {
+	resp, err := makeHTTPRequest(ctx, "POST", api.FullBatchGetOperationsPath,
+		&tssv1.GetOperationsRequest{OperationIds: operationIDs})
+	if err != nil {
+		return err
+	}
+
+	var getResp tssv1.GetOperationsResponse
+	if err := parseHTTPResponse(resp, &getResp); err != nil {
+		return err
 	}
 
-	return outputRawOperationResponse(rawResp)
+	return outputGetOperationsResponse(&getResp)
 }
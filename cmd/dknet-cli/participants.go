@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// participantsFileUsage describes the participants file flags
+const participantsFileUsage = "File listing participant IDs, one or comma separated per line, or a JSON/YAML list; " +
+	"merged with the participants given as flags"
+
+// participantsFileDoc is the structured form of a participants file
+type participantsFileDoc struct {
+	Participants []string `yaml:"participants"`
+}
+
+// readParticipantsFile reads the participants listed in a file. Files ending in .json, .yaml
+// or .yml hold a list, or an object with a participants list. Other files list one or more
+// comma separated participants per line, blank lines and lines starting with # are skipped.
+func readParticipantsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read participants file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		// JSON is valid YAML, one decoder reads both
+		var list []string
+		if err := yaml.Unmarshal(data, &list); err == nil {
+			return list, nil
+		}
+		var doc participantsFileDoc
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse participants file %s: expected a list of participants "+
+				"or an object with a participants list", path)
+		}
+		return doc.Participants, nil
+	}
+
+	var participants []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, entry := range strings.Split(line, ",") {
+			// Tolerate trailing commas
+			if entry = strings.TrimSpace(entry); entry != "" {
+				participants = append(participants, entry)
+			}
+		}
+	}
+	return participants, nil
+}
+
+// collectParticipants merges the participants given as flags with those of a participants
+// file, if any, and checks every entry: entries are trimmed, must not be empty or contain
+// spaces, and must not be given twice.
+func collectParticipants(flagValues []string, file string) ([]string, error) {
+	entries := flagValues
+	if file != "" {
+		fromFile, err := readParticipantsFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(append([]string(nil), flagValues...), fromFile...)
+	}
+
+	participants := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		participant := strings.TrimSpace(entry)
+		switch {
+		case participant == "":
+			return nil, fmt.Errorf("participants list contains an empty entry")
+		case strings.ContainsAny(participant, " \t"):
+			return nil, fmt.Errorf("invalid participant %q: participants are peer IDs or node names without spaces", participant)
+		case seen[participant]:
+			return nil, fmt.Errorf("participant %s is given more than once", participant)
+		}
+		seen[participant] = true
+		participants = append(participants, participant)
+	}
+	return participants, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// keyPolicyFlags holds the flags describing the signing policy of a key
+type keyPolicyFlags struct {
+	maxMessageBytes  int
+	allowedHashModes []string
+	requiredRoles    []string
+	validationURL    string
+}
+
+// register adds the policy flags to cmd
+func (f *keyPolicyFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.maxMessageBytes, "max-message-bytes", 0, "Largest message or typed data the key signs, 0 for no limit")
+	cmd.Flags().StringSliceVar(&f.allowedHashModes, "allowed-hash-modes", nil,
+		"Hash modes the key signs with: eip191, eip712 or sha256d, empty for all")
+	cmd.Flags().StringSliceVar(&f.requiredRoles, "required-roles", nil,
+		"Roles of which a client starting a signing operation needs at least one, empty for any client")
+	cmd.Flags().StringVar(&f.validationURL, "validation-url", "",
+		"Validation service consulted for the key in addition to the node's")
+}
+
+// policy returns the policy given by the flags, nil if none is set
+func (f *keyPolicyFlags) policy() *tssv1.KeyPolicy {
+	if f.maxMessageBytes == 0 && len(f.allowedHashModes) == 0 && len(f.requiredRoles) == 0 && f.validationURL == "" {
+		return nil
+	}
+	return &tssv1.KeyPolicy{
+		MaxMessageBytes:  int32(f.maxMessageBytes),
+		AllowedHashModes: f.allowedHashModes,
+		RequiredRoles:    f.requiredRoles,
+		ValidationUrl:    f.validationURL,
+	}
+}
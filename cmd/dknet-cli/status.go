@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dreamer-zq/DKNet/internal/api"
+	healthv1 "github.com/dreamer-zq/DKNet/proto/health/v1"
+)
+
+func createStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show node health and storage usage",
+		Long: `Show the health of the node together with its peer, operation and storage
+statistics: the number of stored keys and operations and the approximate bytes used.
+Storage statistics are refreshed at most once a minute by the node.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if useGRPC {
+				return statusGRPC(ctx)
+			}
+			return statusHTTP(ctx)
+		},
+	}
+}
+
+func statusGRPC(ctx context.Context) error {
+	resp, err := healthv1.NewHealthServiceClient(grpcConn).Check(addAuthToContext(ctx), &healthv1.CheckRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to check health: %w", err)
+	}
+
+	return outputStatusResponse(resp)
+}
+
+func statusHTTP(ctx context.Context) error {
+	resp, err := makeHTTPRequest(ctx, "GET", api.HealthPath, nil)
+	if err != nil {
+		return err
+	}
+
+	var healthResp healthv1.CheckResponse
+	if err := parseHTTPResponse(resp, &healthResp); err != nil {
+		return err
+	}
+
+	return outputStatusResponse(&healthResp)
+}
+
+func outputStatusResponse(resp *healthv1.CheckResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	fmt.Printf("📊 Node Status\n")
+	fmt.Printf("Status: %s\n", resp.Status)
+	fmt.Printf("Details: %s\n", resp.Details)
+	if resp.Timestamp != nil {
+		fmt.Printf("Checked At: %s\n", resp.Timestamp.AsTime().Format(time.RFC3339))
+	}
+	for _, key := range slices.Sorted(maps.Keys(resp.Metadata)) {
+		fmt.Printf("  %s: %s\n", key, resp.Metadata[key])
+	}
+
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,16 +17,36 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dreamer-zq/DKNet/internal/api"
 	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
 )
 
 const (
+	outputFormatText = "text"
 	outputFormatJSON = "json"
-	unknownValue     = "Unknown"
+	outputFormatYAML = "yaml"
 )
 
+// validateOutputFormat checks the value of the --output flag
+func validateOutputFormat() error {
+	switch outputFormat {
+	case outputFormatText, outputFormatJSON, outputFormatYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q, must be one of: %s, %s, %s",
+			outputFormat, outputFormatText, outputFormatJSON, outputFormatYAML)
+	}
+}
+
 func setupConnection(cmd *cobra.Command, args []string) error {
+	if err := validateOutputFormat(); err != nil {
+		return err
+	}
+
 	// Check for JWT token from environment variable if not provided via flag
 	if jwtToken == "" {
 		if envToken := os.Getenv("DKNET_JWT_TOKEN"); envToken != "" {
@@ -33,7 +55,7 @@ func setupConnection(cmd *cobra.Command, args []string) error {
 	}
 
 	if useGRPC {
-		conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := api.NewClientConnPool(serverAddr, grpcConns, grpc.WithTransportCredentials(insecure.NewCredentials()))
 		if err != nil {
 			return fmt.Errorf("failed to connect to gRPC server: %w", err)
 		}
@@ -80,6 +102,9 @@ func makeHTTPRequest(ctx context.Context, method, path string, body interface{})
 	if jwtToken != "" {
 		req.Header.Set("Authorization", "Bearer "+jwtToken)
 	}
+	if operationTimeout > 0 {
+		req.Header.Set(api.OperationTimeoutHeader, operationTimeout.String())
+	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -110,64 +135,131 @@ func makeHTTPRequest(ctx context.Context, method, path string, body interface{})
 	return respBody, nil
 }
 
+// makeHTTPHeadRequest sends a HEAD request, the answer is carried by the status code and headers
+func makeHTTPHeadRequest(ctx context.Context, path string) (int, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, serverAddr+path, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Add JWT authentication if token is provided
+	if jwtToken != "" {
+		req.Header.Set("Authorization", "Bearer "+jwtToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+	}
+
+	return resp.StatusCode, resp.Header, nil
+}
+
+// parseHTTPResponse decodes a response body, the server encodes responses with the proto JSON mapping
+func parseHTTPResponse(data []byte, msg proto.Message) error {
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
 func cleanup(_ *cobra.Command, _ []string) {
 	if grpcConn != nil {
 		_ = grpcConn.Close()
 	}
 }
 
-// addAuthToContext adds JWT authentication to gRPC context
+// addAuthToContext adds JWT authentication and the requested operation timeout to gRPC context
 func addAuthToContext(ctx context.Context) context.Context {
 	if jwtToken != "" {
-		md := metadata.Pairs("authorization", "Bearer "+jwtToken)
-		ctx = metadata.NewOutgoingContext(ctx, md)
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+jwtToken)
+	}
+	if operationTimeout > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, api.OperationTimeoutMetadata, operationTimeout.String())
 	}
 	return ctx
 }
 
 // Unified output functions
 func outputStartKeygenResponse(resp *tssv1.StartKeygenResponse) error {
-	if outputFormat == outputFormatJSON {
-		return outputJSON(resp)
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	fmt.Printf("✅ Operation started successfully\n")
-	fmt.Printf("Operation ID: %s\n", resp.OperationId)
-	fmt.Printf("Status: %s\n", resp.Status)
-	fmt.Printf("Created At: %s\n", resp.CreatedAt.AsTime().Format(time.RFC3339))
+	outputStartedOperation(resp.OperationId, resp.Status, resp.CreatedAt, resp.AlreadyCompleted, resp.Error)
+	outputStartKeygenResult(resp.Result)
 
 	return nil
 }
 
 func outputStartSigningResponse(resp *tssv1.StartSigningResponse) error {
-	if outputFormat == outputFormatJSON {
-		return outputJSON(resp)
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	fmt.Printf("✅ Operation started successfully\n")
-	fmt.Printf("Operation ID: %s\n", resp.OperationId)
-	fmt.Printf("Status: %s\n", resp.Status)
-	fmt.Printf("Created At: %s\n", resp.CreatedAt.AsTime().Format(time.RFC3339))
+	outputStartedOperation(resp.OperationId, resp.Status, resp.CreatedAt, resp.AlreadyCompleted, resp.Error)
+	if result := resp.Result; result != nil {
+		fmt.Printf("🎯 Result:\n")
+		fmt.Printf("  Signature: %s\n", result.Signature)
+		fmt.Printf("  R: %s\n", result.R)
+		fmt.Printf("  S: %s\n", result.S)
+	}
 
 	return nil
 }
 
 func outputStartResharingResponse(resp *tssv1.StartResharingResponse) error {
-	if outputFormat == outputFormatJSON {
-		return outputJSON(resp)
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	fmt.Printf("✅ Operation started successfully\n")
-	fmt.Printf("Operation ID: %s\n", resp.OperationId)
-	fmt.Printf("Status: %s\n", resp.Status)
-	fmt.Printf("Created At: %s\n", resp.CreatedAt.AsTime().Format(time.RFC3339))
+	outputStartedOperation(resp.OperationId, resp.Status, resp.CreatedAt, resp.AlreadyCompleted, resp.Error)
+	outputStartKeygenResult(resp.Result)
 
 	return nil
 }
 
+// outputStartedOperation prints the operation a start request returned. Requests naming
+// the operation ID of a finished operation return that operation instead of starting one.
+func outputStartedOperation(
+	operationID string,
+	status tssv1.OperationStatus,
+	createdAt *timestamppb.Timestamp,
+	alreadyCompleted bool,
+	errorMessage *string,
+) {
+	if alreadyCompleted {
+		fmt.Printf("ℹ️  Operation already finished, no new operation was started\n")
+	} else {
+		fmt.Printf("✅ Operation started successfully\n")
+	}
+	fmt.Printf("Operation ID: %s\n", operationID)
+	fmt.Printf("Status: %s\n", status)
+	fmt.Printf("Created At: %s\n", createdAt.AsTime().Format(time.RFC3339))
+	if errorMessage != nil {
+		fmt.Printf("❌ Error: %s\n", *errorMessage)
+	}
+}
+
+// outputStartKeygenResult prints the key of a finished keygen or resharing operation
+func outputStartKeygenResult(result *tssv1.KeygenResult) {
+	if result == nil {
+		return
+	}
+	fmt.Printf("🎯 Result:\n")
+	fmt.Printf("  Public Key: %s\n", result.PublicKey)
+	fmt.Printf("  Key ID: %s\n", result.KeyId)
+	if result.Address != "" {
+		fmt.Printf("  Address: %s\n", result.Address)
+	}
+}
+
 func outputGetOperationResponse(resp *tssv1.GetOperationResponse) error {
-	if outputFormat == outputFormatJSON {
-		return outputJSON(resp)
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
 	// Text format output
@@ -176,30 +268,80 @@ func outputGetOperationResponse(resp *tssv1.GetOperationResponse) error {
 	fmt.Printf("Type: %s\n", resp.Type)
 	fmt.Printf("Session ID: %s\n", resp.SessionId)
 	fmt.Printf("Status: %s\n", resp.Status)
-	fmt.Printf("Participants: %s\n", strings.Join(resp.Participants, ", "))
+	fmt.Printf("Participants: %s\n", strings.Join(namedParticipants(resp), ", "))
 	fmt.Printf("Created At: %s\n", resp.CreatedAt.AsTime().Format(time.RFC3339))
 
 	if resp.CompletedAt != nil {
 		fmt.Printf("Completed At: %s\n", resp.CompletedAt.AsTime().Format(time.RFC3339))
 	}
 
+	if len(resp.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", formatLabels(resp.Labels))
+	}
+
 	if resp.Error != nil {
 		fmt.Printf("❌ Error: %s\n", *resp.Error)
 	}
 
+	if len(resp.Events) > 0 {
+		fmt.Printf("🕒 Events:\n")
+		for _, event := range resp.Events {
+			fmt.Printf("  %s %s\n", event.Time.AsTime().Format(time.RFC3339Nano), formatOperationEvent(event))
+		}
+	}
+
+	if len(resp.RoundTimings) > 0 {
+		fmt.Printf("⏱️  Rounds:\n")
+		var total int64
+		for _, timing := range resp.RoundTimings {
+			total += timing.DurationMs
+		}
+		for _, timing := range resp.RoundTimings {
+			share := 0.0
+			if total > 0 {
+				share = float64(timing.DurationMs) * 100 / float64(total)
+			}
+			fmt.Printf("  round %d: %s (%.0f%%)\n", timing.Round,
+				time.Duration(timing.DurationMs)*time.Millisecond, share)
+		}
+	}
+
 	if resp.Result != nil {
 		fmt.Printf("🎯 Result:\n")
 		switch result := resp.Result.(type) {
 		case *tssv1.GetOperationResponse_KeygenResult:
 			fmt.Printf("  Public Key: %s\n", result.KeygenResult.PublicKey)
 			fmt.Printf("  Key ID: %s\n", result.KeygenResult.KeyId)
+			if result.KeygenResult.Address != "" {
+				fmt.Printf("  Address: %s\n", result.KeygenResult.Address)
+			}
+			if result.KeygenResult.Alias != "" {
+				fmt.Printf("  Alias: %s\n", result.KeygenResult.Alias)
+			}
 		case *tssv1.GetOperationResponse_SigningResult:
 			fmt.Printf("  Signature: %s\n", result.SigningResult.Signature)
 			fmt.Printf("  R: %s\n", result.SigningResult.R)
 			fmt.Printf("  S: %s\n", result.SigningResult.S)
+			if result.SigningResult.DerivationPath != "" {
+				fmt.Printf("  Derivation Path: %s\n", result.SigningResult.DerivationPath)
+				fmt.Printf("  Child Public Key: %s\n", result.SigningResult.PublicKey)
+				fmt.Printf("  Child Address: %s\n", result.SigningResult.Address)
+			}
+			if len(result.SigningResult.Signers) > 0 {
+				fmt.Printf("  Signers: %s\n", strings.Join(result.SigningResult.Signers, ", "))
+			}
+			if result.SigningResult.MessageDigest != "" {
+				fmt.Printf("  Message Digest: %s (%s)\n", result.SigningResult.MessageDigest, result.SigningResult.HashMode)
+			}
+			if signingContext := result.SigningResult.Context; signingContext != nil {
+				fmt.Printf("  Context: key %s, session %s\n", signingContext.KeyId, signingContext.SessionId)
+			}
 		case *tssv1.GetOperationResponse_ResharingResult:
 			fmt.Printf("  New Public Key: %s\n", result.ResharingResult.PublicKey)
 			fmt.Printf("  New Key ID: %s\n", result.ResharingResult.KeyId)
+			if result.ResharingResult.Address != "" {
+				fmt.Printf("  Address: %s\n", result.ResharingResult.Address)
+			}
 		}
 	}
 
@@ -212,7 +354,11 @@ func outputGetOperationResponse(resp *tssv1.GetOperationResponse) error {
 			fmt.Printf("  Parties: %d\n", len(request.KeygenRequest.Participants))
 		case *tssv1.GetOperationResponse_SigningRequest:
 			fmt.Printf("  Key ID: %s\n", request.SigningRequest.KeyId)
-			fmt.Printf("  Message: %x\n", request.SigningRequest.Message)
+			if resp.MessageOmitted {
+				fmt.Printf("  Message SHA-256: %s (message not stored)\n", resp.MessageSha256)
+			} else {
+				fmt.Printf("  Message: %x\n", request.SigningRequest.Message)
+			}
 			fmt.Printf("  Participants: %s\n", strings.Join(request.SigningRequest.Participants, ", "))
 		case *tssv1.GetOperationResponse_ResharingRequest:
 			fmt.Printf("  Key ID: %s\n", request.ResharingRequest.KeyId)
@@ -225,144 +371,273 @@ func outputGetOperationResponse(resp *tssv1.GetOperationResponse) error {
 	return nil
 }
 
-// outputRawOperationResponse outputs operation response from raw JSON
-func outputRawOperationResponse(resp map[string]interface{}) error {
-	fmt.Printf("📋 Operation Details\n")
-
-	if operationID, ok := resp["operation_id"].(string); ok {
-		fmt.Printf("Operation ID: %s\n", operationID)
+func outputListOperationsResponse(resp *tssv1.ListOperationsResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	if opType, ok := resp["type"].(float64); ok {
-		typeStr := unknownValue
-		switch int(opType) {
-		case 1:
-			typeStr = "KEYGEN"
-		case 2:
-			typeStr = "SIGNING"
-		case 3:
-			typeStr = "RESHARING"
+	if len(resp.Operations) == 0 {
+		fmt.Println("No operations found")
+	}
+	for i, op := range resp.Operations {
+		if i > 0 {
+			fmt.Println()
 		}
-		fmt.Printf("Type: %s\n", typeStr)
-	}
-
-	if sessionID, ok := resp["session_id"].(string); ok {
-		fmt.Printf("Session ID: %s\n", sessionID)
-	}
-
-	if status, ok := resp["status"].(float64); ok {
-		statusStr := unknownValue
-		switch int(status) {
-		case 1:
-			statusStr = "PENDING"
-		case 2:
-			statusStr = "IN_PROGRESS"
-		case 3:
-			statusStr = "COMPLETED"
-		case 4:
-			statusStr = "FAILED"
-		case 5:
-			statusStr = "CANCELED"
+		if err := outputGetOperationResponse(op); err != nil {
+			return err
 		}
-		fmt.Printf("Status: %s\n", statusStr)
 	}
+	if resp.NextPageToken != "" {
+		fmt.Printf("\nNext page token: %s\n", resp.NextPageToken)
+	}
+	return nil
+}
 
-	if participants, ok := resp["participants"].([]interface{}); ok {
-		var participantStrs []string
-		for _, p := range participants {
-			if pStr, ok := p.(string); ok {
-				participantStrs = append(participantStrs, pStr)
-			}
-		}
-		fmt.Printf("Participants: %s\n", strings.Join(participantStrs, ", "))
+func outputGetOperationsResponse(resp *tssv1.GetOperationsResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	if createdAt, ok := resp["created_at"].(map[string]interface{}); ok {
-		if seconds, ok := createdAt["seconds"].(float64); ok {
-			t := time.Unix(int64(seconds), 0)
-			fmt.Printf("Created At: %s\n", t.Format(time.RFC3339))
+	for i, result := range resp.Results {
+		if i > 0 {
+			fmt.Println()
+		}
+		if !result.Found {
+			fmt.Printf("❓ Operation %s not found\n", result.OperationId)
+			continue
+		}
+		if err := outputGetOperationResponse(result.Operation); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	if completedAt, ok := resp["completed_at"].(map[string]interface{}); ok {
-		if seconds, ok := completedAt["seconds"].(float64); ok {
-			t := time.Unix(int64(seconds), 0)
-			fmt.Printf("Completed At: %s\n", t.Format(time.RFC3339))
-		}
+// formatLabels formats labels as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
 	}
+	return strings.Join(pairs, ", ")
+}
 
-	if errorMsg, ok := resp["error"].(string); ok && errorMsg != "" {
-		fmt.Printf("❌ Error: %s\n", errorMsg)
+// formatOperationEvent describes an event as its status transition and detail
+func formatOperationEvent(event *tssv1.OperationEvent) string {
+	text := event.ToStatus.String()
+	if event.FromStatus != event.ToStatus {
+		text = event.FromStatus.String() + " -> " + text
+	}
+	if event.Detail != "" {
+		text += ": " + event.Detail
 	}
+	return text
+}
 
-	// Handle results
-	if result, ok := resp["Result"]; ok && result != nil {
-		fmt.Printf("🎯 Result:\n")
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			if keygenResult, ok := resultMap["KeygenResult"].(map[string]interface{}); ok {
-				if publicKey, ok := keygenResult["public_key"].(string); ok {
-					fmt.Printf("  Public Key: %s\n", publicKey)
-				}
-				if keyID, ok := keygenResult["key_id"].(string); ok {
-					fmt.Printf("  Key ID: %s\n", keyID)
-				}
-			}
-			if signingResult, ok := resultMap["SigningResult"].(map[string]interface{}); ok {
-				if signature, ok := signingResult["signature"].(string); ok {
-					fmt.Printf("  Signature: %s\n", signature)
-				}
-				if r, ok := signingResult["r"].(string); ok {
-					fmt.Printf("  R: %s\n", r)
-				}
-				if s, ok := signingResult["s"].(string); ok {
-					fmt.Printf("  S: %s\n", s)
-				}
-			}
-		}
+func outputGetKeyMetadataResponse(resp *tssv1.GetKeyMetadataResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	// Handle original request
-	if request, ok := resp["Request"]; ok && request != nil {
-		fmt.Printf("📝 Original Request:\n")
-		if requestMap, ok := request.(map[string]interface{}); ok {
-			if keygenReq, ok := requestMap["KeygenRequest"].(map[string]interface{}); ok {
-				if threshold, ok := keygenReq["threshold"].(float64); ok {
-					fmt.Printf("  Threshold: %d\n", int(threshold))
-				}
-				if participants, ok := keygenReq["participants"].([]interface{}); ok {
-					var participantStrs []string
-					for _, p := range participants {
-						if pStr, ok := p.(string); ok {
-							participantStrs = append(participantStrs, pStr)
-						}
-					}
-					fmt.Printf("  Participants: %s\n", strings.Join(participantStrs, ", "))
-					fmt.Printf("  Parties: %d\n", len(participantStrs))
-				}
-			}
-			if signingReq, ok := requestMap["SigningRequest"].(map[string]interface{}); ok {
-				if keyID, ok := signingReq["key_id"].(string); ok {
-					fmt.Printf("  Key ID: %s\n", keyID)
-				}
-				if message, ok := signingReq["message"].(string); ok {
-					fmt.Printf("  Message: %s\n", message)
-				}
-			}
+	fmt.Printf("📋 Key Metadata\n")
+	if resp.KeyId != "" {
+		fmt.Printf("Key ID: %s\n", resp.KeyId)
+	}
+	if resp.Alias != "" {
+		fmt.Printf("Alias: %s\n", resp.Alias)
+	}
+	fmt.Printf("Moniker: %s\n", resp.Moniker)
+	fmt.Printf("Threshold: %d\n", resp.Threshold)
+	fmt.Printf("Participants: %s\n", strings.Join(resp.Participants, ", "))
+	if resp.ChainFamily != "" {
+		fmt.Printf("Chain Family: %s\n", resp.ChainFamily)
+	}
+	if len(resp.Addresses) > 0 {
+		fmt.Printf("Addresses:\n")
+		formats := slices.Sorted(maps.Keys(resp.Addresses))
+		for _, format := range formats {
+			fmt.Printf("  %s: %s\n", format, resp.Addresses[format])
 		}
 	}
+	if resp.Policy != nil {
+		fmt.Printf("Policy:\n")
+		printKeyPolicy(resp.Policy)
+	}
 
 	return nil
 }
 
-func outputGetKeyMetadataResponse(resp *tssv1.GetKeyMetadataResponse) error {
-	if outputFormat == outputFormatJSON {
-		return outputJSON(resp)
+func outputUpdateKeyPolicyResponse(resp *tssv1.UpdateKeyPolicyResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
 	}
 
-	fmt.Printf("📋 Key Metadata\n")
-	fmt.Printf("Moniker: %s\n", resp.Moniker)
-	fmt.Printf("Threshold: %d\n", resp.Threshold)
+	if resp.Policy == nil {
+		fmt.Printf("✅ Policy of key %s removed\n", resp.KeyId)
+		return nil
+	}
+	fmt.Printf("✅ Policy of key %s updated\n", resp.KeyId)
+	printKeyPolicy(resp.Policy)
+
+	return nil
+}
+
+// printKeyPolicy prints the set restrictions of a key policy
+func printKeyPolicy(policy *tssv1.KeyPolicy) {
+	if policy.MaxMessageBytes > 0 {
+		fmt.Printf("  Max Message Bytes: %d\n", policy.MaxMessageBytes)
+	}
+	if len(policy.AllowedHashModes) > 0 {
+		fmt.Printf("  Allowed Hash Modes: %s\n", strings.Join(policy.AllowedHashModes, ", "))
+	}
+	if len(policy.RequiredRoles) > 0 {
+		fmt.Printf("  Required Roles: %s\n", strings.Join(policy.RequiredRoles, ", "))
+	}
+	if policy.ValidationUrl != "" {
+		fmt.Printf("  Validation URL: %s\n", policy.ValidationUrl)
+	}
+}
+
+func outputSyncPeersResponse(resp *tssv1.SyncPeersResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	fmt.Printf("✅ Peer discovery triggered\n")
+	fmt.Printf("Connected Peers: %d\n", resp.ConnectedPeers)
+
+	return nil
+}
+
+func outputSetMaintenanceModeResponse(resp *tssv1.SetMaintenanceModeResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	if resp.Enabled {
+		fmt.Printf("🚧 Maintenance mode on, new operations are rejected\n")
+	} else {
+		fmt.Printf("✅ Maintenance mode off, new operations are accepted\n")
+	}
+
+	return nil
+}
+
+func outputHasKeyResponse(keyID string, resp *tssv1.HasKeyResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	fmt.Printf("🔑 Key %s\n", keyID)
+	fmt.Printf("Known: %t\n", resp.Known)
+	fmt.Printf("Has Share: %t\n", resp.HasShare)
+
+	return nil
+}
+
+func outputFindKeyHoldersResponse(keyID string, resp *tssv1.FindKeyHoldersResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	if resp.KeyId == "" {
+		fmt.Printf("🔑 Key %s is not known to any reachable node\n", keyID)
+		return nil
+	}
+
+	fmt.Printf("🔑 Key %s\n", resp.KeyId)
+	fmt.Printf("Threshold: %d (%d holders needed to sign)\n", resp.Threshold, resp.Threshold+1)
 	fmt.Printf("Participants: %s\n", strings.Join(resp.Participants, ", "))
+	fmt.Printf("Holders: %s\n", strings.Join(resp.Holders, ", "))
+	if len(resp.Unreachable) > 0 {
+		fmt.Printf("Unreachable: %s\n", strings.Join(resp.Unreachable, ", "))
+	}
+	fmt.Printf("Quorum Available: %t\n", resp.QuorumAvailable)
+
+	return nil
+}
+
+func outputGetNodeInfoResponse(resp *tssv1.GetNodeInfoResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	valueOr := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	}
+	const unknown = "unknown"
+
+	fmt.Printf("🖥️  Node Info\n")
+	fmt.Printf("Node ID: %s\n", resp.NodeId)
+	fmt.Printf("Moniker: %s\n", valueOr(resp.Moniker, unknown))
+	fmt.Printf("Version: %s\n", valueOr(resp.Version, "dev"))
+	fmt.Printf("Git Commit: %s\n", valueOr(resp.GitCommit, unknown))
+	fmt.Printf("Curves: %s\n", strings.Join(resp.Curves, ", "))
+	fmt.Printf("Algorithms: %s\n", strings.Join(resp.Algorithms, ", "))
+	fmt.Printf("Chain Families: %s\n", strings.Join(resp.ChainFamilies, ", "))
+	fmt.Printf("Address Formats: %s\n", strings.Join(resp.AddressFormats, ", "))
+	fmt.Printf("Key ID Format: %s\n", resp.KeyIdFormat)
+	fmt.Printf("Auth Enabled: %t\n", resp.AuthEnabled)
+	fmt.Printf("Access Control Enabled: %t\n", resp.AccessControlEnabled)
+	fmt.Printf("Validation Enabled: %t\n", resp.ValidationEnabled)
+	fmt.Printf("TLS Enabled: %t\n", resp.TlsEnabled)
+	fmt.Printf("Maintenance: %t\n", resp.Maintenance)
+	fmt.Printf("Addresses:\n")
+	for _, addr := range resp.Addresses {
+		fmt.Printf("  - %s\n", addr)
+	}
+
+	return nil
+}
+
+func outputGetNodeAddressResponse(resp *tssv1.GetNodeAddressResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	fmt.Printf("🌐 Node Address\n")
+	fmt.Printf("Node ID: %s\n", resp.NodeId)
+	fmt.Printf("Connected: %t\n", resp.Connected)
+	fmt.Printf("Addresses:\n")
+	for _, addr := range resp.Addresses {
+		fmt.Printf("  - %s\n", addr)
+	}
+
+	return nil
+}
+
+func outputGetNetworkAddressesResponse(resp *tssv1.GetNetworkAddressesResponse) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(resp)
+	}
+
+	fmt.Printf("🌐 Known Nodes (%d)\n", len(resp.Nodes))
+	for _, node := range resp.Nodes {
+		fmt.Printf("- %s\n", nodeLabel(node))
+		fmt.Printf("  Connected: %t\n", node.Connected)
+		for _, addr := range node.Addresses {
+			fmt.Printf("  Address: %s\n", addr)
+		}
+	}
 
 	return nil
 }
+
+// namedParticipants returns the participants of an operation, each followed by its name
+// when the node knows one
+func namedParticipants(resp *tssv1.GetOperationResponse) []string {
+	if len(resp.ParticipantDetails) != len(resp.Participants) {
+		return resp.Participants
+	}
+	participants := make([]string, len(resp.ParticipantDetails))
+	for i, participant := range resp.ParticipantDetails {
+		participants[i] = participant.PeerId
+		if participant.Moniker != "" {
+			participants[i] = fmt.Sprintf("%s (%s)", participant.PeerId, participant.Moniker)
+		}
+	}
+	return participants
+}
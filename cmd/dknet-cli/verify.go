@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/dreamer-zq/DKNet/internal/tss"
+)
+
+const (
+	// hashModeEIP191 hashes the message with the Ethereum personal message prefix, as the server does
+	hashModeEIP191 = "eip191"
+	// hashModeDigest treats the message as an already hashed 32 byte digest
+	hashModeDigest = "digest"
+)
+
+// verifyResult is the outcome of a local signature verification
+type verifyResult struct {
+	Valid            bool   `json:"valid"`
+	Digest           string `json:"digest"`
+	RecoveredAddress string `json:"recovered_address"`
+	RecoveredPubKey  string `json:"recovered_public_key"`
+	ExpectedAddress  string `json:"expected_address"`
+}
+
+func createVerifyLocalCommand() *cobra.Command {
+	var pubKey, message, signature, hashMode string
+	var contextKeyID, contextSessionID string
+	var messageHex bool
+
+	cmd := &cobra.Command{
+		Use:   "verify-local",
+		Short: "Verify a signature locally without a server",
+		Long: `Verify an Ethereum style signature (R || S || V) entirely client-side by
+recovering the signer with ecrecover and comparing it with the expected key.
+
+--pubkey accepts the hex public key or the 0x address reported by keygen.
+With --hash-mode=eip191 (default) the message is hashed with the Ethereum personal
+message prefix like the server does; with --hash-mode=digest the message must be the
+hex encoded 32 byte digest that was signed.
+
+Context-bound signatures cover a digest bound to the key and session they were produced
+in. Pass the key ID and session ID of the signing context from the operation result with
+--context-key-id and --context-session-id to verify them.`,
+		Args: cobra.NoArgs,
+		// No connection is needed, only the output format is checked
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutputFormat()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			digest, err := verifyDigest(message, messageHex, hashMode)
+			if err != nil {
+				return err
+			}
+			if contextKeyID != "" || contextSessionID != "" {
+				if contextKeyID == "" || contextSessionID == "" {
+					return fmt.Errorf("--context-key-id and --context-session-id must be given together")
+				}
+				digest = tss.ContextBoundDigest(contextKeyID, contextSessionID, digest)
+			}
+
+			result, err := verifyLocal(pubKey, digest, signature)
+			if err != nil {
+				return err
+			}
+			if err := outputVerifyResult(result); err != nil {
+				return err
+			}
+			if !result.Valid {
+				return fmt.Errorf("signature was not produced by %s", result.ExpectedAddress)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&pubKey, "pubkey", "", "Public key (hex) or address of the expected signer (required)")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Signed message, or the digest with --hash-mode=digest (required)")
+	cmd.Flags().BoolVar(&messageHex, "hex", false, "Treat message as hex string")
+	cmd.Flags().StringVar(&signature, "signature", "", "Hex signature R || S || V (required)")
+	cmd.Flags().StringVar(&hashMode, "hash-mode", hashModeEIP191, "How the message was hashed (eip191|digest)")
+	cmd.Flags().StringVar(&contextKeyID, "context-key-id", "", "Key ID of the signing context of a context-bound signature")
+	cmd.Flags().StringVar(&contextSessionID, "context-session-id", "",
+		"Session ID of the signing context of a context-bound signature")
+
+	for _, name := range []string{"pubkey", "message", "signature"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			panic(fmt.Sprintf("Failed to mark %s flag as required: %v", name, err))
+		}
+	}
+
+	return cmd
+}
+
+// verifyDigest returns the 32 byte hash the signature is expected to cover
+func verifyDigest(message string, messageHex bool, hashMode string) ([]byte, error) {
+	switch hashMode {
+	case hashModeEIP191:
+		messageBytes := []byte(message)
+		if messageHex {
+			var err error
+			if messageBytes, err = decodeHex(message); err != nil {
+				return nil, fmt.Errorf("invalid hex message: %w", err)
+			}
+		}
+		return accounts.TextHash(messageBytes), nil
+	case hashModeDigest:
+		digest, err := decodeHex(message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex digest: %w", err)
+		}
+		if len(digest) != 32 {
+			return nil, fmt.Errorf("digest must be 32 bytes, got %d", len(digest))
+		}
+		return digest, nil
+	default:
+		return nil, fmt.Errorf("invalid hash mode %q, must be one of: %s, %s", hashMode, hashModeEIP191, hashModeDigest)
+	}
+}
+
+// verifyLocal recovers the signer of digest and compares it with the expected key
+func verifyLocal(expected string, digest []byte, signature string) (*verifyResult, error) {
+	expectedAddr, err := parseExpectedSigner(expected)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := decodeHex(signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex signature: %w", err)
+	}
+	recoverable, err := recoverableSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := crypto.SigToPub(digest, recoverable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key: %w", err)
+	}
+	recovered := crypto.PubkeyToAddress(*pub)
+
+	return &verifyResult{
+		Valid:            recovered == expectedAddr,
+		Digest:           "0x" + hex.EncodeToString(digest),
+		RecoveredAddress: recovered.Hex(),
+		RecoveredPubKey:  hex.EncodeToString(crypto.FromECDSAPub(pub)[1:]),
+		ExpectedAddress:  expectedAddr.Hex(),
+	}, nil
+}
+
+// parseExpectedSigner returns the address of a hex public key (64 byte X || Y as reported
+// by keygen, 65 byte uncompressed or 33 byte compressed) or of a 0x address
+func parseExpectedSigner(value string) (common.Address, error) {
+	raw, err := decodeHex(value)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid hex public key: %w", err)
+	}
+
+	switch len(raw) {
+	case common.AddressLength:
+		return common.BytesToAddress(raw), nil
+	case 64:
+		raw = append([]byte{0x04}, raw...)
+	case 33:
+		pub, err := crypto.DecompressPubkey(raw)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("invalid compressed public key: %w", err)
+		}
+		return crypto.PubkeyToAddress(*pub), nil
+	}
+
+	pub, err := crypto.UnmarshalPubkey(raw)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// recoverableSignature converts R || S || V with a legacy (27/28), EIP-155 or raw (0/1)
+// v value to the 65 byte form with a 0/1 recovery ID expected by ecrecover
+func recoverableSignature(sig []byte) ([]byte, error) {
+	if len(sig) <= 64 {
+		return nil, fmt.Errorf("signature must be R || S || V, got %d bytes", len(sig))
+	}
+
+	v := new(big.Int).SetBytes(sig[64:])
+	var recoveryID uint64
+	switch {
+	case !v.IsUint64():
+		return nil, fmt.Errorf("invalid signature v value %s", v)
+	case v.Uint64() <= 1:
+		recoveryID = v.Uint64()
+	case v.Uint64() == 27 || v.Uint64() == 28:
+		recoveryID = v.Uint64() - 27
+	case v.Uint64() >= 35:
+		recoveryID = (v.Uint64() - 35) % 2
+	default:
+		return nil, fmt.Errorf("invalid signature v value %s", v)
+	}
+
+	out := bytes.Clone(sig[:65])
+	out[64] = byte(recoveryID)
+	return out, nil
+}
+
+// decodeHex decodes a hex string with an optional 0x prefix
+func decodeHex(value string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X"))
+}
+
+func outputVerifyResult(result *verifyResult) error {
+	if outputFormat != outputFormatText {
+		return outputStructured(result)
+	}
+
+	if result.Valid {
+		fmt.Printf("✅ Signature is valid\n")
+	} else {
+		fmt.Printf("❌ Signature is invalid\n")
+	}
+	fmt.Printf("Digest: %s\n", result.Digest)
+	fmt.Printf("Recovered Address: %s\n", result.RecoveredAddress)
+	fmt.Printf("Recovered Public Key: %s\n", result.RecoveredPubKey)
+	fmt.Printf("Expected Address: %s\n", result.ExpectedAddress)
+
+	return nil
+}
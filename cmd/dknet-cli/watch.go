@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/dreamer-zq/DKNet/internal/api"
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// spinnerFrames are drawn in turn in front of the live status line
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is how often the live status line is redrawn
+const spinnerInterval = 100 * time.Millisecond
+
+func createWatchOperationCommand() *cobra.Command {
+	var interval, maxWait time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch <operation-id>",
+		Short: "Watch an operation until it completes",
+		Long: `Poll an operation and show a live status line with a spinner, the current protocol
+round and the elapsed time until the operation completes, then print its result.
+
+The live line is only drawn on a terminal with text output. The command exits with an
+error if the operation failed or was canceled, or if --max-wait elapsed first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			if maxWait > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, maxWait)
+				defer cancel()
+			}
+
+			return watchOperation(ctx, args[0], interval)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", time.Second, "How often the operation is polled")
+	cmd.Flags().DurationVar(&maxWait, "max-wait", 0, "Give up after this long (0 waits until the operation completes)")
+
+	return cmd
+}
+
+// watchOperation polls an operation until it reached a terminal state and prints it
+func watchOperation(ctx context.Context, operationID string, interval time.Duration) error {
+	live := outputFormat == outputFormatText && term.IsTerminal(int(os.Stdout.Fd()))
+	started := time.Now()
+
+	spinner := time.NewTicker(spinnerInterval)
+	defer spinner.Stop()
+
+	var (
+		resp     *tssv1.GetOperationResponse
+		nextPoll time.Time
+		frame    int
+	)
+	for {
+		if !time.Now().Before(nextPoll) {
+			var err error
+			if resp, err = fetchOperation(ctx, operationID); err != nil {
+				clearStatusLine(live)
+				return err
+			}
+			nextPoll = time.Now().Add(interval)
+			if operationFinished(resp.Status) {
+				break
+			}
+		}
+
+		if live {
+			fmt.Printf("\r\033[K%s %s", spinnerFrames[frame%len(spinnerFrames)], watchStatusLine(resp, started))
+			frame++
+		}
+
+		select {
+		case <-ctx.Done():
+			clearStatusLine(live)
+			return fmt.Errorf("stopped watching operation %s (%s): %w", operationID, resp.Status, ctx.Err())
+		case <-spinner.C:
+		}
+	}
+
+	clearStatusLine(live)
+	if err := outputGetOperationResponse(resp); err != nil {
+		return err
+	}
+	if resp.Status != tssv1.OperationStatus_OPERATION_STATUS_COMPLETED {
+		return fmt.Errorf("operation %s ended with status %s", operationID, resp.Status)
+	}
+	return nil
+}
+
+// fetchOperation gets an operation over the selected transport
+func fetchOperation(ctx context.Context, operationID string) (*tssv1.GetOperationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if useGRPC {
+		resp, err := tssClient.GetOperation(addAuthToContext(ctx), &tssv1.GetOperationRequest{OperationId: operationID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operation: %w", err)
+		}
+		return resp, nil
+	}
+
+	data, err := makeHTTPRequest(ctx, "GET", api.GetOperationPath(operationID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp tssv1.GetOperationResponse
+	if err := parseHTTPResponse(data, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// operationFinished reports whether an operation reached a terminal state
+func operationFinished(status tssv1.OperationStatus) bool {
+	switch status {
+	case tssv1.OperationStatus_OPERATION_STATUS_COMPLETED,
+		tssv1.OperationStatus_OPERATION_STATUS_FAILED,
+		tssv1.OperationStatus_OPERATION_STATUS_CANCELED,
+		tssv1.OperationStatus_OPERATION_STATUS_TIMED_OUT:
+		return true
+	}
+	return false
+}
+
+// watchStatusLine describes the progress of a running operation: its status, the last
+// round recorded in its events and the time since it was created
+func watchStatusLine(resp *tssv1.GetOperationResponse, started time.Time) string {
+	parts := []string{resp.Type.String(), resp.OperationId, resp.Status.String()}
+	for i := len(resp.Events) - 1; i >= 0; i-- {
+		if detail := resp.Events[i].Detail; strings.HasPrefix(detail, "round ") {
+			parts = append(parts, detail)
+			break
+		}
+	}
+
+	if resp.CreatedAt != nil && resp.CreatedAt.AsTime().Before(started) {
+		started = resp.CreatedAt.AsTime()
+	}
+	parts = append(parts, time.Since(started).Round(time.Second).String())
+	return strings.Join(parts, " · ")
+}
+
+// clearStatusLine removes the live status line before other output is printed
+func clearStatusLine(live bool) {
+	if live {
+		fmt.Print("\r\033[K")
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dreamer-zq/DKNet/internal/api"
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// runKeygenWizard asks the node for the known nodes, lets the user pick the participants
+// and threshold and submits the keygen after confirmation. Prompts go to stderr so that
+// json/yaml output on stdout stays machine readable.
+func runKeygenWizard(alias, chainFamily string, labels map[string]string, policy *tssv1.KeyPolicy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	nodes, err := getNetworkAddresses(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("the node does not know any nodes")
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	out := os.Stderr
+
+	_, _ = fmt.Fprintf(out, "🔑 Key generation wizard\n\nKnown nodes:\n")
+	for i, node := range nodes {
+		_, _ = fmt.Fprintf(out, "  [%d] %s\n", i+1, nodeLabel(node))
+	}
+	_, _ = fmt.Fprintln(out)
+
+	participants, err := promptParticipants(in, out, nodes)
+	if err != nil {
+		return err
+	}
+	threshold, err := promptThreshold(in, out, len(participants))
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(out, "\nSummary:\n")
+	_, _ = fmt.Fprintf(out, "  Participants (%d):\n", len(participants))
+	for _, id := range participants {
+		_, _ = fmt.Fprintf(out, "    - %s\n", nodeLabel(findNode(nodes, id)))
+	}
+	_, _ = fmt.Fprintf(out, "  Threshold: %d (any %d of %d participants can sign)\n", threshold, threshold+1, len(participants))
+	if alias != "" {
+		_, _ = fmt.Fprintf(out, "  Alias: %s\n", alias)
+	}
+	if chainFamily != "" {
+		_, _ = fmt.Fprintf(out, "  Chain family: %s\n", chainFamily)
+	}
+
+	confirmed, err := promptConfirm(in, out, "Start key generation?")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		_, _ = fmt.Fprintln(out, "Aborted")
+		return nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if useGRPC {
+		return keygenGRPC(ctx, threshold, participants, alias, chainFamily, labels, policy)
+	}
+	return keygenHTTP(ctx, threshold, participants, alias, chainFamily, labels, policy)
+}
+
+// getNetworkAddresses returns this node and the peers it is connected to
+func getNetworkAddresses(ctx context.Context) ([]*tssv1.NodeAddress, error) {
+	if useGRPC {
+		resp, err := tssClient.GetNetworkAddresses(addAuthToContext(ctx), &tssv1.GetNetworkAddressesRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get network addresses: %w", err)
+		}
+		return resp.Nodes, nil
+	}
+
+	resp, err := makeHTTPRequest(ctx, "GET", api.FullNetworkAddressesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var addrResp tssv1.GetNetworkAddressesResponse
+	if err := parseHTTPResponse(resp, &addrResp); err != nil {
+		return nil, err
+	}
+	return addrResp.Nodes, nil
+}
+
+// nodeLabel describes a node for selection, leading with its moniker when known
+func nodeLabel(node *tssv1.NodeAddress) string {
+	label := node.NodeId
+	if node.Moniker != "" {
+		label = node.Moniker + " (" + node.NodeId + ")"
+	}
+	if node.Self {
+		label += " [this node]"
+	}
+	return label
+}
+
+// findNode returns the node with the given ID
+func findNode(nodes []*tssv1.NodeAddress, nodeID string) *tssv1.NodeAddress {
+	for _, node := range nodes {
+		if node.NodeId == nodeID {
+			return node
+		}
+	}
+	return &tssv1.NodeAddress{NodeId: nodeID}
+}
+
+// resolveNode maps a selection (list number, moniker or node ID) to a node ID
+func resolveNode(nodes []*tssv1.NodeAddress, selection string) (string, error) {
+	if n, err := strconv.Atoi(selection); err == nil {
+		if n < 1 || n > len(nodes) {
+			return "", fmt.Errorf("no node numbered %d", n)
+		}
+		return nodes[n-1].NodeId, nil
+	}
+	for _, node := range nodes {
+		if node.NodeId == selection || (node.Moniker != "" && node.Moniker == selection) {
+			return node.NodeId, nil
+		}
+	}
+	return "", fmt.Errorf("unknown node %q", selection)
+}
+
+// promptParticipants asks for the participants until a valid selection is entered
+func promptParticipants(in *bufio.Reader, out io.Writer, nodes []*tssv1.NodeAddress) ([]string, error) {
+	for {
+		line, err := prompt(in, out, "Select participants (comma separated numbers, monikers or node IDs, or 'all'): ")
+		if err != nil {
+			return nil, err
+		}
+
+		participants, err := parseParticipants(nodes, line)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "❌ %v\n", err)
+			continue
+		}
+		return participants, nil
+	}
+}
+
+// parseParticipants resolves a comma separated participant selection to node IDs
+func parseParticipants(nodes []*tssv1.NodeAddress, line string) ([]string, error) {
+	if strings.EqualFold(line, "all") {
+		participants := make([]string, len(nodes))
+		for i, node := range nodes {
+			participants[i] = node.NodeId
+		}
+		return participants, nil
+	}
+
+	var participants []string
+	for _, selection := range strings.Split(line, ",") {
+		selection = strings.TrimSpace(selection)
+		if selection == "" {
+			continue
+		}
+		nodeID, err := resolveNode(nodes, selection)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(participants, nodeID) {
+			return nil, fmt.Errorf("node %q selected more than once", selection)
+		}
+		participants = append(participants, nodeID)
+	}
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("participants list cannot be empty")
+	}
+	return participants, nil
+}
+
+// promptThreshold asks for the threshold until a value with t+1 <= n is entered
+func promptThreshold(in *bufio.Reader, out io.Writer, parties int) (int, error) {
+	for {
+		line, err := prompt(in, out, fmt.Sprintf("Threshold t, any t+1 participants can sign (0-%d): ", parties-1))
+		if err != nil {
+			return 0, err
+		}
+
+		threshold, err := strconv.Atoi(line)
+		if err != nil || threshold < 0 || threshold >= parties {
+			_, _ = fmt.Fprintf(out, "❌ threshold must be a number between 0 and %d (t+1 <= n required)\n", parties-1)
+			continue
+		}
+		return threshold, nil
+	}
+}
+
+// promptConfirm asks a yes/no question, defaulting to no
+func promptConfirm(in *bufio.Reader, out io.Writer, question string) (bool, error) {
+	line, err := prompt(in, out, question+" [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(line) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// prompt prints a question and reads the trimmed answer
+func prompt(in *bufio.Reader, out io.Writer, question string) (string, error) {
+	_, _ = fmt.Fprint(out, question)
+	line, err := in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("input closed")
+		}
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -3,9 +3,13 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,23 +17,29 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/dreamer-zq/DKNet/internal/api"
 	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
 )
 
 var (
-	nodeAddr string
-	nodeID   string
-	jwtToken string
-	logger   *zap.Logger
+	nodeAddr  string
+	nodeID    string
+	nodes     []string
+	jwtToken  string
+	grpcConns int
+	logger    *zap.Logger
 )
 
 const (
-	textMessageFormat = "text"
-	hexMessageFormat  = "hex"
-	na                = "N/A"
+	na = "N/A"
+
+	// connectionCheckInterval is how often the connection to the node is checked
+	connectionCheckInterval = 10 * time.Second
 )
 
 func main() {
@@ -52,14 +62,20 @@ func main() {
 TSS operations as MCP tools for LLM clients.
 
 Example:
-  dknet-mcp --node-addr localhost:9095 --node-id 12D3KooWExample...`,
+  dknet-mcp --node-addr localhost:9095 --node-id 12D3KooWExample...
+
+Several nodes of the cluster can be given with --node, tool calls then go to a
+reachable node holding the key they use and fail over to the next one:
+  dknet-mcp --node localhost:9095=12D3KooWFirst... --node localhost:9096=12D3KooWSecond...`,
 		RunE: runMCPServer,
 	}
 
 	rootCmd.PersistentFlags().StringVar(&nodeAddr, "node-addr", "localhost:9095", "DKNet node gRPC address")
-	rootCmd.PersistentFlags().StringVar(&nodeID, "node-id", "", "Node ID for X-Node-ID header (required)")
+	rootCmd.PersistentFlags().StringVar(&nodeID, "node-id", "", "Node ID for X-Node-ID header (required without --node)")
+	rootCmd.PersistentFlags().StringArrayVar(&nodes, "node", nil,
+		"Node as ADDR=NODE_ID, repeat for several nodes (replaces --node-addr and --node-id)")
 	rootCmd.PersistentFlags().StringVar(&jwtToken, "jwt-token", "", "JWT token for authentication (if required)")
-	_ = rootCmd.MarkPersistentFlagRequired("node-id")
+	rootCmd.PersistentFlags().IntVar(&grpcConns, "grpc-conns", 4, "Number of gRPC connections to spread tool calls over")
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.Fatal("Command execution failed", zap.Error(err))
@@ -67,31 +83,63 @@ Example:
 }
 
 func runMCPServer(cmd *cobra.Command, args []string) error {
+	endpoints, err := parseNodes(nodes, nodeAddr, nodeID)
+	if err != nil {
+		return err
+	}
 	logger.Info("Starting DKNet MCP Server",
+		zap.Strings("nodes", nodes),
 		zap.String("node_address", nodeAddr),
 		zap.String("node_id", nodeID),
+		zap.Int("grpc_conns", grpcConns),
 		zap.Bool("jwt_enabled", jwtToken != ""))
 
-	// Create gRPC connection to DKNet node
-	conn, err := grpc.NewClient(nodeAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return fmt.Errorf("failed to connect to DKNet node: %w", err)
+	// Shut down cleanly on SIGINT/SIGTERM, canceling in-flight tool calls
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	clusterNodes := make([]*api.ClusterNode, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		// Create gRPC connection to DKNet node
+		// Concurrent tool calls are spread over a pool so they are not limited by the
+		// stream limit of a single connection. The reconnect backoff is capped so a
+		// restarted node is picked up within seconds.
+		conn, err := api.NewClientConnPool(endpoint.Addr, grpcConns,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff: backoff.Config{
+					BaseDelay:  time.Second,
+					Multiplier: 1.6,
+					Jitter:     0.2,
+					MaxDelay:   5 * time.Second,
+				},
+				MinConnectTimeout: 5 * time.Second,
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to DKNet node %s: %w", endpoint.Addr, err)
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		node := api.NewClusterNode(endpoint.Addr, endpoint.NodeID, conn)
+		clusterNodes = append(clusterNodes, node)
+
+		// Test connection
+		_, err = node.Client.GetOperation(node.OutgoingContext(contextWithAuth(ctx)), &tssv1.GetOperationRequest{
+			OperationId: "test-connection",
+		})
+		// Ignore the "not found" error, we just want to test connectivity
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			logger.Warn("Failed to test connection", zap.String("node_address", endpoint.Addr), zap.Error(err))
+		} else {
+			logger.Info("Successfully connected to DKNet node", zap.String("node_address", endpoint.Addr))
+		}
+		go monitorConnection(ctx, endpoint.Addr, conn)
 	}
-	defer func() {
-		_ = conn.Close()
-	}()
-
-	// Test connection
-	tssClient := tssv1.NewTSSServiceClient(conn)
-	ctx := contextWithAuth(context.Background())
-	_, err = tssClient.GetOperation(ctx, &tssv1.GetOperationRequest{
-		OperationId: "test-connection",
-	})
-	// Ignore the "not found" error, we just want to test connectivity
-	if err != nil && !strings.Contains(err.Error(), "not found") {
-		logger.Warn("Failed to test connection", zap.Error(err))
-	} else {
-		logger.Info("Successfully connected to DKNet node")
+	selector, err := api.NewNodeSelector(clusterNodes)
+	if err != nil {
+		return err
 	}
 
 	// Create MCP server using the correct API
@@ -102,27 +150,94 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 	)
 
 	// Register TSS tools
-	if err := registerTSSTools(s, tssClient); err != nil {
+	if err := registerTSSTools(s, selector); err != nil {
 		return fmt.Errorf("failed to register TSS tools: %w", err)
 	}
 
 	logger.Info("DKNet MCP Server ready - connect your LLM client via stdio")
 
-	// Start the stdio server - this is the correct way
-	return server.ServeStdio(s)
+	// Serve stdio until the client disconnects or a signal arrives
+	err = server.NewStdioServer(s).Listen(ctx, os.Stdin, os.Stdout)
+	if errors.Is(err, context.Canceled) {
+		logger.Info("Shutting down DKNet MCP Server")
+		return nil
+	}
+	return err
+}
+
+// monitorConnection logs changes of the node connection state and asks the pool to
+// reconnect right away when the node becomes unreachable, so tool calls recover
+// without restarting the MCP server
+func monitorConnection(ctx context.Context, addr string, conn *api.ClientConnPool) {
+	ticker := time.NewTicker(connectionCheckInterval)
+	defer ticker.Stop()
+
+	last := conn.State()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		state := conn.State()
+		if state != last {
+			if state == connectivity.Ready {
+				logger.Info("Connection to DKNet node is ready",
+					zap.String("node_address", addr), zap.String("previous_state", last.String()))
+			} else {
+				logger.Warn("Connection to DKNet node changed",
+					zap.String("node_address", addr), zap.String("state", state.String()))
+			}
+			last = state
+		}
+
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			if err := conn.Reconnect(); err != nil {
+				logger.Warn("Failed to reconnect to DKNet node", zap.String("node_address", addr), zap.Error(err))
+			}
+		}
+	}
 }
 
+// contextWithAuth adds the JWT token to the outgoing metadata, the node ID is added by
+// the node a call is sent to
 func contextWithAuth(ctx context.Context) context.Context {
 	if jwtToken != "" {
 		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+jwtToken)
 	}
-	if nodeID != "" {
-		ctx = metadata.AppendToOutgoingContext(ctx, "x-node-id", nodeID)
-	}
 	return ctx
 }
 
-func registerTSSTools(s *server.MCPServer, tssClient tssv1.TSSServiceClient) error {
+// nodeEndpoint is a node given on the command line
+type nodeEndpoint struct {
+	Addr   string
+	NodeID string
+}
+
+// parseNodes returns the nodes given as ADDR=NODE_ID with --node, or the single node
+// given with --node-addr and --node-id
+func parseNodes(specs []string, addr, id string) ([]nodeEndpoint, error) {
+	if len(specs) == 0 {
+		if id == "" {
+			return nil, errors.New("--node-id or --node is required")
+		}
+		return []nodeEndpoint{{Addr: addr, NodeID: id}}, nil
+	}
+
+	endpoints := make([]nodeEndpoint, 0, len(specs))
+	for _, spec := range specs {
+		addr, id, ok := strings.Cut(spec, "=")
+		addr, id = strings.TrimSpace(addr), strings.TrimSpace(id)
+		if !ok || addr == "" || id == "" {
+			return nil, fmt.Errorf("invalid --node %q, expected ADDR=NODE_ID", spec)
+		}
+		endpoints = append(endpoints, nodeEndpoint{Addr: addr, NodeID: id})
+	}
+	return endpoints, nil
+}
+
+func registerTSSTools(s *server.MCPServer, selector *api.NodeSelector) error {
 	// Register keygen tool
 	keygenTool := mcp.NewTool("tss_keygen",
 		mcp.WithDescription("Generate a new distributed threshold signature key using DKNet cluster"),
@@ -139,6 +254,12 @@ func registerTSSTools(s *server.MCPServer, tssClient tssv1.TSSServiceClient) err
 		mcp.WithString("operation_id",
 			mcp.Description("Optional operation ID for idempotency"),
 		),
+		mcp.WithString("alias",
+			mcp.Description("Optional human-readable alias usable instead of the key ID"),
+		),
+		mcp.WithString("chain_family",
+			mcp.Description("Chain family of the key: ethereum (default) or bitcoin"),
+		),
 	)
 
 	s.AddTool(keygenTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -171,6 +292,9 @@ func registerTSSTools(s *server.MCPServer, tssClient tssv1.TSSServiceClient) err
 			}
 		}
 
+		alias, _ := args["alias"].(string)
+		chainFamily, _ := args["chain_family"].(string)
+
 		// Validate parameters
 		if int(threshold) < 0 {
 			return mcp.NewToolResultError("threshold must be non-negative"), nil
@@ -183,22 +307,31 @@ func registerTSSTools(s *server.MCPServer, tssClient tssv1.TSSServiceClient) err
 			return mcp.NewToolResultError("participants list cannot be empty"), nil
 		}
 
-		// Start keygen operation via gRPC
-		authCtx := contextWithAuth(ctx)
-		resp, err := tssClient.StartKeygen(authCtx, &tssv1.StartKeygenRequest{
-			OperationId:  operationID,
-			Threshold:    int32(threshold),
-			Participants: participants,
+		// Start keygen operation via gRPC on one of the participants
+		var resp *tssv1.StartKeygenResponse
+		var node *api.ClusterNode
+		err := selector.Call(contextWithAuth(ctx), "", participants, func(ctx context.Context, n *api.ClusterNode) (err error) {
+			node = n
+			resp, err = n.Client.StartKeygen(ctx, &tssv1.StartKeygenRequest{
+				OperationId:  operationID,
+				Threshold:    int32(threshold),
+				Participants: participants,
+				Alias:        alias,
+				ChainFamily:  chainFamily,
+			})
+			return err
 		})
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start keygen: %v", err)), nil
 		}
 
 		logger.Info("Keygen operation started",
-			zap.String("operation_id", resp.OperationId))
+			zap.String("operation_id", resp.OperationId),
+			zap.String("node_address", node.Addr))
 
 		// Wait for operation to complete
-		result, err := waitForOperationCompletion(authCtx, tssClient, resp.OperationId, 10*time.Minute)
+		nodeCtx := node.OutgoingContext(contextWithAuth(ctx))
+		result, err := waitForOperationCompletion(nodeCtx, node.Client, resp.OperationId, 10*time.Minute)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Keygen operation failed: %v", err)), nil
 		}
@@ -214,6 +347,7 @@ func registerTSSTools(s *server.MCPServer, tssClient tssv1.TSSServiceClient) err
 
 **Generated Key:**
 - Key ID: %s
+- Address: %s
 - Public Key: %s
 
 The distributed key has been securely generated and stored across the DKNet cluster.`,
@@ -226,6 +360,7 @@ The distributed key has been securely generated and stored across the DKNet clus
 			strings.Join(participants, ", "),
 			result.CreatedAt.AsTime().Format(time.RFC3339),
 			extractKeyID(result),
+			extractAddress(result),
 			extractPublicKey(result),
 		)
 
@@ -241,7 +376,7 @@ The distributed key has been securely generated and stored across the DKNet clus
 		),
 		mcp.WithString("key_id",
 			mcp.Required(),
-			mcp.Description("ID of the key to use for signing"),
+			mcp.Description("ID or alias of the key to use for signing"),
 		),
 		mcp.WithString("participants",
 			mcp.Required(),
@@ -251,7 +386,7 @@ The distributed key has been securely generated and stored across the DKNet clus
 			mcp.Description("Optional operation ID"),
 		),
 		mcp.WithString("message_format",
-			mcp.Description("Message format: 'text' or 'hex' (default: text)"),
+			mcp.Description("Message format: 'text', 'hex' or 'base64' (default: text)"),
 		),
 	)
 
@@ -290,7 +425,7 @@ The distributed key has been securely generated and stored across the DKNet clus
 			}
 		}
 
-		messageFormat := textMessageFormat
+		messageFormat := api.MessageEncodingText
 		if format, exists := args["message_format"]; exists {
 			if formatStr, ok := format.(string); ok {
 				messageFormat = formatStr
@@ -298,28 +433,23 @@ The distributed key has been securely generated and stored across the DKNet clus
 		}
 
 		// Convert message to bytes based on format
-		var messageBytes []byte
-
-		switch messageFormat {
-		case hexMessageFormat:
-			var decodeErr error
-			messageBytes, decodeErr = hex.DecodeString(messageStr)
-			if decodeErr != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Invalid hex message: %v", decodeErr)), nil
-			}
-		case textMessageFormat:
-			messageBytes = []byte(messageStr)
-		default:
-			return mcp.NewToolResultError("message_format must be 'text' or 'hex'"), nil
+		messageBytes, err := api.DecodeMessage(messageStr, messageFormat)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Start signing operation via gRPC
-		authCtx := contextWithAuth(ctx)
-		resp, err := tssClient.StartSigning(authCtx, &tssv1.StartSigningRequest{
-			OperationId:  operationID,
-			Message:      messageBytes,
-			KeyId:        keyID,
-			Participants: participants,
+		// Start signing operation via gRPC on a participant holding a share of the key
+		var resp *tssv1.StartSigningResponse
+		var node *api.ClusterNode
+		err = selector.Call(contextWithAuth(ctx), keyID, participants, func(ctx context.Context, n *api.ClusterNode) (err error) {
+			node = n
+			resp, err = n.Client.StartSigning(ctx, &tssv1.StartSigningRequest{
+				OperationId:  operationID,
+				Message:      messageBytes,
+				KeyId:        keyID,
+				Participants: participants,
+			})
+			return err
 		})
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to start signing: %v", err)), nil
@@ -327,10 +457,12 @@ The distributed key has been securely generated and stored across the DKNet clus
 
 		logger.Info("Signing operation started",
 			zap.String("operation_id", resp.OperationId),
-			zap.String("key_id", keyID))
+			zap.String("key_id", keyID),
+			zap.String("node_address", node.Addr))
 
 		// Wait for operation to complete
-		result, err := waitForOperationCompletion(authCtx, tssClient, resp.OperationId, 5*time.Minute)
+		nodeCtx := node.OutgoingContext(contextWithAuth(ctx))
+		result, err := waitForOperationCompletion(nodeCtx, node.Client, resp.OperationId, 5*time.Minute)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Signing operation failed: %v", err)), nil
 		}
@@ -353,6 +485,8 @@ The distributed key has been securely generated and stored across the DKNet clus
 - R: %s
 - S: %s
 - V: %d
+- Signed Digest: %s
+- Hash Mode: %s
 
 The message has been successfully signed using the distributed threshold signature scheme.`,
 			result.OperationId,
@@ -366,11 +500,53 @@ The message has been successfully signed using the distributed threshold signatu
 			extractSignatureR(result),
 			extractSignatureS(result),
 			extractRecoveryID(result),
+			extractMessageDigest(result),
+			extractHashMode(result),
 		)
 
 		return mcp.NewToolResultText(response), nil
 	})
 
+	// Register network info tool
+	networkInfoTool := mcp.NewTool("tss_network_info",
+		mcp.WithDescription("List the DKNet node and the peers it is connected to, "+
+			"use the node IDs as participants for keygen and signing"),
+	)
+
+	s.AddTool(networkInfoTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var resp *tssv1.GetNetworkAddressesResponse
+		err := selector.Call(contextWithAuth(ctx), "", nil, func(ctx context.Context, n *api.ClusterNode) (err error) {
+			resp, err = n.Client.GetNetworkAddresses(ctx, &tssv1.GetNetworkAddressesRequest{})
+			return err
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get network info: %v", err)), nil
+		}
+
+		var b strings.Builder
+		b.WriteString("**DKNet Network:**\n")
+		for _, node := range resp.Nodes {
+			name := node.NodeId
+			if node.Moniker != "" {
+				name = fmt.Sprintf("%s (%s)", node.NodeId, node.Moniker)
+			}
+			state := "connected"
+			switch {
+			case node.Self:
+				state = "this node"
+			case !node.Connected:
+				state = "disconnected"
+			}
+			fmt.Fprintf(&b, "- %s [%s]\n", name, state)
+			for _, addr := range node.Addresses {
+				fmt.Fprintf(&b, "  - %s\n", addr)
+			}
+		}
+		fmt.Fprintf(&b, "\nTotal nodes: %d", len(resp.Nodes))
+
+		return mcp.NewToolResultText(b.String()), nil
+	})
+
 	return nil
 }
 
@@ -417,6 +593,8 @@ func waitForOperationCompletion(
 				return nil, fmt.Errorf("operation failed")
 			case tssv1.OperationStatus_OPERATION_STATUS_CANCELED:
 				return nil, fmt.Errorf("operation was canceled")
+			case tssv1.OperationStatus_OPERATION_STATUS_TIMED_OUT:
+				return nil, fmt.Errorf("operation timed out")
 			}
 		}
 	}
@@ -430,6 +608,13 @@ func extractKeyID(resp *tssv1.GetOperationResponse) string {
 	return na
 }
 
+func extractAddress(resp *tssv1.GetOperationResponse) string {
+	if result := resp.GetKeygenResult(); result != nil && result.Address != "" {
+		return result.Address
+	}
+	return na
+}
+
 func extractPublicKey(resp *tssv1.GetOperationResponse) string {
 	if result := resp.GetKeygenResult(); result != nil {
 		return result.PublicKey
@@ -457,3 +642,17 @@ func extractRecoveryID(resp *tssv1.GetOperationResponse) int {
 	}
 	return 0
 }
+
+func extractMessageDigest(resp *tssv1.GetOperationResponse) string {
+	if result := resp.GetSigningResult(); result != nil && result.MessageDigest != "" {
+		return result.MessageDigest
+	}
+	return na
+}
+
+func extractHashMode(resp *tssv1.GetOperationResponse) string {
+	if result := resp.GetSigningResult(); result != nil && result.HashMode != "" {
+		return result.HashMode
+	}
+	return na
+}
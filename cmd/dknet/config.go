@@ -21,10 +21,12 @@ func generateAndSaveNodeConfig(
 	configPath string,
 	dockerMode bool,
 ) error {
-	// Set the correct private key file path based on mode
+	// Set the correct data paths based on mode
+	dataDir := "."
 	privateKeyFile := "./node_key"
 	storagePath := "./data/storage"
 	if dockerMode {
+		dataDir = "/app/node"
 		privateKeyFile = "/app/node/node_key"
 		storagePath = "/app/data/storage"
 	}
@@ -36,14 +38,23 @@ func generateAndSaveNodeConfig(
 				Port: httpPort,
 			},
 			GRPC: config.GRPCConfig{
-				Host: "0.0.0.0",
-				Port: grpcPort,
+				Host:                          "0.0.0.0",
+				Port:                          grpcPort,
+				HealthWatchIntervalSeconds:    10,
+				HealthWatchSendTimeoutSeconds: 5,
 			},
 		},
 		P2P: config.P2PConfig{
-			ListenAddrs:    []string{fmt.Sprintf("/ip4/%s/tcp/%d", listenAddr, p2pPort)},
-			BootstrapPeers: bootstrapPeers,
-			PrivateKeyFile: privateKeyFile,
+			ListenAddrs:     []string{fmt.Sprintf("/ip4/%s/tcp/%d", listenAddr, p2pPort)},
+			BootstrapPeers:  bootstrapPeers,
+			PrivateKeyFile:  privateKeyFile,
+			Compression:     "gzip",
+			MaxMessageBytes: 16 << 20,
+			SendConcurrency: 16,
+			DHT:             config.DHTConfig{Mode: "server"},
+			MDNS:            config.MDNSConfig{MinIntervalSeconds: 5, MaxIntervalSeconds: 300},
+			PeerScoring:     config.PeerScoringConfig{ViolationThreshold: 10, WindowSeconds: 60, BanSeconds: 600},
+			ConnManager:     config.ConnManagerConfig{LowWater: 160, HighWater: 192, GracePeriodSeconds: 60},
 		},
 		Storage: config.StorageConfig{
 			Type:    "leveldb",
@@ -51,20 +62,47 @@ func generateAndSaveNodeConfig(
 			Options: make(map[string]string),
 		},
 		TSS: config.TSSConfig{
-			Moniker: moniker,
+			Moniker:                     moniker,
+			Mode:                        "full",
+			SyncRetries:                 3,
+			SyncRetryIntervalMs:         500,
+			SyncAckTimeoutSeconds:       60,
+			JoinQuorum:                  "all",
+			KeyIDFormat:                 "uuid",
+			SessionLookupTimeoutSeconds: 15,
+			EarlyMessageWindowSeconds:   30,
+			MaxMessageBytes:             65536,
+			OperationCacheSize:          256,
+			MinOperationTimeoutSeconds:  10,
+			MaxOperationTimeoutSeconds:  3600,
 			ValidationService: &config.ValidationServiceConfig{
-				Enabled:            false,
-				URL:                "",
-				TimeoutSeconds:     30,
-				Headers:            make(map[string]string),
-				InsecureSkipVerify: false,
+				Enabled:                 false,
+				URL:                     "",
+				TimeoutSeconds:          30,
+				Headers:                 make(map[string]string),
+				InsecureSkipVerify:      false,
+				BreakerFailureThreshold: 5,
+				BreakerCooldownSeconds:  30,
+				OnError:                 config.ValidationOnErrorReject,
 			},
 		},
+		DataDir:  dataDir,
 		Security: generateDefaultSecurityConfig(),
 		Logging: config.LoggingConfig{
 			Level:       "debug",
 			Environment: "dev",
 			Output:      "stdout",
+			Rotation: config.LogRotationConfig{
+				MaxSizeMB:  100,
+				MaxAgeDays: 30,
+				MaxBackups: 10,
+			},
+		},
+		Notifications: config.NotificationConfig{
+			MinSeverity:                "warning",
+			TimeoutSeconds:             10,
+			QueueSize:                  100,
+			HealthCheckIntervalSeconds: 60,
 		},
 	}
 
@@ -169,5 +207,16 @@ func generateDefaultSecurityConfig() config.SecurityConfig {
 			Enabled:      false,
 			AllowedPeers: []string{},
 		},
+		P2PEncryption:           "required",
+		EnforcePasswordStrength: true,
+		KDF: config.KDFConfig{
+			Algorithm: "argon2id",
+			Time:      3,
+			MemoryKiB: 64 * 1024,
+			Threads:   4,
+			ScryptN:   1 << 15,
+			ScryptR:   8,
+			ScryptP:   1,
+		},
 	}
 }
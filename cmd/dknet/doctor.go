@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/spf13/cobra"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/security"
+)
+
+// doctorProbeTimeout bounds the reachability probe of the validation service
+const doctorProbeTimeout = 5 * time.Second
+
+// errCheckSkipped marks a check that does not apply to the configuration
+var errCheckSkipped = errors.New("skipped")
+
+// doctorCheck is a single check of the doctor report
+type doctorCheck struct {
+	name string
+	run  func(cfg *config.NodeConfig) (string, error)
+}
+
+func runDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check a node configuration without starting the server",
+		Long: `Run a series of checks against a node directory and print a pass/fail report:
+- config.yaml loads and passes validation
+- the storage path is writable
+- the P2P key loads, is of a type P2P message encryption supports, and which peer ID it yields
+- listen addresses and bootstrap peers are valid multiaddrs
+- TLS certificate and key load when TLS is enabled
+- API authentication has its secret or public key when enabled
+- the encryption password from TSS_ENCRYPTION_PASSWORD_FILE or TSS_ENCRYPTION_PASSWORD
+  meets the password strength policy when set
+- the validation service is reachable when enabled`,
+		RunE: runDoctor,
+		// Failed checks are a report, not a usage error
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP(flagNodeDir, "", "", "Node directory containing config.yaml and node_key")
+	_ = cmd.MarkFlagRequired(flagNodeDir)
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	nodeDir, err := cmd.Flags().GetString(flagNodeDir)
+	if err != nil {
+		return fmt.Errorf("failed to get node directory: %w", err)
+	}
+
+	fmt.Printf("Checking node %s\n\n", nodeDir)
+
+	// Every other check needs a valid config
+	cfg, err := config.Load(nodeDir)
+	if err != nil {
+		printCheckResult("config", "", err)
+		return fmt.Errorf("configuration is invalid")
+	}
+	printCheckResult("config", filepath.Join(cfg.ConfigDir, "config.yaml"), nil)
+
+	checks := []doctorCheck{
+		{name: "storage", run: checkStorageWritable},
+		{name: "p2p key", run: checkP2PKey},
+		{name: "listen addresses", run: checkListenAddrs},
+		{name: "bootstrap peers", run: checkBootstrapPeers},
+		{name: "tls", run: checkTLSCertificate},
+		{name: "api auth", run: checkAPIAuth},
+		{name: "encryption password", run: checkEncryptionPassword},
+		{name: "validation service", run: checkValidationService},
+	}
+
+	failed := 0
+	for _, check := range checks {
+		detail, err := check.run(cfg)
+		if err != nil && !errors.Is(err, errCheckSkipped) {
+			failed++
+		}
+		printCheckResult(check.name, detail, err)
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks)+1)
+	}
+	fmt.Println("✅ All checks passed")
+	return nil
+}
+
+func printCheckResult(name, detail string, err error) {
+	switch {
+	case errors.Is(err, errCheckSkipped):
+		fmt.Printf("[SKIP] %-20s %s\n", name, detail)
+	case err != nil:
+		fmt.Printf("[FAIL] %-20s %v\n", name, err)
+	default:
+		fmt.Printf("[PASS] %-20s %s\n", name, detail)
+	}
+}
+
+// checkStorageWritable creates and removes a file in the storage directory, or in its
+// closest existing parent when the storage has not been created yet
+func checkStorageWritable(cfg *config.NodeConfig) (string, error) {
+	if cfg.Storage.Type == "memory" {
+		return "memory storage", errCheckSkipped
+	}
+
+	dir := cfg.Storage.Path
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to stat %s: %w", dir, err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no existing parent directory of %s", cfg.Storage.Path)
+		}
+		dir = parent
+	}
+
+	f, err := os.CreateTemp(dir, ".dknet-doctor-*")
+	if err != nil {
+		return "", fmt.Errorf("storage path %s is not writable: %w", cfg.Storage.Path, err)
+	}
+	name := f.Name()
+	_ = f.Close()
+	_ = os.Remove(name)
+	return fmt.Sprintf("%s (%s)", cfg.Storage.Path, cfg.Storage.Type), nil
+}
+
+func checkP2PKey(cfg *config.NodeConfig) (string, error) {
+	privKey, err := loadNodeKey(cfg.P2P.PrivateKeyFile)
+	if err != nil {
+		return "", err
+	}
+	// The node refuses to start with keys peer encryption cannot use
+	if err := security.CheckKeyType(privKey.Type()); err != nil {
+		return "", err
+	}
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get peer ID from private key: %w", err)
+	}
+	return fmt.Sprintf("peer ID %s (%s)", peerID, privKey.Type()), nil
+}
+
+func checkListenAddrs(cfg *config.NodeConfig) (string, error) {
+	if len(cfg.P2P.ListenAddrs) == 0 {
+		return "", fmt.Errorf("no listen addresses configured")
+	}
+	for _, addr := range cfg.P2P.ListenAddrs {
+		if _, err := multiaddr.NewMultiaddr(addr); err != nil {
+			return "", fmt.Errorf("invalid listen address %s: %w", addr, err)
+		}
+	}
+	return fmt.Sprintf("%d valid", len(cfg.P2P.ListenAddrs)), nil
+}
+
+// checkBootstrapPeers parses the bootstrap peers the way the DHT does, which would
+// otherwise only log and drop invalid entries at startup
+func checkBootstrapPeers(cfg *config.NodeConfig) (string, error) {
+	if len(cfg.P2P.BootstrapPeers) == 0 {
+		return "none configured", errCheckSkipped
+	}
+	for _, addr := range cfg.P2P.BootstrapPeers {
+		if _, err := peer.AddrInfoFromString(addr); err != nil {
+			return "", fmt.Errorf("invalid bootstrap peer %s: %w", addr, err)
+		}
+	}
+	return fmt.Sprintf("%d valid", len(cfg.P2P.BootstrapPeers)), nil
+}
+
+func checkTLSCertificate(cfg *config.NodeConfig) (string, error) {
+	if !cfg.Security.TLSEnabled {
+		return "TLS disabled", errCheckSkipped
+	}
+	if _, err := tls.LoadX509KeyPair(cfg.Security.CertFile, cfg.Security.KeyFile); err != nil {
+		return "", fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	return cfg.Security.CertFile, nil
+}
+
+// checkAPIAuth reports the verification material of API authentication. Its presence is
+// already enforced by config validation, this only makes it visible in the report.
+func checkAPIAuth(cfg *config.NodeConfig) (string, error) {
+	auth := cfg.Security.APIAuth
+	if !auth.Enabled {
+		return "authentication disabled", errCheckSkipped
+	}
+	algorithm := auth.Algorithm
+	if algorithm == "" {
+		algorithm = config.DefaultJWTAlgorithm
+	}
+	switch {
+	case auth.JWTSecret != "":
+		return algorithm + " with JWT secret", nil
+	case auth.PublicKeyFile != "":
+		return algorithm + " with public key " + auth.PublicKeyFile, nil
+	case auth.JWKSURL != "":
+		return algorithm + " with JWKS " + auth.JWKSURL, nil
+	}
+	return "", fmt.Errorf("no JWT secret or public key configured")
+}
+
+// checkEncryptionPassword applies the startup password strength policy to the password
+// from TSS_ENCRYPTION_PASSWORD_FILE or TSS_ENCRYPTION_PASSWORD. Interactively entered
+// passwords are checked at startup.
+func checkEncryptionPassword(cfg *config.NodeConfig) (string, error) {
+	password, err := common.LookupPassword(context.Background())
+	if errors.Is(err, common.ErrPasswordNotSet) {
+		return "no password file or environment variable set, the password is prompted at startup", errCheckSkipped
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := common.ValidatePassword(password); err != nil {
+		if cfg.Security.EnforcePasswordStrength {
+			return "", fmt.Errorf("weak encryption password: %w", err)
+		}
+		return "weak password allowed, enforce_password_strength is disabled", nil
+	}
+	return "meets the strength policy", nil
+}
+
+// checkValidationService sends a HEAD request to the validation service. Any HTTP
+// response counts as reachable, the service only has to accept POST requests.
+func checkValidationService(cfg *config.NodeConfig) (string, error) {
+	vs := cfg.TSS.ValidationService
+	if vs == nil || !vs.Enabled {
+		return "validation disabled", errCheckSkipped
+	}
+
+	client := &http.Client{Timeout: doctorProbeTimeout}
+	if vs.InsecureSkipVerify {
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, vs.URL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("invalid validation service URL: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("validation service %s is unreachable: %w", vs.URL, err)
+	}
+	_ = resp.Body.Close()
+	return fmt.Sprintf("%s (HTTP %d)", vs.URL, resp.StatusCode), nil
+}
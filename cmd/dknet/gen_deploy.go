@@ -0,0 +1,298 @@
+package main
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	deployFormatDocker  = "docker"
+	deployFormatSystemd = "systemd"
+
+	// Ports the node listens on inside its container
+	dockerHTTPPort = 8080
+	dockerGRPCPort = 9090
+	dockerP2PPort  = 4001
+)
+
+// deployNode is a node of a generated deployment
+type deployNode struct {
+	Index     int
+	Dir       string
+	PeerID    string
+	Host      string
+	HTTPPort  int
+	GRPCPort  int
+	P2PPort   int
+	Multiaddr string
+}
+
+// SystemdUnitConfig represents the template data of a systemd unit
+type SystemdUnitConfig struct {
+	Name    string
+	User    string
+	NodeDir string
+	EnvFile string
+	Binary  string
+}
+
+func runGenDeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen-deploy",
+		Short: "Generate a deployable TSS cluster",
+		Long: `Generate everything needed to run a TSS cluster in one step:
+- P2P private keys and configuration files for each node
+- Bootstrap peers wired from each node's peer ID and address
+- A docker-compose.yaml (--format docker) or one systemd unit per node (--format systemd)
+
+Node N uses the N-th port after each base port. In docker mode the ports are the
+published host ports and node N gets the (N+1)-th address of --subnet, the first one
+being the bridge gateway. In systemd mode the ports are the listen ports and --hosts
+gives the address of each node's machine.`,
+		RunE: runGenDeploy,
+	}
+
+	cmd.Flags().IntP("nodes", "n", 3, "Number of nodes in the cluster")
+	cmd.Flags().String("out", "deployments/dknet", "Output directory for the generated deployment")
+	cmd.Flags().String("format", deployFormatDocker, "Deployment format (docker|systemd)")
+	cmd.Flags().Int("http-port", 18081, "HTTP port of the first node")
+	cmd.Flags().Int("grpc-port", 19095, "gRPC port of the first node")
+	cmd.Flags().Int("p2p-port", 14001, "P2P port of the first node")
+	cmd.Flags().String("subnet", "172.20.0.0/16", "Docker network subnet (docker only)")
+	cmd.Flags().String("image", defaultDockerImage, "Docker image (docker only)")
+	cmd.Flags().StringSlice("hosts", nil, "Address of each node's host, defaults to 127.0.0.1 for all nodes (systemd only)")
+	cmd.Flags().String("install-dir", "/opt/dknet", "Directory the node directories are installed to (systemd only)")
+	cmd.Flags().String("binary", "/usr/local/bin/dknet", "Path of the dknet binary (systemd only)")
+	cmd.Flags().String("user", "dknet", "User the nodes run as (systemd only)")
+
+	return cmd
+}
+
+func runGenDeploy(cmd *cobra.Command, args []string) error {
+	nodes, _ := cmd.Flags().GetInt("nodes")
+	outputDir, _ := cmd.Flags().GetString("out")
+	format, _ := cmd.Flags().GetString("format")
+	httpPort, _ := cmd.Flags().GetInt("http-port")
+	grpcPort, _ := cmd.Flags().GetInt("grpc-port")
+	p2pPort, _ := cmd.Flags().GetInt("p2p-port")
+	subnet, _ := cmd.Flags().GetString("subnet")
+	image, _ := cmd.Flags().GetString("image")
+	hosts, _ := cmd.Flags().GetStringSlice("hosts")
+	installDir, _ := cmd.Flags().GetString("install-dir")
+	binary, _ := cmd.Flags().GetString("binary")
+	user, _ := cmd.Flags().GetString("user")
+
+	if nodes < 1 {
+		return fmt.Errorf("nodes must be at least 1")
+	}
+	for name, base := range map[string]int{"http-port": httpPort, "grpc-port": grpcPort, "p2p-port": p2pPort} {
+		if base < 1 || base+nodes-1 > 65535 {
+			return fmt.Errorf("%s %d leaves no valid port for %d nodes", name, base, nodes)
+		}
+	}
+
+	var err error
+	switch format {
+	case deployFormatDocker:
+		if hosts, err = subnetHostAddrs(subnet, nodes); err != nil {
+			return err
+		}
+	case deployFormatSystemd:
+		if len(hosts) == 0 {
+			for range nodes {
+				hosts = append(hosts, "127.0.0.1")
+			}
+		}
+		if len(hosts) != nodes {
+			return fmt.Errorf("got %d hosts for %d nodes", len(hosts), nodes)
+		}
+		for _, host := range hosts {
+			if addr, parseErr := netip.ParseAddr(host); parseErr != nil || !addr.Is4() {
+				return fmt.Errorf("invalid host %q, must be an IPv4 address", host)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid format %q, must be one of: %s, %s", format, deployFormatDocker, deployFormatSystemd)
+	}
+
+	// Never overwrite the keys of an existing deployment
+	if _, statErr := os.Stat(filepath.Join(outputDir, "node1", "node_key")); statErr == nil {
+		return fmt.Errorf("%s already contains a deployment, choose another --out", outputDir)
+	}
+
+	fmt.Printf("Generating %s deployment with %d nodes in %s...\n", format, nodes, outputDir)
+
+	// Step 1: Generate keys and addresses for all nodes
+	deployNodes := make([]*deployNode, 0, nodes)
+	for i := 1; i <= nodes; i++ {
+		node := &deployNode{
+			Index:    i,
+			Dir:      filepath.Join(outputDir, fmt.Sprintf("node%d", i)),
+			Host:     hosts[i-1],
+			HTTPPort: httpPort + i - 1,
+			GRPCPort: grpcPort + i - 1,
+			P2PPort:  p2pPort + i - 1,
+		}
+		if err := ensureNodeDirectory(node.Dir); err != nil {
+			return err
+		}
+
+		_, peerID, err := generateAndSaveNodeKey(node.Dir)
+		if err != nil {
+			return fmt.Errorf("failed to generate key for node %d: %w", i, err)
+		}
+		node.PeerID = peerID.String()
+
+		// Containers reach each other on the bridge network at the in-container port
+		listenPort := node.P2PPort
+		if format == deployFormatDocker {
+			listenPort = dockerP2PPort
+		}
+		node.Multiaddr = fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", node.Host, listenPort, node.PeerID)
+
+		deployNodes = append(deployNodes, node)
+		fmt.Printf("Generated keys for node%d (peer: %s)\n", i, node.PeerID)
+	}
+
+	// Step 2: Generate configuration files with all other nodes as bootstrap peers
+	for _, node := range deployNodes {
+		var bootstrapPeers []string
+		for _, other := range deployNodes {
+			if other.Index != node.Index {
+				bootstrapPeers = append(bootstrapPeers, other.Multiaddr)
+			}
+		}
+
+		dockerMode := format == deployFormatDocker
+		listenAddr, nodeP2PPort, nodeHTTPPort, nodeGRPCPort := defaultBindIP, node.P2PPort, node.HTTPPort, node.GRPCPort
+		if dockerMode {
+			nodeP2PPort, nodeHTTPPort, nodeGRPCPort = dockerP2PPort, dockerHTTPPort, dockerGRPCPort
+		}
+
+		configFile := filepath.Join(node.Dir, "config.yaml")
+		nodeName := fmt.Sprintf("TSS Node %d", node.Index)
+		if err := generateAndSaveNodeConfig(nodeName, bootstrapPeers, listenAddr, nodeP2PPort,
+			nodeHTTPPort, nodeGRPCPort, configFile, dockerMode); err != nil {
+			return fmt.Errorf("failed to generate config for node %d: %w", node.Index, err)
+		}
+		if err := generateNodeInfo(node.Dir, node.PeerID, node.Host, nodeP2PPort, bootstrapPeers, dockerMode); err != nil {
+			return fmt.Errorf("failed to generate node info for node %d: %w", node.Index, err)
+		}
+	}
+
+	// Step 3: Generate the service definitions
+	if format == deployFormatDocker {
+		if err := writeDeployDockerCompose(outputDir, image, subnet, deployNodes); err != nil {
+			return err
+		}
+		fmt.Println("Generated docker-compose.yaml")
+	} else {
+		if err := writeDeploySystemdUnits(outputDir, installDir, binary, user, deployNodes); err != nil {
+			return err
+		}
+		fmt.Printf("Generated %d systemd units\n", len(deployNodes))
+	}
+
+	printGenDeployUsage(format, outputDir, installDir, deployNodes)
+	return nil
+}
+
+// subnetHostAddrs returns the addresses of n nodes in an IPv4 subnet, skipping the
+// network address and the gateway
+func subnetHostAddrs(subnet string, n int) ([]string, error) {
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil || !prefix.Addr().Is4() {
+		return nil, fmt.Errorf("invalid subnet %q, must be an IPv4 CIDR", subnet)
+	}
+	prefix = prefix.Masked()
+
+	addrs := make([]string, n)
+	addr := prefix.Addr().Next() // gateway
+	for i := range addrs {
+		addr = addr.Next()
+		// The last address of the subnet is the broadcast address
+		if !prefix.Contains(addr.Next()) {
+			return nil, fmt.Errorf("subnet %s is too small for %d nodes", subnet, n)
+		}
+		addrs[i] = addr.String()
+	}
+	return addrs, nil
+}
+
+// writeDeployDockerCompose writes a docker-compose.yaml running every node on its own address
+func writeDeployDockerCompose(outputDir, image, subnet string, nodes []*deployNode) error {
+	var nodeConfigs []DockerNodeConfig
+	for _, node := range nodes {
+		var dependencies []string
+		for j := 1; j < node.Index; j++ {
+			dependencies = append(dependencies, fmt.Sprintf("tss-node%d", j))
+		}
+
+		nodeConfigs = append(nodeConfigs, DockerNodeConfig{
+			Name:         fmt.Sprintf("Node %d", node.Index),
+			ServiceName:  fmt.Sprintf("tss-node%d", node.Index),
+			NodeDir:      fmt.Sprintf("node%d", node.Index),
+			HTTPPort:     node.HTTPPort,
+			GRPCPort:     node.GRPCPort,
+			P2PPort:      node.P2PPort,
+			IP:           node.Host,
+			StartPeriod:  5 + (node.Index-1)*5,
+			UseCustomIP:  true,
+			Dependencies: dependencies,
+		})
+	}
+
+	return writeDockerCompose(outputDir, &DockerComposeConfig{
+		Image:           image,
+		Nodes:           nodeConfigs,
+		UseCustomSubnet: true,
+		Subnet:          subnet,
+	})
+}
+
+// writeDeploySystemdUnits writes a dknet-nodeN.service unit next to each node directory
+func writeDeploySystemdUnits(outputDir, installDir, binary, user string, nodes []*deployNode) error {
+	for _, node := range nodes {
+		nodeDir := filepath.Join(installDir, fmt.Sprintf("node%d", node.Index))
+		unit := &SystemdUnitConfig{
+			Name:    fmt.Sprintf("node%d", node.Index),
+			User:    user,
+			NodeDir: nodeDir,
+			EnvFile: filepath.Join(nodeDir, "dknet.env"),
+			Binary:  binary,
+		}
+		unitFile := filepath.Join(outputDir, fmt.Sprintf("dknet-node%d.service", node.Index))
+		if err := renderTemplate("systemd.tmpl", unitFile, unit); err != nil {
+			return fmt.Errorf("failed to generate systemd unit for node %d: %w", node.Index, err)
+		}
+	}
+	return nil
+}
+
+func printGenDeployUsage(format, outputDir, installDir string, nodes []*deployNode) {
+	fmt.Printf("✅ Deployment generated in %s\n\n", outputDir)
+
+	fmt.Println("Nodes:")
+	for _, node := range nodes {
+		fmt.Printf("  node%d  HTTP :%d  gRPC :%d  %s\n", node.Index, node.HTTPPort, node.GRPCPort, node.Multiaddr)
+	}
+	fmt.Println("")
+
+	if format == deployFormatDocker {
+		fmt.Println("🚀 To start the cluster:")
+		fmt.Printf("   cd %s\n", outputDir)
+		fmt.Println("   export TSS_ENCRYPTION_PASSWORD=\"YourSecurePassword123!\"")
+		fmt.Println("   docker compose up -d")
+		return
+	}
+
+	fmt.Println("🚀 On each node's host:")
+	fmt.Printf("   copy %s/nodeN to %s/nodeN\n", outputDir, installDir)
+	fmt.Printf("   echo 'TSS_ENCRYPTION_PASSWORD=...' > %s/nodeN/dknet.env && chmod 600 %s/nodeN/dknet.env\n", installDir, installDir)
+	fmt.Printf("   copy %s/dknet-nodeN.service to /etc/systemd/system/\n", outputDir)
+	fmt.Println("   systemctl daemon-reload && systemctl enable --now dknet-nodeN")
+}
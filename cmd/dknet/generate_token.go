@@ -43,6 +43,14 @@ and generates a token that can be used by clients to authenticate with the API.`
 			}
 
 			jwtConfig := cfg.Security.APIAuth
+			algorithm := jwtConfig.Algorithm
+			if algorithm == "" {
+				algorithm = config.DefaultJWTAlgorithm
+			}
+			signingMethod, ok := jwt.GetSigningMethod(algorithm).(*jwt.SigningMethodHMAC)
+			if !ok {
+				return fmt.Errorf("cannot generate %s tokens locally, issue them with your identity provider", algorithm)
+			}
 			if jwtConfig.JWTSecret == "" {
 				return fmt.Errorf("JWT secret is not configured in server configuration")
 			}
@@ -68,7 +76,11 @@ and generates a token that can be used by clients to authenticate with the API.`
 				claims["exp"] = time.Now().Add(time.Duration(expiryHours) * time.Hour).Unix()
 			}
 
-			token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+			if jwtConfig.JWTAudience != "" {
+				claims["aud"] = jwtConfig.JWTAudience
+			}
+
+			token := jwt.NewWithClaims(signingMethod, claims)
 			tokenString, err := token.SignedString([]byte(jwtConfig.JWTSecret))
 			if err != nil {
 				return fmt.Errorf("failed to generate token: %w", err)
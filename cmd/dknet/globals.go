@@ -14,4 +14,6 @@ const (
 	flagOutput  = "output"
 	flagDocker  = "docker"
 	flagNodeDir = "node-dir"
+
+	flagPasswordFile = "password-file"
 )
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dreamer-zq/DKNet/internal/app"
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+)
+
+func runKeygenImportExternalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keygen-import-external",
+		Short: "Import a key share generated by another TSS system",
+		Long: `Import this node's share of a key generated by another threshold signature system.
+
+The share file is JSON with the threshold, the participants (peer IDs or node names),
+the hex encoded group public key, an optional alias and chain family, and the share as
+tss-lib v2 ECDSA keygen save data. The share must have been generated for the party keys
+DKNet derives from the participants' peer IDs. It is checked against the public key and
+stored encrypted with the node's encryption password under the key ID of the public key.
+
+Every participant imports its own share. Stop the node before importing, the storage is
+opened directly.`,
+		RunE:         runKeygenImportExternal,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP(flagNodeDir, "", "", "node directory containing config.yaml, node_key, and data/")
+	cmd.Flags().StringP("file", "f", "", "JSON file holding the key share")
+	cmd.Flags().String(flagPasswordFile, "",
+		"file holding the encryption password (can also use TSS_ENCRYPTION_PASSWORD_FILE env var)")
+	_ = cmd.MarkFlagRequired(flagNodeDir)
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runKeygenImportExternal(cmd *cobra.Command, args []string) error {
+	nodeDir, _ := cmd.Flags().GetString(flagNodeDir)
+	shareFile, _ := cmd.Flags().GetString("file")
+	passwordFile, _ := cmd.Flags().GetString(flagPasswordFile)
+
+	cfg, err := config.Load(nodeDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	data, err := os.ReadFile(shareFile)
+	if err != nil {
+		return fmt.Errorf("failed to read key share: %w", err)
+	}
+	var share tss.ExternalKeyShare
+	if err := json.Unmarshal(data, &share); err != nil {
+		return fmt.Errorf("failed to parse key share: %w", err)
+	}
+
+	password, err := common.ReadPassword(cmd.Context(), common.FilePasswordProvider(passwordFile))
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+
+	result, err := app.ImportKeyShare(cmd.Context(), cfg, logger, password, &share)
+	if err != nil {
+		return fmt.Errorf("failed to import key share: %w", err)
+	}
+
+	fmt.Println("Key share imported.")
+	fmt.Printf("Key ID:     %s\n", result.KeyID)
+	fmt.Printf("Address:    %s\n", result.Address)
+	fmt.Printf("Public key: %s\n", result.PublicKey)
+	if result.Alias != "" {
+		fmt.Printf("Alias:      %s\n", result.Alias)
+	}
+	return nil
+}
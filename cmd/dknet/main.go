@@ -39,7 +39,9 @@ using threshold cryptography to ensure no single point of failure.`,
 		RunE: runServer,
 	}
 
-	rootCmd.AddCommand(runStartCmd(), runInitClusterCmd(), runInitNodeCmd(), runShowNodeCmd(), generateTokenCmd(), version.NewCommand())
+	rootCmd.AddCommand(runStartCmd(), runInitClusterCmd(), runInitNodeCmd(), runShowNodeCmd(), runGenDeployCmd(), runSimulateCmd(),
+		runDoctorCmd(), runKeygenImportExternalCmd(),
+		generateTokenCmd(), version.NewCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -59,6 +61,8 @@ func runStartCmd() *cobra.Command {
 
 	cmd.Flags().StringP(flagNodeDir, "", "", "node directory containing config.yaml, node_key, and data/")
 	_ = cmd.MarkFlagRequired(flagNodeDir)
+	cmd.Flags().String(flagPasswordFile, "",
+		"file holding the encryption password, e.g. a mounted secret (can also use TSS_ENCRYPTION_PASSWORD_FILE env var)")
 
 	return cmd
 }
@@ -76,36 +80,45 @@ func runServer(cmd *cobra.Command, args []string) error {
 	}
 
 	// Reconfigure logger based on configuration
-	configuredLogger, err := common.NewLogger(&cfg.Logging)
+	var appOpts []app.Option
+	configuredLogger, logLevel, err := common.NewLoggerWithLevel(&cfg.Logging)
 	if err != nil {
 		logger.Warn("Failed to create configured logger, using default", zap.Error(err))
 	} else {
 		// Replace global logger
 		logger = configuredLogger
+		appOpts = append(appOpts, app.WithLogLevel(logLevel))
 		logger.Info("Logger reconfigured",
 			zap.String("level", cfg.Logging.Level),
 			zap.String("environment", cfg.Logging.Environment),
 			zap.String("output", cfg.Logging.Output))
 	}
 
-	// Get encryption password from environment or interactive input
+	// Get encryption password from a file, the environment or interactive input
 	fmt.Println("DKNet TSS Server - Secure Key Storage")
 	fmt.Println("=====================================")
 	fmt.Println("This server uses encrypted storage for TSS private keys.")
 
-	// Try environment variable first
-	password, err := common.ReadPassword()
+	// Flags are only defined on the start command
+	passwordFile, _ := cmd.Flags().GetString(flagPasswordFile)
+	password, err := common.ReadPassword(cmd.Context(), common.FilePasswordProvider(passwordFile))
 	if err != nil {
 		return fmt.Errorf("failed to read password: %w", err)
 	}
-	fmt.Println("Using password from TSS_ENCRYPTION_PASSWORD environment variable.")
+	if err := common.ValidatePassword(password); err != nil {
+		if cfg.Security.EnforcePasswordStrength {
+			return fmt.Errorf("weak encryption password: %w", err)
+		}
+		logger.Warn("Weak encryption password, enable security.enforce_password_strength to reject it", zap.Error(err))
+	}
+	fmt.Println("Encryption password loaded.")
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Initialize and start the application with encryption password
-	application, err := app.New(cfg, logger, password)
+	application, err := app.NewWithOptions(cfg, logger, append(appOpts, app.WithPassword(password))...)
 	if err != nil {
 		return fmt.Errorf("failed to create application: %w", err)
 	}
@@ -115,11 +128,13 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to start application: %w", err)
 	}
 
-	// Wait for interrupt signal for graceful shutdown
+	// Reload the config on SIGHUP, wait for interrupt signal for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 
-	<-sigChan
+	for sig := <-sigChan; sig == syscall.SIGHUP; sig = <-sigChan {
+		reloadConfig(nodeDir, application)
+	}
 	logger.Info("Shutdown signal received, stopping server...")
 
 	// Graceful shutdown
@@ -132,3 +147,16 @@ func runServer(cmd *cobra.Command, args []string) error {
 	logger.Info("Server stopped gracefully")
 	return nil
 }
+
+// reloadConfig re-reads the node config and applies the fields that can change live.
+// An invalid config is rejected and the running config kept.
+func reloadConfig(nodeDir string, application *app.App) {
+	logger.Info("Reload signal received, reloading config", zap.String("node_dir", nodeDir))
+
+	cfg, err := config.Load(nodeDir)
+	if err != nil {
+		logger.Error("Failed to reload config, keeping the running config", zap.Error(err))
+		return
+	}
+	application.Reload(cfg)
+}
@@ -137,14 +137,9 @@ func loadNodeConfig(nodeDir string) (*config.NodeConfig, error) {
 
 // loadPeerIDFromKeyFile loads the peer ID from the key file
 func loadPeerIDFromKeyFile(keyFile string) (peer.ID, error) {
-	data, err := os.ReadFile(keyFile)
+	privKey, err := loadNodeKey(keyFile)
 	if err != nil {
-		return "", fmt.Errorf("failed to read key file %s: %w", keyFile, err)
-	}
-
-	privKey, err := crypto.UnmarshalPrivateKey(data)
-	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal private key: %w", err)
+		return "", err
 	}
 
 	peerID, err := peer.IDFromPrivateKey(privKey)
@@ -155,6 +150,20 @@ func loadPeerIDFromKeyFile(keyFile string) (peer.ID, error) {
 	return peerID, nil
 }
 
+// loadNodeKey loads the P2P private key from the key file
+func loadNodeKey(keyFile string) (crypto.PrivKey, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", keyFile, err)
+	}
+
+	privKey, err := crypto.UnmarshalPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal private key: %w", err)
+	}
+	return privKey, nil
+}
+
 // buildDisplayMultiaddr builds the display multiaddr for the node
 func buildDisplayMultiaddr(cfg *config.NodeConfig, listenAddr string, port int, peerID string) string {
 	// If listen address is 0.0.0.0 (Docker mode), try to infer the correct IP
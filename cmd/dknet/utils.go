@@ -1,7 +1,6 @@
 package main
 
 import (
-	"crypto/rand"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/spf13/cobra"
 
+	"github.com/dreamer-zq/DKNet/internal/security"
 	"github.com/dreamer-zq/DKNet/templates"
 )
 
@@ -35,7 +35,7 @@ func ensureNodeDirectory(nodeDir string) error {
 // generateAndSaveNodeKey generates P2P key pair and saves private key to file
 func generateAndSaveNodeKey(nodeDir string) (crypto.PrivKey, peer.ID, error) {
 	// Generate private key
-	privKey, _, err := crypto.GenerateKeyPairWithReader(crypto.Secp256k1, 2048, rand.Reader)
+	privKey, err := security.GenerateNodeKey()
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to generate private key: %w", err)
 	}
@@ -93,25 +93,17 @@ type DockerNodeConfig struct {
 
 // DockerComposeConfig represents the full docker-compose configuration
 type DockerComposeConfig struct {
+	Image           string
 	Nodes           []DockerNodeConfig
 	UseCustomSubnet bool
 	Subnet          string
 }
 
+// defaultDockerImage is the image used by generated docker-compose files
+const defaultDockerImage = "dknet/dknet:latest"
+
 // generateDockerCompose generates docker-compose.yaml file using template
 func generateDockerCompose(outputDir string, nodes int) error {
-	// Read template
-	tmplContent, err := templates.GetTemplate("docker-compose.tmpl")
-	if err != nil {
-		return fmt.Errorf("failed to read docker-compose template: %w", err)
-	}
-
-	// Parse template
-	tmpl, err := template.New("docker-compose").Parse(string(tmplContent))
-	if err != nil {
-		return fmt.Errorf("failed to parse docker-compose template: %w", err)
-	}
-
 	// Generate node configurations
 	var nodeConfigs []DockerNodeConfig
 	for i := 1; i <= nodes; i++ {
@@ -136,18 +128,37 @@ func generateDockerCompose(outputDir string, nodes int) error {
 		nodeConfigs = append(nodeConfigs, nodeConfig)
 	}
 
-	// Create template data
-	config := DockerComposeConfig{
+	return writeDockerCompose(outputDir, &DockerComposeConfig{
+		Image:           defaultDockerImage,
 		Nodes:           nodeConfigs,
 		UseCustomSubnet: true,
 		Subnet:          "172.20.0.0/16",
+	})
+}
+
+// writeDockerCompose renders the docker-compose template into outputDir/docker-compose.yaml
+func writeDockerCompose(outputDir string, config *DockerComposeConfig) error {
+	return renderTemplate("docker-compose.tmpl", filepath.Join(outputDir, "docker-compose.yaml"), config)
+}
+
+// renderTemplate renders an embedded template into outputFile
+func renderTemplate(name, outputFile string, data any) error {
+	// Read template
+	tmplContent, err := templates.GetTemplate(name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s template: %w", name, err)
+	}
+
+	// Parse template
+	tmpl, err := template.New(name).Parse(string(tmplContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s template: %w", name, err)
 	}
 
 	// Generate output file
-	outputFile := filepath.Join(outputDir, "docker-compose.yaml")
 	file, err := os.Create(outputFile)
 	if err != nil {
-		return fmt.Errorf("failed to create docker-compose.yaml: %w", err)
+		return fmt.Errorf("failed to create %s: %w", outputFile, err)
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -156,8 +167,8 @@ func generateDockerCompose(outputDir string, nodes int) error {
 	}()
 
 	// Execute template
-	if err := tmpl.Execute(file, config); err != nil {
-		return fmt.Errorf("failed to execute docker-compose template: %w", err)
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to execute %s template: %w", name, err)
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -38,14 +39,84 @@ type Authenticator interface {
 type authenticator struct {
 	config *config.AuthConfig
 	logger *zap.Logger
+
+	parser  *jwt.Parser
+	keyFunc jwt.Keyfunc
 }
 
-// NewAuthenticator creates a new authenticator
-func NewAuthenticator(cfg *config.AuthConfig, logger *zap.Logger) Authenticator {
-	return &authenticator{
+// NewAuthenticator creates a new authenticator.
+// The verification key is selected by the configured algorithm: the shared secret for
+// HMAC, otherwise a PEM public key file or keys fetched from a JWKS URL.
+func NewAuthenticator(cfg *config.AuthConfig, logger *zap.Logger) (Authenticator, error) {
+	a := &authenticator{
 		config: cfg,
 		logger: logger,
 	}
+	if !cfg.Enabled {
+		return a, nil
+	}
+
+	algorithm := cfg.Algorithm
+	if algorithm == "" {
+		algorithm = config.DefaultJWTAlgorithm
+	}
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{method.Alg()})}
+	if cfg.JWTAudience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.JWTAudience))
+	}
+	a.parser = jwt.NewParser(opts...)
+
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		secret := []byte(cfg.JWTSecret)
+		a.keyFunc = func(*jwt.Token) (any, error) { return secret, nil }
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if cfg.JWKSURL != "" {
+			cache := newJWKSCache(cfg.JWKSURL, logger.Named("jwks"))
+			a.keyFunc = func(token *jwt.Token) (any, error) {
+				kid, _ := token.Header["kid"].(string)
+				return cache.getKey(context.Background(), kid)
+			}
+			break
+		}
+
+		publicKey, err := loadJWTPublicKey(cfg.PublicKeyFile, method)
+		if err != nil {
+			return nil, err
+		}
+		a.keyFunc = func(*jwt.Token) (any, error) { return publicKey, nil }
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+
+	return a, nil
+}
+
+// loadJWTPublicKey reads a PEM encoded RSA or ECDSA public key
+func loadJWTPublicKey(path string, method jwt.SigningMethod) (any, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT public key file: %w", err)
+	}
+
+	if _, ok := method.(*jwt.SigningMethodECDSA); ok {
+		key, err := jwt.ParseECPublicKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ECDSA public key: %w", err)
+		}
+		return key, nil
+	}
+
+	key, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSA public key: %w", err)
+	}
+	return key, nil
 }
 
 // Authenticate validates the JWT token
@@ -59,13 +130,8 @@ func (a *authenticator) Authenticate(ctx context.Context, token string) (*AuthCo
 	}
 	// Remove "Bearer " prefix if present
 	tokenString := strings.TrimPrefix(token, "Bearer ")
-	jwtToken, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(a.config.JWTSecret), nil
-	})
+	// The parser only accepts the configured algorithm, which rules out algorithm confusion
+	jwtToken, err := a.parser.Parse(tokenString, a.keyFunc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JWT token: %w", err)
 	}
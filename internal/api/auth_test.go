@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "alice",
+		"iss":   "test-idp",
+		"aud":   "dknet",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"operator"},
+	}
+}
+
+func writePublicKey(t *testing.T, key *rsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwt.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func TestAuthenticateRS256PublicKeyFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	auth, err := NewAuthenticator(&config.AuthConfig{
+		Enabled:       true,
+		Algorithm:     "RS256",
+		PublicKeyFile: writePublicKey(t, key),
+		JWTIssuer:     "test-idp",
+		JWTAudience:   "dknet",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	authCtx, err := auth.Authenticate(context.Background(), "Bearer "+signRS256(t, key, "", validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, "alice", authCtx.UserID)
+	require.Equal(t, []string{"operator"}, authCtx.Roles)
+
+	// Wrong audience
+	claims := validClaims()
+	claims["aud"] = "someone-else"
+	_, err = auth.Authenticate(context.Background(), signRS256(t, key, "", claims))
+	require.Error(t, err)
+
+	// Wrong issuer
+	claims = validClaims()
+	claims["iss"] = "evil-idp"
+	_, err = auth.Authenticate(context.Background(), signRS256(t, key, "", claims))
+	require.Error(t, err)
+
+	// Signed by another key
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = auth.Authenticate(context.Background(), signRS256(t, other, "", validClaims()))
+	require.Error(t, err)
+
+	// HS256 token using the public key bytes as secret must not be accepted
+	pubPEM, err := os.ReadFile(writePublicKey(t, key))
+	require.NoError(t, err)
+	hsToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString(pubPEM)
+	require.NoError(t, err)
+	_, err = auth.Authenticate(context.Background(), hsToken)
+	require.Error(t, err)
+}
+
+func TestAuthenticateRS256JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	auth, err := NewAuthenticator(&config.AuthConfig{
+		Enabled:   true,
+		Algorithm: "RS256",
+		JWKSURL:   server.URL,
+		JWTIssuer: "test-idp",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = auth.Authenticate(context.Background(), signRS256(t, key, "key-1", validClaims()))
+	require.NoError(t, err)
+
+	// Cached keys are reused
+	_, err = auth.Authenticate(context.Background(), signRS256(t, key, "key-1", validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+
+	// Unknown key IDs are rejected without hammering the JWKS endpoint
+	_, err = auth.Authenticate(context.Background(), signRS256(t, key, "key-2", validClaims()))
+	require.Error(t, err)
+	require.Equal(t, 1, requests)
+}
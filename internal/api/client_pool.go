@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ClientConnPool spreads gRPC calls over several client connections. A single HTTP/2
+// connection is limited by the server's max concurrent streams, so batch workloads
+// issuing many calls in parallel benefit from more than one connection.
+//
+// Each connection reconnects on its own after transient failures. A connection that
+// was shut down is re-created on its next use until the pool is closed.
+type ClientConnPool struct {
+	target string
+	opts   []grpc.DialOption
+
+	mu     sync.Mutex
+	conns  []*grpc.ClientConn
+	closed bool
+	next   atomic.Uint64
+}
+
+var _ grpc.ClientConnInterface = (*ClientConnPool)(nil)
+
+var errPoolClosed = errors.New("connection pool is closed")
+
+// NewClientConnPool creates size connections to target, each with the given dial options
+func NewClientConnPool(target string, size int, opts ...grpc.DialOption) (*ClientConnPool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("connection pool size must be at least 1, got %d", size)
+	}
+
+	pool := &ClientConnPool{target: target, opts: opts, conns: make([]*grpc.ClientConn, 0, size)}
+	for range size {
+		conn, err := grpc.NewClient(target, opts...)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+		pool.conns = append(pool.conns, conn)
+	}
+	return pool, nil
+}
+
+// Invoke performs a unary RPC on the next connection of the pool
+func (p *ClientConnPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	conn, err := p.pick()
+	if err != nil {
+		return err
+	}
+	return conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream begins a streaming RPC on the next connection of the pool
+func (p *ClientConnPool) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	conn, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewStream(ctx, desc, method, opts...)
+}
+
+// Size returns the number of connections in the pool
+func (p *ClientConnPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.conns)
+}
+
+// State returns the best connectivity state among the connections of the pool,
+// the pool is Ready as long as one of its connections is
+func (p *ClientConnPool) State() connectivity.State {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best := connectivity.Shutdown
+	for _, conn := range p.conns {
+		if state := conn.GetState(); stateRank(state) < stateRank(best) {
+			best = state
+		}
+	}
+	return best
+}
+
+// Reconnect re-creates the connections that were shut down and makes the others
+// retry immediately instead of waiting for their reconnect backoff
+func (p *ClientConnPool) Reconnect() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return errPoolClosed
+	}
+	for i := range p.conns {
+		conn, err := p.connAt(i)
+		if err != nil {
+			return err
+		}
+		conn.ResetConnectBackoff()
+		conn.Connect()
+	}
+	return nil
+}
+
+// Close closes all connections of the pool
+func (p *ClientConnPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	var errs []error
+	for _, conn := range p.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// pick returns connections in round-robin order
+func (p *ClientConnPool) pick() (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, errPoolClosed
+	}
+	return p.connAt(int((p.next.Add(1) - 1) % uint64(len(p.conns))))
+}
+
+// connAt returns the i-th connection, re-creating it if it was shut down.
+// The caller must hold p.mu.
+func (p *ClientConnPool) connAt(i int) (*grpc.ClientConn, error) {
+	if p.conns[i].GetState() != connectivity.Shutdown {
+		return p.conns[i], nil
+	}
+
+	conn, err := grpc.NewClient(p.target, p.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-create connection to %s: %w", p.target, err)
+	}
+	p.conns[i] = conn
+	return conn, nil
+}
+
+// stateRank orders connectivity states from the most to the least usable
+func stateRank(state connectivity.State) int {
+	switch state {
+	case connectivity.Ready:
+		return 0
+	case connectivity.Connecting:
+		return 1
+	case connectivity.Idle:
+		return 2
+	case connectivity.TransientFailure:
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// stubSigningServer accepts signing requests after a fixed delay standing in for the
+// work the node does before answering (dedup, validation, operation sync)
+type stubSigningServer struct {
+	tssv1.UnimplementedTSSServiceServer
+	delay   time.Duration
+	started atomic.Int64
+	clients sync.Map // remote address -> struct{}
+}
+
+func (s *stubSigningServer) StartSigning(ctx context.Context, req *tssv1.StartSigningRequest) (*tssv1.StartSigningResponse, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		s.clients.Store(p.Addr.String(), struct{}{})
+	}
+	time.Sleep(s.delay)
+	n := s.started.Add(1)
+	return &tssv1.StartSigningResponse{
+		OperationId: fmt.Sprintf("op-%d", n),
+		Status:      tssv1.OperationStatus_OPERATION_STATUS_PENDING,
+		CreatedAt:   timestamppb.Now(),
+	}, nil
+}
+
+func startStubServer(tb testing.TB, srv *stubSigningServer, maxStreams uint32) string {
+	tb.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(tb, err)
+
+	grpcServer := grpc.NewServer(grpc.MaxConcurrentStreams(maxStreams))
+	tssv1.RegisterTSSServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	tb.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestClientConnPool(t *testing.T) {
+	srv := &stubSigningServer{}
+	addr := startStubServer(t, srv, 100)
+
+	_, err := NewClientConnPool(addr, 0, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.Error(t, err)
+
+	pool, err := NewClientConnPool(addr, 3, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	require.Equal(t, 3, pool.Size())
+
+	client := tssv1.NewTSSServiceClient(pool)
+	for range 6 {
+		_, err := client.StartSigning(context.Background(), &tssv1.StartSigningRequest{KeyId: "key"})
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 6, srv.started.Load())
+
+	// Calls are spread round-robin, so every connection reached the server
+	clients := 0
+	srv.clients.Range(func(_, _ any) bool {
+		clients++
+		return true
+	})
+	require.Equal(t, 3, clients)
+
+	require.NoError(t, pool.Close())
+}
+
+// BenchmarkSigningThroughput measures signing requests per second accepted by a local
+// server that allows 8 concurrent streams per connection, for several pool sizes
+func BenchmarkSigningThroughput(b *testing.B) {
+	for _, size := range []int{1, 2, 4} {
+		b.Run(fmt.Sprintf("conns=%d", size), func(b *testing.B) {
+			srv := &stubSigningServer{delay: time.Millisecond}
+			addr := startStubServer(b, srv, 8)
+
+			pool, err := NewClientConnPool(addr, size, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(b, err)
+			defer func() { _ = pool.Close() }()
+			client := tssv1.NewTSSServiceClient(pool)
+
+			req := &tssv1.StartSigningRequest{
+				Message:      []byte("benchmark"),
+				KeyId:        "key",
+				Participants: []string{"node1", "node2", "node3"},
+			}
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			start := time.Now()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := client.StartSigning(context.Background(), req); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+			b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "ops/s")
+		})
+	}
+}
+
+func TestClientConnPoolReconnects(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+
+	srv := &stubSigningServer{}
+	grpcServer := grpc.NewServer()
+	tssv1.RegisterTSSServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+
+	pool, err := NewClientConnPool(addr, 2, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client := tssv1.NewTSSServiceClient(pool)
+	_, err = client.StartSigning(context.Background(), &tssv1.StartSigningRequest{KeyId: "key"})
+	require.NoError(t, err)
+	require.Equal(t, connectivity.Ready, pool.State())
+
+	// A connection that was shut down is re-created on its next use
+	pool.mu.Lock()
+	require.NoError(t, pool.conns[0].Close())
+	pool.mu.Unlock()
+	for range 2 {
+		_, err = client.StartSigning(context.Background(), &tssv1.StartSigningRequest{KeyId: "key"})
+		require.NoError(t, err)
+	}
+
+	// The node restarts on the same address
+	grpcServer.Stop()
+	require.Eventually(t, func() bool {
+		return pool.State() != connectivity.Ready
+	}, 5*time.Second, 10*time.Millisecond)
+
+	listener, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	grpcServer = grpc.NewServer()
+	tssv1.RegisterTSSServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	require.NoError(t, pool.Reconnect())
+	require.Eventually(t, func() bool {
+		_, err := client.StartSigning(context.Background(), &tssv1.StartSigningRequest{KeyId: "key"})
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, pool.Close())
+	_, err = client.StartSigning(context.Background(), &tssv1.StartSigningRequest{KeyId: "key"})
+	require.ErrorIs(t, err, errPoolClosed)
+	require.ErrorIs(t, pool.Reconnect(), errPoolClosed)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// Encodings of the message of an HTTP signing request
+const (
+	// MessageEncodingBase64 is standard or URL safe base64, padded or not, as protojson
+	// encodes bytes. It is the default.
+	MessageEncodingBase64 = "base64"
+	// MessageEncodingHex is hex with an optional 0x prefix
+	MessageEncodingHex = "hex"
+	// MessageEncodingText is the UTF-8 text of the message itself
+	MessageEncodingText = "text"
+)
+
+// DecodeMessage returns the message encoded as encoding, base64 when encoding is empty
+func DecodeMessage(message, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", MessageEncodingBase64:
+		enc := base64.StdEncoding
+		if strings.ContainsAny(message, "-_") {
+			enc = base64.URLEncoding
+		}
+		if len(message)%4 != 0 {
+			enc = enc.WithPadding(base64.NoPadding)
+		}
+		decoded, err := enc.DecodeString(message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 message: %w", err)
+		}
+		return decoded, nil
+	case MessageEncodingHex:
+		decoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(message, "0x"), "0X"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex message: %w", err)
+		}
+		return decoded, nil
+	case MessageEncodingText:
+		return []byte(message), nil
+	default:
+		return nil, fmt.Errorf("unknown message encoding %q, expected %s, %s or %s",
+			encoding, MessageEncodingBase64, MessageEncodingHex, MessageEncodingText)
+	}
+}
+
+// signRequestBody is the JSON body of an HTTP signing request, a StartSigningRequest whose
+// message is a string in message_encoding
+type signRequestBody struct {
+	*tssv1.StartSigningRequest
+	Message         string `json:"message"`
+	MessageEncoding string `json:"message_encoding"`
+}
+
+// signingRequest returns the signing request of the body with its message decoded
+func (b *signRequestBody) signingRequest() (*tssv1.StartSigningRequest, error) {
+	req := b.StartSigningRequest
+	if req == nil {
+		req = &tssv1.StartSigningRequest{}
+	}
+	message, err := DecodeMessage(b.Message, b.MessageEncoding)
+	if err != nil {
+		return nil, err
+	}
+	req.Message = message
+	return req, nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+func TestDecodeMessage(t *testing.T) {
+	message := []byte("hello dknet\xff\xfe")
+	for _, tc := range []struct {
+		encoded, encoding string
+	}{
+		{"aGVsbG8gZGtuZXT//g==", ""},
+		{"aGVsbG8gZGtuZXT//g==", MessageEncodingBase64},
+		{"aGVsbG8gZGtuZXT//g", MessageEncodingBase64},
+		{"aGVsbG8gZGtuZXT__g==", MessageEncodingBase64},
+		{"68656c6c6f20646b6e6574fffe", MessageEncodingHex},
+		{"0x68656c6c6f20646b6e6574fffe", MessageEncodingHex},
+		{"hello dknet\xff\xfe", MessageEncodingText},
+	} {
+		decoded, err := DecodeMessage(tc.encoded, tc.encoding)
+		require.NoError(t, err, "%s %q", tc.encoding, tc.encoded)
+		require.Equal(t, message, decoded)
+	}
+
+	_, err := DecodeMessage("hello dknet", MessageEncodingBase64)
+	require.ErrorContains(t, err, "invalid base64 message")
+	_, err = DecodeMessage("0xzz", MessageEncodingHex)
+	require.ErrorContains(t, err, "invalid hex message")
+	_, err = DecodeMessage("hello", "utf16")
+	require.ErrorContains(t, err, "unknown message encoding")
+}
+
+func TestSignRequestBody(t *testing.T) {
+	var body signRequestBody
+	require.NoError(t, json.Unmarshal([]byte(`{"message":"hello","message_encoding":"text","key_id":"treasury",
+		"participants":["node1","node2"],"chain_id":1,"metadata":{"purpose":"payout"}}`), &body))
+	req, err := body.signingRequest()
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), req.Message)
+	require.Equal(t, "treasury", req.KeyId)
+	require.Equal(t, []string{"node1", "node2"}, req.Participants)
+	require.Equal(t, uint64(1), req.GetChainId())
+	require.Equal(t, map[string]string{"purpose": "payout"}, req.Metadata)
+}
+
+func TestSignHandlerMessageEncodings(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.POST(APIVersionPrefix+SignPath, s.signHandler)
+
+	sign := func(message, encoding string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(map[string]any{
+			"message":          message,
+			"message_encoding": encoding,
+			"key_id":           "0x1111111111111111111111111111111111111111",
+			"participants":     []string{"node1", "node2"},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullSignPath, strings.NewReader(string(body))))
+		return rec
+	}
+
+	// Decoded messages reach the service, which is not among the participants
+	for encoding, message := range map[string]string{
+		"":                    "aGVsbG8=",
+		MessageEncodingBase64: "aGVsbG8=",
+		MessageEncodingHex:    "0x68656c6c6f",
+		MessageEncodingText:   "hello",
+	} {
+		rec := sign(message, encoding)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "this node is not a participant", encoding)
+	}
+
+	rec := sign("hello", MessageEncodingHex)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "invalid hex message")
+
+	rec = sign("hello", "utf16")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "unknown message encoding")
+
+	rec = sign("hello", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "invalid base64 message")
+
+	// An empty message is still rejected
+	rec = sign("", MessageEncodingText)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.NotContains(t, rec.Body.String(), "this node is not a participant")
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -9,8 +10,8 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/dreamer-zq/DKNet/internal/p2p"
 	"github.com/dreamer-zq/DKNet/internal/tss"
@@ -27,11 +28,18 @@ func (s *Server) startGRPCServer() error {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	// Create gRPC server with authentication interceptors
+	// Create gRPC server with authentication and request validation interceptors
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(GRPCAuthInterceptor(s.authenticator, s.logger)),
+		grpc.ChainUnaryInterceptor(
+			GRPCRequestIDInterceptor(s.logger.Named("access")),
+			GRPCAuthInterceptor(s.authenticator, s.logger),
+			GRPCValidationInterceptor(s.logger),
+		),
 		grpc.StreamInterceptor(GRPCAuthStreamInterceptor(s.authenticator, s.logger)),
 	}
+	if streams := s.config.Server.GRPC.MaxConcurrentStreams; streams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(uint32(streams)))
+	}
 	s.grpcServer = grpc.NewServer(opts...)
 
 	// Register services
@@ -61,17 +69,29 @@ func (s *Server) setupGRPCServices() {
 	tssServer := &gRPCTSSServer{
 		tssService: s.tssService,
 		network:    s.network,
+		moniker:    s.config.TSS.Moniker,
 		logger:     s.logger,
+		scope:      s.scope,
+		nodeInfo:   s.nodeInfo,
 	}
 
 	healthServer := &gRPCHealthServer{
-		logger: s.logger,
+		checkHealth:      s.checkHealth,
+		watchInterval:    time.Duration(s.config.Server.GRPC.HealthWatchIntervalSeconds) * time.Second,
+		watchSendTimeout: time.Duration(s.config.Server.GRPC.HealthWatchSendTimeoutSeconds) * time.Second,
+		logger:           s.logger,
 	}
 
 	// Register services with the gRPC server
 	tssv1.RegisterTSSServiceServer(s.grpcServer, tssServer)
 	healthv1.RegisterHealthServiceServer(s.grpcServer, healthServer)
 
+	// Let tools such as grpcurl list the services and their schemas
+	if s.config.Server.GRPC.Reflection {
+		reflection.Register(s.grpcServer)
+		s.logger.Info("gRPC reflection enabled")
+	}
+
 	s.logger.Info("gRPC services registered successfully")
 }
 
@@ -80,159 +100,382 @@ type gRPCTSSServer struct {
 	tssv1.UnimplementedTSSServiceServer
 	tssService *tss.Service
 	network    *p2p.Network
+	moniker    string
 	logger     *zap.Logger
+	scope      *operationScope
+	nodeInfo   func() *tssv1.GetNodeInfoResponse
 }
 
 // gRPCHealthServer implements the Health gRPC service
 type gRPCHealthServer struct {
 	healthv1.UnimplementedHealthServiceServer
-	logger *zap.Logger
+	checkHealth      func(ctx context.Context) *healthv1.CheckResponse
+	watchInterval    time.Duration
+	watchSendTimeout time.Duration
+	logger           *zap.Logger
 }
 
 // StartKeygen implements TSSService.StartKeygen
 func (g *gRPCTSSServer) StartKeygen(ctx context.Context, req *tssv1.StartKeygenRequest) (*tssv1.StartKeygenResponse, error) {
 	// Start keygen operation
+	operationID := g.scope.operationID(ctx, req.OperationId)
+	timeout, err := grpcRequestedTimeout(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = g.scope.withOwner(ctx, ctx)
 	operation, err := g.tssService.StartKeygen(
 		ctx,
-		req.OperationId,
+		operationID,
 		int(req.Threshold),
 		req.Participants,
+		req.Alias,
+		tss.ChainFamily(req.ChainFamily),
+		tss.SignatureAlgorithm(req.Algorithm),
+		tss.OperationOptions{Labels: req.Labels, Timeout: timeout, Policy: keyPolicyFromProto(req.Policy)},
 	)
 	if err != nil {
 		g.logger.Error("Failed to start keygen", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to start keygen: %v", err)
+		return nil, status.Errorf(startOperationCode(err), "failed to start keygen: %v", err)
 	}
 
 	// Convert to proto response
-	return &tssv1.StartKeygenResponse{
-		OperationId: operation.ID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
-	}, nil
+	snapshot := operation.Snapshot()
+	return buildStartKeygenResponse(snapshot), nil
 }
 
 // StartSigning implements TSSService.StartSigning
 func (g *gRPCTSSServer) StartSigning(ctx context.Context, req *tssv1.StartSigningRequest) (*tssv1.StartSigningResponse, error) {
 	// Start signing operation
+	operationID := g.scope.operationID(ctx, req.OperationId)
+	timeout, err := grpcRequestedTimeout(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = g.scope.withOwner(ctx, ctx)
 	operation, err := g.tssService.StartSigning(
 		ctx,
-		req.OperationId,
+		operationID,
 		req.Message,
 		req.KeyId,
 		req.Participants,
+		req.GetChainId(),
+		tss.SigningOptions{
+			Labels:         req.Labels,
+			Timeout:        timeout,
+			Metadata:       req.Metadata,
+			DerivationPath: req.DerivationPath,
+			ContextBound:   req.ContextBound,
+		},
 	)
 	if err != nil {
 		g.logger.Error("Failed to start signing", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to start signing: %v", err)
+		return nil, status.Errorf(startOperationCode(err), "failed to start signing: %v", err)
 	}
 
 	// Convert to proto response
-	return &tssv1.StartSigningResponse{
-		OperationId: operation.ID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
-	}, nil
+	snapshot := operation.Snapshot()
+	return buildStartSigningResponse(snapshot), nil
+}
+
+// SignTypedData implements TSSService.SignTypedData
+func (g *gRPCTSSServer) SignTypedData(ctx context.Context, req *tssv1.SignTypedDataRequest) (*tssv1.StartSigningResponse, error) {
+	// Start typed data signing operation
+	operationID := g.scope.operationID(ctx, req.OperationId)
+	timeout, err := grpcRequestedTimeout(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = g.scope.withOwner(ctx, ctx)
+	operation, err := g.tssService.StartTypedDataSigning(
+		ctx,
+		operationID,
+		[]byte(req.TypedData),
+		req.KeyId,
+		req.Participants,
+		req.GetChainId(),
+		tss.SigningOptions{
+			Labels:         req.Labels,
+			Timeout:        timeout,
+			Metadata:       req.Metadata,
+			DerivationPath: req.DerivationPath,
+			ContextBound:   req.ContextBound,
+		},
+	)
+	if err != nil {
+		g.logger.Error("Failed to start typed data signing", zap.Error(err))
+		return nil, status.Errorf(startOperationCode(err), "failed to start typed data signing: %v", err)
+	}
+
+	// Convert to proto response
+	snapshot := operation.Snapshot()
+	return buildStartSigningResponse(snapshot), nil
 }
 
 // StartResharing implements TSSService.StartResharing
 func (g *gRPCTSSServer) StartResharing(ctx context.Context, req *tssv1.StartResharingRequest) (*tssv1.StartResharingResponse, error) {
 	// Start resharing operation
+	operationID := g.scope.operationID(ctx, req.OperationId)
+	timeout, err := grpcRequestedTimeout(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = g.scope.withOwner(ctx, ctx)
 	operation, err := g.tssService.StartResharing(
 		ctx,
-		req.OperationId,
+		operationID,
 		req.KeyId,
 		int(req.NewThreshold),
 		req.NewParticipants,
+		tss.OperationOptions{Labels: req.Labels, Timeout: timeout},
 	)
 	if err != nil {
 		g.logger.Error("Failed to start resharing", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to start resharing: %v", err)
+		return nil, status.Errorf(startOperationCode(err), "failed to start resharing: %v", err)
 	}
 
 	// Convert to proto response
-	return &tssv1.StartResharingResponse{
-		OperationId: operation.ID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
-	}, nil
+	snapshot := operation.Snapshot()
+	return buildStartResharingResponse(snapshot), nil
 }
 
-// GetOperation implements TSSService.GetOperation
-func (g *gRPCTSSServer) GetOperation(ctx context.Context, req *tssv1.GetOperationRequest) (*tssv1.GetOperationResponse, error) {
-	// First try to get from active operations in memory
-	operation, exists := g.tssService.GetOperation(req.OperationId)
-	if exists {
-		operation.RLock()
-		defer operation.RUnlock()
-
-		return buildOperationResponse(operation), nil
+// RefreshShares implements TSSService.RefreshShares
+func (g *gRPCTSSServer) RefreshShares(ctx context.Context, req *tssv1.RefreshSharesRequest) (*tssv1.StartResharingResponse, error) {
+	operationID := g.scope.operationID(ctx, req.OperationId)
+	timeout, err := grpcRequestedTimeout(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = g.scope.withOwner(ctx, ctx)
+	operation, err := g.tssService.RefreshShares(ctx, operationID, req.KeyId, tss.OperationOptions{Labels: req.Labels, Timeout: timeout})
+	if err != nil {
+		g.logger.Error("Failed to start share refresh", zap.Error(err))
+		return nil, status.Errorf(startOperationCode(err), "failed to start share refresh: %v", err)
 	}
 
-	// If not found in memory, try persistent storage
-	operationData, err := g.tssService.GetOperationData(ctx, req.OperationId)
+	snapshot := operation.Snapshot()
+	return buildStartResharingResponse(snapshot), nil
+}
+
+// GetOperation implements TSSService.GetOperation
+func (g *gRPCTSSServer) GetOperation(ctx context.Context, req *tssv1.GetOperationRequest) (*tssv1.GetOperationResponse, error) {
+	_, operationData, err := g.scope.findOperation(ctx, g.tssService, req.OperationId)
 	if err != nil {
-		g.logger.Warn("Operation not found", zap.String("operation_id", req.OperationId), zap.Error(err))
+		g.logger.Warn("Operation not found", zap.String("operation_id", req.OperationId))
 		return nil, status.Errorf(codes.NotFound, "operation not found")
 	}
+	return buildOperationResponse(operationData, g.tssService), nil
+}
 
-	// Use reflection to access private fields since operationData is private
-	// This is a temporary solution until we can make the fields public or create a proper interface
-	return buildOperationResponseFromStorage(operationData), nil
+// GetOperations implements TSSService.GetOperations
+func (g *gRPCTSSServer) GetOperations(ctx context.Context, req *tssv1.GetOperationsRequest) (*tssv1.GetOperationsResponse, error) {
+	return g.scope.findOperations(ctx, g.tssService, req.OperationIds), nil
+}
+
+// ListOperations implements TSSService.ListOperations
+func (g *gRPCTSSServer) ListOperations(ctx context.Context, req *tssv1.ListOperationsRequest) (*tssv1.ListOperationsResponse, error) {
+	operations, nextPageToken, err := g.tssService.ListOperations(ctx, req.LabelSelector, int(req.PageSize), req.PageToken)
+	if err != nil {
+		g.logger.Error("Failed to list operations", zap.Error(err))
+		if errors.Is(err, tss.ErrInvalidLabels) || errors.Is(err, tss.ErrInvalidPageToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list operations: %v", err)
+	}
+	return buildListOperationsResponse(g.scope.visibleOperations(ctx, operations), nextPageToken, g.tssService), nil
 }
 
 // GetKeyMetadata implements TSSService.GetKeyMetadata
 func (g *gRPCTSSServer) GetKeyMetadata(ctx context.Context, req *tssv1.GetKeyMetadataRequest) (*tssv1.GetKeyMetadataResponse, error) {
+	// The key may be looked up by alias
+	keyID, err := g.tssService.ResolveKeyID(ctx, req.KeyId)
+	if err != nil {
+		if errors.Is(err, tss.ErrKeyAliasNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to resolve key: %v", err)
+	}
+
 	// Get key metadata
-	metadata, err := g.tssService.LoadKeyMetadata(ctx, req.KeyId)
+	metadata, err := g.tssService.LoadKeyMetadata(ctx, keyID)
 	if err != nil {
 		g.logger.Error("Failed to get key metadata", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "failed to get key metadata: %v", err)
 	}
 
+	addresses, err := g.tssService.KeyAddresses(ctx, keyID)
+	if err != nil {
+		g.logger.Error("Failed to derive key addresses", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to derive key addresses: %v", err)
+	}
+
 	// Convert to proto response
-	return &tssv1.GetKeyMetadataResponse{
-		Moniker:      metadata.Moniker,
-		Threshold:    int32(metadata.Threshold),
-		Participants: metadata.Participants,
+	return buildKeyMetadataResponse(keyID, metadata, addresses), nil
+}
+
+// HasKey implements TSSService.HasKey
+func (g *gRPCTSSServer) HasKey(ctx context.Context, req *tssv1.HasKeyRequest) (*tssv1.HasKeyResponse, error) {
+	presence, err := g.tssService.HasKey(ctx, req.KeyId)
+	if err != nil {
+		g.logger.Error("Failed to check key", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to check key: %v", err)
+	}
+	return &tssv1.HasKeyResponse{Known: presence.Known, HasShare: presence.HasShare}, nil
+}
+
+// FindKeyHolders implements TSSService.FindKeyHolders
+func (g *gRPCTSSServer) FindKeyHolders(
+	ctx context.Context,
+	req *tssv1.FindKeyHoldersRequest,
+) (*tssv1.FindKeyHoldersResponse, error) {
+	holders, err := g.tssService.FindKeyHolders(ctx, req.KeyId)
+	if err != nil {
+		g.logger.Error("Failed to find key holders", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to find key holders: %v", err)
+	}
+	return buildFindKeyHoldersResponse(holders), nil
+}
+
+// SyncPeers implements TSSService.SyncPeers
+func (g *gRPCTSSServer) SyncPeers(ctx context.Context, req *tssv1.SyncPeersRequest) (*tssv1.SyncPeersResponse, error) {
+	connected, err := g.network.SyncPeers()
+	if err != nil {
+		if errors.Is(err, p2p.ErrPeerSyncRateLimited) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
+		g.logger.Error("Failed to sync peers", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to sync peers: %v", err)
+	}
+
+	return &tssv1.SyncPeersResponse{
+		ConnectedPeers: int32(connected),
+	}, nil
+}
+
+// GetNodeAddress implements TSSService.GetNodeAddress
+func (g *gRPCTSSServer) GetNodeAddress(ctx context.Context, req *tssv1.GetNodeAddressRequest) (*tssv1.GetNodeAddressResponse, error) {
+	info, err := g.network.GetPeerInfo(req.NodeId)
+	if err != nil {
+		if errors.Is(err, p2p.ErrPeerNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		g.logger.Error("Failed to get node address", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to get node address: %v", err)
+	}
+
+	return buildNodeAddressResponse(info), nil
+}
+
+// GetNetworkAddresses implements TSSService.GetNetworkAddresses
+func (g *gRPCTSSServer) GetNetworkAddresses(
+	ctx context.Context,
+	req *tssv1.GetNetworkAddressesRequest,
+) (*tssv1.GetNetworkAddressesResponse, error) {
+	return buildNetworkAddressesResponse(g.network.ListPeers(), g.network.GetHostID(), g.moniker), nil
+}
+
+// SetMaintenanceMode implements TSSService.SetMaintenanceMode
+func (g *gRPCTSSServer) SetMaintenanceMode(
+	ctx context.Context,
+	req *tssv1.SetMaintenanceModeRequest,
+) (*tssv1.SetMaintenanceModeResponse, error) {
+	if err := g.tssService.SetMaintenanceMode(ctx, req.Enabled); err != nil {
+		g.logger.Error("Failed to set maintenance mode", zap.Error(err))
+		if nodeUnavailable(err) {
+			return nil, status.Errorf(codes.Unavailable, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to set maintenance mode: %v", err)
+	}
+
+	return &tssv1.SetMaintenanceModeResponse{
+		Enabled: g.tssService.MaintenanceMode(),
 	}, nil
 }
 
+// UpdateKeyPolicy implements TSSService.UpdateKeyPolicy
+func (g *gRPCTSSServer) UpdateKeyPolicy(
+	ctx context.Context,
+	req *tssv1.UpdateKeyPolicyRequest,
+) (*tssv1.UpdateKeyPolicyResponse, error) {
+	if !g.scope.isAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, errAdminRequired.Error())
+	}
+
+	keyID, policy, err := g.tssService.UpdateKeyPolicy(ctx, req.KeyId, keyPolicyFromProto(req.Policy))
+	if err != nil {
+		g.logger.Error("Failed to update key policy", zap.Error(err))
+		if errors.Is(err, tss.ErrInvalidKeyPolicy) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if errors.Is(err, tss.ErrKeyNotHeld) || errors.Is(err, tss.ErrKeyAliasNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		if nodeUnavailable(err) {
+			return nil, status.Errorf(codes.Unavailable, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update key policy: %v", err)
+	}
+
+	return &tssv1.UpdateKeyPolicyResponse{
+		KeyId:  keyID,
+		Policy: keyPolicyToProto(policy),
+	}, nil
+}
+
+// GetNodeInfo implements TSSService.GetNodeInfo
+func (g *gRPCTSSServer) GetNodeInfo(ctx context.Context, req *tssv1.GetNodeInfoRequest) (*tssv1.GetNodeInfoResponse, error) {
+	return g.nodeInfo(), nil
+}
+
 // Check implements HealthService.Check
 func (g *gRPCHealthServer) Check(ctx context.Context, req *healthv1.CheckRequest) (*healthv1.CheckResponse, error) {
-	return &healthv1.CheckResponse{
-		Status:    healthv1.HealthStatus_HEALTH_STATUS_SERVING,
-		Timestamp: timestamppb.Now(),
-		Details:   "DKNet is healthy",
-		Metadata: map[string]string{
-			"service": "dknet",
-			"version": "1.0.0",
-		},
-	}, nil
+	return g.checkHealth(ctx), nil
 }
 
-// Watch implements HealthService.Watch
+// Watch implements HealthService.Watch. It sends the current status right away and then
+// every watchInterval. A client that does not receive an update within watchSendTimeout
+// ends the stream so a stuck subscriber cannot hold the handler forever.
 func (g *gRPCHealthServer) Watch(req *healthv1.WatchRequest, stream healthv1.HealthService_WatchServer) error {
-	ticker := time.NewTicker(10 * time.Second)
+	ticker := time.NewTicker(g.watchInterval)
 	defer ticker.Stop()
 
 	for {
+		check := g.checkHealth(stream.Context())
+		resp := &healthv1.WatchResponse{
+			Status:    check.Status,
+			Timestamp: check.Timestamp,
+			Details:   check.Details,
+			Metadata:  check.Metadata,
+		}
+		if err := g.sendWatchResponse(stream, resp); err != nil {
+			return err
+		}
+
 		select {
 		case <-stream.Context().Done():
 			return nil
 		case <-ticker.C:
-			resp := &healthv1.WatchResponse{
-				Status:    healthv1.HealthStatus_HEALTH_STATUS_SERVING,
-				Timestamp: timestamppb.Now(),
-				Details:   "DKNet is healthy",
-				Metadata: map[string]string{
-					"service": "dknet",
-					"version": "1.0.0",
-				},
-			}
-
-			if err := stream.Send(resp); err != nil {
-				return err
-			}
 		}
 	}
 }
+
+// sendWatchResponse sends a Watch update, giving up after watchSendTimeout. Returning
+// from Watch cancels the stream, which also unblocks the pending Send.
+func (g *gRPCHealthServer) sendWatchResponse(stream healthv1.HealthService_WatchServer, resp *healthv1.WatchResponse) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- stream.Send(resp)
+	}()
+
+	timer := time.NewTimer(g.watchSendTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		g.logger.Warn("Health watch client is not receiving updates, closing stream",
+			zap.Duration("send_timeout", g.watchSendTimeout))
+		return status.Errorf(codes.DeadlineExceeded, "health update not received within %s", g.watchSendTimeout)
+	}
+}
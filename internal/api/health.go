@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	healthv1 "github.com/dreamer-zq/DKNet/proto/health/v1"
+	"github.com/dreamer-zq/DKNet/version"
+)
+
+// healthCheckTimeout bounds the storage probe of a health check
+const healthCheckTimeout = 5 * time.Second
+
+// checkHealth reports the node as serving when the TSS service can reach its storage
+func (s *Server) checkHealth(ctx context.Context) *healthv1.CheckResponse {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	resp := &healthv1.CheckResponse{
+		Status:    healthv1.HealthStatus_HEALTH_STATUS_SERVING,
+		Timestamp: timestamppb.Now(),
+		Details:   "DKNet is healthy",
+		Metadata: map[string]string{
+			"service":         "dknet",
+			"version":         nodeVersion(),
+			"node_id":         s.network.GetHostID(),
+			"moniker":         s.config.TSS.Moniker,
+			"connected_peers": strconv.Itoa(len(s.network.ListPeers()) - 1),
+		},
+	}
+
+	if banned := s.network.BannedPeers(); len(banned) > 0 {
+		ids := make([]string, len(banned))
+		for i, ban := range banned {
+			ids[i] = ban.NodeID
+		}
+		resp.Metadata["banned_peers"] = strings.Join(ids, ",")
+	}
+
+	running, queued := s.tssService.OperationStats()
+	resp.Metadata["running_operations"] = strconv.Itoa(running)
+	resp.Metadata["queued_operations"] = strconv.Itoa(queued)
+
+	// An open breaker rejects signing but leaves the node serving everything else
+	if state := s.tssService.ValidationBreakerState(); state != "" {
+		resp.Metadata["validation_breaker"] = string(state)
+	}
+
+	// Storage statistics are informational, failing to collect them does not fail the check
+	if stats, err := s.tssService.StorageStats(ctx); err != nil {
+		s.logger.Warn("Failed to collect storage statistics", zap.Error(err))
+	} else {
+		resp.Metadata["stored_keys"] = strconv.Itoa(stats.Keys)
+		resp.Metadata["stored_operations"] = strconv.Itoa(stats.Operations)
+		resp.Metadata["storage_bytes"] = strconv.FormatInt(stats.Bytes, 10)
+	}
+
+	if err := s.tssService.CheckHealth(ctx); err != nil {
+		s.logger.Warn("Health check failed", zap.Error(err))
+		resp.Status = healthv1.HealthStatus_HEALTH_STATUS_NOT_SERVING
+		resp.Details = err.Error()
+	}
+	return resp
+}
+
+// nodeVersion returns the version the node was built as, "dev" for builds without one
+func nodeVersion() string {
+	if version.Version == "" {
+		return "dev"
+	}
+	return version.Version
+}
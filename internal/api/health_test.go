@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	healthv1 "github.com/dreamer-zq/DKNet/proto/health/v1"
+)
+
+// fakeWatchStream is a health Watch stream whose Send is provided by the test
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	send func(*healthv1.WatchResponse) error
+}
+
+func (f *fakeWatchStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeWatchStream) Send(resp *healthv1.WatchResponse) error {
+	return f.send(resp)
+}
+
+func newTestHealthServer(status healthv1.HealthStatus) *gRPCHealthServer {
+	return &gRPCHealthServer{
+		checkHealth: func(ctx context.Context) *healthv1.CheckResponse {
+			return &healthv1.CheckResponse{Status: status, Timestamp: timestamppb.Now()}
+		},
+		watchInterval:    10 * time.Millisecond,
+		watchSendTimeout: 100 * time.Millisecond,
+		logger:           zap.NewNop(),
+	}
+}
+
+func TestHealthWatchSendsStatus(t *testing.T) {
+	server := newTestHealthServer(healthv1.HealthStatus_HEALTH_STATUS_NOT_SERVING)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []*healthv1.WatchResponse
+	stream := &fakeWatchStream{ctx: ctx, send: func(resp *healthv1.WatchResponse) error {
+		received = append(received, resp)
+		if len(received) == 3 {
+			cancel()
+		}
+		return nil
+	}}
+
+	require.NoError(t, server.Watch(&healthv1.WatchRequest{}, stream))
+	require.Len(t, received, 3)
+	for _, resp := range received {
+		require.Equal(t, healthv1.HealthStatus_HEALTH_STATUS_NOT_SERVING, resp.Status)
+	}
+}
+
+func TestHealthWatchSlowConsumer(t *testing.T) {
+	server := newTestHealthServer(healthv1.HealthStatus_HEALTH_STATUS_SERVING)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The client stopped reading, Send blocks until the stream is torn down
+	sendReturned := make(chan struct{})
+	stream := &fakeWatchStream{ctx: ctx, send: func(*healthv1.WatchResponse) error {
+		defer close(sendReturned)
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	done := make(chan error, 1)
+	go func() { done <- server.Watch(&healthv1.WatchRequest{}, stream) }()
+
+	select {
+	case err := <-done:
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not give up on a slow consumer")
+	}
+
+	// gRPC cancels the stream context once the handler returned
+	cancel()
+	select {
+	case <-sendReturned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked Send was not released")
+	}
+}
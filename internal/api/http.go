@@ -2,18 +2,34 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/tss"
 	healthv1 "github.com/dreamer-zq/DKNet/proto/health/v1"
 	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
 )
 
+// maxOperationWait caps the long-polling wait of GET /operations/:id, it must stay
+// below the server's WriteTimeout
+const maxOperationWait = 25 * time.Second
+
+// KeyShareHeader is set by HEAD /keys/:key_id to whether this node holds a share of the key
+const KeyShareHeader = "X-Key-Share"
+
+// protoJSON encodes HTTP responses with the proto JSON mapping, keeping the proto field
+// names so responses use the same snake_case names as the request bodies
+var protoJSON = protojson.MarshalOptions{UseProtoNames: true}
+
 // startHTTPServer starts the HTTP server
 func (s *Server) startHTTPServer() error {
 	// Set Gin mode
@@ -21,7 +37,7 @@ func (s *Server) startHTTPServer() error {
 
 	// Create Gin router
 	router := gin.New()
-	router.Use(gin.Logger(), gin.Recovery())
+	router.Use(HTTPRequestIDMiddleware(s.logger.Named("access")), gin.Recovery())
 
 	// Setup routes
 	s.setupHTTPRoutes(router)
@@ -36,11 +52,20 @@ func (s *Server) startHTTPServer() error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Serve the certificate through a reloader so rotated certificates apply without a restart
+	if s.config.Security.TLSEnabled {
+		reloader, err := newCertReloader(s.config.Security.CertFile, s.config.Security.KeyFile, s.logger)
+		if err != nil {
+			return err
+		}
+		s.httpServer.TLSConfig = reloader.tlsConfig()
+	}
+
 	// Start server in a goroutine
 	go func() {
 		var err error
 		if s.config.Security.TLSEnabled {
-			err = s.httpServer.ListenAndServeTLS(s.config.Security.CertFile, s.config.Security.KeyFile)
+			err = s.httpServer.ListenAndServeTLS("", "")
 		} else {
 			err = s.httpServer.ListenAndServe()
 		}
@@ -75,25 +100,35 @@ func (s *Server) setupHTTPRoutes(router *gin.Engine) {
 	api.Use(HTTPAuthMiddleware(s.authenticator, s.logger))
 	api.POST(KeygenPath, s.keygenHandler)
 	api.POST(SignPath, s.signHandler)
+	api.POST(SignTypedDataPath, s.signTypedDataHandler)
 	api.POST(ResharePath, s.reshareHandler)
+	api.POST(RefreshPath, s.refreshHandler)
 
+	api.GET(OperationsPath, s.listOperationsHandler)
 	api.GET(OperationPathPattern, s.getOperationHandler)
+	api.POST(OperationsActionPattern, s.operationsActionHandler)
 	api.GET(KeyMetadataPath, s.getKeyMetadataHandler)
+	api.HEAD(KeyMetadataPath, s.hasKeyHandler)
+	api.PUT(KeyPolicyPath, s.updateKeyPolicyHandler)
+	api.GET(KeyHoldersPath, s.findKeyHoldersHandler)
+
+	api.POST(NetworkSyncPath, s.syncPeersHandler)
+	api.GET(NetworkAddressesPath, s.getNetworkAddressesHandler)
+	api.GET(NodeAddressPathPattern, s.getNodeAddressHandler)
+
+	api.POST(MaintenancePath, s.setMaintenanceModeHandler)
+	api.GET(NodeInfoPath, s.getNodeInfoHandler)
 }
 
 // healthHandler handles health check requests
 func (s *Server) healthHandler(c *gin.Context) {
-	resp := &healthv1.CheckResponse{
-		Status:    healthv1.HealthStatus_HEALTH_STATUS_SERVING,
-		Timestamp: timestamppb.Now(),
-		Details:   "DKNet is healthy",
-		Metadata: map[string]string{
-			"service": "dknet",
-			"version": "1.0.0",
-		},
-	}
+	resp := s.checkHealth(c.Request.Context())
 
-	c.JSON(http.StatusOK, resp)
+	code := http.StatusOK
+	if resp.Status != healthv1.HealthStatus_HEALTH_STATUS_SERVING {
+		code = http.StatusServiceUnavailable
+	}
+	writeProto(c, code, resp)
 }
 
 // keygenHandler handles keygen requests
@@ -103,122 +138,351 @@ func (s *Server) keygenHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if violations := validateRequest(&req); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
+		return
+	}
 
-	// Use background context for async TSS operations to avoid HTTP timeout cancellation
+	timeout, err := requestedTimeout(c.GetHeader(OperationTimeoutHeader))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Detach async TSS operations from the request to avoid HTTP timeout cancellation
+	ctx := s.operationContext(c.Request.Context())
 	operation, err := s.tssService.StartKeygen(
-		context.Background(),
-		req.OperationId,
+		ctx,
+		s.scope.operationID(c.Request.Context(), req.OperationId),
 		int(req.Threshold),
 		req.Participants,
+		req.Alias,
+		tss.ChainFamily(req.ChainFamily),
+		tss.SignatureAlgorithm(req.Algorithm),
+		tss.OperationOptions{Labels: req.Labels, Timeout: timeout, Policy: keyPolicyFromProto(req.Policy)},
 	)
 	if err != nil {
 		s.logger.Error("Failed to start keygen", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(startOperationStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := &tssv1.StartKeygenResponse{
-		OperationId: operation.ID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
-	}
+	snapshot := operation.Snapshot()
+	resp := buildStartKeygenResponse(snapshot)
 
-	c.JSON(http.StatusAccepted, resp)
+	writeProto(c, startResponseStatus(snapshot), resp)
 }
 
 // signHandler handles signing requests
 func (s *Server) signHandler(c *gin.Context) {
-	var req tssv1.StartSigningRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var body signRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req, err := body.signingRequest()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if violations := validateRequest(req); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
+		return
+	}
 
-	// Use background context for async TSS operations to avoid HTTP timeout cancellation
+	timeout, err := requestedTimeout(c.GetHeader(OperationTimeoutHeader))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Detach async TSS operations from the request to avoid HTTP timeout cancellation
+	ctx := s.operationContext(c.Request.Context())
 	operation, err := s.tssService.StartSigning(
-		context.Background(),
-		req.OperationId,
+		ctx,
+		s.scope.operationID(c.Request.Context(), req.OperationId),
 		req.Message,
 		req.KeyId,
 		req.Participants,
+		req.GetChainId(),
+		tss.SigningOptions{
+			Labels:         req.Labels,
+			Timeout:        timeout,
+			Metadata:       req.Metadata,
+			DerivationPath: req.DerivationPath,
+			ContextBound:   req.ContextBound,
+		},
 	)
 	if err != nil {
 		s.logger.Error("Failed to start signing", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(startOperationStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshot := operation.Snapshot()
+	resp := buildStartSigningResponse(snapshot)
+
+	writeProto(c, startResponseStatus(snapshot), resp)
+}
+
+// signTypedDataHandler handles EIP-712 typed data signing requests
+func (s *Server) signTypedDataHandler(c *gin.Context) {
+	var req signTypedDataBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if violations := validateRequest(&tssv1.SignTypedDataRequest{
+		OperationId:  s.scope.operationID(c.Request.Context(), req.OperationID),
+		TypedData:    string(req.typedData()),
+		KeyId:        req.KeyID,
+		Participants: req.Participants,
+		Labels:       req.Labels,
+		Metadata:     req.Metadata,
+	}); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
 		return
 	}
 
-	resp := &tssv1.StartSigningResponse{
-		OperationId: operation.ID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
+	var chainID uint64
+	if req.ChainID != nil {
+		chainID = *req.ChainID
 	}
 
-	c.JSON(http.StatusAccepted, resp)
+	timeout, err := requestedTimeout(c.GetHeader(OperationTimeoutHeader))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Detach async TSS operations from the request to avoid HTTP timeout cancellation
+	ctx := s.operationContext(c.Request.Context())
+	operation, err := s.tssService.StartTypedDataSigning(
+		ctx,
+		s.scope.operationID(c.Request.Context(), req.OperationID),
+		req.typedData(),
+		req.KeyID,
+		req.Participants,
+		chainID,
+		tss.SigningOptions{
+			Labels:         req.Labels,
+			Timeout:        timeout,
+			Metadata:       req.Metadata,
+			DerivationPath: req.DerivationPath,
+			ContextBound:   req.ContextBound,
+		},
+	)
+	if err != nil {
+		s.logger.Error("Failed to start typed data signing", zap.Error(err))
+		c.JSON(startOperationStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshot := operation.Snapshot()
+	resp := buildStartSigningResponse(snapshot)
+
+	writeProto(c, startResponseStatus(snapshot), resp)
 }
 
 // reshareHandler handles resharing requests
 func (s *Server) reshareHandler(c *gin.Context) {
-	var req tssv1.StartResharingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var body reshareBody
+	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if violations := validateRequest(&body); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
+		return
+	}
 
-	// Use background context for async TSS operations to avoid HTTP timeout cancellation
+	timeout, err := requestedTimeout(c.GetHeader(OperationTimeoutHeader))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Detach async TSS operations from the request to avoid HTTP timeout cancellation
+	ctx := s.operationContext(c.Request.Context())
 	operation, err := s.tssService.StartResharing(
-		context.Background(),
-		req.OperationId,
-		req.KeyId,
-		int(req.NewThreshold),
-		req.NewParticipants,
+		ctx,
+		s.scope.operationID(c.Request.Context(), body.OperationId),
+		body.KeyId,
+		int(body.NewThreshold),
+		body.NewParticipants,
+		tss.OperationOptions{Labels: body.Labels, Timeout: timeout},
 	)
 	if err != nil {
 		s.logger.Error("Failed to start resharing", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(startOperationStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := &tssv1.StartResharingResponse{
-		OperationId: operation.ID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
+	snapshot := operation.Snapshot()
+	resp := buildStartResharingResponse(snapshot)
+
+	writeProto(c, startResponseStatus(snapshot), resp)
+}
+
+// refreshHandler handles share refresh requests
+func (s *Server) refreshHandler(c *gin.Context) {
+	var req tssv1.RefreshSharesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if violations := validateRequest(&req); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
+		return
 	}
 
-	c.JSON(http.StatusAccepted, resp)
+	timeout, err := requestedTimeout(c.GetHeader(OperationTimeoutHeader))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Detach async TSS operations from the request to avoid HTTP timeout cancellation
+	ctx := s.operationContext(c.Request.Context())
+	operation, err := s.tssService.RefreshShares(
+		ctx,
+		s.scope.operationID(c.Request.Context(), req.OperationId),
+		req.KeyId,
+		tss.OperationOptions{Labels: req.Labels, Timeout: timeout},
+	)
+	if err != nil {
+		s.logger.Error("Failed to start share refresh", zap.Error(err))
+		c.JSON(startOperationStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshot := operation.Snapshot()
+	resp := buildStartResharingResponse(snapshot)
+
+	writeProto(c, startResponseStatus(snapshot), resp)
 }
 
-// getOperationHandler handles get operation requests
+// getOperationHandler handles get operation requests. With ?wait=<duration> a
+// running operation is long-polled until it finishes or the wait expires.
 func (s *Server) getOperationHandler(c *gin.Context) {
 	operationID := c.Param("operation_id")
 
-	// First try to get from active operations in memory
-	operation, exists := s.tssService.GetOperation(operationID)
-	if exists {
-		operation.RLock()
-		defer operation.RUnlock()
-
-		c.JSON(http.StatusOK, buildOperationResponse(operation))
+	wait, err := parseOperationWait(c.Query("wait"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// If not found in memory, try persistent storage
-	ctx := context.Background()
-	operationData, err := s.tssService.GetOperationData(ctx, operationID)
+	operation, operationData, err := s.scope.findOperation(c.Request.Context(), s.tssService, operationID)
 	if err != nil {
-		s.logger.Warn("Operation not found", zap.String("operation_id", operationID), zap.Error(err))
+		s.logger.Warn("Operation not found", zap.String("operation_id", operationID))
 		c.JSON(http.StatusNotFound, gin.H{"error": "operation not found"})
 		return
 	}
 
-	resp := buildOperationResponseFromStorage(operationData)
+	// Long-poll operations that are still active in memory
+	if operation != nil && wait > 0 {
+		waitCtx, cancel := context.WithTimeout(c.Request.Context(), wait)
+		defer cancel()
+
+		// Return as soon as the operation finishes, the current state on timeout
+		select {
+		case <-operation.Done():
+		case <-waitCtx.Done():
+		}
+		operationData = operation.Snapshot()
+	}
+
+	writeProto(c, http.StatusOK, buildOperationResponse(operationData, s.tssService))
+}
+
+// operationsActionHandler dispatches custom methods on the operations collection, such as
+// POST /operations:batchGet
+func (s *Server) operationsActionHandler(c *gin.Context) {
+	switch c.Param("action") {
+	case BatchGetOperationsAction:
+		s.batchGetOperationsHandler(c)
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	}
+}
+
+// batchGetOperationsHandler handles bulk operation status lookups
+func (s *Server) batchGetOperationsHandler(c *gin.Context) {
+	var req tssv1.GetOperationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if violations := validateRequest(&req); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, s.scope.findOperations(c.Request.Context(), s.tssService, req.OperationIds))
+}
+
+// listOperationsHandler handles list operations requests. Each ?label=key=value query
+// parameter adds a label the listed operations must have, ?page_size and ?page_token
+// page through the operations.
+func (s *Server) listOperationsHandler(c *gin.Context) {
+	selector, err := parseLabelSelector(c.QueryArray("label"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	pageSize, err := parsePageSize(c.Query("page_size"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operations, nextPageToken, err := s.tssService.ListOperations(c.Request.Context(), selector, pageSize, c.Query("page_token"))
+	if err != nil {
+		s.logger.Error("Failed to list operations", zap.Error(err))
+		code := http.StatusInternalServerError
+		if errors.Is(err, tss.ErrInvalidLabels) || errors.Is(err, tss.ErrInvalidPageToken) {
+			code = http.StatusBadRequest
+		}
+		c.JSON(code, gin.H{"error": err.Error()})
+		return
+	}
+
+	operations = s.scope.visibleOperations(c.Request.Context(), operations)
+	writeProto(c, http.StatusOK, buildListOperationsResponse(operations, nextPageToken, s.tssService))
+}
 
-	c.JSON(http.StatusOK, resp)
+// parsePageSize parses the page_size query parameter, empty for the default page size
+func parsePageSize(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	pageSize, err := strconv.Atoi(value)
+	if err != nil || pageSize < 0 {
+		return 0, fmt.Errorf("invalid page_size %q: must be a non-negative integer", value)
+	}
+	return pageSize, nil
+}
+
+// parseOperationWait parses the wait query parameter, capping it at maxOperationWait
+func parseOperationWait(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	wait, err := time.ParseDuration(value)
+	if err != nil || wait < 0 {
+		return 0, fmt.Errorf("invalid wait %q, must be a duration such as 10s", value)
+	}
+	return min(wait, maxOperationWait), nil
 }
 
 // getKeyMetadataHandler handles get key metadata requests
 func (s *Server) getKeyMetadataHandler(c *gin.Context) {
-	keyID := c.Param("key_id")
+	// The key may be looked up by alias
+	keyID, err := s.tssService.ResolveKeyID(context.Background(), c.Param("key_id"))
+	if err != nil {
+		if errors.Is(err, tss.ErrKeyAliasNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	metadata, err := s.tssService.LoadKeyMetadata(context.Background(), keyID)
 	if err != nil {
@@ -227,9 +491,167 @@ func (s *Server) getKeyMetadataHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, &tssv1.GetKeyMetadataResponse{
-		Moniker:      metadata.Moniker,
-		Threshold:    int32(metadata.Threshold),
-		Participants: metadata.Participants,
+	addresses, err := s.tssService.KeyAddresses(context.Background(), keyID)
+	if err != nil {
+		s.logger.Error("Failed to derive key addresses", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, buildKeyMetadataResponse(keyID, metadata, addresses))
+}
+
+// hasKeyHandler answers whether this node knows a key, 404 if it does not, and whether
+// it holds a share of it in the KeyShareHeader
+func (s *Server) hasKeyHandler(c *gin.Context) {
+	presence, err := s.tssService.HasKey(c.Request.Context(), c.Param("key_id"))
+	if err != nil {
+		s.logger.Error("Failed to check key", zap.Error(err))
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	if !presence.Known {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	c.Header(KeyShareHeader, strconv.FormatBool(presence.HasShare))
+	c.Status(http.StatusOK)
+}
+
+// findKeyHoldersHandler lists the reachable nodes holding a share of a key
+func (s *Server) findKeyHoldersHandler(c *gin.Context) {
+	holders, err := s.tssService.FindKeyHolders(c.Request.Context(), c.Param("key_id"))
+	if err != nil {
+		s.logger.Error("Failed to find key holders", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, buildFindKeyHoldersResponse(holders))
+}
+
+// updateKeyPolicyHandler replaces the signing policy of a key with the policy in the body
+func (s *Server) updateKeyPolicyHandler(c *gin.Context) {
+	var policy tssv1.KeyPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req := &tssv1.UpdateKeyPolicyRequest{KeyId: c.Param("key_id"), Policy: &policy}
+	if violations := validateRequest(req); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": violations.Error()})
+		return
+	}
+	if !s.scope.isAdmin(c.Request.Context()) {
+		c.JSON(http.StatusForbidden, gin.H{"error": errAdminRequired.Error()})
+		return
+	}
+
+	keyID, updated, err := s.tssService.UpdateKeyPolicy(c.Request.Context(), req.KeyId, keyPolicyFromProto(req.Policy))
+	if err != nil {
+		s.logger.Error("Failed to update key policy", zap.Error(err))
+		code := http.StatusInternalServerError
+		if errors.Is(err, tss.ErrInvalidKeyPolicy) {
+			code = http.StatusBadRequest
+		}
+		if errors.Is(err, tss.ErrKeyNotHeld) || errors.Is(err, tss.ErrKeyAliasNotFound) {
+			code = http.StatusNotFound
+		}
+		if nodeUnavailable(err) {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, &tssv1.UpdateKeyPolicyResponse{
+		KeyId:  keyID,
+		Policy: keyPolicyToProto(updated),
+	})
+}
+
+// syncPeersHandler handles peer sync requests
+func (s *Server) syncPeersHandler(c *gin.Context) {
+	connected, err := s.network.SyncPeers()
+	if err != nil {
+		if errors.Is(err, p2p.ErrPeerSyncRateLimited) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		s.logger.Error("Failed to sync peers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, &tssv1.SyncPeersResponse{
+		ConnectedPeers: int32(connected),
 	})
 }
+
+// setMaintenanceModeHandler pauses or resumes accepting new operations
+func (s *Server) setMaintenanceModeHandler(c *gin.Context) {
+	var req tssv1.SetMaintenanceModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := s.tssService.SetMaintenanceMode(c.Request.Context(), req.Enabled); err != nil {
+		s.logger.Error("Failed to set maintenance mode", zap.Error(err))
+		code := http.StatusInternalServerError
+		if nodeUnavailable(err) {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, &tssv1.SetMaintenanceModeResponse{
+		Enabled: s.tssService.MaintenanceMode(),
+	})
+}
+
+// getNodeAddressHandler handles single node address lookups
+func (s *Server) getNodeAddressHandler(c *gin.Context) {
+	info, err := s.network.GetPeerInfo(c.Param("node_id"))
+	if err != nil {
+		if errors.Is(err, p2p.ErrPeerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		s.logger.Error("Failed to get node address", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProto(c, http.StatusOK, buildNodeAddressResponse(info))
+}
+
+// getNetworkAddressesHandler lists this node and its connected peers
+func (s *Server) getNetworkAddressesHandler(c *gin.Context) {
+	writeProto(c, http.StatusOK, buildNetworkAddressesResponse(s.network.ListPeers(), s.network.GetHostID(), s.config.TSS.Moniker))
+}
+
+// getNodeInfoHandler handles requests for the identity and capabilities of this node
+func (s *Server) getNodeInfoHandler(c *gin.Context) {
+	writeProto(c, http.StatusOK, s.nodeInfo())
+}
+
+// operationContext returns the context TSS operations are started with. It is not
+// canceled with the HTTP request, but keeps the caller and the request ID.
+func (s *Server) operationContext(reqCtx context.Context) context.Context {
+	ctx := context.WithoutCancel(reqCtx)
+	return s.scope.withOwner(ctx, ctx)
+}
+
+// writeProto writes a proto message as JSON. Unlike c.JSON, enums are encoded by name,
+// timestamps as RFC 3339 strings and oneof fields without their Go wrapper types.
+func writeProto(c *gin.Context, code int, msg proto.Message) {
+	data, err := protoJSON.Marshal(msg)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to encode response: %v", err)})
+		return
+	}
+	c.Data(code, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,485 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+func TestParseOperationWait(t *testing.T) {
+	wait, err := parseOperationWait("")
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	wait, err = parseOperationWait("10s")
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, wait)
+
+	// Waits are capped below the HTTP write timeout
+	wait, err = parseOperationWait("5m")
+	require.NoError(t, err)
+	require.Equal(t, maxOperationWait, wait)
+
+	for _, value := range []string{"soon", "-1s"} {
+		_, err = parseOperationWait(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestRequestedTimeout(t *testing.T) {
+	timeout, err := requestedTimeout("")
+	require.NoError(t, err)
+	require.Zero(t, timeout)
+
+	timeout, err = requestedTimeout("90s")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, timeout)
+
+	for _, value := range []string{"soon", "0s", "-1m"} {
+		_, err = requestedTimeout(value)
+		require.Error(t, err, value)
+	}
+
+	ctx := context.Background()
+	md := metadata.Pairs(OperationTimeoutMetadata, "later")
+	_, err = grpcRequestedTimeout(metadata.NewIncomingContext(ctx, md))
+	require.Error(t, err)
+	timeout, err = grpcRequestedTimeout(ctx)
+	require.NoError(t, err)
+	require.Zero(t, timeout)
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := parseLabelSelector([]string{"team=payments", "env=", "example.com/project=a"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "payments", "env": "", "example.com/project": "a"}, selector)
+
+	_, err = parseLabelSelector([]string{"team"})
+	require.Error(t, err)
+
+	// The selector survives the round trip through the client path
+	require.Equal(t, FullOperationsPath+"?label=env%3Dprod&label=team%3Dpayments",
+		GetListOperationsPath(map[string]string{"team": "payments", "env": "prod"}, 0, ""))
+	require.Equal(t, FullOperationsPath, GetListOperationsPath(nil, 0, ""))
+	require.Equal(t, FullOperationsPath+"?label=team%3Dpayments&page_size=2&page_token=b3AtMg",
+		GetListOperationsPath(map[string]string{"team": "payments"}, 2, "b3AtMg"))
+}
+
+func TestParsePageSize(t *testing.T) {
+	pageSize, err := parsePageSize("")
+	require.NoError(t, err)
+	require.Zero(t, pageSize)
+
+	pageSize, err = parsePageSize("50")
+	require.NoError(t, err)
+	require.Equal(t, 50, pageSize)
+
+	for _, value := range []string{"-1", "ten"} {
+		_, err = parsePageSize(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestWriteProtoUsesProtoJSONMapping(t *testing.T) {
+	resp := &tssv1.GetOperationResponse{
+		OperationId: "op-1",
+		Type:        tssv1.OperationType_OPERATION_TYPE_SIGNING,
+		Status:      tssv1.OperationStatus_OPERATION_STATUS_COMPLETED,
+		CreatedAt:   timestamppb.New(time.Date(2024, 6, 11, 13, 45, 30, 0, time.UTC)),
+		Result: &tssv1.GetOperationResponse_SigningResult{
+			SigningResult: &tssv1.SigningResult{Signature: "0x01", R: "0x02", S: "0x03", V: 27},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	writeProto(c, http.StatusOK, resp)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+	require.Equal(t, "op-1", raw["operation_id"])
+	require.Equal(t, "OPERATION_TYPE_SIGNING", raw["type"])
+	require.Equal(t, "OPERATION_STATUS_COMPLETED", raw["status"])
+	require.Equal(t, "2024-06-11T13:45:30Z", raw["created_at"])
+	require.Contains(t, raw, "signing_result")
+	require.NotContains(t, raw, "Result")
+
+	// Clients decode the body straight into the proto type
+	var decoded tssv1.GetOperationResponse
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &decoded))
+	require.True(t, proto.Equal(resp, &decoded))
+}
+
+func TestBuildOperationResponseOmittedMessage(t *testing.T) {
+	data := &tss.OperationData{
+		ID:        "op-1",
+		Type:      tss.OperationSigning,
+		Status:    tss.StatusCompleted,
+		CreatedAt: time.Now(),
+		Request: &tss.SigningRequest{
+			KeyID: "0xabc", MessageSHA256: "ab12", IsTypedData: true, Metadata: map[string]string{"invoice": "42"},
+		},
+	}
+	resp := buildOperationResponse(data, nil)
+	require.True(t, resp.MessageOmitted)
+	require.Equal(t, "ab12", resp.MessageSha256)
+	require.Equal(t, "0xabc", resp.GetTypedDataRequest().GetKeyId())
+	require.Empty(t, resp.GetTypedDataRequest().GetTypedData())
+	require.Equal(t, map[string]string{"invoice": "42"}, resp.GetTypedDataRequest().GetMetadata())
+
+	data.Request = &tss.SigningRequest{KeyID: "0xabc", Message: []byte("hello")}
+	resp = buildOperationResponse(data, nil)
+	require.False(t, resp.MessageOmitted)
+	require.Empty(t, resp.MessageSha256)
+	require.Equal(t, []byte("hello"), resp.GetSigningRequest().GetMessage())
+}
+
+func TestHasKeyHandler(t *testing.T) {
+	transport, err := p2p.NewMemoryHub().NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+	kdf := plugin.KDFParams{Algorithm: plugin.KDFArgon2id, Time: 1, Memory: 64, Threads: 1}
+	keyCipher, err := plugin.NewKeyCipher("test-password", kdf)
+	require.NoError(t, err)
+	share, err := keyCipher.Encrypt([]byte("{}"))
+	require.NoError(t, err)
+	record, err := json.Marshal(map[string]any{"key_data": share, "threshold": 1, "participants": []string{"node2", "node3"}})
+	require.NoError(t, err)
+	keyID := "0x1111111111111111111111111111111111111111"
+	require.NoError(t, store.Save(context.Background(), keyID, record))
+
+	// The record has no integrity tag, the migration adds one at startup
+	service, err := tss.NewService(&tss.Config{PeerID: transport.GetHostID(), KDF: kdf},
+		store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+
+	s := &Server{tssService: service, logger: zap.NewNop()}
+	router := gin.New()
+	router.HEAD(APIVersionPrefix+KeyMetadataPath, s.hasKeyHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, GetKeyPath(keyID), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "false", rec.Header().Get(KeyShareHeader))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, GetKeyPath("0x2222222222222222222222222222222222222222"), nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Empty(t, rec.Header().Get(KeyShareHeader))
+}
+
+func TestMaintenanceModeHandler(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.POST(APIVersionPrefix+MaintenancePath, s.setMaintenanceModeHandler)
+	router.POST(APIVersionPrefix+KeygenPath, s.keygenHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullMaintenancePath, strings.NewReader(`{"enabled":true}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"enabled":true}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullKeygenPath,
+		strings.NewReader(`{"threshold":1,"participants":["node1","node2"]}`)))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Contains(t, rec.Body.String(), "maintenance mode")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullMaintenancePath, strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, service.MaintenanceMode())
+}
+
+func TestReadOnlyNodeRefusesOperations(t *testing.T) {
+	transport, err := p2p.NewMemoryHub().NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+	service, err := tss.NewService(&tss.Config{
+		PeerID: transport.GetHostID(),
+		Mode:   tss.ModeReadOnly,
+		KDF:    plugin.KDFParams{Algorithm: plugin.KDFArgon2id, Time: 1, Memory: 64, Threads: 1},
+	}, store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+	saveScopeTestOperation(t, store, &tss.OperationData{ID: "op-done", Type: tss.OperationSigning, Status: tss.StatusCompleted})
+
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.GET(APIVersionPrefix+OperationPathPattern, s.getOperationHandler)
+	router.POST(APIVersionPrefix+KeygenPath, s.keygenHandler)
+	router.POST(APIVersionPrefix+MaintenancePath, s.setMaintenanceModeHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, GetOperationPath("op-done"), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullKeygenPath,
+		strings.NewReader(`{"threshold":1,"participants":["node1","node2"]}`)))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Contains(t, rec.Body.String(), "read-only")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullMaintenancePath, strings.NewReader(`{"enabled":true}`)))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestReshareHandlerChecksNewParties(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.POST(APIVersionPrefix+ResharePath, s.reshareHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullResharePath, strings.NewReader(
+		`{"key_id":"0xabc","new_threshold":1,"new_parties":5,"new_participants":["node1","node2","node3"]}`)))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "new_parties")
+
+	// A matching count passes validation and reaches the TSS service
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullResharePath, strings.NewReader(
+		`{"key_id":"0xabc","new_threshold":1,"new_parties":3,"new_participants":["node1","node2","node3"]}`)))
+	require.NotContains(t, rec.Body.String(), "new_parties")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Contains(t, rec.Body.String(), tss.ErrKeyAliasNotFound.Error())
+}
+
+func TestRefreshHandlerUnknownKey(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.POST(APIVersionPrefix+RefreshPath, s.refreshHandler)
+
+	for keyID, want := range map[string]error{
+		"treasury": tss.ErrKeyAliasNotFound,
+		"0x1111111111111111111111111111111111111111": tss.ErrKeyNotHeld,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FullRefreshPath, strings.NewReader(`{"key_id":"`+keyID+`"}`)))
+		require.Equal(t, http.StatusNotFound, rec.Code, keyID)
+		require.Contains(t, rec.Body.String(), want.Error())
+	}
+}
+
+func TestStartOperationStatus(t *testing.T) {
+	for err, want := range map[error]int{
+		fmt.Errorf("%w: treasury", tss.ErrKeyAliasNotFound): http.StatusNotFound,
+		tss.ErrKeyNotHeld:               http.StatusNotFound,
+		tss.ErrInvalidParticipants:      http.StatusBadRequest,
+		tss.ErrInvalidTypedData:         http.StatusBadRequest,
+		tss.ErrKeyPolicyViolation:       http.StatusForbidden,
+		tss.ErrSigningInProgress:        http.StatusConflict,
+		tss.ErrKeyAliasExists:           http.StatusConflict,
+		tss.ErrParticipantBusy:          http.StatusTooManyRequests,
+		tss.ErrReadOnly:                 http.StatusServiceUnavailable,
+		plugin.ErrValidationUnavailable: http.StatusServiceUnavailable,
+		errors.New("disk full"):         http.StatusInternalServerError,
+	} {
+		require.Equal(t, want, startOperationStatus(err), err.Error())
+	}
+}
+
+func TestFindKeyHoldersHandler(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.GET(APIVersionPrefix+KeyHoldersPath, s.findKeyHoldersHandler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, GetKeyHoldersPath("treasury"), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp tssv1.FindKeyHoldersResponse
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Empty(t, resp.KeyId)
+	require.False(t, resp.QuorumAvailable)
+}
+
+func TestRetryFinishedOperation(t *testing.T) {
+	service, store := newScopeTestService(t)
+	keyID := "0x1111111111111111111111111111111111111111"
+	saveScopeTestOperation(t, store, &tss.OperationData{
+		ID: "keygen-1", Type: tss.OperationKeygen, Status: tss.StatusCompleted, CreatedAt: time.Now(),
+		Result: &tss.KeygenResult{PublicKey: "04ab", KeyID: keyID},
+	})
+	saveScopeTestOperation(t, store, &tss.OperationData{
+		ID: "sign-1", Type: tss.OperationSigning, Status: tss.StatusCompleted, CreatedAt: time.Now(),
+		Result: &tss.SigningResult{Signature: "0x01", R: "0x02", S: "0x03", V: 27},
+	})
+	saveScopeTestOperation(t, store, &tss.OperationData{
+		ID: "sign-2", Type: tss.OperationSigning, Status: tss.StatusFailed, CreatedAt: time.Now(), Error: "party timed out",
+	})
+
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.POST(APIVersionPrefix+KeygenPath, s.keygenHandler)
+	router.POST(APIVersionPrefix+SignPath, s.signHandler)
+	post := func(path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, APIVersionPrefix+path, strings.NewReader(body)))
+		return rec
+	}
+
+	// Retrying a finished keygen returns its key without starting a new operation
+	rec := post(KeygenPath, `{"operation_id":"keygen-1","threshold":1,"participants":["a","b"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var keygenResp tssv1.StartKeygenResponse
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &keygenResp))
+	require.True(t, keygenResp.AlreadyCompleted)
+	require.Equal(t, tssv1.OperationStatus_OPERATION_STATUS_COMPLETED, keygenResp.Status)
+	require.Equal(t, keyID, keygenResp.Result.GetKeyId())
+	require.Nil(t, keygenResp.Error)
+
+	// Retrying a finished signing operation returns its signature
+	rec = post(SignPath, `{"operation_id":"sign-1","message":"aGVsbG8=","key_id":"`+keyID+`","participants":["a","b"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var signResp tssv1.StartSigningResponse
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &signResp))
+	require.True(t, signResp.AlreadyCompleted)
+	require.Equal(t, "0x01", signResp.Result.GetSignature())
+
+	// and a failed one its error, over gRPC as well
+	g := &gRPCTSSServer{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	grpcResp, err := g.StartSigning(context.Background(), &tssv1.StartSigningRequest{
+		OperationId: "sign-2", Message: []byte("hello"), KeyId: keyID, Participants: []string{"a", "b"},
+	})
+	require.NoError(t, err)
+	require.True(t, grpcResp.AlreadyCompleted)
+	require.Equal(t, tssv1.OperationStatus_OPERATION_STATUS_FAILED, grpcResp.Status)
+	require.Nil(t, grpcResp.Result)
+	require.Equal(t, "party timed out", grpcResp.GetError())
+}
+
+func TestUpdateKeyPolicyHandler(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{Enabled: true, JWTSecret: "secret"})}
+	router := gin.New()
+	router.PUT(APIVersionPrefix+KeyPolicyPath, s.updateKeyPolicyHandler)
+
+	put := func(roles []string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, GetKeyPolicyPath("0x1111111111111111111111111111111111111111"), strings.NewReader(body))
+		req = req.WithContext(SetAuthContext(req.Context(), &AuthContext{UserID: "alice", Roles: roles, Authenticated: true}))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Only admins may change key policies
+	rec := put([]string{"operator"}, `{"max_message_bytes":64}`)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = put([]string{"admin"}, `{"allowed_hash_modes":["sha3"]}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "invalid key policy")
+
+	rec = put([]string{"admin"}, `{"max_message_bytes":64}`)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestBatchGetOperationsHandler(t *testing.T) {
+	service, store := newScopeTestService(t)
+	saveScopeTestOperation(t, store, &tss.OperationData{
+		ID: "op-1", Type: tss.OperationSigning, Status: tss.StatusCompleted, CreatedAt: time.Now(),
+	})
+	s := &Server{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	router := gin.New()
+	router.GET(APIVersionPrefix+OperationPathPattern, s.getOperationHandler)
+	router.POST(APIVersionPrefix+OperationsActionPattern, s.operationsActionHandler)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return rec
+	}
+
+	// A missing operation is marked instead of failing the batch
+	rec := post(FullBatchGetOperationsPath, `{"operation_ids":["missing","op-1"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp tssv1.GetOperationsResponse
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 2)
+	require.Equal(t, "missing", resp.Results[0].OperationId)
+	require.False(t, resp.Results[0].Found)
+	require.Nil(t, resp.Results[0].Operation)
+	require.True(t, resp.Results[1].Found)
+	require.Equal(t, tssv1.OperationStatus_OPERATION_STATUS_COMPLETED, resp.Results[1].Operation.Status)
+
+	ids, err := json.Marshal(map[string][]string{"operation_ids": make([]string, maxBatchOperationIDs+1)})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, post(FullBatchGetOperationsPath, string(ids)).Code)
+	require.Equal(t, http.StatusBadRequest, post(FullBatchGetOperationsPath, `{}`).Code)
+	require.Equal(t, http.StatusNotFound, post(FullOperationsPath+":batchDelete", `{}`).Code)
+}
+
+func TestOperationParticipantDetails(t *testing.T) {
+	hub := p2p.NewMemoryHub()
+	var peerIDs []string
+	for range 3 {
+		transport, err := hub.NewTransport(zap.NewNop())
+		require.NoError(t, err)
+		peerIDs = append(peerIDs, transport.GetHostID())
+	}
+	self, alice, unnamed := peerIDs[0], peerIDs[1], peerIDs[2]
+
+	transport, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+	service, err := tss.NewService(&tss.Config{
+		PeerID:  self,
+		Moniker: "node-self",
+		// The first of several names of a node names it
+		NodeNames: map[string]string{"Alice": alice, "Zed": alice},
+		KDF:       plugin.KDFParams{Algorithm: plugin.KDFArgon2id, Time: 1, Memory: 64, Threads: 1},
+	}, store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+
+	saveScopeTestOperation(t, store, &tss.OperationData{
+		ID:           "op-1",
+		Type:         tss.OperationKeygen,
+		Status:       tss.StatusCompleted,
+		Participants: []string{self, alice, unnamed},
+		CreatedAt:    time.Now(),
+		Request:      &tss.KeygenRequest{Threshold: 1, Participants: []string{self, alice, unnamed}},
+	})
+
+	g := &gRPCTSSServer{tssService: service, logger: zap.NewNop(), scope: newOperationScope(&config.AuthConfig{})}
+	resp, err := g.GetOperation(context.Background(), &tssv1.GetOperationRequest{OperationId: "op-1"})
+	require.NoError(t, err)
+
+	// The plain list is kept, the details join it with names from the address book
+	require.Equal(t, []string{self, alice, unnamed}, resp.Participants)
+	require.Len(t, resp.ParticipantDetails, 3)
+	for i, want := range []string{"node-self", "Alice", ""} {
+		require.Equal(t, peerIDs[i], resp.ParticipantDetails[i].PeerId)
+		require.Equal(t, peerIDs[i], resp.ParticipantDetails[i].NodeId)
+		require.Equal(t, want, resp.ParticipantDetails[i].Moniker)
+	}
+}
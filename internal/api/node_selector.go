@@ -0,0 +1,151 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// NodeIDMetadata is the gRPC metadata key naming the node a call is addressed to
+const NodeIDMetadata = "x-node-id"
+
+// nodeProbeTimeout bounds how long NodeSelector waits for a node to report whether it
+// holds a key
+const nodeProbeTimeout = 3 * time.Second
+
+// ErrNoNodeAvailable is returned when none of the nodes of a NodeSelector can serve a call
+var ErrNoNodeAvailable = errors.New("no node available")
+
+// ClusterNode is a node of a cluster a client sends calls to
+type ClusterNode struct {
+	// Addr is the gRPC address of the node
+	Addr string
+	// NodeID is the peer ID of the node, sent with every call as NodeIDMetadata
+	NodeID string
+	// Conn is the connection to the node, State is used to skip unreachable nodes when
+	// it implements it (e.g. *grpc.ClientConn or *ClientConnPool)
+	Conn grpc.ClientConnInterface
+	// Client calls the node through Conn
+	Client tssv1.TSSServiceClient
+}
+
+// NewClusterNode returns the node nodeID reachable through conn at addr
+func NewClusterNode(addr, nodeID string, conn grpc.ClientConnInterface) *ClusterNode {
+	return &ClusterNode{Addr: addr, NodeID: nodeID, Conn: conn, Client: tssv1.NewTSSServiceClient(conn)}
+}
+
+// OutgoingContext returns ctx with the node ID of the node in the outgoing metadata
+func (n *ClusterNode) OutgoingContext(ctx context.Context) context.Context {
+	if n.NodeID == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, NodeIDMetadata, n.NodeID)
+}
+
+// unreachable reports whether the connection to the node is known to be down
+func (n *ClusterNode) unreachable() bool {
+	conn, ok := n.Conn.(interface{ State() connectivity.State })
+	if !ok {
+		return false
+	}
+	state := conn.State()
+	return state == connectivity.TransientFailure || state == connectivity.Shutdown
+}
+
+// NodeSelector spreads calls over several nodes of a cluster in round-robin order. Nodes
+// whose connection is down or that do not hold a share of the key a call uses are skipped,
+// and calls failing because their node is unavailable are retried on the next node.
+type NodeSelector struct {
+	nodes []*ClusterNode
+	next  atomic.Uint64
+}
+
+// NewNodeSelector returns a selector over nodes
+func NewNodeSelector(nodes []*ClusterNode) (*NodeSelector, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("at least one node is required")
+	}
+	return &NodeSelector{nodes: nodes}, nil
+}
+
+// Call runs call against a node holding a share of keyID, any node when keyID is empty.
+// Nodes among participants are tried before the others, participants may also be given by
+// names the selector does not know. The context passed to call carries the node's ID.
+// When call fails with codes.Unavailable it is tried on the next suitable node.
+func (s *NodeSelector) Call(
+	ctx context.Context,
+	keyID string,
+	participants []string,
+	call func(ctx context.Context, node *ClusterNode) error,
+) error {
+	var errs []error
+	for _, node := range s.candidates(participants) {
+		nodeCtx := node.OutgoingContext(ctx)
+		if err := s.check(nodeCtx, node, keyID); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", node.Addr, err))
+			continue
+		}
+
+		err := call(nodeCtx, node)
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", node.Addr, err))
+	}
+	return fmt.Errorf("%w: %w", ErrNoNodeAvailable, errors.Join(errs...))
+}
+
+// candidates returns the nodes in the order a call tries them: round-robin, with the
+// participants first
+func (s *NodeSelector) candidates(participants []string) []*ClusterNode {
+	start := s.next.Add(1) - 1
+	nodes := make([]*ClusterNode, len(s.nodes))
+	for i := range s.nodes {
+		nodes[i] = s.nodes[(start+uint64(i))%uint64(len(s.nodes))]
+	}
+	slices.SortStableFunc(nodes, func(a, b *ClusterNode) int {
+		return cmp.Compare(participantRank(participants, a), participantRank(participants, b))
+	})
+	return nodes
+}
+
+// participantRank is 0 for participants and 1 for other nodes
+func participantRank(participants []string, node *ClusterNode) int {
+	if slices.Contains(participants, node.NodeID) {
+		return 0
+	}
+	return 1
+}
+
+// check returns why node cannot serve a call on keyID, nil if it can
+func (s *NodeSelector) check(ctx context.Context, node *ClusterNode, keyID string) error {
+	if node.unreachable() {
+		return errors.New("not reachable")
+	}
+	if keyID == "" {
+		return nil
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, nodeProbeTimeout)
+	defer cancel()
+	presence, err := node.Client.HasKey(probeCtx, &tssv1.HasKeyRequest{KeyId: keyID})
+	if err != nil {
+		return fmt.Errorf("failed to look up key: %w", err)
+	}
+	if !presence.HasShare {
+		return fmt.Errorf("does not hold a share of key %s", keyID)
+	}
+	return nil
+}
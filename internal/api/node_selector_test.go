@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// stubKeyServer holds shares of keys and answers signing requests with the node ID they
+// were addressed to
+type stubKeyServer struct {
+	tssv1.UnimplementedTSSServiceServer
+	keys        map[string]bool
+	unavailable bool
+}
+
+func (s *stubKeyServer) HasKey(_ context.Context, req *tssv1.HasKeyRequest) (*tssv1.HasKeyResponse, error) {
+	return &tssv1.HasKeyResponse{Known: s.keys[req.KeyId], HasShare: s.keys[req.KeyId]}, nil
+}
+
+func (s *stubKeyServer) StartSigning(ctx context.Context, _ *tssv1.StartSigningRequest) (*tssv1.StartSigningResponse, error) {
+	if s.unavailable {
+		return nil, status.Error(codes.Unavailable, "node is in maintenance mode")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return &tssv1.StartSigningResponse{OperationId: md.Get(NodeIDMetadata)[0]}, nil
+}
+
+// newStubClusterNode serves srv and returns a node connected to it, stopping the server
+// right away when stopped is set
+func newStubClusterNode(t *testing.T, nodeID string, srv *stubKeyServer, stopped bool) *ClusterNode {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	tssv1.RegisterTSSServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	if stopped {
+		grpcServer.Stop()
+	}
+
+	conn, err := NewClientConnPool(listener.Addr().String(), 1, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewClusterNode(listener.Addr().String(), nodeID, conn)
+}
+
+// startSigning signs with keyID through selector, returning the ID of the node that took the call
+func startSigning(selector *NodeSelector, keyID string, participants []string) (string, error) {
+	var nodeID string
+	err := selector.Call(context.Background(), keyID, participants, func(ctx context.Context, node *ClusterNode) error {
+		resp, err := node.Client.StartSigning(ctx, &tssv1.StartSigningRequest{KeyId: keyID})
+		if err != nil {
+			return err
+		}
+		nodeID = resp.OperationId
+		return nil
+	})
+	return nodeID, err
+}
+
+func TestNodeSelectorFailover(t *testing.T) {
+	down := newStubClusterNode(t, "node-down", &stubKeyServer{keys: map[string]bool{"key-1": true}}, true)
+	other := newStubClusterNode(t, "node-other", &stubKeyServer{keys: map[string]bool{"key-2": true}}, false)
+	maintenance := newStubClusterNode(t, "node-maintenance",
+		&stubKeyServer{keys: map[string]bool{"key-1": true}, unavailable: true}, false)
+	holder := newStubClusterNode(t, "node-holder", &stubKeyServer{keys: map[string]bool{"key-1": true, "key-2": true}}, false)
+
+	selector, err := NewNodeSelector([]*ClusterNode{down, other, maintenance, holder})
+	require.NoError(t, err)
+
+	// Whichever node the round-robin starts at, key-1 calls skip the node that is down and the
+	// one without the key, and fail over from the node in maintenance mode
+	for range 4 {
+		nodeID, err := startSigning(selector, "key-1", nil)
+		require.NoError(t, err)
+		require.Equal(t, "node-holder", nodeID)
+	}
+
+	// Calls are spread over the nodes holding the key
+	seen := make(map[string]bool)
+	for range 4 {
+		nodeID, err := startSigning(selector, "key-2", nil)
+		require.NoError(t, err)
+		seen[nodeID] = true
+	}
+	require.Equal(t, map[string]bool{"node-other": true, "node-holder": true}, seen)
+
+	// Participants are tried first
+	for range 4 {
+		nodeID, err := startSigning(selector, "key-2", []string{"node-other"})
+		require.NoError(t, err)
+		require.Equal(t, "node-other", nodeID)
+	}
+
+	// No node holds key-3
+	_, err = startSigning(selector, "key-3", nil)
+	require.ErrorIs(t, err, ErrNoNodeAvailable)
+	require.ErrorContains(t, err, "does not hold a share of key key-3")
+}
+
+func TestNodeSelectorKeepsCallErrors(t *testing.T) {
+	selector, err := NewNodeSelector([]*ClusterNode{
+		newStubClusterNode(t, "node-1", &stubKeyServer{}, false),
+		newStubClusterNode(t, "node-2", &stubKeyServer{}, false),
+	})
+	require.NoError(t, err)
+
+	// Errors other than Unavailable are returned without trying other nodes
+	calls := 0
+	err = selector.Call(context.Background(), "", nil, func(context.Context, *ClusterNode) error {
+		calls++
+		return status.Error(codes.InvalidArgument, "bad request")
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, 1, calls)
+
+	_, err = NewNodeSelector(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+	"github.com/dreamer-zq/DKNet/version"
+)
+
+// nodeInfo describes this node from its configuration, the TSS service and the P2P host
+func (s *Server) nodeInfo() *tssv1.GetNodeInfoResponse {
+	nodeID := s.network.GetHostID()
+	var addresses []string
+	if info, err := s.network.GetPeerInfo(nodeID); err == nil {
+		addresses = info.Addresses
+	}
+	return buildNodeInfoResponse(s.config, s.tssService, nodeID, addresses)
+}
+
+// buildNodeInfoResponse describes the node nodeID listening on addresses
+func buildNodeInfoResponse(
+	cfg *config.NodeConfig,
+	tssService *tss.Service,
+	nodeID string,
+	addresses []string,
+) *tssv1.GetNodeInfoResponse {
+	capabilities := tssService.Capabilities()
+	return &tssv1.GetNodeInfoResponse{
+		NodeId:    nodeID,
+		Moniker:   cfg.TSS.Moniker,
+		Version:   version.Version,
+		GitCommit: version.GitCommit,
+		Curves:    capabilities.Curves,
+		Algorithms: common.Map(capabilities.Algorithms, func(algorithm tss.SignatureAlgorithm) string {
+			return string(algorithm)
+		}),
+		ChainFamilies: common.Map(capabilities.ChainFamilies, func(family tss.ChainFamily) string {
+			return string(family)
+		}),
+		AddressFormats: common.Map(capabilities.AddressFormats, func(format tss.AddressFormat) string {
+			return string(format)
+		}),
+		KeyIdFormat:          capabilities.KeyIDFormat,
+		AuthEnabled:          cfg.Security.APIAuth.Enabled,
+		AccessControlEnabled: cfg.Security.AccessControl.Enabled,
+		ValidationEnabled:    tssService.ValidationEnabled(),
+		TlsEnabled:           cfg.Security.TLSEnabled,
+		Maintenance:          tssService.MaintenanceMode(),
+		Addresses:            addresses,
+	}
+}
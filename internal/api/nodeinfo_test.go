@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+	"github.com/dreamer-zq/DKNet/version"
+)
+
+func TestBuildNodeInfoResponse(t *testing.T) {
+	service, _ := newScopeTestService(t)
+	require.NoError(t, service.SetMaintenanceMode(context.Background(), true))
+
+	version.Version, version.GitCommit = "v1.2.3", "abc123"
+	t.Cleanup(func() { version.Version, version.GitCommit = "", "" })
+
+	cfg := &config.NodeConfig{
+		TSS: config.TSSConfig{Moniker: "alice"},
+		Security: config.SecurityConfig{
+			TLSEnabled: true,
+			APIAuth:    config.AuthConfig{Enabled: true},
+		},
+	}
+	addresses := []string{"/ip4/127.0.0.1/tcp/4001"}
+	info := buildNodeInfoResponse(cfg, service, "node1", addresses)
+
+	require.Equal(t, "node1", info.NodeId)
+	require.Equal(t, "alice", info.Moniker)
+	require.Equal(t, "v1.2.3", info.Version)
+	require.Equal(t, "abc123", info.GitCommit)
+	require.Equal(t, []string{tss.CurveSecp256k1}, info.Curves)
+	require.Equal(t, []string{"ecdsa"}, info.Algorithms)
+	require.Equal(t, []string{"ethereum", "bitcoin"}, info.ChainFamilies)
+	require.Equal(t, []string{"ethereum", "btc_p2pkh", "btc_p2wpkh"}, info.AddressFormats)
+	require.Equal(t, tss.KeyIDFormatAddress, info.KeyIdFormat)
+	require.True(t, info.AuthEnabled)
+	require.False(t, info.AccessControlEnabled)
+	require.False(t, info.ValidationEnabled)
+	require.True(t, info.TlsEnabled)
+	require.True(t, info.Maintenance)
+	require.Equal(t, addresses, info.Addresses)
+}
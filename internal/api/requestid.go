@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dreamer-zq/DKNet/internal/tss"
+)
+
+const (
+	// RequestIDHeader carries the ID correlating a client request with the operation it starts
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDMetadata is the gRPC metadata key of the request ID
+	RequestIDMetadata = "x-request-id"
+
+	// maxRequestIDLength bounds client supplied request IDs, longer IDs are replaced
+	maxRequestIDLength = 128
+)
+
+// requestIDContextKey is the context key of the request ID
+type requestIDContextKey struct{}
+
+// GetRequestID returns the request ID of a request context
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// withRequestID returns ctx carrying requestID for the API and the TSS service
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return tss.WithRequestID(context.WithValue(ctx, requestIDContextKey{}, requestID), requestID)
+}
+
+// requestIDOrNew returns the client supplied request ID if it is usable, a new one otherwise
+func requestIDOrNew(requestID string) string {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return uuid.New().String()
+	}
+	for _, r := range requestID {
+		if r < 0x21 || r > 0x7e {
+			return uuid.New().String()
+		}
+	}
+	return requestID
+}
+
+// HTTPRequestIDMiddleware honors or generates the request ID of every HTTP request,
+// returns it in the response header and writes a structured access log entry
+func HTTPRequestIDMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestID := requestIDOrNew(c.GetHeader(RequestIDHeader))
+		c.Request = c.Request.WithContext(withRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+
+		logger.Info("HTTP request",
+			zap.String("request_id", requestID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()))
+	}
+}
+
+// GRPCRequestIDInterceptor honors or generates the request ID of every unary call,
+// returns it in the response header metadata and writes a structured access log entry
+func GRPCRequestIDInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		var requestID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDMetadata); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		requestID = requestIDOrNew(requestID)
+		ctx = withRequestID(ctx, requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+
+		resp, err := handler(ctx, req)
+
+		logger.Info("gRPC request",
+			zap.String("request_id", requestID),
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("latency", time.Since(start)))
+		return resp, err
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestIDOrNew(t *testing.T) {
+	require.Equal(t, "req-1", requestIDOrNew("req-1"))
+
+	for _, value := range []string{"", "has space", "line\nbreak", strings.Repeat("a", maxRequestIDLength+1)} {
+		generated := requestIDOrNew(value)
+		require.NotEqual(t, value, generated)
+		require.NotEmpty(t, generated)
+	}
+}
+
+func TestHTTPRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HTTPRequestIDMiddleware(zap.NewNop()))
+
+	var seen string
+	router.GET("/", func(c *gin.Context) {
+		seen = GetRequestID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	// A client supplied ID is kept and returned
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, "req-1", seen)
+	require.Equal(t, "req-1", rec.Header().Get(RequestIDHeader))
+
+	// Otherwise one is generated
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NotEmpty(t, seen)
+	require.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+}
+
+func TestGRPCRequestIDInterceptor(t *testing.T) {
+	interceptor := GRPCRequestIDInterceptor(zap.NewNop())
+	info := &grpc.UnaryServerInfo{FullMethod: "/tss.v1.TSSService/StartSigning"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadata, "req-1"))
+	resp, err := interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		return GetRequestID(ctx), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "req-1", resp)
+}
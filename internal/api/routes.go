@@ -1,5 +1,12 @@
 package api
 
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
 // API路径常量定义 - 供客户端和服务端共享使用
 const (
 	// API版本前缀
@@ -9,27 +16,97 @@ const (
 	HealthPath = "/health"
 
 	// TSS操作路径
-	KeygenPath  = "/keygen"
-	SignPath    = "/sign"
-	ResharePath = "/reshare"
+	KeygenPath        = "/keygen"
+	SignPath          = "/sign"
+	SignTypedDataPath = "/sign/typed-data"
+	ResharePath       = "/reshare"
+	RefreshPath       = "/reshare/refresh"
 
 	// 操作查询路径
 	OperationsPath = "/operations"
+	// 批量查询操作的自定义方法
+	BatchGetOperationsAction = ":batchGet"
+
+	// 密钥查询路径
+	KeysPath = "/keys"
+	// 密钥策略路径后缀
+	KeyPolicySuffix = "/policy"
+	// 密钥持有节点路径后缀
+	KeyHoldersSuffix = "/holders"
+
+	// 网络管理路径
+	NetworkSyncPath      = "/network/sync"
+	NetworkAddressesPath = "/network/addresses"
+
+	// 节点管理路径
+	MaintenancePath = "/node/maintenance"
+	NodeInfoPath    = "/node/info"
 
 	// 完整的API路径
-	FullKeygenPath     = APIVersionPrefix + KeygenPath
-	FullSignPath       = APIVersionPrefix + SignPath
-	FullResharePath    = APIVersionPrefix + ResharePath
-	FullOperationsPath = APIVersionPrefix + OperationsPath
+	FullKeygenPath             = APIVersionPrefix + KeygenPath
+	FullSignPath               = APIVersionPrefix + SignPath
+	FullSignTypedDataPath      = APIVersionPrefix + SignTypedDataPath
+	FullResharePath            = APIVersionPrefix + ResharePath
+	FullRefreshPath            = APIVersionPrefix + RefreshPath
+	FullOperationsPath         = APIVersionPrefix + OperationsPath
+	FullBatchGetOperationsPath = FullOperationsPath + BatchGetOperationsAction
+	FullKeysPath               = APIVersionPrefix + KeysPath
+	FullNetworkSyncPath        = APIVersionPrefix + NetworkSyncPath
+	FullNetworkAddressesPath   = APIVersionPrefix + NetworkAddressesPath
+	FullMaintenancePath        = APIVersionPrefix + MaintenancePath
+	FullNodeInfoPath           = APIVersionPrefix + NodeInfoPath
 )
 
+// GetNodeAddressPath 返回特定节点地址的完整路径
+func GetNodeAddressPath(nodeID string) string {
+	return FullNetworkAddressesPath + "/" + nodeID
+}
+
+// GetKeyPath 返回特定密钥的完整路径
+func GetKeyPath(keyID string) string {
+	return FullKeysPath + "/" + url.PathEscape(keyID)
+}
+
+// GetKeyPolicyPath 返回特定密钥策略的完整路径
+func GetKeyPolicyPath(keyID string) string {
+	return GetKeyPath(keyID) + KeyPolicySuffix
+}
+
+// GetKeyHoldersPath 返回查询特定密钥持有节点的完整路径
+func GetKeyHoldersPath(keyID string) string {
+	return GetKeyPath(keyID) + KeyHoldersSuffix
+}
+
 // GetOperationPath 返回特定操作的完整路径
 func GetOperationPath(operationID string) string {
 	return FullOperationsPath + "/" + operationID
 }
 
+// GetListOperationsPath 返回按标签过滤的操作列表路径，pageSize 为 0 时使用默认分页大小
+func GetListOperationsPath(labelSelector map[string]string, pageSize int, pageToken string) string {
+	query := url.Values{}
+	for _, key := range slices.Sorted(maps.Keys(labelSelector)) {
+		query.Add("label", key+"="+labelSelector[key])
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+	if len(query) == 0 {
+		return FullOperationsPath
+	}
+	return FullOperationsPath + "?" + query.Encode()
+}
+
 // API路径模式（用于路由注册）
 const (
 	OperationPathPattern = OperationsPath + "/:operation_id"
-	KeyMetadataPath      = "/keys/:key_id"
+	// gin 不支持转义路径中的冒号，操作集合上的自定义方法按参数匹配后由处理函数分派
+	OperationsActionPattern = OperationsPath + ":action"
+	KeyMetadataPath         = KeysPath + "/:key_id"
+	KeyPolicyPath           = KeyMetadataPath + KeyPolicySuffix
+	KeyHoldersPath          = KeyMetadataPath + KeyHoldersSuffix
+	NodeAddressPathPattern  = NetworkAddressesPath + "/:node_id"
 )
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+// scopedOperationIDPrefix marks operation IDs derived from a client supplied ID and its owner
+const scopedOperationIDPrefix = "op-"
+
+// errOperationNotFound is returned for operations that do not exist or belong to another user
+var errOperationNotFound = errors.New("operation not found")
+
+// errAdminRequired is returned to authenticated callers without the admin role
+var errAdminRequired = errors.New("the admin role is required")
+
+// AdminRole is the role required to change key policies when API authentication is enabled
+const AdminRole = "admin"
+
+// operationScope scopes operations to the authenticated user when API authentication is
+// enabled: client supplied operation IDs are namespaced by a keyed HMAC of the user, so
+// users sharing an ID neither collide on idempotency nor see each other's operations.
+type operationScope struct {
+	enabled bool
+	key     []byte
+}
+
+// newOperationScope creates the operation scope of the API authentication configuration
+func newOperationScope(cfg *config.AuthConfig) *operationScope {
+	key := cfg.OperationIDSecret
+	if key == "" {
+		key = cfg.JWTSecret
+	}
+	return &operationScope{enabled: cfg.Enabled, key: []byte(key)}
+}
+
+// owner returns the authenticated user of ctx, empty when operations are not scoped
+func (o *operationScope) owner(ctx context.Context) string {
+	if !o.enabled {
+		return ""
+	}
+	authCtx, ok := GetAuthContext(ctx)
+	if !ok {
+		return ""
+	}
+	return authCtx.UserID
+}
+
+// withOwner returns opCtx recording the authenticated user of reqCtx as the owner of
+// the operations started with it, and the user's roles for key policies
+func (o *operationScope) withOwner(reqCtx, opCtx context.Context) context.Context {
+	if owner := o.owner(reqCtx); owner != "" {
+		opCtx = tss.WithOperationOwner(opCtx, owner)
+	}
+	if authCtx, ok := GetAuthContext(reqCtx); ok && o.enabled {
+		opCtx = tss.WithCallerRoles(opCtx, authCtx.Roles)
+	}
+	return opCtx
+}
+
+// isAdmin reports whether the caller of ctx may change key policies: any caller when API
+// authentication is disabled, otherwise users with the admin role
+func (o *operationScope) isAdmin(ctx context.Context) bool {
+	if !o.enabled {
+		return true
+	}
+	authCtx, ok := GetAuthContext(ctx)
+	return ok && slices.Contains(authCtx.Roles, AdminRole)
+}
+
+// operationID returns the ID an operation with the client supplied operationID is stored
+// under. Generated IDs, requested by an empty operationID, are left empty.
+func (o *operationScope) operationID(ctx context.Context, operationID string) string {
+	owner := o.owner(ctx)
+	if owner == "" || operationID == "" {
+		return operationID
+	}
+
+	mac := hmac.New(sha256.New, o.key)
+	mac.Write([]byte(owner))
+	mac.Write([]byte{0})
+	mac.Write([]byte(operationID))
+	return scopedOperationIDPrefix + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// visible returns true if the caller of ctx may see an operation of owner. Operations
+// without an owner, such as those synced from other nodes, are visible to everyone.
+func (o *operationScope) visible(ctx context.Context, owner string) bool {
+	caller := o.owner(ctx)
+	return caller == "" || owner == "" || owner == caller
+}
+
+// findOperation returns the caller's operation with the given ID, either as returned at
+// creation or as originally supplied by the client. The in-memory operation is returned
+// as well while the operation is active.
+func (o *operationScope) findOperation(
+	ctx context.Context,
+	service *tss.Service,
+	operationID string,
+) (*tss.Operation, *tss.OperationData, error) {
+	candidates := []string{operationID}
+	if scoped := o.operationID(ctx, operationID); scoped != operationID {
+		candidates = []string{scoped, operationID}
+	}
+
+	for _, id := range candidates {
+		if operation, exists := service.GetOperation(id); exists {
+			if data := operation.Snapshot(); o.visible(ctx, data.Owner) {
+				return operation, data, nil
+			}
+			continue
+		}
+		if data, err := service.GetOperationData(ctx, id); err == nil && o.visible(ctx, data.Owner) {
+			return nil, data, nil
+		}
+	}
+	return nil, nil, errOperationNotFound
+}
+
+// findOperations looks up each of operationIDs like findOperation, marking the operations
+// that do not exist or belong to another user as not found
+func (o *operationScope) findOperations(
+	ctx context.Context,
+	service *tss.Service,
+	operationIDs []string,
+) *tssv1.GetOperationsResponse {
+	resp := &tssv1.GetOperationsResponse{
+		Results: make([]*tssv1.OperationLookup, len(operationIDs)),
+	}
+	for i, operationID := range operationIDs {
+		lookup := &tssv1.OperationLookup{OperationId: operationID}
+		if _, data, err := o.findOperation(ctx, service, operationID); err == nil {
+			lookup.Found = true
+			lookup.Operation = buildOperationResponse(data, service)
+		}
+		resp.Results[i] = lookup
+	}
+	return resp
+}
+
+// visibleOperations filters operations down to those the caller of ctx may see
+func (o *operationScope) visibleOperations(ctx context.Context, operations []*tss.OperationData) []*tss.OperationData {
+	visible := operations[:0]
+	for _, data := range operations {
+		if o.visible(ctx, data.Owner) {
+			visible = append(visible, data)
+		}
+	}
+	return visible
+}
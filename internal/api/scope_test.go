@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+func newScopeTestService(t *testing.T) (*tss.Service, storage.Storage) {
+	t.Helper()
+
+	transport, err := p2p.NewMemoryHub().NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+	service, err := tss.NewService(&tss.Config{
+		PeerID: transport.GetHostID(),
+		KDF:    plugin.KDFParams{Algorithm: plugin.KDFArgon2id, Time: 1, Memory: 64, Threads: 1},
+	}, store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+	return service, store
+}
+
+func saveScopeTestOperation(t *testing.T, store storage.Storage, data *tss.OperationData) {
+	t.Helper()
+
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(context.Background(), "operation:"+data.ID, encoded))
+}
+
+func TestOperationScopeSeparatesUsers(t *testing.T) {
+	service, store := newScopeTestService(t)
+	scope := newOperationScope(&config.AuthConfig{Enabled: true, OperationIDSecret: "secret"})
+
+	alice := SetAuthContext(context.Background(), &AuthContext{Authenticated: true, UserID: "alice"})
+	bob := SetAuthContext(context.Background(), &AuthContext{Authenticated: true, UserID: "bob"})
+
+	// The same client supplied ID maps to a stable ID per user
+	aliceID, bobID := scope.operationID(alice, "tx-1"), scope.operationID(bob, "tx-1")
+	require.NotEqual(t, aliceID, bobID)
+	require.Equal(t, aliceID, scope.operationID(alice, "tx-1"))
+	require.Empty(t, scope.operationID(alice, ""))
+
+	for _, data := range []*tss.OperationData{
+		{ID: aliceID, Type: tss.OperationSigning, Status: tss.StatusCompleted, CreatedAt: time.Now(), Owner: "alice"},
+		{ID: bobID, Type: tss.OperationKeygen, Status: tss.StatusCompleted, CreatedAt: time.Now(), Owner: "bob"},
+		{ID: "synced", Type: tss.OperationSigning, Status: tss.StatusCompleted, CreatedAt: time.Now()},
+	} {
+		saveScopeTestOperation(t, store, data)
+	}
+
+	// Each user finds its own operation by the original or the returned ID
+	for _, id := range []string{"tx-1", aliceID} {
+		_, data, err := scope.findOperation(alice, service, id)
+		require.NoError(t, err)
+		require.Equal(t, aliceID, data.ID)
+	}
+	_, data, err := scope.findOperation(bob, service, "tx-1")
+	require.NoError(t, err)
+	require.Equal(t, bobID, data.ID)
+
+	// Operations of other users are not found, operations without owner are
+	_, _, err = scope.findOperation(bob, service, aliceID)
+	require.ErrorIs(t, err, errOperationNotFound)
+	_, _, err = scope.findOperation(alice, service, "synced")
+	require.NoError(t, err)
+
+	operations, _, err := service.ListOperations(context.Background(), nil, 0, "")
+	require.NoError(t, err)
+	visible := scope.visibleOperations(alice, operations)
+	require.Len(t, visible, 2)
+	for _, data := range visible {
+		require.NotEqual(t, bobID, data.ID)
+	}
+}
+
+func TestOperationScopeDisabled(t *testing.T) {
+	scope := newOperationScope(&config.AuthConfig{})
+	ctx := SetAuthContext(context.Background(), &AuthContext{Authenticated: true, UserID: "alice"})
+
+	require.Equal(t, "tx-1", scope.operationID(ctx, "tx-1"))
+	require.True(t, scope.visible(ctx, "bob"))
+}
+
+func TestTypedDataSigningScopesOperationID(t *testing.T) {
+	service, store := newScopeTestService(t)
+	s := &Server{tssService: service, logger: zap.NewNop(),
+		scope: newOperationScope(&config.AuthConfig{Enabled: true, OperationIDSecret: "secret"})}
+	router := gin.New()
+	router.POST(APIVersionPrefix+SignTypedDataPath, s.signTypedDataHandler)
+
+	alice := SetAuthContext(context.Background(), &AuthContext{Authenticated: true, UserID: "alice"})
+	aliceID := s.scope.operationID(alice, "tx-1")
+	saveScopeTestOperation(t, store, &tss.OperationData{
+		ID: aliceID, Type: tss.OperationSigning, Status: tss.StatusCompleted, CreatedAt: time.Now(), Owner: "alice",
+		Result: &tss.SigningResult{Signature: "0x01", R: "0x02", S: "0x03", V: 27},
+	})
+
+	// A retry with the client supplied ID finds the operation under the scoped ID
+	req := httptest.NewRequest(http.MethodPost, FullSignTypedDataPath, strings.NewReader(
+		`{"operation_id":"tx-1","typed_data":{},"key_id":"0xabc","participants":["a","b"]}`)).WithContext(alice)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp tssv1.StartSigningResponse
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, aliceID, resp.OperationId)
+	require.True(t, resp.AlreadyCompleted)
+}
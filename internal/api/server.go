@@ -20,6 +20,7 @@ type Server struct {
 	network       *p2p.Network
 	logger        *zap.Logger
 	authenticator Authenticator
+	scope         *operationScope
 
 	httpServer *http.Server
 	grpcServer *grpc.Server
@@ -32,12 +33,18 @@ func NewServer(
 	network *p2p.Network,
 	logger *zap.Logger,
 ) (*Server, error) {
+	authenticator, err := NewAuthenticator(&cfg.Security.APIAuth, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+
 	return &Server{
 		config:        cfg,
 		tssService:    tssService,
 		network:       network,
 		logger:        logger,
-		authenticator: NewAuthenticator(&cfg.Security.APIAuth, logger),
+		authenticator: authenticator,
+		scope:         newOperationScope(&cfg.Security.APIAuth),
 	}, nil
 }
 
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// OperationTimeoutHeader lets HTTP clients request a timeout for the operation they start
+	OperationTimeoutHeader = "X-Operation-Timeout"
+	// OperationTimeoutMetadata is the gRPC metadata key of the requested operation timeout
+	OperationTimeoutMetadata = "x-operation-timeout"
+)
+
+// requestedTimeout parses the operation timeout requested by value, a duration such as
+// 90s. An empty value requests nothing, the server's bounds are applied by the TSS service.
+func requestedTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid operation timeout %q, must be a positive duration such as 90s", value)
+	}
+	return timeout, nil
+}
+
+// grpcRequestedTimeout returns the operation timeout requested in the incoming gRPC metadata
+func grpcRequestedTimeout(ctx context.Context) (time.Duration, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(OperationTimeoutMetadata)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	return requestedTimeout(values[0])
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// certCheckInterval bounds how often the certificate files are checked for changes
+const certCheckInterval = 10 * time.Second
+
+// certReloader serves a TLS certificate from disk and reloads it when the certificate
+// or key file changes, so rotated certificates are picked up without a restart
+type certReloader struct {
+	certFile      string
+	keyFile       string
+	checkInterval time.Duration
+	logger        *zap.Logger
+
+	mutex     sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+}
+
+// newCertReloader loads the certificate once, failing when it cannot be loaded
+func newCertReloader(certFile, keyFile string, logger *zap.Logger) (*certReloader, error) {
+	r := &certReloader{
+		certFile:      certFile,
+		keyFile:       keyFile,
+		checkInterval: certCheckInterval,
+		logger:        logger,
+	}
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// tlsConfig returns a server TLS configuration serving the reloaded certificate
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: r.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// GetCertificate implements tls.Config.GetCertificate. Failed reloads are logged and the
+// previous certificate keeps being served.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCheck) < r.checkInterval {
+		return r.cert, nil
+	}
+	r.lastCheck = now
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		r.logger.Warn("Failed to check TLS certificate files", zap.Error(err))
+		return r.cert, nil
+	}
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return r.cert, nil
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		// The files may be mid-rotation, retry on a later handshake
+		r.logger.Warn("Failed to reload TLS certificate, serving the previous one", zap.Error(err))
+		return r.cert, nil
+	}
+	r.logger.Info("TLS certificate reloaded", zap.String("cert_file", r.certFile))
+	return r.cert, nil
+}
+
+func (r *certReloader) load(certMod, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	r.cert = &cert
+	r.certMod = certMod
+	r.keyMod = keyMod
+	return nil
+}
+
+func (r *certReloader) modTimes() (certMod, keyMod time.Time, err error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS key: %w", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
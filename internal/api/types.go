@@ -1,12 +1,77 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
 	"github.com/dreamer-zq/DKNet/internal/tss"
 	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
 )
 
+// nodeUnavailable reports whether err refuses an operation because of the state of this
+// node, such as maintenance mode or a read-only replica, rather than the request
+func nodeUnavailable(err error) bool {
+	return errors.Is(err, tss.ErrMaintenanceMode) || errors.Is(err, tss.ErrReadOnly)
+}
+
+// startOperationCode classifies an error starting an operation of any type, the gRPC
+// handlers report it with the code and the HTTP handlers with startOperationStatus
+func startOperationCode(err error) codes.Code {
+	switch {
+	case nodeUnavailable(err) || errors.Is(err, plugin.ErrValidationUnavailable) ||
+		errors.Is(err, tss.ErrJoinQuorumNotReached):
+		return codes.Unavailable
+	case errors.Is(err, tss.ErrParticipantBusy):
+		return codes.ResourceExhausted
+	case errors.Is(err, tss.ErrSigningInProgress):
+		return codes.Aborted
+	case errors.Is(err, tss.ErrKeyAliasExists):
+		return codes.AlreadyExists
+	case errors.Is(err, tss.ErrKeyPolicyViolation):
+		return codes.PermissionDenied
+	case errors.Is(err, tss.ErrKeyNotHeld) || errors.Is(err, tss.ErrKeyAliasNotFound):
+		return codes.NotFound
+	case errors.Is(err, tss.ErrInvalidKeyAlias) || errors.Is(err, tss.ErrUnsupportedChainFamily) ||
+		errors.Is(err, tss.ErrUnsupportedAlgorithm) || errors.Is(err, tss.ErrInvalidKeyPolicy) ||
+		errors.Is(err, tss.ErrInvalidLabels) || errors.Is(err, tss.ErrInvalidParticipants) ||
+		errors.Is(err, tss.ErrNotParticipant) || errors.Is(err, tss.ErrInvalidTypedData) ||
+		errors.Is(err, tss.ErrInvalidSigningMetadata) || errors.Is(err, tss.ErrChainFamilyMismatch) ||
+		errors.Is(err, tss.ErrMessageTooLarge) || errors.Is(err, tss.ErrInvalidDerivationPath):
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
+	}
+}
+
+// startOperationStatus returns the HTTP status of an error starting an operation
+func startOperationStatus(err error) int {
+	switch startOperationCode(err) {
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Aborted, codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // Helper functions to convert between internal types and proto types
 func convertOperationStatus(status tss.OperationStatus) tssv1.OperationStatus {
 	switch status {
@@ -20,6 +85,8 @@ func convertOperationStatus(status tss.OperationStatus) tssv1.OperationStatus {
 		return tssv1.OperationStatus_OPERATION_STATUS_FAILED
 	case tss.StatusCancelled:
 		return tssv1.OperationStatus_OPERATION_STATUS_CANCELED
+	case tss.StatusTimedOut:
+		return tssv1.OperationStatus_OPERATION_STATUS_TIMED_OUT
 	default:
 		return tssv1.OperationStatus_OPERATION_STATUS_UNSPECIFIED
 	}
@@ -38,85 +105,88 @@ func convertOperationType(opType tss.OperationType) tssv1.OperationType {
 	}
 }
 
-// buildOperationResponse builds a complete operation response from in-memory operation
-func buildOperationResponse(operation *tss.Operation) *tssv1.GetOperationResponse {
+// nodeNamer names nodes by peer ID, implemented by tss.Service
+type nodeNamer interface {
+	NodeName(peerID string) string
+}
+
+// buildOperationResponse builds a complete operation response from operation data, as
+// returned by GetOperationData or an in-memory operation's Snapshot. Participants are
+// named by names, which may be nil.
+func buildOperationResponse(data *tss.OperationData, names nodeNamer) *tssv1.GetOperationResponse {
 	response := &tssv1.GetOperationResponse{
-		OperationId: operation.ID,
-		Type:        convertOperationType(operation.Type),
-		SessionId:   operation.SessionID,
-		Status:      convertOperationStatus(operation.Status),
-		CreatedAt:   timestamppb.New(operation.CreatedAt),
+		OperationId:        data.ID,
+		Type:               convertOperationType(data.Type),
+		SessionId:          data.SessionID,
+		Status:             convertOperationStatus(data.Status),
+		Participants:       data.Participants,
+		ParticipantDetails: buildParticipantDetails(data.Participants, names),
+		CreatedAt:          timestamppb.New(data.CreatedAt),
+		Labels:             data.Labels,
 	}
 
-	// Add participants
-	for _, p := range operation.Participants {
-		response.Participants = append(response.Participants, p.Id)
+	// Add completion time if available
+	if data.CompletedAt != nil {
+		response.CompletedAt = timestamppb.New(*data.CompletedAt)
 	}
 
-	// Add completion time if available
-	if operation.CompletedAt != nil {
-		response.CompletedAt = timestamppb.New(*operation.CompletedAt)
+	for _, event := range data.Events {
+		response.Events = append(response.Events, &tssv1.OperationEvent{
+			Time:       timestamppb.New(event.Time),
+			FromStatus: convertOperationStatus(event.From),
+			ToStatus:   convertOperationStatus(event.To),
+			Detail:     event.Detail,
+		})
+	}
+	for _, timing := range data.RoundTimings {
+		duration := timing.Duration
+		if duration == 0 && data.IsActive() {
+			// The round the node is in
+			duration = time.Since(timing.Started)
+		}
+		response.RoundTimings = append(response.RoundTimings, &tssv1.RoundTiming{
+			Round:      int32(timing.Round),
+			Started:    timestamppb.New(timing.Started),
+			DurationMs: duration.Milliseconds(),
+		})
 	}
 
 	// Add error if available
-	if operation.Error != nil {
-		errMsg := operation.Error.Error()
-		response.Error = &errMsg
+	if data.Error != "" {
+		response.Error = &data.Error
 	}
 
-	// Add result based on operation type
-	if operation.Result != nil {
-		switch operation.Type {
+	// Add result based on operation type if available
+	if data.Result != nil {
+		switch data.Type {
 		case tss.OperationKeygen:
-			if keygenResult, ok := operation.Result.(*tss.KeygenResult); ok {
-				response.Result = &tssv1.GetOperationResponse_KeygenResult{
-					KeygenResult: &tssv1.KeygenResult{
-						PublicKey: keygenResult.PublicKey,
-						KeyId:     keygenResult.KeyID,
-					},
-				}
+			if keygenResult := convertKeygenResult(data.Result); keygenResult != nil {
+				response.Result = &tssv1.GetOperationResponse_KeygenResult{KeygenResult: keygenResult}
 			}
 		case tss.OperationSigning:
-			if signingResult, ok := operation.Result.(*tss.SigningResult); ok {
-				response.Result = &tssv1.GetOperationResponse_SigningResult{
-					SigningResult: &tssv1.SigningResult{
-						Signature: signingResult.Signature,
-						R:         signingResult.R,
-						S:         signingResult.S,
-						V:         int32(signingResult.V),
-					},
-				}
+			if signingResult := convertSigningResult(data.Result); signingResult != nil {
+				response.Result = &tssv1.GetOperationResponse_SigningResult{SigningResult: signingResult}
 			}
 		case tss.OperationResharing:
-			if resharingResult, ok := operation.Result.(*tss.KeygenResult); ok {
-				response.Result = &tssv1.GetOperationResponse_ResharingResult{
-					ResharingResult: &tssv1.KeygenResult{
-						PublicKey: resharingResult.PublicKey,
-						KeyId:     resharingResult.KeyID,
-					},
-				}
+			if resharingResult := convertKeygenResult(data.Result); resharingResult != nil {
+				response.Result = &tssv1.GetOperationResponse_ResharingResult{ResharingResult: resharingResult}
 			}
 		}
 	}
 
-	// Add original request
-	if operation.Request != nil {
-		switch req := operation.Request.(type) {
+	// Add original request if available
+	if data.Request != nil {
+		switch req := data.Request.(type) {
 		case *tss.KeygenRequest:
 			response.Request = &tssv1.GetOperationResponse_KeygenRequest{
 				KeygenRequest: &tssv1.StartKeygenRequest{
 					Threshold:    int32(req.Threshold),
 					Participants: req.Participants,
+					Alias:        req.Alias,
 				},
 			}
 		case *tss.SigningRequest:
-			response.Request = &tssv1.GetOperationResponse_SigningRequest{
-				SigningRequest: &tssv1.StartSigningRequest{
-					Message:      req.Message,
-					KeyId:        req.KeyID,
-					Participants: req.Participants,
-				},
-			}
+			setSigningRequest(response, req)
 		case *tss.ResharingRequest:
 			response.Request = &tssv1.GetOperationResponse_ResharingRequest{
 				ResharingRequest: &tssv1.StartResharingRequest{
@@ -131,91 +201,313 @@ func buildOperationResponse(operation *tss.Operation) *tssv1.GetOperationRespons
 	return response
 }
 
-// buildOperationResponseFromStorage builds a complete operation response from storage data
-// This function takes the same parameters as the TSS service's GetOperationData method returns
-func buildOperationResponseFromStorage(data *tss.OperationData) *tssv1.GetOperationResponse {
-	response := &tssv1.GetOperationResponse{
-		OperationId:  data.ID,
-		Type:         convertOperationType(data.Type),
-		SessionId:    data.SessionID,
-		Status:       convertOperationStatus(data.Status),
-		Participants: data.Participants,
-		CreatedAt:    timestamppb.New(data.CreatedAt),
+// convertKeygenResult converts the result of a keygen or resharing operation, nil for others
+func convertKeygenResult(result any) *tssv1.KeygenResult {
+	keygenResult, ok := result.(*tss.KeygenResult)
+	if !ok {
+		return nil
 	}
+	return &tssv1.KeygenResult{
+		PublicKey: keygenResult.PublicKey,
+		KeyId:     keygenResult.KeyID,
+		Alias:     keygenResult.Alias,
+		Address:   keygenResult.Address,
+	}
+}
 
-	// Add completion time if available
-	if data.CompletedAt != nil {
-		response.CompletedAt = timestamppb.New(*data.CompletedAt)
+// convertSigningResult converts the result of a signing operation, nil for others
+func convertSigningResult(result any) *tssv1.SigningResult {
+	signingResult, ok := result.(*tss.SigningResult)
+	if !ok {
+		return nil
+	}
+	return &tssv1.SigningResult{
+		Signature:      signingResult.Signature,
+		R:              signingResult.R,
+		S:              signingResult.S,
+		V:              int32(signingResult.V),
+		DerivationPath: signingResult.DerivationPath,
+		PublicKey:      signingResult.PublicKey,
+		Address:        signingResult.Address,
+		Signers:        signingResult.Signers,
+		MessageDigest:  signingResult.MessageDigest,
+		HashMode:       string(signingResult.HashMode),
+		Context:        convertSigningContext(signingResult.Context),
 	}
+}
 
-	// Add error if available
-	if data.Error != "" {
-		response.Error = &data.Error
+// buildStartKeygenResponse builds the response to a keygen request for the operation it
+// started, or for the finished operation its operation ID named, see finishedOperationError
+func buildStartKeygenResponse(data *tss.OperationData) *tssv1.StartKeygenResponse {
+	return &tssv1.StartKeygenResponse{
+		OperationId:      data.ID,
+		Status:           convertOperationStatus(data.Status),
+		CreatedAt:        timestamppb.New(data.CreatedAt),
+		AlreadyCompleted: data.IsCompleted(),
+		Result:           convertKeygenResult(data.Result),
+		Error:            finishedOperationError(data),
 	}
+}
 
-	// Add result based on operation type if available
-	if data.Result != nil {
-		switch data.Type {
-		case tss.OperationKeygen:
-			if keygenResult, ok := data.Result.(*tss.KeygenResult); ok {
-				response.Result = &tssv1.GetOperationResponse_KeygenResult{
-					KeygenResult: &tssv1.KeygenResult{
-						PublicKey: keygenResult.PublicKey,
-						KeyId:     keygenResult.KeyID,
-					},
-				}
-			}
-		case tss.OperationSigning:
-			if signingResult, ok := data.Result.(*tss.SigningResult); ok {
-				response.Result = &tssv1.GetOperationResponse_SigningResult{
-					SigningResult: &tssv1.SigningResult{
-						Signature: signingResult.Signature,
-						R:         signingResult.R,
-						S:         signingResult.S,
-						V:         int32(signingResult.V),
-					},
-				}
-			}
-		case tss.OperationResharing:
-			if resharingResult, ok := data.Result.(*tss.KeygenResult); ok {
-				response.Result = &tssv1.GetOperationResponse_ResharingResult{
-					ResharingResult: &tssv1.KeygenResult{
-						PublicKey: resharingResult.PublicKey,
-						KeyId:     resharingResult.KeyID,
-					},
-				}
-			}
+// buildStartSigningResponse builds the response to a signing request like buildStartKeygenResponse
+func buildStartSigningResponse(data *tss.OperationData) *tssv1.StartSigningResponse {
+	return &tssv1.StartSigningResponse{
+		OperationId:      data.ID,
+		Status:           convertOperationStatus(data.Status),
+		CreatedAt:        timestamppb.New(data.CreatedAt),
+		AlreadyCompleted: data.IsCompleted(),
+		Result:           convertSigningResult(data.Result),
+		Error:            finishedOperationError(data),
+	}
+}
+
+// buildStartResharingResponse builds the response to a resharing request like buildStartKeygenResponse
+func buildStartResharingResponse(data *tss.OperationData) *tssv1.StartResharingResponse {
+	return &tssv1.StartResharingResponse{
+		OperationId:      data.ID,
+		Status:           convertOperationStatus(data.Status),
+		CreatedAt:        timestamppb.New(data.CreatedAt),
+		AlreadyCompleted: data.IsCompleted(),
+		Result:           convertKeygenResult(data.Result),
+		Error:            finishedOperationError(data),
+	}
+}
+
+// finishedOperationError returns the error of a finished operation for start responses,
+// nil while the operation runs. Retrying a request with the operation ID of a finished
+// operation returns that operation instead of starting a new one.
+func finishedOperationError(data *tss.OperationData) *string {
+	if !data.IsCompleted() || data.Error == "" {
+		return nil
+	}
+	return &data.Error
+}
+
+// startResponseStatus is the HTTP status of a start response: 202 Accepted for running
+// operations, 200 OK for finished operations the request's operation ID named
+func startResponseStatus(data *tss.OperationData) int {
+	if data.IsCompleted() {
+		return http.StatusOK
+	}
+	return http.StatusAccepted
+}
+
+// setSigningRequest sets the original request of a message or typed data signing operation
+func setSigningRequest(response *tssv1.GetOperationResponse, req *tss.SigningRequest) {
+	if req.MessageOmitted() {
+		response.MessageOmitted = true
+		response.MessageSha256 = req.MessageSHA256
+	}
+	if len(req.TypedData) > 0 || req.IsTypedData {
+		response.Request = &tssv1.GetOperationResponse_TypedDataRequest{
+			TypedDataRequest: &tssv1.SignTypedDataRequest{
+				TypedData:      string(req.TypedData),
+				KeyId:          req.KeyID,
+				Participants:   req.Participants,
+				ChainId:        chainIDPtr(req.ChainID),
+				DerivationPath: req.DerivationPath,
+				ContextBound:   req.ContextBound,
+				Metadata:       req.Metadata,
+			},
 		}
+		return
 	}
 
-	// Add original request if available
-	if data.Request != nil {
-		switch req := data.Request.(type) {
-		case *tss.KeygenRequest:
-			response.Request = &tssv1.GetOperationResponse_KeygenRequest{
-				KeygenRequest: &tssv1.StartKeygenRequest{
-					Threshold:    int32(req.Threshold),
-					Participants: req.Participants,
-				},
-			}
-		case *tss.SigningRequest:
-			response.Request = &tssv1.GetOperationResponse_SigningRequest{
-				SigningRequest: &tssv1.StartSigningRequest{
-					Message:      req.Message,
-					KeyId:        req.KeyID,
-					Participants: req.Participants,
-				},
-			}
-		case *tss.ResharingRequest:
-			response.Request = &tssv1.GetOperationResponse_ResharingRequest{
-				ResharingRequest: &tssv1.StartResharingRequest{
-					KeyId:           req.KeyID,
-					NewThreshold:    int32(req.NewThreshold),
-					NewParticipants: req.NewParticipants,
-				},
-			}
+	response.Request = &tssv1.GetOperationResponse_SigningRequest{
+		SigningRequest: &tssv1.StartSigningRequest{
+			Message:        req.Message,
+			KeyId:          req.KeyID,
+			Participants:   req.Participants,
+			ChainId:        chainIDPtr(req.ChainID),
+			Metadata:       req.Metadata,
+			DerivationPath: req.DerivationPath,
+			ContextBound:   req.ContextBound,
+		},
+	}
+}
+
+// convertSigningContext converts the context of a context-bound signature, nil for others
+func convertSigningContext(signingContext *tss.SigningContext) *tssv1.SigningContext {
+	if signingContext == nil {
+		return nil
+	}
+	return &tssv1.SigningContext{
+		KeyId:     signingContext.KeyID,
+		SessionId: signingContext.SessionID,
+	}
+}
+
+// signTypedDataBody is the HTTP body of a typed data signing request. Unlike in
+// SignTypedDataRequest, typed_data may be a JSON object as well as a JSON string.
+type signTypedDataBody struct {
+	OperationID    string            `json:"operation_id"`
+	TypedData      json.RawMessage   `json:"typed_data" binding:"required"`
+	KeyID          string            `json:"key_id"`
+	Participants   []string          `json:"participants"`
+	ChainID        *uint64           `json:"chain_id"`
+	DerivationPath string            `json:"derivation_path"`
+	Labels         map[string]string `json:"labels"`
+	ContextBound   bool              `json:"context_bound"`
+	Metadata       map[string]string `json:"metadata"`
+}
+
+// reshareBody is the HTTP body of a resharing request. Older clients also sent new_parties,
+// the size of the new committee, which is derived from new_participants and must match it.
+type reshareBody struct {
+	tssv1.StartResharingRequest
+	NewParties *int32 `json:"new_parties"`
+}
+
+// typedData returns the typed data JSON, unquoting it when sent as a string
+func (b *signTypedDataBody) typedData() []byte {
+	var quoted string
+	if err := json.Unmarshal(b.TypedData, &quoted); err == nil {
+		return []byte(quoted)
+	}
+	return b.TypedData
+}
+
+// buildParticipantDetails joins participant peer IDs with their names
+func buildParticipantDetails(participants []string, names nodeNamer) []*tssv1.ParticipantInfo {
+	details := make([]*tssv1.ParticipantInfo, len(participants))
+	for i, peerID := range participants {
+		details[i] = &tssv1.ParticipantInfo{PeerId: peerID, NodeId: peerID}
+		if names != nil {
+			details[i].Moniker = names.NodeName(peerID)
 		}
 	}
+	return details
+}
 
-	return response
+// buildListOperationsResponse converts listed operations to their proto representation
+func buildListOperationsResponse(operations []*tss.OperationData, nextPageToken string, names nodeNamer) *tssv1.ListOperationsResponse {
+	resp := &tssv1.ListOperationsResponse{
+		Operations:    make([]*tssv1.GetOperationResponse, len(operations)),
+		NextPageToken: nextPageToken,
+	}
+	for i, data := range operations {
+		resp.Operations[i] = buildOperationResponse(data, names)
+	}
+	return resp
+}
+
+// parseLabelSelector parses key=value label selector entries, the characters of keys and
+// values are validated by the TSS service
+func parseLabelSelector(entries []string) (map[string]string, error) {
+	selector := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid label selector %q, must be key=value", entry)
+		}
+		selector[key] = value
+	}
+	return selector, nil
+}
+
+// chainIDPtr converts an optional chain ID to its proto representation
+func chainIDPtr(chainID uint64) *uint64 {
+	if chainID == 0 {
+		return nil
+	}
+	return &chainID
+}
+
+// buildFindKeyHoldersResponse converts the holders of a key to their proto representation
+func buildFindKeyHoldersResponse(holders *tss.KeyHolders) *tssv1.FindKeyHoldersResponse {
+	return &tssv1.FindKeyHoldersResponse{
+		KeyId:           holders.KeyID,
+		Threshold:       int32(holders.Threshold),
+		Participants:    holders.Participants,
+		Holders:         holders.Holders,
+		Unreachable:     holders.Unreachable,
+		QuorumAvailable: holders.QuorumAvailable(),
+	}
+}
+
+// buildKeyMetadataResponse builds the key metadata response, including the addresses
+// derived from the key's public key
+func buildKeyMetadataResponse(
+	keyID string,
+	metadata *tss.KeyMetadata,
+	addresses map[tss.AddressFormat]string,
+) *tssv1.GetKeyMetadataResponse {
+	resp := &tssv1.GetKeyMetadataResponse{
+		Moniker:      metadata.Moniker,
+		Threshold:    int32(metadata.Threshold),
+		Participants: metadata.Participants,
+		KeyId:        keyID,
+		Alias:        metadata.Alias,
+		ChainFamily:  string(metadata.Family()),
+		Addresses:    make(map[string]string, len(addresses)),
+		Policy:       keyPolicyToProto(metadata.Policy),
+	}
+	for format, address := range addresses {
+		resp.Addresses[string(format)] = address
+	}
+	return resp
+}
+
+// keyPolicyFromProto converts a key policy from its proto representation, nil if unset
+func keyPolicyFromProto(policy *tssv1.KeyPolicy) *tss.KeyPolicy {
+	if policy == nil {
+		return nil
+	}
+	hashModes := make([]tss.HashMode, len(policy.AllowedHashModes))
+	for i, mode := range policy.AllowedHashModes {
+		hashModes[i] = tss.HashMode(mode)
+	}
+	return &tss.KeyPolicy{
+		MaxMessageBytes:  int(policy.MaxMessageBytes),
+		AllowedHashModes: hashModes,
+		RequiredRoles:    policy.RequiredRoles,
+		ValidationURL:    policy.ValidationUrl,
+	}
+}
+
+// keyPolicyToProto converts a key policy to its proto representation, nil if the key has none
+func keyPolicyToProto(policy *tss.KeyPolicy) *tssv1.KeyPolicy {
+	if policy == nil {
+		return nil
+	}
+	hashModes := make([]string, len(policy.AllowedHashModes))
+	for i, mode := range policy.AllowedHashModes {
+		hashModes[i] = string(mode)
+	}
+	return &tssv1.KeyPolicy{
+		MaxMessageBytes:  int32(policy.MaxMessageBytes),
+		AllowedHashModes: hashModes,
+		RequiredRoles:    policy.RequiredRoles,
+		ValidationUrl:    policy.ValidationURL,
+	}
+}
+
+// buildNodeAddressResponse converts peer info to its proto representation
+func buildNodeAddressResponse(info *p2p.PeerInfo) *tssv1.GetNodeAddressResponse {
+	return &tssv1.GetNodeAddressResponse{
+		NodeId:    info.NodeID,
+		Addresses: info.Addresses,
+		Connected: info.Connected,
+	}
+}
+
+// buildNetworkAddressesResponse converts the known peers to their proto representation.
+// Peers do not exchange monikers, so only the local node is named.
+func buildNetworkAddressesResponse(infos []*p2p.PeerInfo, selfID, moniker string) *tssv1.GetNetworkAddressesResponse {
+	resp := &tssv1.GetNetworkAddressesResponse{
+		Nodes: make([]*tssv1.NodeAddress, len(infos)),
+	}
+	for i, info := range infos {
+		node := &tssv1.NodeAddress{
+			NodeId:    info.NodeID,
+			Addresses: info.Addresses,
+			Connected: info.Connected,
+			Self:      info.NodeID == selfID,
+		}
+		if node.Self {
+			node.Moniker = moniker
+		}
+		resp.Nodes[i] = node
+	}
+	return resp
 }
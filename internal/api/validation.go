@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+const (
+	// maxOperationIDLength limits client supplied operation IDs
+	maxOperationIDLength = 128
+	// maxSigningMessageBytes limits the message or typed data of a signing request
+	maxSigningMessageBytes = 1 << 20
+	// maxBatchOperationIDs limits the operations looked up by a single GetOperations request
+	maxBatchOperationIDs = 100
+)
+
+// fieldViolations collects the invalid fields of a request
+type fieldViolations []*errdetails.BadRequest_FieldViolation
+
+func (v *fieldViolations) add(field, format string, args ...any) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// Error lists the violations as "field: description" pairs
+func (v fieldViolations) Error() string {
+	parts := make([]string, len(v))
+	for i, violation := range v {
+		parts[i] = violation.Field + ": " + violation.Description
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+// grpcError returns an InvalidArgument status carrying the violations as BadRequest details
+func (v fieldViolations) grpcError() error {
+	st := status.New(codes.InvalidArgument, v.Error())
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func (v *fieldViolations) checkOperationID(operationID string) {
+	if len(operationID) > maxOperationIDLength {
+		v.add("operation_id", "must be at most %d characters", maxOperationIDLength)
+	}
+}
+
+func (v *fieldViolations) checkRequired(field, value string) {
+	if value == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *fieldViolations) checkParticipants(field string, participants []string) {
+	if len(participants) == 0 {
+		v.add(field, "must not be empty")
+		return
+	}
+	seen := make(map[string]bool, len(participants))
+	for _, participant := range participants {
+		switch {
+		case participant == "":
+			v.add(field, "must not contain empty node IDs")
+			return
+		case seen[participant]:
+			v.add(field, "contains %s more than once", participant)
+			return
+		}
+		seen[participant] = true
+	}
+}
+
+func (v *fieldViolations) checkThreshold(field string, threshold int32, participants int) {
+	if threshold < 0 {
+		v.add(field, "must not be negative")
+	} else if participants > 0 && int(threshold) >= participants {
+		v.add(field, "must be less than the number of participants (%d)", participants)
+	}
+}
+
+func (v *fieldViolations) checkSigningPayload(field string, size int) {
+	if size == 0 {
+		v.add(field, "is required")
+	} else if size > maxSigningMessageBytes {
+		v.add(field, "must be at most %d bytes", maxSigningMessageBytes)
+	}
+}
+
+// validateRequest checks the field invariants of a TSS API request before it reaches the
+// TSS service. Requests of other types are not checked.
+func validateRequest(req any) fieldViolations {
+	var v fieldViolations
+	switch r := req.(type) {
+	case *tssv1.StartKeygenRequest:
+		v.checkOperationID(r.OperationId)
+		v.checkParticipants("participants", r.Participants)
+		v.checkThreshold("threshold", r.Threshold, len(r.Participants))
+	case *tssv1.StartSigningRequest:
+		v.checkOperationID(r.OperationId)
+		v.checkRequired("key_id", r.KeyId)
+		v.checkSigningPayload("message", len(r.Message))
+		v.checkParticipants("participants", r.Participants)
+	case *tssv1.SignTypedDataRequest:
+		v.checkOperationID(r.OperationId)
+		v.checkRequired("key_id", r.KeyId)
+		v.checkSigningPayload("typed_data", len(r.TypedData))
+		v.checkParticipants("participants", r.Participants)
+	case *tssv1.StartResharingRequest:
+		v.checkOperationID(r.OperationId)
+		v.checkRequired("key_id", r.KeyId)
+		v.checkParticipants("new_participants", r.NewParticipants)
+		v.checkThreshold("new_threshold", r.NewThreshold, len(r.NewParticipants))
+	case *reshareBody:
+		v = validateRequest(&r.StartResharingRequest)
+		if r.NewParties != nil && int(*r.NewParties) != len(r.NewParticipants) {
+			v.add("new_parties", "must equal the number of new_participants (%d)", len(r.NewParticipants))
+		}
+	case *tssv1.RefreshSharesRequest:
+		v.checkOperationID(r.OperationId)
+		v.checkRequired("key_id", r.KeyId)
+	case *tssv1.GetKeyMetadataRequest:
+		v.checkRequired("key_id", r.KeyId)
+	case *tssv1.UpdateKeyPolicyRequest:
+		v.checkRequired("key_id", r.KeyId)
+		if r.Policy.GetMaxMessageBytes() < 0 {
+			v.add("policy.max_message_bytes", "must not be negative")
+		}
+	case *tssv1.HasKeyRequest:
+		v.checkRequired("key_id", r.KeyId)
+	case *tssv1.FindKeyHoldersRequest:
+		v.checkRequired("key_id", r.KeyId)
+	case *tssv1.GetOperationRequest:
+		v.checkRequired("operation_id", r.OperationId)
+	case *tssv1.GetOperationsRequest:
+		if len(r.OperationIds) == 0 {
+			v.add("operation_ids", "must not be empty")
+		} else if len(r.OperationIds) > maxBatchOperationIDs {
+			v.add("operation_ids", "must contain at most %d IDs", maxBatchOperationIDs)
+		}
+		if slices.Contains(r.OperationIds, "") {
+			v.add("operation_ids", "must not contain empty IDs")
+		}
+	case *tssv1.ListOperationsRequest:
+		if r.PageSize < 0 {
+			v.add("page_size", "must not be negative")
+		}
+	case *tssv1.GetNodeAddressRequest:
+		v.checkRequired("node_id", r.NodeId)
+	}
+	return v
+}
+
+// GRPCValidationInterceptor creates a gRPC unary interceptor rejecting requests that violate
+// field invariants with InvalidArgument, listing the invalid fields as BadRequest details
+func GRPCValidationInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if violations := validateRequest(req); len(violations) > 0 {
+			logger.Warn("Rejected invalid gRPC request",
+				zap.String("method", info.FullMethod),
+				zap.Error(violations))
+			return nil, violations.grpcError()
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	tssv1 "github.com/dreamer-zq/DKNet/proto/tss/v1"
+)
+
+func TestValidateRequestRejectsInvalidFields(t *testing.T) {
+	participants := []string{"node1", "node2", "node3"}
+
+	for name, tc := range map[string]struct {
+		req   any
+		field string
+	}{
+		"negative threshold": {
+			req:   &tssv1.StartKeygenRequest{Threshold: -1, Participants: participants},
+			field: "threshold",
+		},
+		"threshold not below participants": {
+			req:   &tssv1.StartKeygenRequest{Threshold: 3, Participants: participants},
+			field: "threshold",
+		},
+		"no keygen participants": {
+			req:   &tssv1.StartKeygenRequest{Threshold: 1},
+			field: "participants",
+		},
+		"duplicate participant": {
+			req:   &tssv1.StartKeygenRequest{Threshold: 1, Participants: []string{"node1", "node1"}},
+			field: "participants",
+		},
+		"long operation ID": {
+			req:   &tssv1.StartKeygenRequest{OperationId: strings.Repeat("x", maxOperationIDLength+1), Threshold: 1, Participants: participants},
+			field: "operation_id",
+		},
+		"empty message": {
+			req:   &tssv1.StartSigningRequest{KeyId: "0xabc", Participants: participants},
+			field: "message",
+		},
+		"oversized message": {
+			req:   &tssv1.StartSigningRequest{KeyId: "0xabc", Message: make([]byte, maxSigningMessageBytes+1), Participants: participants},
+			field: "message",
+		},
+		"signing without key": {
+			req:   &tssv1.StartSigningRequest{Message: []byte("hello"), Participants: participants},
+			field: "key_id",
+		},
+		"typed data without participants": {
+			req:   &tssv1.SignTypedDataRequest{KeyId: "0xabc", TypedData: "{}"},
+			field: "participants",
+		},
+		"negative new threshold": {
+			req:   &tssv1.StartResharingRequest{KeyId: "0xabc", NewThreshold: -1, NewParticipants: participants},
+			field: "new_threshold",
+		},
+		"new parties mismatch": {
+			req: &reshareBody{
+				StartResharingRequest: tssv1.StartResharingRequest{KeyId: "0xabc", NewThreshold: 1, NewParticipants: participants},
+				NewParties:            proto.Int32(5),
+			},
+			field: "new_parties",
+		},
+		"refresh without key": {
+			req:   &tssv1.RefreshSharesRequest{},
+			field: "key_id",
+		},
+		"empty operation lookup": {
+			req:   &tssv1.GetOperationRequest{},
+			field: "operation_id",
+		},
+		"negative page size": {
+			req:   &tssv1.ListOperationsRequest{PageSize: -1},
+			field: "page_size",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			violations := validateRequest(tc.req)
+			require.Len(t, violations, 1)
+			require.Equal(t, tc.field, violations[0].Field)
+		})
+	}
+
+	require.Empty(t, validateRequest(&tssv1.StartKeygenRequest{Threshold: 1, Participants: participants}))
+	require.Empty(t, validateRequest(&tssv1.StartSigningRequest{KeyId: "treasury", Message: []byte("hello"), Participants: participants[:2]}))
+	require.Empty(t, validateRequest(&tssv1.SyncPeersRequest{}))
+	require.Empty(t, validateRequest(&reshareBody{
+		StartResharingRequest: tssv1.StartResharingRequest{KeyId: "0xabc", NewThreshold: 1, NewParticipants: participants},
+		NewParties:            proto.Int32(3),
+	}))
+}
+
+func TestGRPCValidationInterceptor(t *testing.T) {
+	interceptor := GRPCValidationInterceptor(zap.NewNop())
+	info := &grpc.UnaryServerInfo{FullMethod: tssv1.TSSService_StartKeygen_FullMethodName}
+
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return &tssv1.StartKeygenResponse{}, nil
+	}
+
+	// Invalid requests never reach the handler and carry the invalid fields
+	_, err := interceptor(context.Background(), &tssv1.StartKeygenRequest{Threshold: -1}, info, handler)
+	require.False(t, called)
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	var fields []string
+	for _, violation := range badRequest.FieldViolations {
+		fields = append(fields, violation.Field)
+	}
+	require.Equal(t, []string{"participants", "threshold"}, fields)
+
+	valid := &tssv1.StartKeygenRequest{Threshold: 1, Participants: []string{"node1", "node2"}}
+	_, err = interceptor(context.Background(), valid, info, handler)
+	require.NoError(t, err)
+	require.True(t, called)
+}
@@ -3,13 +3,16 @@ package app
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/dreamer-zq/DKNet/internal/api"
 	"github.com/dreamer-zq/DKNet/internal/common"
 	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/notify"
 	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
 	"github.com/dreamer-zq/DKNet/internal/storage"
 	"github.com/dreamer-zq/DKNet/internal/tss"
 )
@@ -22,29 +25,50 @@ type App struct {
 	tssService *tss.Service
 	storage    storage.Storage
 	api        *api.Server
+	logLevel   *zap.AtomicLevel // nil when the log level cannot be reloaded
+	notifier   notify.Notifier
+
+	// stopMonitor stops the health monitor, nil when it is not running
+	stopMonitor context.CancelFunc
 }
 
-// New creates a new application instance
+// New creates a new application instance with the storage backend selected by the config
 func New(cfg *config.NodeConfig, logger *zap.Logger, password string) (*App, error) {
-	// Initialize storage (always use plain storage, encryption is handled at TSS level)
-	store, err := storage.NewLevelDBStorage(cfg.Storage.Path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create LevelDB storage: %w", err)
+	return NewWithOptions(cfg, logger, WithPassword(password))
+}
+
+// NewWithStorage creates a new application instance backed by a pre-constructed storage.
+// The application takes ownership of store and closes it on Stop or on error.
+func NewWithStorage(cfg *config.NodeConfig, store storage.Storage, logger *zap.Logger, password string) (*App, error) {
+	return NewWithOptions(cfg, logger, WithPassword(password), WithStorage(store))
+}
+
+// NewWithOptions creates a new application instance. Components not supplied through
+// options are built from cfg. The application takes ownership of supplied components
+// and stops/closes them on Stop or on error.
+func NewWithOptions(cfg *config.NodeConfig, logger *zap.Logger, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
 	}
 
-	// Create P2P network
-	network, err := p2p.NewNetwork(&p2p.Config{
-		ListenAddrs:    cfg.P2P.ListenAddrs,
-		BootstrapPeers: cfg.P2P.BootstrapPeers,
-		PrivateKeyFile: cfg.P2P.PrivateKeyFile,
-		AccessControl:  &cfg.Security.AccessControl,
-		NetMod:         cfg.P2P.NetMod,
-	}, logger.Named("p2p"))
-	if err != nil {
-		common.LogMsgDo("failed to create P2P network", func() error {
-			return store.Close()
-		})
-		return nil, fmt.Errorf("failed to create P2P network: %w", err)
+	store := o.storage
+	if store == nil {
+		var err error
+		if store, err = newStorage(cfg, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	network := o.network
+	if network == nil {
+		var err error
+		if network, err = newNetwork(cfg, logger); err != nil {
+			common.LogMsgDo("failed to close storage", func() error {
+				return store.Close()
+			})
+			return nil, err
+		}
 	}
 
 	// Use peer ID as node ID for TSS service
@@ -53,13 +77,26 @@ func New(cfg *config.NodeConfig, logger *zap.Logger, password string) (*App, err
 		zap.String("peer_id", peerID),
 		zap.String("moniker", cfg.TSS.Moniker))
 
+	notifier := o.notifier
+	if notifier == nil {
+		var err error
+		if notifier, err = notify.New(&cfg.Notifications, peerID, logger.Named("notify")); err != nil {
+			common.LogDo(func() error {
+				return store.Close()
+			})
+			common.LogDo(func() error {
+				return network.Stop()
+			})
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+	}
+
 	// Initialize TSS service with encryption
-	tssService, err := tss.NewService(&tss.Config{
-		PeerID:            peerID, // Use peer ID for TSS service
-		Moniker:           cfg.TSS.Moniker,
-		ValidationService: cfg.TSS.ValidationService,
-	}, store, network, logger.Named("tss"), password)
+	tssConfig := newTSSConfig(cfg, peerID, o.validationService)
+	tssConfig.Notifier = notifier
+	tssService, err := tss.NewService(tssConfig, store, network, logger.Named("tss"), o.password)
 	if err != nil {
+		notifier.Close()
 		common.LogDo(func() error {
 			return store.Close()
 		})
@@ -76,6 +113,7 @@ func New(cfg *config.NodeConfig, logger *zap.Logger, password string) (*App, err
 	// Initialize API server
 	apiServer, err := api.NewServer(cfg, tssService, network, logger.Named("api"))
 	if err != nil {
+		notifier.Close()
 		common.LogMsgDo("failed to close storage", func() error {
 			return store.Close()
 		})
@@ -92,9 +130,111 @@ func New(cfg *config.NodeConfig, logger *zap.Logger, password string) (*App, err
 		network:    network,
 		tssService: tssService,
 		api:        apiServer,
+		logLevel:   o.logLevel,
+		notifier:   notifier,
 	}, nil
 }
 
+// newTSSConfig maps the node config to the TSS service config
+func newTSSConfig(cfg *config.NodeConfig, peerID string, validator plugin.ValidationService) *tss.Config {
+	return &tss.Config{
+		PeerID:            peerID, // Use peer ID for TSS service
+		Moniker:           cfg.TSS.Moniker,
+		Mode:              cfg.TSS.Mode,
+		ValidationService: cfg.TSS.ValidationService,
+		Validator:         validator,
+		EncryptMetadata:   cfg.Storage.EncryptMetadata,
+		SigningDedupTTL:   time.Duration(cfg.TSS.SigningDedupTTLSeconds) * time.Second,
+		SyncRetries:       cfg.TSS.SyncRetries,
+		SyncRetryInterval: time.Duration(cfg.TSS.SyncRetryIntervalMs) * time.Millisecond,
+		SyncAckTimeout:    time.Duration(cfg.TSS.SyncAckTimeoutSeconds) * time.Second,
+		JoinTimeout:       time.Duration(cfg.TSS.JoinTimeoutSeconds) * time.Second,
+		JoinQuorum:        cfg.TSS.JoinQuorum,
+		KeyIDFormat:       cfg.TSS.KeyIDFormat,
+		TrimSigningQuorum: cfg.TSS.TrimSigningQuorum,
+
+		SessionLookupTimeout: time.Duration(cfg.TSS.SessionLookupTimeoutSeconds) * time.Second,
+		EarlyMessageWindow:   time.Duration(cfg.TSS.EarlyMessageWindowSeconds) * time.Second,
+
+		MaxConcurrentOperations: cfg.TSS.MaxConcurrentOperations,
+		MaxMessageBytes:         cfg.TSS.MaxMessageBytes,
+		PersistFullMessage:      cfg.TSS.PersistFullMessage,
+		OperationCacheSize:      cfg.TSS.OperationCacheSize,
+		NodeNames:               cfg.TSS.NodeNames,
+
+		MinOperationTimeout: time.Duration(cfg.TSS.MinOperationTimeoutSeconds) * time.Second,
+		MaxOperationTimeout: time.Duration(cfg.TSS.MaxOperationTimeoutSeconds) * time.Second,
+
+		KDF: plugin.KDFParams{
+			Algorithm: cfg.Security.KDF.Algorithm,
+			Time:      cfg.Security.KDF.Time,
+			Memory:    cfg.Security.KDF.MemoryKiB,
+			Threads:   cfg.Security.KDF.Threads,
+			N:         cfg.Security.KDF.ScryptN,
+			R:         cfg.Security.KDF.ScryptR,
+			P:         cfg.Security.KDF.ScryptP,
+		},
+	}
+}
+
+// newStorage creates the storage backend selected by the config
+func newStorage(cfg *config.NodeConfig, logger *zap.Logger) (storage.Storage, error) {
+	// Always use plain storage, encryption is handled at TSS level
+	if cfg.Storage.Type == "memory" {
+		logger.Warn("Using in-memory storage, keys and operations are lost on restart")
+		return storage.NewMemoryStorage(), nil
+	}
+
+	if cfg.TSS.Mode == tss.ModeReadOnly {
+		store, err := storage.NewReadOnlyLevelDBStorage(cfg.Storage.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open LevelDB storage read-only: %w", err)
+		}
+		return store, nil
+	}
+
+	store, err := storage.NewLevelDBStorage(cfg.Storage.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LevelDB storage: %w", err)
+	}
+	return store, nil
+}
+
+// newNetwork creates the P2P network from the config
+func newNetwork(cfg *config.NodeConfig, logger *zap.Logger) (*p2p.Network, error) {
+	network, err := p2p.NewNetwork(&p2p.Config{
+		ListenAddrs:     cfg.P2P.ListenAddrs,
+		BootstrapPeers:  cfg.P2P.BootstrapPeers,
+		PrivateKeyFile:  cfg.P2P.PrivateKeyFile,
+		AccessControl:   &cfg.Security.AccessControl,
+		NetMod:          cfg.P2P.NetMod,
+		DHTMode:         cfg.P2P.DHT.Mode,
+		Compression:     cfg.P2P.Compression,
+		MaxMessageBytes: cfg.P2P.MaxMessageBytes,
+		SendConcurrency: cfg.P2P.SendConcurrency,
+		Encryption:      cfg.Security.P2PEncryption,
+		MDNSRediscovery: p2p.MDNSRediscovery{
+			MinInterval:   time.Duration(cfg.P2P.MDNS.MinIntervalSeconds) * time.Second,
+			MaxInterval:   time.Duration(cfg.P2P.MDNS.MaxIntervalSeconds) * time.Second,
+			ExpectedPeers: cfg.P2P.MDNS.ExpectedPeers,
+		},
+		PeerScoring: p2p.PeerScoring{
+			ViolationThreshold: cfg.P2P.PeerScoring.ViolationThreshold,
+			Window:             time.Duration(cfg.P2P.PeerScoring.WindowSeconds) * time.Second,
+			BanDuration:        time.Duration(cfg.P2P.PeerScoring.BanSeconds) * time.Second,
+		},
+		ConnManager: p2p.ConnManager{
+			LowWater:    cfg.P2P.ConnManager.LowWater,
+			HighWater:   cfg.P2P.ConnManager.HighWater,
+			GracePeriod: time.Duration(cfg.P2P.ConnManager.GracePeriodSeconds) * time.Second,
+		},
+	}, logger.Named("p2p"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create P2P network: %w", err)
+	}
+	return network, nil
+}
+
 // Start starts the application
 func (a *App) Start(ctx context.Context) error {
 	a.logger.Info("Starting DKNet application")
@@ -112,6 +252,12 @@ func (a *App) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start API server: %w", err)
 	}
 
+	if cfg := a.config.Notifications; cfg.Enabled && cfg.HealthCheckIntervalSeconds > 0 {
+		monitorCtx, cancel := context.WithCancel(context.Background())
+		a.stopMonitor = cancel
+		go a.monitorHealth(monitorCtx, time.Duration(cfg.HealthCheckIntervalSeconds)*time.Second)
+	}
+
 	a.logger.Info("DKNet application started successfully")
 	return nil
 }
@@ -122,6 +268,10 @@ func (a *App) Stop() error {
 
 	var errs []error
 
+	if a.stopMonitor != nil {
+		a.stopMonitor()
+	}
+
 	// Stop API server
 	if err := a.api.Stop(); err != nil {
 		errs = append(errs, fmt.Errorf("failed to stop API server: %w", err))
@@ -137,6 +287,9 @@ func (a *App) Stop() error {
 		errs = append(errs, fmt.Errorf("failed to close storage: %w", err))
 	}
 
+	// Deliver the notifications of operations that ended while stopping
+	a.notifier.Close()
+
 	if len(errs) > 0 {
 		return errs[0]
 	}
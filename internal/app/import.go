@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/tss"
+)
+
+// errOffline is returned by the transport of a TSS service used while the node is stopped
+var errOffline = errors.New("the P2P network is not running")
+
+// ImportKeyShare stores a key share generated by another threshold signature system in the
+// storage of a stopped node, encrypted with the node's encryption password
+func ImportKeyShare(
+	ctx context.Context,
+	cfg *config.NodeConfig,
+	logger *zap.Logger,
+	password string,
+	share *tss.ExternalKeyShare,
+) (*tss.KeygenResult, error) {
+	if cfg.Storage.Type == "memory" {
+		return nil, fmt.Errorf("cannot import keys into in-memory storage")
+	}
+
+	keyBytes, err := os.ReadFile(cfg.P2P.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node key: %w", err)
+	}
+	privKey, err := crypto.UnmarshalPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node key: %w", err)
+	}
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive peer ID: %w", err)
+	}
+
+	store, err := newStorage(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer common.LogMsgDo("failed to close storage", func() error {
+		return store.Close()
+	})
+
+	transport := &offlineTransport{nodeID: peerID.String(), privKey: privKey}
+	tssService, err := tss.NewService(newTSSConfig(cfg, peerID.String(), nil),
+		store, transport, logger.Named("tss"), password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TSS service: %w", err)
+	}
+	return tssService.ImportKeyShare(ctx, share)
+}
+
+// offlineTransport is the transport of a TSS service that only works on local storage
+type offlineTransport struct {
+	nodeID  string
+	privKey crypto.PrivKey
+}
+
+func (t *offlineTransport) GetHostID() string { return t.nodeID }
+
+func (t *offlineTransport) PrivateKey() crypto.PrivKey { return t.privKey }
+
+func (t *offlineTransport) SendMessage(context.Context, *p2p.Message) error { return errOffline }
+
+func (t *offlineTransport) SetMessageHandler(p2p.MessageHandler) {}
+
+func (t *offlineTransport) EnsurePeerKeys(context.Context, []string) error { return errOffline }
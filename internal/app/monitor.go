@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// healthCheckTimeout bounds a single round of health checks
+const healthCheckTimeout = 10 * time.Second
+
+// monitorHealth periodically checks the storage and peer connectivity of the node until
+// ctx is done. A notification is sent when a check starts failing, not on every round.
+func (a *App) monitorHealth(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failing := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for check, err := range a.checkHealth(ctx) {
+			if err == nil {
+				if failing[check] {
+					a.logger.Info("Health check recovered", zap.String("check", check))
+				}
+				failing[check] = false
+				continue
+			}
+			if !failing[check] {
+				a.logger.Warn("Health check failed", zap.String("check", check), zap.Error(err))
+				a.notifier.NodeUnhealthy(check, err.Error())
+			}
+			failing[check] = true
+		}
+	}
+}
+
+// checkHealth runs the health checks of the node and returns their results by name
+func (a *App) checkHealth(ctx context.Context) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	results := map[string]error{
+		"storage": a.tssService.CheckHealth(ctx),
+	}
+	if minPeers := a.config.Notifications.MinConnectedPeers; minPeers > 0 {
+		// The first listed peer is this node
+		var err error
+		if connected := len(a.network.ListPeers()) - 1; connected < minPeers {
+			err = fmt.Errorf("%d peers connected, at least %d expected", connected, minPeers)
+		}
+		results["peers"] = err
+	}
+	return results
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/notify"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// Option configures how NewWithOptions wires the application
+type Option func(*options)
+
+type options struct {
+	storage           storage.Storage
+	network           *p2p.Network
+	validationService plugin.ValidationService
+	password          string
+	logLevel          *zap.AtomicLevel
+	notifier          notify.Notifier
+}
+
+// WithStorage uses the given storage instead of building one from the config
+func WithStorage(store storage.Storage) Option {
+	return func(o *options) {
+		o.storage = store
+	}
+}
+
+// WithNetwork uses the given P2P network instead of building one from the config
+func WithNetwork(network *p2p.Network) Option {
+	return func(o *options) {
+		o.network = network
+	}
+}
+
+// WithValidationService uses the given signing request validator instead of the
+// HTTP validation service from the config
+func WithValidationService(validationService plugin.ValidationService) Option {
+	return func(o *options) {
+		o.validationService = validationService
+	}
+}
+
+// WithPassword sets the password used to encrypt key data at rest
+func WithPassword(password string) Option {
+	return func(o *options) {
+		o.password = password
+	}
+}
+
+// WithLogLevel sets the level of the application's logger, allowing Reload to change it
+func WithLogLevel(level zap.AtomicLevel) Option {
+	return func(o *options) {
+		o.logLevel = &level
+	}
+}
+
+// WithNotifier uses the given notifier instead of the notifiers from the config
+func WithNotifier(notifier notify.Notifier) Option {
+	return func(o *options) {
+		o.notifier = notifier
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"reflect"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+// Reload applies the fields of cfg that can change while the node is running: the
+// log level and the validation service. Changes to any other field are logged and
+// ignored until the next restart.
+func (a *App) Reload(cfg *config.NodeConfig) {
+	if sections := restartRequiredChanges(a.config, cfg); len(sections) > 0 {
+		a.logger.Warn("Ignoring config changes that require a restart", zap.Strings("sections", sections))
+	}
+
+	if cfg.Logging.Level != a.config.Logging.Level {
+		if a.logLevel == nil {
+			a.logger.Warn("Log level cannot be changed while running, the logger has no atomic level")
+		} else {
+			// Log the change at the more verbose of the two levels so it is not dropped
+			level := common.ParseLevel(cfg.Logging.Level)
+			logReload := func() {
+				a.logger.Info("Log level reloaded",
+					zap.String("from", a.config.Logging.Level),
+					zap.String("to", cfg.Logging.Level))
+			}
+			if level > a.logLevel.Level() {
+				logReload()
+				a.logLevel.SetLevel(level)
+			} else {
+				a.logLevel.SetLevel(level)
+				logReload()
+			}
+			a.config.Logging.Level = cfg.Logging.Level
+		}
+	}
+
+	if !reflect.DeepEqual(cfg.TSS.ValidationService, a.config.TSS.ValidationService) {
+		a.tssService.ReloadValidationService(cfg.TSS.ValidationService)
+		a.config.TSS.ValidationService = cfg.TSS.ValidationService
+	}
+}
+
+// restartRequiredChanges returns the config sections that differ between the running
+// and the reloaded config, apart from the fields Reload applies live
+func restartRequiredChanges(running, reloaded *config.NodeConfig) []string {
+	next := *reloaded
+	next.Logging.Level = running.Logging.Level
+	next.TSS.ValidationService = running.TSS.ValidationService
+
+	sections := []struct {
+		name            string
+		running, reload any
+	}{
+		{"server", running.Server, next.Server},
+		{"p2p", running.P2P, next.P2P},
+		{"storage", running.Storage, next.Storage},
+		{"tss", running.TSS, next.TSS},
+		{"security", running.Security, next.Security},
+		{"logging", running.Logging, next.Logging},
+		{"notifications", running.Notifications, next.Notifications},
+		{"data_dir", running.DataDir, next.DataDir},
+	}
+
+	var changed []string
+	for _, section := range sections {
+		if !reflect.DeepEqual(section.running, section.reload) {
+			changed = append(changed, section.name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgorithm identifies the algorithm used to compress a payload
+type CompressionAlgorithm string
+
+const (
+	// CompressionNone sends payloads as-is
+	CompressionNone CompressionAlgorithm = "none"
+	// CompressionGzip compresses payloads with gzip (the historical default)
+	CompressionGzip CompressionAlgorithm = "gzip"
+	// CompressionZstd compresses payloads with zstandard
+	CompressionZstd CompressionAlgorithm = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	// zstd encoders and decoders are safe for concurrent EncodeAll/DecodeAll calls
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// ParseCompressionAlgorithm parses a compression algorithm name, defaulting to gzip when empty
+func ParseCompressionAlgorithm(name string) (CompressionAlgorithm, error) {
+	switch alg := CompressionAlgorithm(strings.ToLower(strings.TrimSpace(name))); alg {
+	case "":
+		return CompressionGzip, nil
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return alg, nil
+	default:
+		return "", fmt.Errorf("unsupported compression algorithm: %s", name)
+	}
+}
+
+// Compress compresses data with the given algorithm.
+// The algorithm is recorded by the format's own magic bytes, so Decompress does not
+// need to know which algorithm the sender was configured with.
+func Compress(alg CompressionAlgorithm, data []byte) ([]byte, error) {
+	switch alg {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip, "":
+		return Gzip(data)
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", alg)
+	}
+}
+
+// ErrDecompressedTooLarge is returned when a payload expands beyond the allowed size
+var ErrDecompressedTooLarge = errors.New("decompressed payload exceeds size limit")
+
+// Decompress detects the algorithm from the payload header and decompresses it.
+// Payloads without a known header are returned unchanged.
+func Decompress(data []byte) ([]byte, error) {
+	return DecompressLimit(data, 0)
+}
+
+// DecompressLimit is like Decompress but fails with ErrDecompressedTooLarge once the
+// output grows beyond limit bytes. A limit <= 0 disables the check.
+func DecompressLimit(data []byte, limit int) ([]byte, error) {
+	var reader io.Reader
+	switch DetectCompression(data) {
+	case CompressionGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = zr.Close() // Ignore close error
+		}()
+		reader = zr
+	case CompressionZstd:
+		if limit <= 0 {
+			return zstdDecoder.DecodeAll(data, nil)
+		}
+		zr, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		reader = zr
+	default:
+		if limit > 0 && len(data) > limit {
+			return nil, ErrDecompressedTooLarge
+		}
+		return data, nil
+	}
+
+	if limit <= 0 {
+		return io.ReadAll(reader)
+	}
+
+	out, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > limit {
+		return nil, ErrDecompressedTooLarge
+	}
+	return out, nil
+}
+
+// DetectCompression returns the algorithm a payload was compressed with
+func DetectCompression(data []byte) CompressionAlgorithm {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		return CompressionGzip
+	case bytes.HasPrefix(data, zstdMagic):
+		return CompressionZstd
+	default:
+		return CompressionNone
+	}
+}
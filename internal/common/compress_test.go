@@ -0,0 +1,113 @@
+package common
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	payload := []byte(`{"session_id":"abc","data":"` + string(bytes.Repeat([]byte("x"), 1024)) + `"}`)
+
+	for _, alg := range []CompressionAlgorithm{CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(string(alg), func(t *testing.T) {
+			compressed, err := Compress(alg, payload)
+			require.NoError(t, err)
+			require.Equal(t, alg, DetectCompression(compressed))
+
+			decompressed, err := Decompress(compressed)
+			require.NoError(t, err)
+			require.Equal(t, payload, decompressed)
+		})
+	}
+}
+
+func TestDecompressLegacyGzip(t *testing.T) {
+	payload := []byte(`{"type":"legacy"}`)
+
+	// Peers on the old fixed algorithm always send plain gzip
+	compressed, err := Gzip(payload)
+	require.NoError(t, err)
+
+	decompressed, err := Decompress(compressed)
+	require.NoError(t, err)
+	require.Equal(t, payload, decompressed)
+}
+
+func TestDecompressLimit(t *testing.T) {
+	payload := bytes.Repeat([]byte("a"), 4096)
+
+	for _, alg := range []CompressionAlgorithm{CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(string(alg), func(t *testing.T) {
+			compressed, err := Compress(alg, payload)
+			require.NoError(t, err)
+
+			_, err = DecompressLimit(compressed, 1024)
+			require.ErrorIs(t, err, ErrDecompressedTooLarge)
+
+			decompressed, err := DecompressLimit(compressed, len(payload))
+			require.NoError(t, err)
+			require.Equal(t, payload, decompressed)
+		})
+	}
+}
+
+func TestParseCompressionAlgorithm(t *testing.T) {
+	alg, err := ParseCompressionAlgorithm("")
+	require.NoError(t, err)
+	require.Equal(t, CompressionGzip, alg)
+
+	alg, err = ParseCompressionAlgorithm("ZSTD")
+	require.NoError(t, err)
+	require.Equal(t, CompressionZstd, alg)
+
+	_, err = ParseCompressionAlgorithm("lz4")
+	require.Error(t, err)
+}
+
+// benchmarkPayload builds a JSON message resembling a TSS wire message with a random
+// (incompressible) party payload of the given size, base64-encoded as on the wire
+func benchmarkPayload(b *testing.B, size int) []byte {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		b.Fatal(err)
+	}
+	raw, err := json.Marshal(map[string]any{
+		"protocol_id":  "/tss/party/0.0.1",
+		"session_id":   "6f1c3f5e-8a9b-4c51-9a56-2b0c8f3e6a11",
+		"type":         "KGRound1Message",
+		"from":         "16Uiu2HAmUx7q8FPDyEs5pFMm3CPa86oUi1u7539pFBUaZavwMwZ8",
+		"is_broadcast": true,
+		"data":         data,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return raw
+}
+
+func BenchmarkCompress(b *testing.B) {
+	for _, size := range []int{256, 64 * 1024} {
+		payload := benchmarkPayload(b, size)
+		for _, alg := range []CompressionAlgorithm{CompressionNone, CompressionGzip, CompressionZstd} {
+			b.Run(fmt.Sprintf("%s/%d", alg, size), func(b *testing.B) {
+				var out []byte
+				for i := 0; i < b.N; i++ {
+					compressed, err := Compress(alg, payload)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if out, err = Decompress(compressed); err != nil {
+						b.Fatal(err)
+					}
+				}
+				compressed, _ := Compress(alg, payload)
+				b.ReportMetric(float64(len(compressed))/float64(len(out)), "ratio")
+			})
+		}
+	}
+}
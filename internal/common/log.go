@@ -3,9 +3,11 @@ package common
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/dreamer-zq/DKNet/internal/config"
 )
@@ -40,22 +42,33 @@ func LogMsgDo(msg string, fn func() error) {
 	}
 }
 
-// NewLogger creates a new zap logger based on configuration
-func NewLogger(cfg *config.LoggingConfig) (*zap.Logger, error) {
-	// Determine log level
-	var level zapcore.Level
-	switch cfg.Level {
+// ParseLevel returns the zap level of a configured log level, info for unknown levels
+func ParseLevel(level string) zapcore.Level {
+	switch level {
 	case LevelDebug:
-		level = zapcore.DebugLevel
+		return zapcore.DebugLevel
 	case LevelInfo:
-		level = zapcore.InfoLevel
+		return zapcore.InfoLevel
 	case LevelWarn:
-		level = zapcore.WarnLevel
+		return zapcore.WarnLevel
 	case LevelError:
-		level = zapcore.ErrorLevel
+		return zapcore.ErrorLevel
 	default:
-		level = zapcore.InfoLevel
+		return zapcore.InfoLevel
 	}
+}
+
+// NewLogger creates a new zap logger based on configuration
+func NewLogger(cfg *config.LoggingConfig) (*zap.Logger, error) {
+	logger, _, err := NewLoggerWithLevel(cfg)
+	return logger, err
+}
+
+// NewLoggerWithLevel creates a new zap logger based on configuration, along with the
+// atomic level that changes the logger's level while it is running
+func NewLoggerWithLevel(cfg *config.LoggingConfig) (*zap.Logger, zap.AtomicLevel, error) {
+	level := ParseLevel(cfg.Level)
+	atomicLevel := zap.NewAtomicLevelAt(level)
 
 	// Determine encoder based on environment
 	var encoder zapcore.Encoder
@@ -79,16 +92,29 @@ func NewLogger(cfg *config.LoggingConfig) (*zap.Logger, error) {
 	case "stdout":
 		writeSyncer = zapcore.AddSync(os.Stdout)
 	default:
-		// Assume it's a file path
+		// Assume it's a file path, rotated once it reaches the configured size
 		file, err := os.OpenFile(cfg.Output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
 		if err != nil {
-			return nil, fmt.Errorf("failed to open log file %s: %w", cfg.Output, err)
+			return nil, atomicLevel, fmt.Errorf("failed to open log file %s: %w", cfg.Output, err)
 		}
-		writeSyncer = zapcore.AddSync(file)
+		// Opened up front so an unusable path fails at startup rather than on first write
+		_ = file.Close()
+		writeSyncer = zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.Output,
+			MaxSize:    cfg.Rotation.MaxSizeMB,
+			MaxAge:     cfg.Rotation.MaxAgeDays,
+			MaxBackups: cfg.Rotation.MaxBackups,
+			Compress:   cfg.Rotation.Compress,
+		})
 	}
 
 	// Create core
-	core := zapcore.NewCore(encoder, writeSyncer, level)
+	core := zapcore.NewCore(encoder, writeSyncer, atomicLevel)
+
+	// Sample repeated entries so high-volume message logs cannot flood the output
+	if cfg.Sampling.Initial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
 
 	// Create logger with caller information for debug level
 	var options []zap.Option
@@ -96,5 +122,5 @@ func NewLogger(cfg *config.LoggingConfig) (*zap.Logger, error) {
 		options = append(options, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
 	}
 
-	return zap.New(core, options...), nil
+	return zap.New(core, options...), atomicLevel, nil
 }
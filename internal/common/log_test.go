@@ -0,0 +1,63 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+func TestNewLoggerFileSampling(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "dknet.log")
+	logger, err := NewLogger(&config.LoggingConfig{
+		Level:       LevelInfo,
+		Environment: EnvPro,
+		Output:      output,
+		Rotation:    config.LogRotationConfig{MaxSizeMB: 1, MaxBackups: 1},
+		Sampling:    config.LogSamplingConfig{Initial: 3, Thereafter: 0},
+	})
+	require.NoError(t, err)
+
+	for range 20 {
+		logger.Info("per-message log")
+	}
+	logger.Info("rare log")
+	require.NoError(t, logger.Sync())
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, 3, strings.Count(string(data), "per-message log"))
+	require.Equal(t, 1, strings.Count(string(data), "rare log"))
+}
+
+func TestNewLoggerInvalidOutput(t *testing.T) {
+	_, err := NewLogger(&config.LoggingConfig{
+		Level:  LevelInfo,
+		Output: filepath.Join(t.TempDir(), "missing", "dknet.log"),
+	})
+	require.Error(t, err)
+}
+
+func TestNewLoggerWithLevelChangesLevel(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "dknet.log")
+	logger, level, err := NewLoggerWithLevel(&config.LoggingConfig{
+		Level:       LevelWarn,
+		Environment: EnvPro,
+		Output:      output,
+	})
+	require.NoError(t, err)
+
+	logger.Info("before reload")
+	level.SetLevel(ParseLevel(LevelDebug))
+	logger.Debug("after reload")
+	require.NoError(t, logger.Sync())
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "before reload")
+	require.Contains(t, string(data), "after reload")
+}
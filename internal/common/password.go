@@ -2,6 +2,8 @@ package common
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -10,37 +12,86 @@ import (
 	"golang.org/x/term"
 )
 
-// ReadPassword reads a password from stdin or environment variable
-func ReadPassword() (string, error) {
-	password, err := readPasswordFromEnv()
-	if err == nil {
-		if validationErr := validatePassword(password); validationErr != nil {
-			return "", validationErr
-		}
-		return password, nil
+const (
+	// PasswordEnv is the environment variable holding the encryption password
+	PasswordEnv = "TSS_ENCRYPTION_PASSWORD"
+	// PasswordFileEnv is the environment variable naming a file holding the encryption password
+	PasswordFileEnv = "TSS_ENCRYPTION_PASSWORD_FILE"
+)
+
+// ErrPasswordNotSet is returned by a PasswordProvider whose source is not configured
+var ErrPasswordNotSet = errors.New("encryption password not set")
+
+// PasswordProvider supplies the encryption password from a non-interactive source, such
+// as a mounted secret file or a secrets manager like Vault or AWS Secrets Manager. It
+// returns ErrPasswordNotSet when its source is not configured.
+type PasswordProvider interface {
+	Password(ctx context.Context) (string, error)
+}
+
+// PasswordProviderFunc adapts a function to a PasswordProvider
+type PasswordProviderFunc func(ctx context.Context) (string, error)
+
+// Password implements PasswordProvider
+func (f PasswordProviderFunc) Password(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// FilePasswordProvider reads the password from a file, an empty path is not configured.
+// A trailing line break, as added by editors and secret tooling, is not part of the password.
+type FilePasswordProvider string
+
+// Password implements PasswordProvider
+func (p FilePasswordProvider) Password(context.Context) (string, error) {
+	if p == "" {
+		return "", ErrPasswordNotSet
 	}
 
-	// Fallback to interactive input
-	password, err = readPasswordWithConfirmation()
+	data, err := os.ReadFile(string(p))
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to read password file: %w", err)
 	}
-
-	if err := validatePassword(password); err != nil {
-		return "", err
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", p)
 	}
-
 	return password, nil
 }
 
-// ReadPasswordFromEnv reads password from environment variable only
-func readPasswordFromEnv() (string, error) {
-	// Try environment variable
-	if password := os.Getenv("TSS_ENCRYPTION_PASSWORD"); password != "" {
+// envPasswordProvider reads the password from the TSS_ENCRYPTION_PASSWORD environment variable
+var envPasswordProvider = PasswordProviderFunc(func(context.Context) (string, error) {
+	if password := os.Getenv(PasswordEnv); password != "" {
 		return password, nil
 	}
+	return "", ErrPasswordNotSet
+})
+
+// LookupPassword returns the password of the first configured source: the given
+// providers in order, the file named by TSS_ENCRYPTION_PASSWORD_FILE and the
+// TSS_ENCRYPTION_PASSWORD environment variable. It returns ErrPasswordNotSet when none
+// is configured.
+func LookupPassword(ctx context.Context, providers ...PasswordProvider) (string, error) {
+	providers = append(providers, FilePasswordProvider(os.Getenv(PasswordFileEnv)), envPasswordProvider)
+	for _, provider := range providers {
+		password, err := provider.Password(ctx)
+		if errors.Is(err, ErrPasswordNotSet) {
+			continue
+		}
+		return password, err
+	}
+	return "", ErrPasswordNotSet
+}
 
-	return "", fmt.Errorf("TSS_ENCRYPTION_PASSWORD environment variable not set")
+// ReadPassword reads a password with LookupPassword, falling back to interactive input
+// from stdin. Callers check its strength with ValidatePassword.
+func ReadPassword(ctx context.Context, providers ...PasswordProvider) (string, error) {
+	password, err := LookupPassword(ctx, providers...)
+	if !errors.Is(err, ErrPasswordNotSet) {
+		return password, err
+	}
+
+	// Fallback to interactive input
+	return readPasswordWithConfirmation()
 }
 
 // ReadPasswordWithConfirmation reads a password and asks for confirmation
@@ -50,10 +101,6 @@ func readPasswordWithConfirmation() (string, error) {
 		return "", err
 	}
 
-	if len(password) < 8 {
-		return "", fmt.Errorf("password must be at least 8 characters long")
-	}
-
 	confirmation, err := readPassword("Confirm encryption password: ")
 	if err != nil {
 		return "", err
@@ -101,7 +148,9 @@ func readPassword(prompt string) (string, error) {
 	return password, nil
 }
 
-func validatePassword(password string) error {
+// ValidatePassword checks that a password has at least 8 characters and contains an
+// uppercase letter, a lowercase letter, a digit and a special character
+func ValidatePassword(password string) error {
 	if len(password) < 8 {
 		return fmt.Errorf("password must be at least 8 characters long")
 	}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePassword(t *testing.T) {
+	for _, password := range []string{
+		"MyCompany@TSS2024!",
+		"Secure#DKNet$Key789",
+		"Aa1!aaaa",
+	} {
+		require.NoError(t, ValidatePassword(password), password)
+	}
+
+	for password, reason := range map[string]string{
+		"Aa1!aaa":            "at least 8 characters",
+		"password123":        "uppercase letter",
+		"PASSWORD123!":       "lowercase letter",
+		"Password!!":         "digit",
+		"Password123":        "special character",
+		"12345678":           "uppercase letter, lowercase letter, special character",
+		"company2024":        "uppercase letter",
+		"Enterprise Crypto1": "special character",
+	} {
+		err := ValidatePassword(password)
+		require.Error(t, err, password)
+		require.Contains(t, err.Error(), reason, password)
+	}
+}
+
+func TestFilePasswordProvider(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// Trailing line breaks are trimmed, other whitespace is part of the password
+	for content, want := range map[string]string{
+		"Secret#Pass1":       "Secret#Pass1",
+		"Secret#Pass1\n":     "Secret#Pass1",
+		"Secret#Pass1\r\n":   "Secret#Pass1",
+		" Secret#Pass1 \n\n": " Secret#Pass1 ",
+	} {
+		path := filepath.Join(dir, "password")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		password, err := FilePasswordProvider(path).Password(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, password)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, []byte("\n"), 0o600))
+	_, err := FilePasswordProvider(empty).Password(ctx)
+	require.Error(t, err)
+
+	_, err = FilePasswordProvider(filepath.Join(dir, "missing")).Password(ctx)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrPasswordNotSet)
+
+	_, err = FilePasswordProvider("").Password(ctx)
+	require.ErrorIs(t, err, ErrPasswordNotSet)
+}
+
+func TestLookupPassword(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("From#File1\n"), 0o600))
+
+	t.Setenv(PasswordEnv, "")
+	t.Setenv(PasswordFileEnv, "")
+	_, err := LookupPassword(ctx)
+	require.ErrorIs(t, err, ErrPasswordNotSet)
+
+	// The environment variable is the last resort
+	t.Setenv(PasswordEnv, "From#Env1")
+	password, err := LookupPassword(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "From#Env1", password)
+
+	t.Setenv(PasswordFileEnv, path)
+	password, err = LookupPassword(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "From#File1", password)
+
+	// Providers, such as a secrets manager, take precedence, unless not configured
+	vault := PasswordProviderFunc(func(context.Context) (string, error) { return "From#Vault1", nil })
+	unset := PasswordProviderFunc(func(context.Context) (string, error) { return "", ErrPasswordNotSet })
+	password, err = LookupPassword(ctx, unset, vault)
+	require.NoError(t, err)
+	require.Equal(t, "From#Vault1", password)
+
+	// Failing sources are reported instead of falling back
+	failing := PasswordProviderFunc(func(context.Context) (string, error) { return "", errors.New("vault sealed") })
+	_, err = LookupPassword(ctx, failing)
+	require.ErrorContains(t, err, "vault sealed")
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -29,19 +30,31 @@ func Distinct[T comparable](input []T) []T {
 	return result
 }
 
-// Retry retries a function until it returns a non-zero value
-func Retry[T comparable](fun func() T, delaySecond, tryCnt int) T {
+// Retry calls fun until it returns a non-zero value, ctx is done or timeout elapses.
+// The delay between attempts starts at initialDelay and doubles up to maxDelay.
+func Retry[T comparable](ctx context.Context, fun func() T, initialDelay, maxDelay, timeout time.Duration) T {
 	var zero T
-	for i := 0; i < tryCnt; i++ {
-		result := fun()
-		if result != zero {
+	deadline := time.Now().Add(timeout)
+	delay := initialDelay
+	for {
+		if result := fun(); result != zero {
 			return result
 		}
-		if i < tryCnt-1 {
-			time.Sleep(time.Duration(delaySecond) * time.Second)
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return zero
+		}
+
+		timer := time.NewTimer(min(delay, remaining))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return zero
 		}
+		delay = min(delay*2, maxDelay)
 	}
-	return zero
 }
 
 // Now returns the current time in UTC
@@ -3,11 +3,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/spf13/viper"
 )
 
@@ -19,6 +21,12 @@ type NodeConfig struct {
 	TSS      TSSConfig      `yaml:"tss" mapstructure:"tss"`
 	Security SecurityConfig `yaml:"security" mapstructure:"security"`
 	Logging  LoggingConfig  `yaml:"logging" mapstructure:"logging"`
+	// Notifications sends alerts about failed operations and unhealthy nodes to operators
+	Notifications NotificationConfig `yaml:"notifications" mapstructure:"notifications"`
+
+	// DataDir is the directory holding node data (p2p key, storage). Relative paths
+	// are resolved against the config directory; relative data file paths against DataDir.
+	DataDir string `yaml:"data_dir" mapstructure:"data_dir"`
 
 	// ConfigDir is the directory containing the config file (not saved to YAML)
 	ConfigDir string `yaml:"-" mapstructure:"-"`
@@ -40,6 +48,15 @@ type HTTPConfig struct {
 type GRPCConfig struct {
 	Port int    `yaml:"port" mapstructure:"port"`
 	Host string `yaml:"host" mapstructure:"host"`
+	// MaxConcurrentStreams limits the concurrent streams per client connection, 0 keeps the gRPC default
+	MaxConcurrentStreams int `yaml:"max_concurrent_streams" mapstructure:"max_concurrent_streams"`
+	// HealthWatchIntervalSeconds is the interval between health Watch updates
+	HealthWatchIntervalSeconds int `yaml:"health_watch_interval_seconds" mapstructure:"health_watch_interval_seconds"`
+	// HealthWatchSendTimeoutSeconds ends a health Watch whose client does not receive an
+	// update within this many seconds
+	HealthWatchSendTimeoutSeconds int `yaml:"health_watch_send_timeout_seconds" mapstructure:"health_watch_send_timeout_seconds"`
+	// Reflection registers the gRPC reflection service so tools such as grpcurl can list the API
+	Reflection bool `yaml:"reflection" mapstructure:"reflection"`
 }
 
 // P2PConfig holds libp2p configuration
@@ -48,18 +65,124 @@ type P2PConfig struct {
 	BootstrapPeers []string `yaml:"bootstrap_peers" mapstructure:"bootstrap_peers"`
 	PrivateKeyFile string   `yaml:"private_key_file" mapstructure:"private_key_file"`
 	NetMod         string   `yaml:"net_mod" mapstructure:"net_mod"`
+	// Compression algorithm for outgoing messages: none, gzip or zstd.
+	// Incoming messages are decoded regardless of this setting.
+	Compression string `yaml:"compression" mapstructure:"compression"`
+	// MaxMessageBytes limits the size of a single incoming P2P message
+	MaxMessageBytes int `yaml:"max_message_bytes" mapstructure:"max_message_bytes"`
+	// SendConcurrency limits how many recipients of an outgoing message are sent to at once,
+	// smoothing broadcast rounds with many parties (0 is unlimited)
+	SendConcurrency int `yaml:"send_concurrency" mapstructure:"send_concurrency"`
+	// DHT configures the Kademlia DHT used when NetMod is "dht"
+	DHT DHTConfig `yaml:"dht" mapstructure:"dht"`
+	// MDNS configures the periodic rediscovery of mDNS peer discovery
+	MDNS MDNSConfig `yaml:"mdns" mapstructure:"mdns"`
+	// PeerScoring configures the banning of peers violating the protocol
+	PeerScoring PeerScoringConfig `yaml:"peer_scoring" mapstructure:"peer_scoring"`
+	// ConnManager limits the open connections, see ConnManagerConfig
+	ConnManager ConnManagerConfig `yaml:"conn_manager" mapstructure:"conn_manager"`
+}
+
+// ConnManagerConfig holds libp2p connection manager limits. Once more than HighWater
+// connections are open, connections are closed down to LowWater, sparing connections younger
+// than GracePeriodSeconds and connections to the parties of running operations.
+type ConnManagerConfig struct {
+	LowWater           int `yaml:"low_water" mapstructure:"low_water"`
+	HighWater          int `yaml:"high_water" mapstructure:"high_water"`
+	GracePeriodSeconds int `yaml:"grace_period_seconds" mapstructure:"grace_period_seconds"`
+}
+
+// PeerScoringConfig holds peer banning configuration. A peer sending ViolationThreshold
+// malformed, undecryptable or otherwise invalid messages within WindowSeconds has its
+// streams reset and its connections refused for BanSeconds.
+type PeerScoringConfig struct {
+	// ViolationThreshold is the number of violations that bans a peer (0 disables banning)
+	ViolationThreshold int `yaml:"violation_threshold" mapstructure:"violation_threshold"`
+	// WindowSeconds is the period over which violations are counted
+	WindowSeconds int `yaml:"window_seconds" mapstructure:"window_seconds"`
+	// BanSeconds is how long a banned peer is refused
+	BanSeconds int `yaml:"ban_seconds" mapstructure:"ban_seconds"`
+}
+
+// MDNSConfig holds mDNS rediscovery configuration. Rediscovery runs every
+// MinIntervalSeconds while peers are being discovered and backs off with jitter up to
+// MaxIntervalSeconds once they are connected, speeding up again when a peer drops.
+type MDNSConfig struct {
+	MinIntervalSeconds int `yaml:"min_interval_seconds" mapstructure:"min_interval_seconds"`
+	MaxIntervalSeconds int `yaml:"max_interval_seconds" mapstructure:"max_interval_seconds"`
+	// ExpectedPeers is the number of peers of a fully connected node, e.g. the cluster size
+	// minus one. When 0 the peers count as discovered once a round finds no new peer.
+	ExpectedPeers int `yaml:"expected_peers" mapstructure:"expected_peers"`
+}
+
+// DHTConfig holds DHT configuration
+type DHTConfig struct {
+	// Mode is server, client or disabled. Clients query the DHT without serving records,
+	// disabled nodes only dial the bootstrap peers and discover others over mDNS.
+	Mode string `yaml:"mode" mapstructure:"mode"`
 }
 
 // StorageConfig holds storage configuration
 type StorageConfig struct {
-	Type    string            `yaml:"type" mapstructure:"type"` // "file", "leveldb"
+	Type    string            `yaml:"type" mapstructure:"type"` // "file", "leveldb", "memory"
 	Path    string            `yaml:"path" mapstructure:"path"`
 	Options map[string]string `yaml:"options" mapstructure:"options"`
+	// EncryptMetadata encrypts persisted operation data (requests, results) at rest
+	EncryptMetadata bool `yaml:"encrypt_metadata" mapstructure:"encrypt_metadata"`
 }
 
 // TSSConfig holds TSS protocol configuration
 type TSSConfig struct {
 	Moniker string `yaml:"moniker" mapstructure:"moniker"`
+	// Mode is "full", or "readonly" for a replica that opens storage read-only and only
+	// answers queries, refusing to start or join operations
+	Mode string `yaml:"mode" mapstructure:"mode"`
+	// SigningDedupTTLSeconds deduplicates signing requests without an operation ID by
+	// message, key, participants and metadata for this many seconds (0 disables deduplication)
+	SigningDedupTTLSeconds int `yaml:"signing_dedup_ttl_seconds" mapstructure:"signing_dedup_ttl_seconds"`
+	// SyncRetries is how often delivery of an operation sync message to a participant is retried
+	SyncRetries int `yaml:"sync_retries" mapstructure:"sync_retries"`
+	// SyncRetryIntervalMs is the delay before the first retry in milliseconds, doubled on each retry
+	SyncRetryIntervalMs int `yaml:"sync_retry_interval_ms" mapstructure:"sync_retry_interval_ms"`
+	// SyncAckTimeoutSeconds fails an operation early when participants have not acknowledged
+	// its sync message within this many seconds (0 disables acknowledgement tracking)
+	SyncAckTimeoutSeconds int `yaml:"sync_ack_timeout_seconds" mapstructure:"sync_ack_timeout_seconds"`
+	// JoinTimeoutSeconds makes keygen requests wait this many seconds for the participants to
+	// join the operation before responding, failing it when too few did (0 responds immediately)
+	JoinTimeoutSeconds int `yaml:"join_timeout_seconds" mapstructure:"join_timeout_seconds"`
+	// JoinQuorum is how many participants must join, "all" or "threshold" for threshold+1
+	JoinQuorum string `yaml:"join_quorum" mapstructure:"join_quorum"`
+	// KeyIDFormat is how new keys are identified: "uuid" for a random ID, stored with the
+	// key's address, or "address" to use the Ethereum address as key ID like older versions
+	KeyIDFormat string `yaml:"key_id_format" mapstructure:"key_id_format"`
+	// TrimSigningQuorum signs with only threshold+1 of the participants a request names
+	TrimSigningQuorum bool `yaml:"trim_signing_quorum" mapstructure:"trim_signing_quorum"`
+	// SessionLookupTimeoutSeconds is how long an incoming TSS message waits for the operation
+	// of its session to be created, e.g. while the sync message is still in flight
+	SessionLookupTimeoutSeconds int `yaml:"session_lookup_timeout_seconds" mapstructure:"session_lookup_timeout_seconds"`
+	// EarlyMessageWindowSeconds buffers TSS messages that arrive before their operation was
+	// created for this many seconds and replays them once it exists. 0 disables buffering,
+	// the message then blocks for up to SessionLookupTimeoutSeconds.
+	EarlyMessageWindowSeconds int `yaml:"early_message_window_seconds" mapstructure:"early_message_window_seconds"`
+	// MaxConcurrentOperations limits how many operations run at once. Further operations this
+	// node initiates wait and are admitted by priority: signing, then keygen, then resharing.
+	// Operations synced from other nodes are rejected at the limit (0 is unlimited)
+	MaxConcurrentOperations int `yaml:"max_concurrent_operations" mapstructure:"max_concurrent_operations"`
+	// MaxMessageBytes rejects signing requests whose message or typed data is larger (0 is unlimited)
+	MaxMessageBytes int `yaml:"max_message_bytes" mapstructure:"max_message_bytes"`
+	// PersistFullMessage stores the message or typed data of finished signing operations,
+	// by default only their SHA-256 hash is stored
+	PersistFullMessage bool `yaml:"persist_full_message" mapstructure:"persist_full_message"`
+	// OperationCacheSize keeps this many recently finished operations in memory, so polling
+	// them does not read storage (0 disables the cache)
+	OperationCacheSize int `yaml:"operation_cache_size" mapstructure:"operation_cache_size"`
+	// NodeNames maps human readable node names to peer IDs, operations may list participants
+	// by these names instead of raw peer IDs. Names are case insensitive.
+	NodeNames map[string]string `yaml:"node_names,omitempty" mapstructure:"node_names"`
+	// MinOperationTimeoutSeconds and MaxOperationTimeoutSeconds bound the operation timeout
+	// clients may request per request, values outside are ignored (a maximum of 0 disables overrides)
+	MinOperationTimeoutSeconds int `yaml:"min_operation_timeout_seconds" mapstructure:"min_operation_timeout_seconds"`
+	MaxOperationTimeoutSeconds int `yaml:"max_operation_timeout_seconds" mapstructure:"max_operation_timeout_seconds"`
 	// Validation service configuration (optional)
 	ValidationService *ValidationServiceConfig `yaml:"validation_service,omitempty" mapstructure:"validation_service"`
 }
@@ -76,8 +199,28 @@ type ValidationServiceConfig struct {
 	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
 	// Skip TLS verification (for development only)
 	InsecureSkipVerify bool `yaml:"insecure_skip_verify" mapstructure:"insecure_skip_verify"`
+	// Sign request bodies so the validation service can verify the calling node
+	SignRequests bool `yaml:"sign_requests" mapstructure:"sign_requests"`
+	// HMAC secret used to sign requests (optional, the node's P2P key is used when empty)
+	SigningSecret string `yaml:"signing_secret,omitempty" mapstructure:"signing_secret"`
+	// Consecutive failures or timeouts after which requests are rejected without calling
+	// the service (default: 5, 0 disables the circuit breaker)
+	BreakerFailureThreshold int `yaml:"breaker_failure_threshold" mapstructure:"breaker_failure_threshold"`
+	// Seconds requests are rejected before a single probe request is sent (default: 30)
+	BreakerCooldownSeconds int `yaml:"breaker_cooldown_seconds" mapstructure:"breaker_cooldown_seconds"`
+	// What to do with a signing request when the service cannot be reached or does not answer
+	// properly: reject (default) or allow. Explicit rejections always reject.
+	OnError string `yaml:"on_error" mapstructure:"on_error"`
 }
 
+// Validation service error policies
+const (
+	// ValidationOnErrorReject rejects signing requests the service could not validate
+	ValidationOnErrorReject = "reject"
+	// ValidationOnErrorAllow signs requests the service could not validate, with a warning
+	ValidationOnErrorAllow = "allow"
+)
+
 // NodeKeyInfo contains information about a node's P2P key
 type NodeKeyInfo struct {
 	PeerID     string
@@ -92,6 +235,29 @@ type SecurityConfig struct {
 	KeyFile       string              `yaml:"key_file" mapstructure:"key_file"`
 	APIAuth       AuthConfig          `yaml:"api_auth" mapstructure:"api_auth"`
 	AccessControl AccessControlConfig `yaml:"access_control" mapstructure:"access_control"`
+	// P2PEncryption controls end-to-end encryption of P2P messages: required, optional or disabled
+	P2PEncryption string `yaml:"p2p_encryption" mapstructure:"p2p_encryption"`
+	// EnforcePasswordStrength rejects a weak encryption password at startup, when disabled
+	// a weak password is only logged as a warning
+	EnforcePasswordStrength bool `yaml:"enforce_password_strength" mapstructure:"enforce_password_strength"`
+	// KDF selects how the storage encryption key is derived from the encryption password
+	KDF KDFConfig `yaml:"kdf" mapstructure:"kdf"`
+}
+
+// KDFConfig holds the key derivation function configuration of the storage encryption key.
+// Changing it only affects newly encrypted data, existing data keeps decrypting with the
+// parameters recorded alongside it and is re-encrypted when read.
+type KDFConfig struct {
+	// Algorithm is argon2id or scrypt
+	Algorithm string `yaml:"algorithm" mapstructure:"algorithm"`
+	// Time, MemoryKiB and Threads are the argon2id costs
+	Time      uint32 `yaml:"time" mapstructure:"time"`
+	MemoryKiB uint32 `yaml:"memory_kib" mapstructure:"memory_kib"`
+	Threads   uint8  `yaml:"threads" mapstructure:"threads"`
+	// ScryptN, ScryptR and ScryptP are the scrypt cost, block size and parallelism
+	ScryptN uint32 `yaml:"scrypt_n" mapstructure:"scrypt_n"`
+	ScryptR uint32 `yaml:"scrypt_r" mapstructure:"scrypt_r"`
+	ScryptP uint32 `yaml:"scrypt_p" mapstructure:"scrypt_p"`
 }
 
 // AuthConfig holds API authentication configuration
@@ -102,8 +268,22 @@ type AuthConfig struct {
 	JWTSecret string `yaml:"jwt_secret" mapstructure:"jwt_secret"`
 	// JWTIssuer is the expected issuer for JWT tokens
 	JWTIssuer string `yaml:"jwt_issuer,omitempty" mapstructure:"jwt_issuer"`
+	// JWTAudience is the expected audience for JWT tokens (optional)
+	JWTAudience string `yaml:"jwt_audience,omitempty" mapstructure:"jwt_audience"`
+	// Algorithm is the JWT signing algorithm: HS256/384/512, RS256/384/512 or ES256/384/512
+	Algorithm string `yaml:"algorithm,omitempty" mapstructure:"algorithm"`
+	// PublicKeyFile is a PEM public key used to verify RS*/ES* tokens
+	PublicKeyFile string `yaml:"public_key_file,omitempty" mapstructure:"public_key_file"`
+	// JWKSURL is a JWKS endpoint used to verify RS*/ES* tokens
+	JWKSURL string `yaml:"jwks_url,omitempty" mapstructure:"jwks_url"`
+	// OperationIDSecret keys the HMAC that scopes client supplied operation IDs to the
+	// authenticated user, the JWT secret is used when empty
+	OperationIDSecret string `yaml:"operation_id_secret,omitempty" mapstructure:"operation_id_secret"`
 }
 
+// DefaultJWTAlgorithm is used when no algorithm is configured
+const DefaultJWTAlgorithm = "HS256"
+
 // AccessControlConfig holds access control configuration
 type AccessControlConfig struct {
 	Enabled      bool     `yaml:"enabled" mapstructure:"enabled"`
@@ -118,6 +298,66 @@ type LoggingConfig struct {
 	Environment string `yaml:"environment" mapstructure:"environment"`
 	// Output sets the log output destination (stdout, file path)
 	Output string `yaml:"output" mapstructure:"output"`
+	// Rotation configures rotation of the log file when Output is a file path
+	Rotation LogRotationConfig `yaml:"rotation" mapstructure:"rotation"`
+	// Sampling limits repeated log entries such as per-message TSS logs
+	Sampling LogSamplingConfig `yaml:"sampling" mapstructure:"sampling"`
+}
+
+// LogRotationConfig holds log file rotation configuration
+type LogRotationConfig struct {
+	// MaxSizeMB is the size in megabytes at which the log file is rotated
+	MaxSizeMB int `yaml:"max_size_mb" mapstructure:"max_size_mb"`
+	// MaxAgeDays removes rotated files older than this many days (0 keeps them)
+	MaxAgeDays int `yaml:"max_age_days" mapstructure:"max_age_days"`
+	// MaxBackups is the number of rotated files to keep (0 keeps all)
+	MaxBackups int `yaml:"max_backups" mapstructure:"max_backups"`
+	// Compress gzips rotated files
+	Compress bool `yaml:"compress" mapstructure:"compress"`
+}
+
+// LogSamplingConfig holds log sampling configuration. Entries are sampled per level and
+// message each second: the first Initial are logged, then every Thereafter-th.
+type LogSamplingConfig struct {
+	// Initial is the number of identical entries logged per second before sampling starts
+	// (0 disables sampling)
+	Initial int `yaml:"initial" mapstructure:"initial"`
+	// Thereafter logs every Thereafter-th entry once Initial is exceeded (0 drops them)
+	Thereafter int `yaml:"thereafter" mapstructure:"thereafter"`
+}
+
+// NotificationConfig holds the configuration of human facing alerts
+type NotificationConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// MinSeverity drops notifications below this severity: info, warning or critical
+	MinSeverity string `yaml:"min_severity" mapstructure:"min_severity"`
+	// TimeoutSeconds bounds the delivery of a single notification
+	TimeoutSeconds int `yaml:"timeout_seconds" mapstructure:"timeout_seconds"`
+	// QueueSize is the number of notifications waiting for delivery before new ones are dropped
+	QueueSize int `yaml:"queue_size" mapstructure:"queue_size"`
+	// HealthCheckIntervalSeconds is how often the node checks its storage and peer
+	// connectivity to alert when a check starts failing (0 disables the checks)
+	HealthCheckIntervalSeconds int `yaml:"health_check_interval_seconds" mapstructure:"health_check_interval_seconds"`
+	// MinConnectedPeers alerts when fewer peers are connected (0 disables the check)
+	MinConnectedPeers int `yaml:"min_connected_peers" mapstructure:"min_connected_peers"`
+	// Slack posts notifications to a Slack incoming webhook
+	Slack SlackNotificationConfig `yaml:"slack" mapstructure:"slack"`
+	// HTTP posts notifications as JSON to an HTTP endpoint
+	HTTP HTTPNotificationConfig `yaml:"http" mapstructure:"http"`
+}
+
+// SlackNotificationConfig configures Slack notifications, disabled without a webhook URL
+type SlackNotificationConfig struct {
+	WebhookURL string `yaml:"webhook_url" mapstructure:"webhook_url"`
+	// Channel overrides the default channel of the webhook
+	Channel string `yaml:"channel,omitempty" mapstructure:"channel"`
+}
+
+// HTTPNotificationConfig configures generic HTTP notifications, disabled without a URL
+type HTTPNotificationConfig struct {
+	URL string `yaml:"url" mapstructure:"url"`
+	// Headers are added to every request, e.g. for authentication
+	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
 }
 
 // Load loads configuration from the specified node directory
@@ -149,6 +389,11 @@ func Load(nodeDir string) (*NodeConfig, error) {
 
 	// Read environment variables
 	v.AutomaticEnv()
+	// Nested keys are not reachable through AutomaticEnv, bind them explicitly next to
+	// TSS_ENCRYPTION_PASSWORD
+	if err := v.BindEnv("security.enforce_password_strength", "TSS_ENFORCE_PASSWORD_STRENGTH"); err != nil {
+		return nil, fmt.Errorf("error binding environment variables: %w", err)
+	}
 
 	// Try to read config file
 	if err := v.ReadInConfig(); err != nil {
@@ -166,8 +411,8 @@ func Load(nodeDir string) (*NodeConfig, error) {
 	// Set the config directory
 	config.ConfigDir = configDir
 
-	// Update paths to be relative to the node directory
-	updatePathsForNodeDir(config, configDir)
+	// Resolve relative paths against the node and data directories
+	resolvePaths(config, configDir)
 
 	// Validate configuration
 	if err := validateConfig(config); err != nil {
@@ -179,32 +424,75 @@ func Load(nodeDir string) (*NodeConfig, error) {
 
 // setDefaults sets default configuration values
 func setDefaults(v *viper.Viper) {
+	// Data files live in the node directory unless configured otherwise
+	v.SetDefault("data_dir", ".")
+
 	// Server defaults
 	v.SetDefault("server.http.host", "0.0.0.0")
 	v.SetDefault("server.http.port", 8080)
 	v.SetDefault("server.grpc.host", "0.0.0.0")
 	v.SetDefault("server.grpc.port", 9090)
+	v.SetDefault("server.grpc.max_concurrent_streams", 0)
+	v.SetDefault("server.grpc.health_watch_interval_seconds", 10)
+	v.SetDefault("server.grpc.health_watch_send_timeout_seconds", 5)
+	v.SetDefault("server.grpc.reflection", false)
 
 	// P2P defaults
 	v.SetDefault("p2p.listen_addrs", []string{"/ip4/0.0.0.0/tcp/4001"})
 	v.SetDefault("p2p.bootstrap_peers", []string{})
-	// Fixed filename in node directory
+	// Fixed filename in data directory
 	v.SetDefault("p2p.private_key_file", "node_key")
 	v.SetDefault("p2p.net_mod", "mdns")
+	// gzip is understood by every peer version
+	v.SetDefault("p2p.compression", "gzip")
+	v.SetDefault("p2p.max_message_bytes", 16<<20)
+	v.SetDefault("p2p.send_concurrency", 16)
+	v.SetDefault("p2p.dht.mode", "server")
+	v.SetDefault("p2p.mdns.min_interval_seconds", 5)
+	v.SetDefault("p2p.mdns.max_interval_seconds", 300)
+	v.SetDefault("p2p.mdns.expected_peers", 0)
+	v.SetDefault("p2p.peer_scoring.violation_threshold", 10)
+	v.SetDefault("p2p.peer_scoring.window_seconds", 60)
+	v.SetDefault("p2p.peer_scoring.ban_seconds", 600)
+	v.SetDefault("p2p.conn_manager.low_water", 160)
+	v.SetDefault("p2p.conn_manager.high_water", 192)
+	v.SetDefault("p2p.conn_manager.grace_period_seconds", 60)
 
 	// Storage defaults
 	v.SetDefault("storage.type", "leveldb")
-	// Fixed directory name in node directory
+	// Fixed directory name in data directory
 	v.SetDefault("storage.path", "data")
+	v.SetDefault("storage.encrypt_metadata", false)
 
 	// TSS defaults
 	hostname, _ := os.Hostname()
 	v.SetDefault("tss.moniker", hostname)
+	v.SetDefault("tss.signing_dedup_ttl_seconds", 0)
+	v.SetDefault("tss.sync_retries", 3)
+	v.SetDefault("tss.sync_retry_interval_ms", 500)
+	v.SetDefault("tss.sync_ack_timeout_seconds", 60)
+	v.SetDefault("tss.join_timeout_seconds", 0)
+	v.SetDefault("tss.join_quorum", "all")
+	v.SetDefault("tss.mode", "full")
+	v.SetDefault("tss.key_id_format", "uuid")
+	v.SetDefault("tss.trim_signing_quorum", false)
+	v.SetDefault("tss.session_lookup_timeout_seconds", 15)
+	v.SetDefault("tss.early_message_window_seconds", 30)
+	v.SetDefault("tss.max_concurrent_operations", 0)
+	v.SetDefault("tss.max_message_bytes", 65536)
+	v.SetDefault("tss.persist_full_message", false)
+	v.SetDefault("tss.operation_cache_size", 256)
+	v.SetDefault("tss.min_operation_timeout_seconds", 10)
+	v.SetDefault("tss.max_operation_timeout_seconds", 3600)
 
 	// Validation service defaults
 	v.SetDefault("tss.validation_service.enabled", false)
 	v.SetDefault("tss.validation_service.timeout_seconds", 30)
 	v.SetDefault("tss.validation_service.insecure_skip_verify", false)
+	v.SetDefault("tss.validation_service.sign_requests", false)
+	v.SetDefault("tss.validation_service.breaker_failure_threshold", 5)
+	v.SetDefault("tss.validation_service.breaker_cooldown_seconds", 30)
+	v.SetDefault("tss.validation_service.on_error", ValidationOnErrorReject)
 
 	// Security defaults
 	v.SetDefault("security.tls_enabled", false)
@@ -213,35 +501,68 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("security.api_auth.enabled", false)
 	v.SetDefault("security.api_auth.jwt_secret", "")
 	v.SetDefault("security.api_auth.jwt_issuer", "")
+	v.SetDefault("security.api_auth.algorithm", DefaultJWTAlgorithm)
 	v.SetDefault("security.access_control.enabled", false)
 	v.SetDefault("security.access_control.allowed_peers", []string{})
+	v.SetDefault("security.p2p_encryption", "required")
+	v.SetDefault("security.enforce_password_strength", true)
+	v.SetDefault("security.kdf.algorithm", "argon2id")
+	v.SetDefault("security.kdf.time", 3)
+	v.SetDefault("security.kdf.memory_kib", 64*1024)
+	v.SetDefault("security.kdf.threads", 4)
+	v.SetDefault("security.kdf.scrypt_n", 1<<15)
+	v.SetDefault("security.kdf.scrypt_r", 8)
+	v.SetDefault("security.kdf.scrypt_p", 1)
 
 	// Logging defaults
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.environment", "dev")
 	v.SetDefault("logging.output", "stdout")
+	v.SetDefault("logging.rotation.max_size_mb", 100)
+	v.SetDefault("logging.rotation.max_age_days", 30)
+	v.SetDefault("logging.rotation.max_backups", 10)
+	v.SetDefault("logging.rotation.compress", false)
+	v.SetDefault("logging.sampling.initial", 0)
+	v.SetDefault("logging.sampling.thereafter", 0)
+
+	// Notification defaults
+	v.SetDefault("notifications.enabled", false)
+	v.SetDefault("notifications.min_severity", "warning")
+	v.SetDefault("notifications.timeout_seconds", 10)
+	v.SetDefault("notifications.queue_size", 100)
+	v.SetDefault("notifications.health_check_interval_seconds", 60)
+	v.SetDefault("notifications.min_connected_peers", 0)
 }
 
-// updatePathsForNodeDir updates relative paths in the config to be absolute paths
-// relative to the specified nodeDir.
-func updatePathsForNodeDir(config *NodeConfig, nodeDir string) {
-	// Update TLS certificate and key files if they are specified
-	if config.Security.CertFile != "" && !filepath.IsAbs(config.Security.CertFile) {
-		config.Security.CertFile = filepath.Join(nodeDir, config.Security.CertFile)
-	}
-	if config.Security.KeyFile != "" && !filepath.IsAbs(config.Security.KeyFile) {
-		config.Security.KeyFile = filepath.Join(nodeDir, config.Security.KeyFile)
+// resolvePaths makes the paths in the config absolute. The data directory and
+// configuration files (TLS, JWT) are resolved against configDir, data files
+// (p2p key, storage) against the data directory.
+func resolvePaths(config *NodeConfig, configDir string) {
+	config.DataDir = resolvePath(configDir, config.DataDir)
+	if config.DataDir == "" {
+		config.DataDir = configDir
 	}
 
+	// Update TLS certificate and key files if they are specified
+	config.Security.CertFile = resolvePath(configDir, config.Security.CertFile)
+	config.Security.KeyFile = resolvePath(configDir, config.Security.KeyFile)
+
+	// Update JWT public key file path
+	config.Security.APIAuth.PublicKeyFile = resolvePath(configDir, config.Security.APIAuth.PublicKeyFile)
+
 	// Update p2p private key file path
-	if config.P2P.PrivateKeyFile != "" && !filepath.IsAbs(config.P2P.PrivateKeyFile) {
-		config.P2P.PrivateKeyFile = filepath.Join(nodeDir, config.P2P.PrivateKeyFile)
-	}
+	config.P2P.PrivateKeyFile = resolvePath(config.DataDir, config.P2P.PrivateKeyFile)
 
 	// Update storage path
-	if config.Storage.Path != "" && !filepath.IsAbs(config.Storage.Path) {
-		config.Storage.Path = filepath.Join(nodeDir, config.Storage.Path)
+	config.Storage.Path = resolvePath(config.DataDir, config.Storage.Path)
+}
+
+// resolvePath returns path joined to baseDir if it is relative and non-empty
+func resolvePath(baseDir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
 	}
+	return filepath.Join(baseDir, path)
 }
 
 // validateConfig validates the configuration
@@ -250,10 +571,143 @@ func validateConfig(config *NodeConfig) error {
 		return fmt.Errorf("moniker cannot be empty")
 	}
 
-	if config.Storage.Type != "file" && config.Storage.Type != "leveldb" {
+	if config.Storage.Type != "file" && config.Storage.Type != "leveldb" && config.Storage.Type != "memory" {
 		return fmt.Errorf("unsupported storage type: %s", config.Storage.Type)
 	}
 
+	validCompressions := []string{"none", "gzip", "zstd"}
+	if !slices.Contains(validCompressions, config.P2P.Compression) {
+		return fmt.Errorf("invalid p2p compression: %s, must be one of: %v", config.P2P.Compression, validCompressions)
+	}
+
+	if config.Server.GRPC.MaxConcurrentStreams < 0 {
+		return fmt.Errorf("grpc max concurrent streams cannot be negative")
+	}
+
+	if config.Server.GRPC.HealthWatchIntervalSeconds <= 0 {
+		return fmt.Errorf("grpc health watch interval must be positive")
+	}
+
+	if config.Server.GRPC.HealthWatchSendTimeoutSeconds <= 0 {
+		return fmt.Errorf("grpc health watch send timeout must be positive")
+	}
+
+	validEncryptionModes := []string{"required", "optional", "disabled"}
+	if !slices.Contains(validEncryptionModes, config.Security.P2PEncryption) {
+		return fmt.Errorf("invalid p2p encryption mode: %s, must be one of: %v", config.Security.P2PEncryption, validEncryptionModes)
+	}
+
+	validKDFAlgorithms := []string{"argon2id", "scrypt"}
+	if !slices.Contains(validKDFAlgorithms, config.Security.KDF.Algorithm) {
+		return fmt.Errorf("invalid kdf algorithm: %s, must be one of: %v", config.Security.KDF.Algorithm, validKDFAlgorithms)
+	}
+
+	validDHTModes := []string{"server", "client", "disabled"}
+	if !slices.Contains(validDHTModes, config.P2P.DHT.Mode) {
+		return fmt.Errorf("invalid p2p dht mode: %s, must be one of: %v", config.P2P.DHT.Mode, validDHTModes)
+	}
+
+	if config.P2P.MaxMessageBytes < 0 {
+		return fmt.Errorf("p2p max message bytes cannot be negative")
+	}
+
+	if config.P2P.SendConcurrency < 0 {
+		return fmt.Errorf("p2p send concurrency cannot be negative")
+	}
+
+	if mdnsCfg := config.P2P.MDNS; mdnsCfg.MinIntervalSeconds <= 0 || mdnsCfg.MaxIntervalSeconds < mdnsCfg.MinIntervalSeconds {
+		return fmt.Errorf("invalid p2p mdns intervals: min must be positive and max at least min")
+	}
+	if config.P2P.MDNS.ExpectedPeers < 0 {
+		return fmt.Errorf("p2p mdns expected peers cannot be negative")
+	}
+	if scoring := config.P2P.PeerScoring; scoring.ViolationThreshold < 0 {
+		return fmt.Errorf("p2p peer scoring violation threshold cannot be negative")
+	} else if scoring.ViolationThreshold > 0 && (scoring.WindowSeconds <= 0 || scoring.BanSeconds <= 0) {
+		return fmt.Errorf("p2p peer scoring window and ban seconds must be positive when banning is enabled")
+	}
+	if connManager := config.P2P.ConnManager; connManager.LowWater <= 0 || connManager.HighWater < connManager.LowWater {
+		return fmt.Errorf("invalid p2p conn manager limits: low water must be positive and high water at least low water")
+	} else if connManager.GracePeriodSeconds < 0 {
+		return fmt.Errorf("p2p conn manager grace period cannot be negative")
+	}
+
+	if config.TSS.SigningDedupTTLSeconds < 0 {
+		return fmt.Errorf("signing dedup TTL cannot be negative")
+	}
+
+	if config.TSS.SyncRetries < 0 {
+		return fmt.Errorf("sync retries cannot be negative")
+	}
+
+	if config.TSS.SyncRetryIntervalMs < 0 {
+		return fmt.Errorf("sync retry interval cannot be negative")
+	}
+
+	if config.TSS.SyncAckTimeoutSeconds < 0 {
+		return fmt.Errorf("sync ack timeout cannot be negative")
+	}
+
+	if config.TSS.JoinTimeoutSeconds < 0 {
+		return fmt.Errorf("join timeout cannot be negative")
+	}
+
+	switch config.TSS.JoinQuorum {
+	case "", "all", "threshold":
+	default:
+		return fmt.Errorf("join quorum must be all or threshold")
+	}
+
+	switch config.TSS.Mode {
+	case "", "full":
+	case "readonly":
+		if config.Storage.Type == "memory" {
+			return fmt.Errorf("readonly mode requires persistent storage")
+		}
+	default:
+		return fmt.Errorf("tss mode must be full or readonly")
+	}
+
+	switch config.TSS.KeyIDFormat {
+	case "", "uuid", "address":
+	default:
+		return fmt.Errorf("key ID format must be uuid or address")
+	}
+
+	if config.TSS.SessionLookupTimeoutSeconds < 0 {
+		return fmt.Errorf("session lookup timeout cannot be negative")
+	}
+
+	if config.TSS.EarlyMessageWindowSeconds < 0 {
+		return fmt.Errorf("early message window cannot be negative")
+	}
+
+	if config.TSS.MaxConcurrentOperations < 0 {
+		return fmt.Errorf("max concurrent operations cannot be negative")
+	}
+
+	if config.TSS.MaxMessageBytes < 0 {
+		return fmt.Errorf("max message bytes cannot be negative")
+	}
+
+	if config.TSS.OperationCacheSize < 0 {
+		return fmt.Errorf("operation cache size cannot be negative")
+	}
+
+	if config.TSS.MinOperationTimeoutSeconds < 0 || config.TSS.MaxOperationTimeoutSeconds < 0 {
+		return fmt.Errorf("operation timeout bounds cannot be negative")
+	}
+	if config.TSS.MaxOperationTimeoutSeconds > 0 &&
+		config.TSS.MaxOperationTimeoutSeconds < config.TSS.MinOperationTimeoutSeconds {
+		return fmt.Errorf("max operation timeout must not be less than min operation timeout")
+	}
+
+	for name, peerID := range config.TSS.NodeNames {
+		if _, err := peer.Decode(peerID); err != nil {
+			return fmt.Errorf("invalid peer ID %q for node name %q: %w", peerID, name, err)
+		}
+	}
+
 	// Validate validation service configuration if enabled
 	if config.TSS.ValidationService != nil && config.TSS.ValidationService.Enabled {
 		if config.TSS.ValidationService.URL == "" {
@@ -262,12 +716,24 @@ func validateConfig(config *NodeConfig) error {
 		if config.TSS.ValidationService.TimeoutSeconds <= 0 {
 			return fmt.Errorf("validation service timeout must be positive")
 		}
+		if config.TSS.ValidationService.BreakerFailureThreshold < 0 {
+			return fmt.Errorf("validation service breaker failure threshold cannot be negative")
+		}
+		if config.TSS.ValidationService.BreakerFailureThreshold > 0 && config.TSS.ValidationService.BreakerCooldownSeconds <= 0 {
+			return fmt.Errorf("validation service breaker cooldown must be positive")
+		}
+		switch config.TSS.ValidationService.OnError {
+		case "", ValidationOnErrorReject, ValidationOnErrorAllow:
+		default:
+			return fmt.Errorf("invalid validation service on_error %q: must be %s or %s",
+				config.TSS.ValidationService.OnError, ValidationOnErrorReject, ValidationOnErrorAllow)
+		}
 	}
 
 	// Validate JWT authentication configuration if enabled
 	if config.Security.APIAuth.Enabled {
-		if config.Security.APIAuth.JWTSecret == "" {
-			return fmt.Errorf("JWT secret cannot be empty when authentication is enabled")
+		if err := validateAuthConfig(&config.Security.APIAuth); err != nil {
+			return fmt.Errorf("invalid API auth configuration: %w", err)
 		}
 	}
 
@@ -276,6 +742,74 @@ func validateConfig(config *NodeConfig) error {
 		return fmt.Errorf("invalid logging configuration: %w", err)
 	}
 
+	if config.Notifications.Enabled {
+		if err := validateNotificationConfig(&config.Notifications); err != nil {
+			return fmt.Errorf("invalid notification configuration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateAuthConfig validates JWT authentication configuration
+func validateAuthConfig(config *AuthConfig) error {
+	algorithm := config.Algorithm
+	if algorithm == "" {
+		algorithm = DefaultJWTAlgorithm
+	}
+
+	switch algorithm {
+	case "HS256", "HS384", "HS512":
+		if config.JWTSecret == "" {
+			return fmt.Errorf("JWT secret cannot be empty when using %s", algorithm)
+		}
+	case "RS256", "RS384", "RS512", "ES256", "ES384", "ES512":
+		if config.PublicKeyFile == "" && config.JWKSURL == "" {
+			return fmt.Errorf("public_key_file or jwks_url is required when using %s", algorithm)
+		}
+		if config.PublicKeyFile != "" && config.JWKSURL != "" {
+			return fmt.Errorf("public_key_file and jwks_url are mutually exclusive")
+		}
+		if config.PublicKeyFile != "" {
+			if _, err := os.Stat(config.PublicKeyFile); err != nil {
+				return fmt.Errorf("JWT public key file: %w", err)
+			}
+		}
+		if config.JWKSURL != "" {
+			u, err := url.Parse(config.JWKSURL)
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				return fmt.Errorf("invalid jwks_url: %s", config.JWKSURL)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported JWT algorithm: %s", algorithm)
+	}
+	return nil
+}
+
+// validateNotificationConfig validates the configuration of enabled notifications
+func validateNotificationConfig(config *NotificationConfig) error {
+	if config.Slack.WebhookURL == "" && config.HTTP.URL == "" {
+		return fmt.Errorf("slack webhook_url or http url is required")
+	}
+	for _, endpoint := range []string{config.Slack.WebhookURL, config.HTTP.URL} {
+		if endpoint == "" {
+			continue
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid notification URL: %s", endpoint)
+		}
+	}
+
+	validSeverities := []string{"info", "warning", "critical"}
+	if config.MinSeverity != "" && !slices.Contains(validSeverities, config.MinSeverity) {
+		return fmt.Errorf("invalid min severity: %s, must be one of: %v", config.MinSeverity, validSeverities)
+	}
+	if config.TimeoutSeconds < 0 || config.QueueSize < 0 || config.HealthCheckIntervalSeconds < 0 ||
+		config.MinConnectedPeers < 0 {
+		return fmt.Errorf("notification settings cannot be negative")
+	}
 	return nil
 }
 
@@ -294,5 +828,12 @@ func validateLoggingConfig(config *LoggingConfig) error {
 	if !isValidEnvironment {
 		return fmt.Errorf("invalid log environment: %s, must be one of: %v", config.Environment, validEnvironments)
 	}
+
+	if config.Rotation.MaxSizeMB < 0 || config.Rotation.MaxAgeDays < 0 || config.Rotation.MaxBackups < 0 {
+		return fmt.Errorf("log rotation settings cannot be negative")
+	}
+	if config.Sampling.Initial < 0 || config.Sampling.Thereafter < 0 {
+		return fmt.Errorf("log sampling settings cannot be negative")
+	}
 	return nil
 }
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePaths(t *testing.T) {
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		dataDir     string
+		wantDataDir string
+	}{
+		{name: "empty data dir defaults to config dir", dataDir: "", wantDataDir: configDir},
+		{name: "relative data dir", dataDir: "var", wantDataDir: filepath.Join(configDir, "var")},
+		{name: "absolute data dir", dataDir: dataDir, wantDataDir: dataDir},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &NodeConfig{
+				DataDir: tt.dataDir,
+				P2P:     P2PConfig{PrivateKeyFile: "node_key"},
+				Storage: StorageConfig{Path: "data"},
+				Security: SecurityConfig{
+					CertFile: "tls/cert.pem",
+					KeyFile:  "/etc/tls/key.pem",
+				},
+			}
+			resolvePaths(cfg, configDir)
+
+			require.Equal(t, tt.wantDataDir, cfg.DataDir)
+			require.Equal(t, filepath.Join(tt.wantDataDir, "node_key"), cfg.P2P.PrivateKeyFile)
+			require.Equal(t, filepath.Join(tt.wantDataDir, "data"), cfg.Storage.Path)
+			require.Equal(t, filepath.Join(configDir, "tls/cert.pem"), cfg.Security.CertFile)
+			require.Equal(t, "/etc/tls/key.pem", cfg.Security.KeyFile)
+			require.Empty(t, cfg.Security.APIAuth.PublicKeyFile)
+		})
+	}
+}
+
+func TestLoadResolvesDataDir(t *testing.T) {
+	nodeDir := t.TempDir()
+	config := "data_dir: ./state\ntss:\n  moniker: node1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(nodeDir, "config.yaml"), []byte(config), 0o600))
+
+	cfg, err := Load(nodeDir)
+	require.NoError(t, err)
+
+	dataDir := filepath.Join(nodeDir, "state")
+	require.Equal(t, nodeDir, cfg.ConfigDir)
+	require.Equal(t, dataDir, cfg.DataDir)
+	require.Equal(t, filepath.Join(dataDir, "node_key"), cfg.P2P.PrivateKeyFile)
+	require.Equal(t, filepath.Join(dataDir, "data"), cfg.Storage.Path)
+}
+
+func TestLoadDefaultsDataDirToNodeDir(t *testing.T) {
+	nodeDir := t.TempDir()
+	config := "tss:\n  moniker: node1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(nodeDir, "config.yaml"), []byte(config), 0o600))
+
+	cfg, err := Load(nodeDir)
+	require.NoError(t, err)
+
+	require.Equal(t, nodeDir, cfg.DataDir)
+	require.Equal(t, filepath.Join(nodeDir, "node_key"), cfg.P2P.PrivateKeyFile)
+	require.Equal(t, filepath.Join(nodeDir, "data"), cfg.Storage.Path)
+}
+
+func TestLoadEnforcePasswordStrength(t *testing.T) {
+	nodeDir := t.TempDir()
+	config := "tss:\n  moniker: node1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(nodeDir, "config.yaml"), []byte(config), 0o600))
+
+	// Weak passwords are rejected unless explicitly allowed
+	cfg, err := Load(nodeDir)
+	require.NoError(t, err)
+	require.True(t, cfg.Security.EnforcePasswordStrength)
+
+	t.Setenv("TSS_ENFORCE_PASSWORD_STRENGTH", "false")
+	cfg, err = Load(nodeDir)
+	require.NoError(t, err)
+	require.False(t, cfg.Security.EnforcePasswordStrength)
+}
+
+func TestLoadDHTMode(t *testing.T) {
+	nodeDir := t.TempDir()
+	configPath := filepath.Join(nodeDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tss:\n  moniker: node1\n"), 0o600))
+
+	cfg, err := Load(nodeDir)
+	require.NoError(t, err)
+	require.Equal(t, "server", cfg.P2P.DHT.Mode)
+
+	config := "tss:\n  moniker: node1\np2p:\n  net_mod: dht\n  dht:\n    mode: disabled\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	cfg, err = Load(nodeDir)
+	require.NoError(t, err)
+	require.Equal(t, "disabled", cfg.P2P.DHT.Mode)
+
+	config = "tss:\n  moniker: node1\np2p:\n  dht:\n    mode: relay\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	_, err = Load(nodeDir)
+	require.ErrorContains(t, err, "invalid p2p dht mode")
+}
+
+func TestLoadNodeNames(t *testing.T) {
+	nodeDir := t.TempDir()
+	configPath := filepath.Join(nodeDir, "config.yaml")
+	peerID := "12D3KooWGZCnvk6cX2UUhc1SHhkGvdfJNZicx4uXEb3niyHHN7ch"
+
+	config := "tss:\n  moniker: node1\n  node_names:\n    Alice: " + peerID + "\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	cfg, err := Load(nodeDir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"alice": peerID}, cfg.TSS.NodeNames)
+
+	config = "tss:\n  moniker: node1\n  node_names:\n    alice: not-a-peer-id\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	_, err = Load(nodeDir)
+	require.ErrorContains(t, err, "invalid peer ID")
+}
+
+func TestLoadNotifications(t *testing.T) {
+	nodeDir := t.TempDir()
+	configPath := filepath.Join(nodeDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tss:\n  moniker: node1\n"), 0o600))
+
+	cfg, err := Load(nodeDir)
+	require.NoError(t, err)
+	require.False(t, cfg.Notifications.Enabled)
+	require.Equal(t, "warning", cfg.Notifications.MinSeverity)
+
+	config := "tss:\n  moniker: node1\nnotifications:\n  enabled: true\n  http:\n    url: https://alerts.example.com\n" +
+		"    headers:\n      Authorization: Bearer token\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	cfg, err = Load(nodeDir)
+	require.NoError(t, err)
+	require.Equal(t, "https://alerts.example.com", cfg.Notifications.HTTP.URL)
+	require.Equal(t, "Bearer token", cfg.Notifications.HTTP.Headers["authorization"])
+
+	config = "tss:\n  moniker: node1\nnotifications:\n  enabled: true\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	_, err = Load(nodeDir)
+	require.ErrorContains(t, err, "invalid notification configuration")
+}
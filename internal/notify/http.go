@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// HTTPNotifier posts notifications as JSON to an HTTP endpoint, for alerting systems
+// and chat tools without a dedicated notifier
+type HTTPNotifier struct {
+	*queue
+}
+
+// NewHTTPNotifier creates a notifier posting each notification as a JSON Message to url
+// with the given additional headers
+func NewHTTPNotifier(url string, headers map[string]string, opts Options, logger *zap.Logger) *HTTPNotifier {
+	return &HTTPNotifier{newQueue("http", &httpSender{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{},
+	}, opts, logger)}
+}
+
+type httpSender struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (s *httpSender) send(ctx context.Context, msg *Message) error {
+	return postJSON(ctx, s.client, s.url, s.headers, msg)
+}
+
+// postJSON posts body as JSON and fails on non 2xx responses
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DKNet-TSS-Node/1.0")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification endpoint returned %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
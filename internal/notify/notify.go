@@ -0,0 +1,337 @@
+// Package notify sends human facing alerts about failed operations and unhealthy nodes
+// to chat tools and HTTP endpoints.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+// Severity ranks how urgently a notification needs attention
+type Severity string
+
+const (
+	// SeverityInfo is for notifications that need no action, such as completed operations
+	SeverityInfo Severity = "info"
+	// SeverityWarning is for problems that may resolve themselves, such as timed out operations
+	SeverityWarning Severity = "warning"
+	// SeverityCritical is for problems that need an operator, such as failed operations
+	SeverityCritical Severity = "critical"
+)
+
+// rank orders severities, unknown severities rank lowest
+func (s Severity) rank() int {
+	switch s {
+	case SeverityWarning:
+		return 1
+	case SeverityCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// ParseSeverity returns the severity of a configured name, info when empty
+func ParseSeverity(name string) (Severity, error) {
+	switch severity := Severity(strings.ToLower(name)); severity {
+	case "":
+		return SeverityInfo, nil
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("unknown severity %q", name)
+	}
+}
+
+// Operation describes the finished operation a notification is about
+type Operation struct {
+	ID           string
+	Type         string
+	Status       string
+	KeyID        string
+	Participants []string
+	Error        string
+	Duration     time.Duration
+}
+
+// Notifier sends alerts. Calls never block the caller, notifications that cannot be
+// delivered are logged and dropped.
+type Notifier interface {
+	// OperationFailed reports an operation that failed, was canceled or timed out
+	OperationFailed(op *Operation)
+	// OperationCompleted reports an operation that completed successfully
+	OperationCompleted(op *Operation)
+	// NodeUnhealthy reports that a health check of this node started failing
+	NodeUnhealthy(check, reason string)
+	// Close stops delivery after the queued notifications were sent
+	Close()
+}
+
+// Message is a formatted notification
+type Message struct {
+	Severity Severity          `json:"severity"`
+	Title    string            `json:"title"`
+	Text     string            `json:"text"`
+	NodeID   string            `json:"node_id"`
+	Time     time.Time         `json:"time"`
+	Fields   map[string]string `json:"fields,omitempty"`
+}
+
+// Options are the settings shared by every notifier
+type Options struct {
+	// NodeID identifies this node in notifications
+	NodeID string
+	// MinSeverity drops notifications of lower severity
+	MinSeverity Severity
+	// Timeout bounds the delivery of a single notification
+	Timeout time.Duration
+	// QueueSize is the number of notifications waiting for delivery before new ones are dropped
+	QueueSize int
+}
+
+const (
+	defaultTimeout   = 10 * time.Second
+	defaultQueueSize = 100
+)
+
+// sender delivers a formatted notification to its destination
+type sender interface {
+	send(ctx context.Context, msg *Message) error
+}
+
+// queue formats notifications and delivers them from a background goroutine, so slow
+// or unreachable destinations never hold up operations
+type queue struct {
+	name    string
+	sender  sender
+	opts    Options
+	logger  *zap.Logger
+	pending chan *Message
+	done    chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newQueue(name string, s sender, opts Options, logger *zap.Logger) *queue {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	q := &queue{
+		name:    name,
+		sender:  s,
+		opts:    opts,
+		logger:  logger,
+		pending: make(chan *Message, opts.QueueSize),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// OperationFailed implements Notifier
+func (q *queue) OperationFailed(op *Operation) {
+	q.enqueue(operationFailedMessage(op))
+}
+
+// OperationCompleted implements Notifier
+func (q *queue) OperationCompleted(op *Operation) {
+	q.enqueue(operationCompletedMessage(op))
+}
+
+// NodeUnhealthy implements Notifier
+func (q *queue) NodeUnhealthy(check, reason string) {
+	q.enqueue(nodeUnhealthyMessage(check, reason))
+}
+
+// Close implements Notifier
+func (q *queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.pending)
+	}
+	q.mu.Unlock()
+	<-q.done
+}
+
+// enqueue queues a notification at or above the minimum severity, dropping it when the
+// queue is full
+func (q *queue) enqueue(msg *Message) {
+	if msg.Severity.rank() < q.opts.MinSeverity.rank() {
+		return
+	}
+	msg.NodeID = q.opts.NodeID
+	msg.Time = time.Now().UTC()
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return
+	}
+	select {
+	case q.pending <- msg:
+	default:
+		q.logger.Warn("Notification queue is full, dropping notification",
+			zap.String("notifier", q.name),
+			zap.String("title", msg.Title))
+	}
+}
+
+// run delivers queued notifications until the queue is closed
+func (q *queue) run() {
+	defer close(q.done)
+	for msg := range q.pending {
+		ctx, cancel := context.WithTimeout(context.Background(), q.opts.Timeout)
+		if err := q.sender.send(ctx, msg); err != nil {
+			q.logger.Warn("Failed to deliver notification",
+				zap.String("notifier", q.name),
+				zap.String("title", msg.Title),
+				zap.Error(err))
+		}
+		cancel()
+	}
+}
+
+// operationFailedMessage formats a failed operation, canceled and timed out operations
+// are warnings
+func operationFailedMessage(op *Operation) *Message {
+	severity := SeverityCritical
+	if op.Status == "canceled" || op.Status == "timed_out" {
+		severity = SeverityWarning
+	}
+	msg := &Message{
+		Severity: severity,
+		Title:    fmt.Sprintf("%s operation %s %s", op.Type, op.ID, op.Status),
+		Text:     op.Error,
+		Fields:   operationFields(op),
+	}
+	if msg.Text == "" {
+		msg.Text = fmt.Sprintf("The %s operation did not complete.", op.Type)
+	}
+	return msg
+}
+
+// operationCompletedMessage formats a completed operation
+func operationCompletedMessage(op *Operation) *Message {
+	return &Message{
+		Severity: SeverityInfo,
+		Title:    fmt.Sprintf("%s operation %s completed", op.Type, op.ID),
+		Text:     fmt.Sprintf("The %s operation completed in %s.", op.Type, op.Duration.Round(time.Millisecond)),
+		Fields:   operationFields(op),
+	}
+}
+
+// nodeUnhealthyMessage formats a failing health check
+func nodeUnhealthyMessage(check, reason string) *Message {
+	return &Message{
+		Severity: SeverityCritical,
+		Title:    fmt.Sprintf("Node unhealthy: %s", check),
+		Text:     reason,
+		Fields:   map[string]string{"check": check},
+	}
+}
+
+// operationFields returns the details of an operation shown with its notification
+func operationFields(op *Operation) map[string]string {
+	fields := map[string]string{
+		"operation_id": op.ID,
+		"type":         op.Type,
+		"status":       op.Status,
+	}
+	if op.KeyID != "" {
+		fields["key_id"] = op.KeyID
+	}
+	if len(op.Participants) > 0 {
+		fields["participants"] = strings.Join(op.Participants, ", ")
+	}
+	if op.Duration > 0 {
+		fields["duration"] = op.Duration.Round(time.Millisecond).String()
+	}
+	return fields
+}
+
+// Multi forwards notifications to every notifier
+type Multi []Notifier
+
+// OperationFailed implements Notifier
+func (m Multi) OperationFailed(op *Operation) {
+	for _, n := range m {
+		n.OperationFailed(op)
+	}
+}
+
+// OperationCompleted implements Notifier
+func (m Multi) OperationCompleted(op *Operation) {
+	for _, n := range m {
+		n.OperationCompleted(op)
+	}
+}
+
+// NodeUnhealthy implements Notifier
+func (m Multi) NodeUnhealthy(check, reason string) {
+	for _, n := range m {
+		n.NodeUnhealthy(check, reason)
+	}
+}
+
+// Close implements Notifier
+func (m Multi) Close() {
+	for _, n := range m {
+		n.Close()
+	}
+}
+
+// Nop discards notifications, it is used when notifications are disabled
+type Nop struct{}
+
+// OperationFailed implements Notifier
+func (Nop) OperationFailed(*Operation) {}
+
+// OperationCompleted implements Notifier
+func (Nop) OperationCompleted(*Operation) {}
+
+// NodeUnhealthy implements Notifier
+func (Nop) NodeUnhealthy(string, string) {}
+
+// Close implements Notifier
+func (Nop) Close() {}
+
+// New creates the notifiers enabled in the configuration, Nop when notifications are disabled
+func New(cfg *config.NotificationConfig, nodeID string, logger *zap.Logger) (Notifier, error) {
+	if !cfg.Enabled {
+		return Nop{}, nil
+	}
+	minSeverity, err := ParseSeverity(cfg.MinSeverity)
+	if err != nil {
+		return nil, err
+	}
+	opts := Options{
+		NodeID:      nodeID,
+		MinSeverity: minSeverity,
+		Timeout:     time.Duration(cfg.TimeoutSeconds) * time.Second,
+		QueueSize:   cfg.QueueSize,
+	}
+
+	var notifiers Multi
+	if cfg.Slack.WebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(cfg.Slack.WebhookURL, cfg.Slack.Channel, opts, logger))
+	}
+	if cfg.HTTP.URL != "" {
+		notifiers = append(notifiers, NewHTTPNotifier(cfg.HTTP.URL, cfg.HTTP.Headers, opts, logger))
+	}
+	if len(notifiers) == 0 {
+		return nil, fmt.Errorf("notifications are enabled without a Slack webhook URL or HTTP URL")
+	}
+	return notifiers, nil
+}
@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+// newTestEndpoint starts an HTTP server decoding posted JSON bodies into values of type T
+func newTestEndpoint[T any](t *testing.T) (*httptest.Server, <-chan T, <-chan http.Header) {
+	t.Helper()
+	bodies := make(chan T, 10)
+	headers := make(chan http.Header, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body T
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		headers <- r.Header
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+	return server, bodies, headers
+}
+
+func TestHTTPNotifier(t *testing.T) {
+	server, bodies, headers := newTestEndpoint[Message](t)
+	n := NewHTTPNotifier(server.URL, map[string]string{"Authorization": "Bearer token"},
+		Options{NodeID: "node1", MinSeverity: SeverityWarning}, zap.NewNop())
+
+	n.OperationCompleted(&Operation{ID: "op-1", Type: "signing", Status: "completed"})
+	n.OperationFailed(&Operation{
+		ID:           "op-2",
+		Type:         "keygen",
+		Status:       "failed",
+		KeyID:        "0xabc",
+		Participants: []string{"node1", "node2"},
+		Error:        "party timed out",
+		Duration:     1500 * time.Millisecond,
+	})
+	n.Close()
+
+	// The completed operation is below the minimum severity
+	require.Len(t, bodies, 1)
+	msg := <-bodies
+	require.Equal(t, SeverityCritical, msg.Severity)
+	require.Equal(t, "node1", msg.NodeID)
+	require.Equal(t, "party timed out", msg.Text)
+	require.Equal(t, map[string]string{
+		"operation_id": "op-2",
+		"type":         "keygen",
+		"status":       "failed",
+		"key_id":       "0xabc",
+		"participants": "node1, node2",
+		"duration":     "1.5s",
+	}, msg.Fields)
+	require.Equal(t, "Bearer token", (<-headers).Get("Authorization"))
+}
+
+func TestSlackNotifier(t *testing.T) {
+	server, bodies, _ := newTestEndpoint[slackMessage](t)
+	n := NewSlackNotifier(server.URL, "#alerts", Options{NodeID: "node1"}, zap.NewNop())
+
+	n.OperationFailed(&Operation{ID: "op-1", Type: "signing", Status: "canceled"})
+	n.NodeUnhealthy("peers", "0 peers connected, at least 2 expected")
+	n.Close()
+
+	require.Len(t, bodies, 2)
+	canceled := <-bodies
+	require.Equal(t, "#alerts", canceled.Channel)
+	require.Equal(t, "[warning] signing operation op-1 canceled", canceled.Text)
+	require.Len(t, canceled.Attachments, 1)
+	require.Equal(t, "warning", canceled.Attachments[0].Color)
+	require.Equal(t, "node node1", canceled.Attachments[0].Footer)
+	// Fields are sorted by name
+	require.Equal(t, "operation_id", canceled.Attachments[0].Fields[0].Title)
+
+	unhealthy := <-bodies
+	require.Equal(t, "danger", unhealthy.Attachments[0].Color)
+	require.Equal(t, "0 peers connected, at least 2 expected", unhealthy.Attachments[0].Text)
+}
+
+// blockingSender blocks deliveries until released
+type blockingSender struct {
+	release chan struct{}
+	sent    chan *Message
+}
+
+func (s *blockingSender) send(ctx context.Context, msg *Message) error {
+	<-s.release
+	s.sent <- msg
+	return nil
+}
+
+func TestQueueDropsWhenFull(t *testing.T) {
+	s := &blockingSender{release: make(chan struct{}), sent: make(chan *Message, 10)}
+	q := newQueue("test", s, Options{QueueSize: 1}, zap.NewNop())
+
+	// The first notification is being delivered, the second is queued, the rest are dropped
+	q.NodeUnhealthy("storage", "first")
+	require.Eventually(t, func() bool { return len(q.pending) == 0 }, time.Second, time.Millisecond)
+	for i := 0; i < 5; i++ {
+		q.NodeUnhealthy("storage", "queued")
+	}
+	close(s.release)
+	q.Close()
+
+	require.Len(t, s.sent, 2)
+	// Notifications after Close are ignored
+	q.NodeUnhealthy("storage", "closed")
+}
+
+func TestNew(t *testing.T) {
+	n, err := New(&config.NotificationConfig{}, "node1", zap.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, Nop{}, n)
+
+	n, err = New(&config.NotificationConfig{
+		Enabled: true,
+		Slack:   config.SlackNotificationConfig{WebhookURL: "https://hooks.slack.com/services/x"},
+		HTTP:    config.HTTPNotificationConfig{URL: "https://alerts.example.com"},
+	}, "node1", zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, n, 2)
+	n.Close()
+
+	_, err = New(&config.NotificationConfig{Enabled: true, MinSeverity: "loud",
+		HTTP: config.HTTPNotificationConfig{URL: "https://alerts.example.com"}}, "node1", zap.NewNop())
+	require.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+// severityColors are the Slack attachment colors of the severities
+var severityColors = map[Severity]string{
+	SeverityInfo:     "good",
+	SeverityWarning:  "warning",
+	SeverityCritical: "danger",
+}
+
+// SlackNotifier posts notifications to a Slack incoming webhook
+type SlackNotifier struct {
+	*queue
+}
+
+// NewSlackNotifier creates a notifier posting to a Slack incoming webhook. channel
+// overrides the webhook's default channel when set.
+func NewSlackNotifier(webhookURL, channel string, opts Options, logger *zap.Logger) *SlackNotifier {
+	return &SlackNotifier{newQueue("slack", &slackSender{
+		webhookURL: webhookURL,
+		channel:    channel,
+		client:     &http.Client{},
+	}, opts, logger)}
+}
+
+type slackSender struct {
+	webhookURL string
+	channel    string
+	client     *http.Client
+}
+
+// slackMessage is the payload of a Slack incoming webhook
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Username    string            `json:"username"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Title  string       `json:"title"`
+	Text   string       `json:"text"`
+	Fields []slackField `json:"fields,omitempty"`
+	Footer string       `json:"footer"`
+	TS     int64        `json:"ts"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func (s *slackSender) send(ctx context.Context, msg *Message) error {
+	return postJSON(ctx, s.client, s.webhookURL, nil, s.format(msg))
+}
+
+// format renders a notification as a Slack message with a color coded attachment
+func (s *slackSender) format(msg *Message) *slackMessage {
+	keys := make([]string, 0, len(msg.Fields))
+	for key := range msg.Fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	fields := make([]slackField, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, slackField{Title: key, Value: msg.Fields[key], Short: len(msg.Fields[key]) < 40})
+	}
+
+	return &slackMessage{
+		Channel:  s.channel,
+		Username: "DKNet",
+		Text:     "[" + string(msg.Severity) + "] " + msg.Title,
+		Attachments: []slackAttachment{{
+			Color:  severityColors[msg.Severity],
+			Title:  msg.Title,
+			Text:   msg.Text,
+			Fields: fields,
+			Footer: "node " + msg.NodeID,
+			TS:     msg.Time.Unix(),
+		}},
+	}
+}
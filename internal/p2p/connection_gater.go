@@ -15,6 +15,8 @@ type connectionGater struct {
 	logger       *zap.Logger
 	allowedPeers map[string]bool
 	enabled      bool
+	// scorer refuses peers banned for protocol violations, whether access control is enabled or not
+	scorer *PeerScorer
 }
 
 // InterceptAccept implements connmgr.ConnectionGater.
@@ -36,14 +38,19 @@ func (c *connectionGater) InterceptAddrDial(peerID peer.ID, addr multiaddr.Multi
 // InterceptPeerDial implements connmgr.ConnectionGater.
 // This is called when dialing a peer (before resolving addresses).
 func (c *connectionGater) InterceptPeerDial(peerID peer.ID) (allow bool) {
-	// Always allow outbound connections - we don't restrict who the current node can connect to
-	// This is necessary for DHT discovery, bootstrap peers, etc.
-	return true
+	// Allow outbound connections to any peer that is not banned - we don't restrict who the
+	// current node can connect to. This is necessary for DHT discovery, bootstrap peers, etc.
+	return !c.scorer.Banned(peerID)
 }
 
 // InterceptSecured implements connmgr.ConnectionGater.
 // This is called after the security handshake, when we have authenticated the peer.
 func (c *connectionGater) InterceptSecured(dir network.Direction, peerID peer.ID, connMultiaddrs network.ConnMultiaddrs) (allow bool) {
+	if c.scorer.Banned(peerID) {
+		c.logger.Debug("Refusing banned peer", zap.String("peer", peerID.String()))
+		return false
+	}
+
 	// If access control is disabled, allow all connections
 	if !c.enabled {
 		return true
@@ -60,6 +67,10 @@ func (c *connectionGater) InterceptSecured(dir network.Direction, peerID peer.ID
 // This is called when a connection has been fully upgraded (secure + multiplexed).
 func (c *connectionGater) InterceptUpgraded(conn network.Conn) (allow bool, reason control.DisconnectReason) {
 	c.logger.Debug("Try to interceptUpgraded", zap.String("peer", conn.RemotePeer().String()))
+	if c.scorer.Banned(conn.RemotePeer()) {
+		return false, control.DisconnectReason(0)
+	}
+
 	// If access control is disabled, allow all connections
 	if !c.enabled {
 		return true, 0
@@ -77,8 +88,9 @@ func (c *connectionGater) InterceptUpgraded(conn network.Conn) (allow bool, reas
 	return false, control.DisconnectReason(0) // Use default disconnect reason
 }
 
-// NewConnectionGater creates a new connection gater
-func NewConnectionGater(allowedPeers []string, enabled bool, logger *zap.Logger) connmgr.ConnectionGater {
+// NewConnectionGater creates a new connection gater. Peers banned by scorer are refused,
+// scorer may be nil.
+func NewConnectionGater(allowedPeers []string, enabled bool, scorer *PeerScorer, logger *zap.Logger) connmgr.ConnectionGater {
 	allowedPeersMap := make(map[string]bool)
 	for _, peer := range allowedPeers {
 		allowedPeersMap[peer] = true
@@ -86,6 +98,7 @@ func NewConnectionGater(allowedPeers []string, enabled bool, logger *zap.Logger)
 	return &connectionGater{
 		allowedPeers: allowedPeersMap,
 		enabled:      enabled,
+		scorer:       scorer,
 		logger:       logger,
 	}
 }
@@ -0,0 +1,81 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"go.uber.org/zap"
+)
+
+// Defaults of the libp2p connection manager, used for unset limits
+const (
+	DefaultConnLowWater    = 160
+	DefaultConnHighWater   = 192
+	DefaultConnGracePeriod = time.Minute
+)
+
+// ConnManager configures the libp2p connection manager. Once more than HighWater connections
+// are open it closes connections down to LowWater, sparing connections younger than
+// GracePeriod and connections to protected peers.
+type ConnManager struct {
+	LowWater    int
+	HighWater   int
+	GracePeriod time.Duration
+}
+
+// PeerProtector is implemented by transports whose connections can be pruned, it keeps the
+// connections to peers taking part in an operation open
+type PeerProtector interface {
+	// Protect keeps the connections to nodeIDs open until they are unprotected for tag
+	Protect(tag string, nodeIDs []string)
+	// Unprotect releases the protection of nodeIDs for tag, other tags still protect them
+	Unprotect(tag string, nodeIDs []string)
+}
+
+var _ PeerProtector = (*Network)(nil)
+
+// newConnManager creates the connection manager of cfg, unset limits take libp2p's defaults
+func newConnManager(cfg ConnManager) (*connmgr.BasicConnMgr, error) {
+	low, high, grace := cfg.LowWater, cfg.HighWater, cfg.GracePeriod
+	if low == 0 {
+		low = DefaultConnLowWater
+	}
+	if high == 0 {
+		high = max(DefaultConnHighWater, low)
+	}
+	if grace == 0 {
+		grace = DefaultConnGracePeriod
+	}
+	return connmgr.NewConnManager(low, high, connmgr.WithGracePeriod(grace))
+}
+
+// Protect implements PeerProtector
+func (n *Network) Protect(tag string, nodeIDs []string) {
+	for _, peerID := range n.decodePeers(nodeIDs) {
+		n.host.ConnManager().Protect(peerID, tag)
+	}
+}
+
+// Unprotect implements PeerProtector
+func (n *Network) Unprotect(tag string, nodeIDs []string) {
+	for _, peerID := range n.decodePeers(nodeIDs) {
+		n.host.ConnManager().Unprotect(peerID, tag)
+	}
+}
+
+// decodePeers returns the peer IDs of nodeIDs other than this node, skipping invalid IDs
+func (n *Network) decodePeers(nodeIDs []string) []peer.ID {
+	peers := make([]peer.ID, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		peerID, err := peer.Decode(nodeID)
+		if err != nil {
+			n.logger.Debug("Skipping invalid peer ID", zap.String("node_id", nodeID), zap.Error(err))
+			continue
+		}
+		if peerID != n.host.ID() {
+			peers = append(peers, peerID)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewConnManagerDefaults(t *testing.T) {
+	manager, err := newConnManager(ConnManager{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = manager.Close() })
+	info := manager.GetInfo()
+	require.Equal(t, DefaultConnLowWater, info.LowWater)
+	require.Equal(t, DefaultConnHighWater, info.HighWater)
+	require.Equal(t, DefaultConnGracePeriod, info.GracePeriod)
+}
+
+func TestProtectedPeersSurvivePruning(t *testing.T) {
+	ctx := context.Background()
+	manager, err := newConnManager(ConnManager{LowWater: 1, HighWater: 2, GracePeriod: time.Nanosecond})
+	require.NoError(t, err)
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"), libp2p.ConnectionManager(manager))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = h.Close() })
+	n := &Network{host: h, logger: zap.NewNop()}
+
+	peers := make([]host.Host, 5)
+	for i := range peers {
+		peers[i] = newTestHost(t)
+		require.NoError(t, h.Connect(ctx, peer.AddrInfo{ID: peers[i].ID(), Addrs: peers[i].Addrs()}))
+	}
+	connected := func(p host.Host) bool {
+		return h.Network().Connectedness(p.ID()) == network.Connected
+	}
+
+	// The parties of an operation stay connected while the others are pruned
+	tag := "dknet-operation:op-1"
+	operationPeers := []string{peers[0].ID().String(), peers[1].ID().String(), h.ID().String()}
+	n.Protect(tag, operationPeers)
+	require.True(t, manager.IsProtected(peers[0].ID(), tag))
+	require.False(t, manager.IsProtected(h.ID(), tag))
+
+	// Pruning closes the unprotected connections down to the low water mark
+	time.Sleep(time.Millisecond)
+	manager.TrimOpenConns(ctx)
+	require.Eventually(t, func() bool {
+		return len(h.Network().Peers()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.True(t, connected(peers[0]))
+	require.True(t, connected(peers[1]))
+
+	// Once the operation ended they can be pruned as well
+	n.Unprotect(tag, operationPeers)
+	require.False(t, manager.IsProtected(peers[0].ID(), tag))
+	manager.TrimOpenConns(ctx)
+	require.Eventually(t, func() bool {
+		return len(h.Network().Peers()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
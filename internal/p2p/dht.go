@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	dht "github.com/libp2p/go-libp2p-kad-dht"
@@ -10,23 +11,43 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	drouting "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"github.com/libp2p/go-libp2p/p2p/discovery/util"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
+const (
+	// DHTModeServer answers DHT queries and stores records for other peers
+	DHTModeServer = "server"
+	// DHTModeClient only queries the DHT, other peers do not route through it
+	DHTModeClient = "client"
+	// DHTModeDisabled does not start the DHT
+	DHTModeDisabled = "disabled"
+)
+
 // dhtNet is a wrapper around the DHT service
 type dhtNet struct {
 	h              host.Host
 	bootstrapPeers []string
+	mode           dht.ModeOpt
 	logger         *zap.Logger
 	ticker         *time.Ticker
 	dhtInstance    *dht.IpfsDHT
+	discovery      *drouting.RoutingDiscovery
 	ctx            context.Context
 	cancel         context.CancelFunc
 }
 
-// NewDHT initializes the DHT service and returns a DhtNet
-func NewDHT(h host.Host, bootstrapPeers []string, logger *zap.Logger) PeerDiscovery {
-	return &dhtNet{h: h, bootstrapPeers: bootstrapPeers, logger: logger}
+// NewDHT initializes the DHT service and returns a DhtNet. The mode is server or client,
+// an empty mode lets the DHT decide from the node's reachability.
+func NewDHT(h host.Host, bootstrapPeers []string, mode string, logger *zap.Logger) PeerDiscovery {
+	n := &dhtNet{h: h, bootstrapPeers: bootstrapPeers, mode: dht.ModeAuto, logger: logger}
+	switch strings.ToLower(mode) {
+	case DHTModeServer:
+		n.mode = dht.ModeServer
+	case DHTModeClient:
+		n.mode = dht.ModeClient
+	}
+	return n
 }
 
 // Start starts the DHT service
@@ -54,7 +75,7 @@ func (n *dhtNet) Start() error {
 	n.dhtInstance, err = dht.New(
 		n.ctx, n.h,
 		dht.BootstrapPeers(bootstrapPeers...),
-		dht.Mode(dht.ModeAuto),
+		dht.Mode(n.mode),
 		dht.RoutingTableRefreshPeriod(30*time.Second),
 	)
 	if err != nil {
@@ -76,6 +97,7 @@ func (n *dhtNet) Start() error {
 
 func (n *dhtNet) startPeerDiscovery() {
 	routingDiscovery := drouting.NewRoutingDiscovery(n.dhtInstance)
+	n.discovery = routingDiscovery
 
 	// Create context with timeout for this discovery round
 	ctx, cancel := context.WithTimeout(n.ctx, 20*time.Second)
@@ -99,6 +121,32 @@ func (n *dhtNet) startPeerDiscovery() {
 	}()
 }
 
+// FindPeer looks up the addresses of a single peer in the DHT
+func (n *dhtNet) FindPeer(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+	if n.dhtInstance == nil {
+		return peer.AddrInfo{}, errors.New("dht is not started")
+	}
+	return n.dhtInstance.FindPeer(ctx, id)
+}
+
+// Rediscover implements PeerDiscovery
+func (n *dhtNet) Rediscover() {
+	if n.discovery == nil {
+		return
+	}
+
+	n.logger.Info("Triggering DHT rediscovery")
+	go func() {
+		// Re-advertise first so peers searching for us find the fresh record
+		ctx, cancel := context.WithTimeout(n.ctx, 10*time.Second)
+		defer cancel()
+		if _, err := n.discovery.Advertise(ctx, DiscoveryRendezvous); err != nil {
+			n.logger.Warn("Failed to advertise during rediscovery", zap.Error(err))
+		}
+		n.discoverPeers(n.discovery)
+	}()
+}
+
 func (n *dhtNet) discoverPeers(routingDiscovery *drouting.RoutingDiscovery) {
 	// Create context with timeout for this discovery round
 	ctx, cancel := context.WithTimeout(n.ctx, 10*time.Second)
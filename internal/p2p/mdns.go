@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"strings"
 	"time"
 
@@ -15,15 +16,79 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// defaultRediscoveryMinInterval is the rediscovery interval while peers are being discovered
+	defaultRediscoveryMinInterval = 5 * time.Second
+	// defaultRediscoveryMaxInterval bounds the rediscovery interval once the peers are connected
+	defaultRediscoveryMaxInterval = 5 * time.Minute
+	// rediscoveryJitter spreads rediscovery rounds of nodes started together by up to ±20%
+	rediscoveryJitter = 0.2
+)
+
+// MDNSRediscovery configures the adaptive interval of periodic mDNS rediscovery. Rounds run
+// every MinInterval while peers are being discovered, back off exponentially up to
+// MaxInterval once the expected peers are connected and speed up again when a peer drops.
+type MDNSRediscovery struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// ExpectedPeers is the number of peers of a fully connected node. When 0 the peers
+	// count as discovered once a round connects no new peer.
+	ExpectedPeers int
+}
+
+// rediscoverySchedule computes the delay before the next rediscovery round
+type rediscoverySchedule struct {
+	cfg       MDNSRediscovery
+	interval  time.Duration
+	lastPeers int
+}
+
+func newRediscoverySchedule(cfg MDNSRediscovery) *rediscoverySchedule {
+	if cfg.MinInterval <= 0 {
+		cfg.MinInterval = defaultRediscoveryMinInterval
+	}
+	if cfg.MaxInterval < cfg.MinInterval {
+		cfg.MaxInterval = max(defaultRediscoveryMaxInterval, cfg.MinInterval)
+	}
+	return &rediscoverySchedule{cfg: cfg, interval: cfg.MinInterval}
+}
+
+// next returns the delay before the next round given the number of connected peers and a
+// random number in [0, 1) for the jitter
+func (s *rediscoverySchedule) next(connected int, random float64) time.Duration {
+	// Without an expected count the peers are discovered once a round adds none
+	settled := connected > 0 && connected == s.lastPeers
+	if s.cfg.ExpectedPeers > 0 {
+		settled = connected >= s.cfg.ExpectedPeers
+	}
+	dropped := connected < s.lastPeers
+	s.lastPeers = connected
+
+	if !settled || dropped {
+		s.interval = s.cfg.MinInterval
+	} else {
+		s.interval = min(2*s.interval, s.cfg.MaxInterval)
+	}
+
+	jittered := time.Duration(float64(s.interval) * (1 + rediscoveryJitter*(2*random-1)))
+	return min(max(jittered, s.cfg.MinInterval), s.cfg.MaxInterval)
+}
+
+// reset returns to the minimum interval, e.g. after a peer disconnected
+func (s *rediscoverySchedule) reset() time.Duration {
+	s.interval = s.cfg.MinInterval
+	return s.interval
+}
+
 // mdnsNet is a wrapper around the MDNS service
 type mdnsNet struct {
-	h        host.Host
-	peerChan chan peer.AddrInfo
-	logger   *zap.Logger
-	service  mdns.Service
-	ticker   *time.Ticker
-	ctx      context.Context
-	cancel   context.CancelFunc
+	h           host.Host
+	peerChan    chan peer.AddrInfo
+	logger      *zap.Logger
+	service     mdns.Service
+	rediscovery MDNSRediscovery
+	ctx         context.Context
+	cancel      context.CancelFunc
 }
 
 // HandlePeerFound is called when a new peer is found
@@ -39,12 +104,13 @@ func (n *mdnsNet) HandlePeerFound(pi peer.AddrInfo) {
 }
 
 // NewMDNS initializes the MDNS service and returns a MdnsNet
-func NewMDNS(peerhost host.Host, logger *zap.Logger) PeerDiscovery {
+func NewMDNS(peerhost host.Host, rediscovery MDNSRediscovery, logger *zap.Logger) PeerDiscovery {
 	// register with service so that we get notified about peer discovery
 	return &mdnsNet{
-		h:        peerhost,
-		peerChan: make(chan peer.AddrInfo, 10), // Buffered channel to prevent blocking
-		logger:   logger,
+		h:           peerhost,
+		peerChan:    make(chan peer.AddrInfo, 10), // Buffered channel to prevent blocking
+		logger:      logger,
+		rediscovery: rediscovery,
 	}
 }
 
@@ -69,21 +135,48 @@ func (n *mdnsNet) Start() error {
 	return nil
 }
 
-// startPeriodicRediscovery starts a periodic rediscovery mechanism
+// startPeriodicRediscovery starts a periodic rediscovery mechanism with an adaptive interval
 func (n *mdnsNet) startPeriodicRediscovery() {
-	// Set up periodic rediscovery every 30 seconds
-	n.ticker = time.NewTicker(30 * time.Second)
+	schedule := newRediscoverySchedule(n.rediscovery)
+
+	// A dropped peer brings rediscovery back to the minimum interval right away
+	disconnected := make(chan struct{}, 1)
+	notifee := &network.NotifyBundle{
+		DisconnectedF: func(_ network.Network, _ network.Conn) {
+			select {
+			case disconnected <- struct{}{}:
+			default:
+			}
+		},
+	}
+	n.h.Network().Notify(notifee)
 
 	go func() {
-		defer n.ticker.Stop()
+		defer n.h.Network().StopNotify(notifee)
+
+		timer := time.NewTimer(schedule.interval)
+		defer timer.Stop()
 
 		for {
 			select {
-			case <-n.ticker.C:
+			case <-timer.C:
 				n.logger.Debug("Performing periodic MDNS rediscovery")
 				// Trigger a new round of discovery
 				n.triggerRediscovery()
 
+				connected := len(n.h.Network().Peers())
+				delay := schedule.next(connected, rand.Float64())
+				n.logger.Debug("Scheduled next MDNS rediscovery",
+					zap.Int("connected_peers", connected),
+					zap.Duration("delay", delay))
+				timer.Reset(delay)
+
+			case <-disconnected:
+				if schedule.interval > schedule.cfg.MinInterval {
+					n.logger.Debug("Peer disconnected, speeding up MDNS rediscovery")
+					timer.Reset(schedule.reset())
+				}
+
 			case <-n.ctx.Done():
 				return
 			}
@@ -91,6 +184,12 @@ func (n *mdnsNet) startPeriodicRediscovery() {
 	}()
 }
 
+// Rediscover implements PeerDiscovery
+func (n *mdnsNet) Rediscover() {
+	n.logger.Info("Triggering MDNS rediscovery")
+	go n.triggerRediscovery()
+}
+
 // triggerRediscovery triggers a new round of MDNS discovery by actively browsing for services
 func (n *mdnsNet) triggerRediscovery() {
 	// Create a context with timeout for this discovery round
@@ -297,10 +396,6 @@ func (n *mdnsNet) Stop() {
 		n.cancel()
 	}
 
-	if n.ticker != nil {
-		n.ticker.Stop()
-	}
-
 	if n.service != nil {
 		if err := n.service.Close(); err != nil {
 			n.logger.Warn("Error closing MDNS service", zap.Error(err))
@@ -0,0 +1,76 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRediscoveryScheduleBacksOff(t *testing.T) {
+	schedule := newRediscoverySchedule(MDNSRediscovery{
+		MinInterval:   5 * time.Second,
+		MaxInterval:   time.Minute,
+		ExpectedPeers: 2,
+	})
+	// Without jitter (random 0.5) the delays are the plain intervals
+	next := func(connected int) time.Duration { return schedule.next(connected, 0.5) }
+
+	// Frequent while peers are still being discovered
+	require.Equal(t, 5*time.Second, next(0))
+	require.Equal(t, 5*time.Second, next(1))
+
+	// Exponential backoff once the expected peers are connected, up to the maximum
+	require.Equal(t, 10*time.Second, next(2))
+	require.Equal(t, 20*time.Second, next(2))
+	require.Equal(t, 40*time.Second, next(2))
+	require.Equal(t, time.Minute, next(2))
+	require.Equal(t, time.Minute, next(2))
+
+	// A dropped peer speeds rediscovery up again
+	require.Equal(t, 5*time.Second, next(1))
+	require.Equal(t, 10*time.Second, next(2))
+
+	// So does a disconnect between rounds
+	require.Equal(t, 5*time.Second, schedule.reset())
+	require.Equal(t, 10*time.Second, next(2))
+}
+
+func TestRediscoveryScheduleWithoutExpectedPeers(t *testing.T) {
+	schedule := newRediscoverySchedule(MDNSRediscovery{MinInterval: time.Second, MaxInterval: 8 * time.Second})
+	next := func(connected int) time.Duration { return schedule.next(connected, 0.5) }
+
+	// Rounds that add peers keep the minimum interval, stable rounds back off
+	require.Equal(t, time.Second, next(0))
+	require.Equal(t, time.Second, next(2))
+	require.Equal(t, time.Second, next(3))
+	require.Equal(t, 2*time.Second, next(3))
+	require.Equal(t, 4*time.Second, next(3))
+	require.Equal(t, time.Second, next(2))
+}
+
+func TestRediscoveryScheduleJitter(t *testing.T) {
+	schedule := newRediscoverySchedule(MDNSRediscovery{MinInterval: time.Second, MaxInterval: time.Minute, ExpectedPeers: 1})
+	for range 4 {
+		schedule.next(1, 0.5)
+	}
+	require.Equal(t, 16*time.Second, schedule.interval)
+
+	// Delays vary by up to 20% around the doubled interval
+	schedule.interval = 10 * time.Second
+	require.Equal(t, 16*time.Second, schedule.next(1, 0))
+	schedule.interval = 10 * time.Second
+	require.InDelta(t, 24*time.Second, schedule.next(1, 0.999999999), float64(time.Millisecond))
+
+	// but stay within the bounds
+	schedule.interval = time.Minute
+	require.Equal(t, time.Minute, schedule.next(1, 0.999999999))
+	require.Equal(t, time.Second, schedule.reset())
+	require.Equal(t, time.Second, schedule.next(0, 0))
+}
+
+func TestRediscoveryScheduleDefaults(t *testing.T) {
+	schedule := newRediscoverySchedule(MDNSRediscovery{})
+	require.Equal(t, defaultRediscoveryMinInterval, schedule.cfg.MinInterval)
+	require.Equal(t, defaultRediscoveryMaxInterval, schedule.cfg.MaxInterval)
+}
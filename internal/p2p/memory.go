@@ -0,0 +1,165 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// MemoryHub connects MemoryTransports within one process, so that several nodes can
+// run without libp2p, e.g. to exercise keygen and signing on a single machine
+type MemoryHub struct {
+	mutex      sync.RWMutex
+	transports map[string]*MemoryTransport
+}
+
+// NewMemoryHub creates an empty memory hub
+func NewMemoryHub() *MemoryHub {
+	return &MemoryHub{transports: make(map[string]*MemoryTransport)}
+}
+
+// NewTransport creates a transport with a fresh secp256k1 identity and connects it to the hub
+func (h *MemoryHub) NewTransport(logger *zap.Logger) (*MemoryTransport, error) {
+	privKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate node key")
+	}
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive peer ID")
+	}
+
+	t := &MemoryTransport{
+		hub:     h,
+		id:      peerID.String(),
+		privKey: privKey,
+		logger:  logger,
+	}
+
+	h.mutex.Lock()
+	h.transports[t.id] = t
+	h.mutex.Unlock()
+	return t, nil
+}
+
+// transport returns the transport of a node, nil when it is not on the hub
+func (h *MemoryHub) transport(nodeID string) *MemoryTransport {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.transports[nodeID]
+}
+
+// MemoryTransport implements Transport by handing messages directly to the handlers of
+// other transports on the same hub. Messages are neither encrypted nor serialized.
+type MemoryTransport struct {
+	hub     *MemoryHub
+	id      string
+	privKey crypto.PrivKey
+	logger  *zap.Logger
+
+	mutex        sync.RWMutex
+	handler      MessageHandler
+	queryHandler QueryHandler
+	wg           sync.WaitGroup
+}
+
+// GetHostID returns the node ID of this transport
+func (t *MemoryTransport) GetHostID() string {
+	return t.id
+}
+
+// PrivateKey returns the private key of this transport's identity
+func (t *MemoryTransport) PrivateKey() crypto.PrivKey {
+	return t.privKey
+}
+
+// SetMessageHandler sets the handler of incoming messages
+func (t *MemoryTransport) SetMessageHandler(handler MessageHandler) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.handler = handler
+}
+
+// EnsurePeerKeys checks that every node is on the hub
+func (t *MemoryTransport) EnsurePeerKeys(_ context.Context, nodeIDs []string) error {
+	var missing []string
+	for _, nodeID := range nodeIDs {
+		if t.hub.transport(nodeID) == nil {
+			missing = append(missing, nodeID)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Wrapf(ErrPeerNotConnected, "%s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// SendMessage delivers a copy of msg to each recipient. Like streams over libp2p, every
+// message is handled in its own goroutine, so delivery order is not guaranteed.
+// Recipients missing from the hub are returned as a *SendError.
+func (t *MemoryTransport) SendMessage(_ context.Context, msg *Message) error {
+	msg.SenderPeerID = t.id
+
+	failed := make(map[string]error)
+	for _, target := range msg.To {
+		if target == t.id {
+			continue
+		}
+
+		recipient := t.hub.transport(target)
+		if recipient == nil {
+			failed[target] = errors.Wrapf(ErrPeerNotConnected, "%s", target)
+			continue
+		}
+
+		targetMsg := msg.Clone()
+		targetMsg.To = []string{target}
+		recipient.deliver(targetMsg)
+	}
+
+	if len(failed) > 0 {
+		return &SendError{Failed: failed}
+	}
+	return nil
+}
+
+// deliver hands an incoming message to the handler
+func (t *MemoryTransport) deliver(msg *Message) {
+	t.mutex.RLock()
+	handler := t.handler
+	t.mutex.RUnlock()
+
+	if handler == nil {
+		t.logger.Warn("Dropping message, no message handler set", zap.String("type", msg.Type))
+		return
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		if err := handler.HandleMessage(context.Background(), msg); err != nil {
+			t.logger.Error("Failed to handle message", zap.Error(err))
+		}
+	}()
+}
+
+// Stop disconnects the transport from the hub and waits for messages being handled
+func (t *MemoryTransport) Stop() error {
+	t.hub.mutex.Lock()
+	delete(t.hub.transports, t.id)
+	t.hub.mutex.Unlock()
+
+	t.wg.Wait()
+	return nil
+}
+
+var (
+	_ Transport = (*Network)(nil)
+	_ Transport = (*MemoryTransport)(nil)
+)
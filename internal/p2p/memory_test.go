@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingHandler forwards handled messages to a channel
+type recordingHandler struct {
+	msgs chan *Message
+}
+
+func (h *recordingHandler) HandleMessage(_ context.Context, msg *Message) error {
+	h.msgs <- msg
+	return nil
+}
+
+func (h *recordingHandler) Stop() {}
+
+func TestMemoryTransport(t *testing.T) {
+	ctx := context.Background()
+	hub := NewMemoryHub()
+
+	sender, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	receiver, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	require.NotEqual(t, sender.GetHostID(), receiver.GetHostID())
+
+	senderHandler := &recordingHandler{msgs: make(chan *Message, 1)}
+	sender.SetMessageHandler(senderHandler)
+	receiverHandler := &recordingHandler{msgs: make(chan *Message, 1)}
+	receiver.SetMessageHandler(receiverHandler)
+
+	require.NoError(t, sender.EnsurePeerKeys(ctx, []string{sender.GetHostID(), receiver.GetHostID()}))
+	require.ErrorIs(t, sender.EnsurePeerKeys(ctx, []string{"unknown"}), ErrPeerNotConnected)
+
+	// Messages to self are skipped, recipients get their own copy
+	data := []byte("payload")
+	msg := &Message{Type: "test", From: sender.GetHostID(), To: []string{sender.GetHostID(), receiver.GetHostID()}, Data: data}
+	require.NoError(t, sender.SendMessage(ctx, msg))
+	data[0] = 'X'
+
+	select {
+	case received := <-receiverHandler.msgs:
+		require.Equal(t, []string{receiver.GetHostID()}, received.To)
+		require.Equal(t, sender.GetHostID(), received.SenderPeerID)
+		require.Equal(t, []byte("payload"), received.Data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not delivered")
+	}
+	require.Empty(t, senderHandler.msgs)
+
+	// An unknown recipient does not keep the message from the others
+	err = sender.SendMessage(ctx, &Message{Type: "test", To: []string{"unknown", receiver.GetHostID()}, Data: data})
+	var sendErr *SendError
+	require.ErrorAs(t, err, &sendErr)
+	require.Equal(t, []string{"unknown"}, sendErr.FailedPeers())
+	require.ErrorIs(t, err, ErrPeerNotConnected)
+	select {
+	case received := <-receiverHandler.msgs:
+		require.Equal(t, []string{receiver.GetHostID()}, received.To)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not delivered to the reachable recipient")
+	}
+
+	// Stopped transports leave the hub
+	require.NoError(t, receiver.Stop())
+	err = sender.SendMessage(ctx, &Message{Type: "test", To: []string{receiver.GetHostID()}})
+	require.ErrorIs(t, err, ErrPeerNotConnected)
+}
@@ -2,9 +2,14 @@ package p2p
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -16,6 +21,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/dreamer-zq/DKNet/internal/common"
 	"github.com/dreamer-zq/DKNet/internal/config"
 	"github.com/dreamer-zq/DKNet/internal/security"
 )
@@ -23,18 +29,76 @@ import (
 const (
 	// DiscoveryRendezvous is a unique string that identifies our application's peer discovery namespace.
 	DiscoveryRendezvous = "/dknet-tss-discovery/1.0"
+	// DefaultMaxMessageBytes is the default upper bound for a single P2P message frame
+	DefaultMaxMessageBytes = 16 << 20
+	// PeerSyncInterval is the minimum time between two manually triggered peer syncs
+	PeerSyncInterval = 10 * time.Second
 )
 
+var (
+	// ErrPeerSyncRateLimited is returned when a peer sync is requested too soon after the previous one
+	ErrPeerSyncRateLimited = errors.New("peer sync rate limited")
+	// ErrPeerNotFound is returned when the peerstore has no addresses for a node
+	ErrPeerNotFound = errors.New("peer not found")
+	// ErrPeerKeyMissing is returned when a node's public key is unknown and could not be fetched
+	ErrPeerKeyMissing = errors.New("peer public key missing")
+	// ErrPeerKeyUnsupported is returned when a node's public key cannot be used for peer encryption
+	ErrPeerKeyUnsupported = errors.New("unsupported peer public key")
+	// ErrPeerNotConnected is returned when sending to a node that is not on the memory hub
+	ErrPeerNotConnected = errors.New("peer not connected")
+)
+
+// SendError reports the recipients a message could not be delivered to. Delivery to the
+// other recipients is not affected by these failures.
+type SendError struct {
+	// Failed maps each failed recipient node ID to its error
+	Failed map[string]error
+}
+
+// Error implements error
+func (e *SendError) Error() string {
+	peers := e.FailedPeers()
+	return fmt.Sprintf("failed to send message to %d peer(s): %s: %v", len(peers), peers[0], e.Failed[peers[0]])
+}
+
+// Unwrap returns the per-recipient errors so errors.Is matches any of them
+func (e *SendError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, peerID := range e.FailedPeers() {
+		errs = append(errs, e.Failed[peerID])
+	}
+	return errs
+}
+
+// FailedPeers returns the sorted node IDs of the failed recipients
+func (e *SendError) FailedPeers() []string {
+	return slices.Sorted(maps.Keys(e.Failed))
+}
+
+// PeerInfo describes the known addresses of a node
+type PeerInfo struct {
+	NodeID    string
+	Addresses []string
+	Connected bool
+}
+
 // Network handles P2P networking for TSS operations
 type Network struct {
 	host           host.Host
 	messageHandler MessageHandler
+	queryHandler   QueryHandler
 	streamManager  *StreamManager
 	logger         *zap.Logger
 	cfg            *Config
 	// Unified message encryption
 	messageEncryption security.MessageEncryption
+	peerDiscovery     PeerDiscovery
 	cancelDiscovery   context.CancelFunc
+	// scorer bans peers violating the protocol, nil when banning is disabled
+	scorer *PeerScorer
+
+	syncMutex    sync.Mutex
+	lastPeerSync time.Time
 }
 
 // Config holds P2P network configuration
@@ -43,6 +107,24 @@ type Config struct {
 	BootstrapPeers []string
 	PrivateKeyFile string
 	NetMod         string
+	// DHTMode is server, client or disabled, it applies when NetMod is "dht"
+	DHTMode string
+	// Compression is the algorithm used for outgoing messages: none, gzip or zstd
+	Compression string
+	// MaxMessageBytes caps the size of a single incoming frame (and its decompressed form)
+	MaxMessageBytes int
+	// Encryption is the end-to-end message encryption mode: required, optional or disabled
+	Encryption string
+	// MDNSRediscovery configures how often mDNS discovery is repeated
+	MDNSRediscovery MDNSRediscovery
+	// PeerScoring configures the banning of peers violating the protocol
+	PeerScoring PeerScoring
+	// SendConcurrency limits how many recipients of a message are sent to at once, so a
+	// broadcast to many parties does not open all its streams together (0 is unlimited)
+	SendConcurrency int
+	// ConnManager limits the open connections, connections to peers of running operations
+	// are protected from pruning
+	ConnManager ConnManager
 
 	// Access control configuration
 	AccessControl *config.AccessControlConfig
@@ -50,6 +132,16 @@ type Config struct {
 
 // NewNetwork creates a new P2P network instance
 func NewNetwork(cfg *Config, logger *zap.Logger) (*Network, error) {
+	compression, err := common.ParseCompressionAlgorithm(cfg.Compression)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid compression")
+	}
+
+	encryptionMode, err := security.ParseEncryptionMode(cfg.Encryption)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid encryption mode")
+	}
+
 	privKey, err := loadPrivateKey(cfg.PrivateKeyFile, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load private key")
@@ -60,6 +152,12 @@ func NewNetwork(cfg *Config, logger *zap.Logger) (*Network, error) {
 		return nil, errors.Wrap(err, "invalid listen addresses")
 	}
 
+	connManager, err := newConnManager(cfg.ConnManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid connection manager limits")
+	}
+
+	scorer := NewPeerScorer(cfg.PeerScoring, logger.Named("peer-scoring"))
 	h, err := libp2p.New(
 		libp2p.ListenAddrs(listenAddrs...),
 		libp2p.Identity(privKey),
@@ -67,9 +165,11 @@ func NewNetwork(cfg *Config, logger *zap.Logger) (*Network, error) {
 		libp2p.EnableHolePunching(),
 		libp2p.EnableNATService(),
 		libp2p.ForceReachabilityPublic(),
+		libp2p.ConnectionManager(connManager),
 		libp2p.ConnectionGater(NewConnectionGater(
 			cfg.AccessControl.AllowedPeers,
 			cfg.AccessControl.Enabled,
+			scorer,
 			logger.Named("connection-gater"),
 		)),
 	)
@@ -81,6 +181,7 @@ func NewNetwork(cfg *Config, logger *zap.Logger) (*Network, error) {
 	encryptionConfig := &security.EncryptionConfig{
 		PrivateKey: privKey,
 		Peerstore:  h.Peerstore(),
+		Mode:       encryptionMode,
 	}
 	messageEncryption, err := security.NewMessageEncryption(encryptionConfig, logger)
 	if err != nil {
@@ -91,13 +192,15 @@ func NewNetwork(cfg *Config, logger *zap.Logger) (*Network, error) {
 		host:              h,
 		logger:            logger,
 		cfg:               cfg,
-		streamManager:     NewStreamManager(h, TssPartyProtocolID),
+		streamManager:     NewStreamManager(h, TssPartyProtocolID, compression),
 		messageEncryption: messageEncryption,
+		scorer:            scorer,
 	}
 	h.SetStreamHandler(TssPartyProtocolID, n.handleStream)
+	h.SetStreamHandler(QueryProtocolID, n.handleQueryStream)
 
-	peerDiscovery := NewPeerDiscovery(h, logger, cfg)
-	if err := peerDiscovery.Start(); err != nil {
+	n.peerDiscovery = NewPeerDiscovery(h, logger, cfg)
+	if err := n.peerDiscovery.Start(); err != nil {
 		return nil, errors.Wrap(err, "failed to start peer discovery")
 	}
 	return n, nil
@@ -122,29 +225,72 @@ func (n *Network) Stop() error {
 	return nil
 }
 
+// SyncPeers triggers an immediate peer discovery round so newly joined nodes are found
+// without waiting for the periodic ticker. It returns the number of currently connected peers.
+func (n *Network) SyncPeers() (int, error) {
+	n.syncMutex.Lock()
+	defer n.syncMutex.Unlock()
+
+	if since := time.Since(n.lastPeerSync); since < PeerSyncInterval {
+		return 0, errors.Wrapf(ErrPeerSyncRateLimited, "retry in %s", (PeerSyncInterval - since).Round(time.Second))
+	}
+	n.lastPeerSync = time.Now()
+
+	n.peerDiscovery.Rediscover()
+	return len(n.host.Network().Peers()), nil
+}
+
 // SetMessageHandler sets the message handler
 func (n *Network) SetMessageHandler(handler MessageHandler) {
 	n.messageHandler = handler
 }
 
 // SendMessage sends a message to the specified peers.
-// It relies on the libp2p host's configured routing (DHT) to find and connect to peers.
+// Peers without known addresses are looked up through peer discovery before dialing.
+// Every recipient is encrypted for and sent to independently, a failing recipient does not
+// keep the message from the others. At most Config.SendConcurrency recipients are sent to at
+// once. Failures are returned as a *SendError.
 func (n *Network) SendMessage(ctx context.Context, msg *Message) error {
 	var (
-		wg   sync.WaitGroup
-		mu   sync.Mutex
-		errs []error
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed = make(map[string]error)
 	)
 
 	// Set the original sender's actual PeerID
 	msg.SenderPeerID = n.GetHostID()
-	sendFn := func(p peer.ID, msg *Message) {
+	fail := func(target string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		failed[target] = err
+	}
+
+	// Each send holds a slot of the semaphore, nil when sends are unbounded
+	var slots chan struct{}
+	if n.cfg.SendConcurrency > 0 {
+		slots = make(chan struct{}, n.cfg.SendConcurrency)
+	}
+	sendFn := func(target string, msg *Message) {
 		defer wg.Done()
-		if err := n.streamManager.sendMessage(ctx, p, msg); err != nil {
-			mu.Lock()
-			defer mu.Unlock()
+		if slots != nil {
+			defer func() { <-slots }()
+		}
+
+		targetPeer, err := peer.Decode(target)
+		if err != nil {
+			fail(target, errors.Wrapf(err, "invalid target peer ID %s", target))
+			return
+		}
+		// Encrypt the message before sending
+		if err := n.encryptMessage(msg); err != nil {
+			fail(target, errors.Wrap(err, "failed to encrypt message"))
+			return
+		}
 
-			errs = append(errs, err)
+		n.ensurePeerAddrs(ctx, targetPeer)
+		if err := n.streamManager.sendMessage(ctx, targetPeer, msg); err != nil {
+			fail(target, err)
 		}
 	}
 
@@ -154,26 +300,30 @@ func (n *Network) SendMessage(ctx context.Context, msg *Message) error {
 			continue
 		}
 
-		targetMsg := msg.Clone()
-		targetMsg.To = []string{target}
-		// Encrypt the message before sending
-		if err := n.encryptMessage(targetMsg); err != nil {
-			return errors.Wrap(err, "failed to encrypt message")
-		}
-
-		targetPeer, err := peer.Decode(target)
-		if err != nil {
-			return errors.Wrapf(err, "invalid target peer ID %s", target)
+		if slots != nil {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				fail(target, ctx.Err())
+				continue
+			}
 		}
 
+		targetMsg := msg.Clone()
+		targetMsg.To = []string{target}
 		wg.Add(1)
-		go sendFn(targetPeer, targetMsg)
+		go sendFn(target, targetMsg)
 	}
 
 	wg.Wait()
 
-	if len(errs) > 0 {
-		return errors.Wrapf(errs[0], "encountered %d error(s) while sending message", len(errs))
+	if len(failed) > 0 {
+		sendErr := &SendError{Failed: failed}
+		n.logger.Warn("Message not delivered to every peer",
+			zap.String("session_id", msg.SessionID),
+			zap.Strings("failed_peers", sendErr.FailedPeers()),
+			zap.Int("recipients", len(msg.To)))
+		return sendErr
 	}
 	return nil
 }
@@ -213,11 +363,24 @@ func (n *Network) handleStream(stream network.Stream) {
 	}()
 
 	remotePeerID := stream.Conn().RemotePeer()
-	reader := msgio.NewReader(stream)
+	if n.scorer.Banned(remotePeerID) {
+		_ = stream.Reset()
+		return
+	}
+	maxSize := n.maxMessageBytes()
+	reader := msgio.NewReaderSize(stream, maxSize)
 
 	for {
 		data, err := reader.ReadMsg()
 		if err != nil {
+			if errors.Is(err, msgio.ErrMsgTooLarge) {
+				n.logger.Warn("Peer exceeded maximum message size, resetting stream",
+					zap.String("peer", remotePeerID.String()),
+					zap.Int("max_message_bytes", maxSize))
+				_ = stream.Reset()
+				n.recordViolation(remotePeerID, "message too large")
+				return
+			}
 			if err != io.EOF && err.Error() != "stream reset" {
 				n.logger.Debug("Stream read error", zap.Error(err), zap.String("peer", remotePeerID.String()))
 			}
@@ -235,21 +398,66 @@ func (n *Network) handleStream(stream network.Stream) {
 // processIncomingMessage handles the logic for a single received message.
 func (n *Network) processIncomingMessage(data []byte, remotePeerID peer.ID) {
 	var msg Message
-	if err := msg.Decompresses(data); err != nil {
+	if err := msg.Decompresses(data, n.maxMessageBytes()); err != nil {
 		n.logger.Error("Failed to decompress message", zap.Error(err), zap.String("peer", remotePeerID.String()))
+		n.recordViolation(remotePeerID, "malformed message")
+		return
+	}
+
+	// Messages are sent directly, so the claimed sender must be the stream's peer
+	if msg.SenderPeerID != remotePeerID.String() {
+		n.logger.Warn("Dropping message with mismatched sender",
+			zap.String("peer_id", remotePeerID.String()),
+			zap.String("sender_peer_id", msg.SenderPeerID))
+		n.recordViolation(remotePeerID, "mismatched sender")
 		return
 	}
 
 	if err := n.decryptMessage(&msg); err != nil {
+		if errors.Is(err, security.ErrPlaintextMessage) {
+			n.logger.Warn("Dropping unencrypted message, p2p encryption is required",
+				zap.String("peer_id", remotePeerID.String()),
+				zap.String("type", msg.Type))
+			n.recordViolation(remotePeerID, "unencrypted message")
+			return
+		}
 		n.logger.Error("Failed to decrypt stream message", zap.String("peer_id", remotePeerID.String()), zap.Error(err))
+		n.recordViolation(remotePeerID, "undecryptable message")
 		return
 	}
 
 	if err := n.messageHandler.HandleMessage(context.Background(), &msg); err != nil {
 		n.logger.Error("Failed to handle message", zap.Error(err))
+		if errors.Is(err, ErrProtocolViolation) {
+			n.recordViolation(remotePeerID, err.Error())
+		}
 	}
 }
 
+// recordViolation counts a protocol violation of peerID and closes its connections
+// once that gets it banned
+func (n *Network) recordViolation(peerID peer.ID, reason string) {
+	if !n.scorer.RecordViolation(peerID, reason) {
+		return
+	}
+	if err := n.host.Network().ClosePeer(peerID); err != nil {
+		n.logger.Warn("Failed to close connections of banned peer", zap.String("peer", peerID.String()), zap.Error(err))
+	}
+}
+
+// BannedPeers returns the peers whose connections are refused for protocol violations
+func (n *Network) BannedPeers() []BannedPeer {
+	return n.scorer.BannedPeers()
+}
+
+// maxMessageBytes returns the configured frame size limit or the default
+func (n *Network) maxMessageBytes() int {
+	if n.cfg == nil || n.cfg.MaxMessageBytes <= 0 {
+		return DefaultMaxMessageBytes
+	}
+	return n.cfg.MaxMessageBytes
+}
+
 // loadPrivateKey loads a private key from a file.
 func loadPrivateKey(keyFile string, logger *zap.Logger) (crypto.PrivKey, error) {
 	logger.Info("Attempting to load private key from", zap.String("key_file", keyFile))
@@ -268,6 +476,71 @@ func loadPrivateKey(keyFile string, logger *zap.Logger) (crypto.PrivKey, error)
 	return privKey, nil
 }
 
+// GetPeerInfo returns the addresses known for a node. Node IDs are libp2p peer IDs.
+func (n *Network) GetPeerInfo(nodeID string) (*PeerInfo, error) {
+	peerID, err := peer.Decode(nodeID)
+	if err != nil {
+		return nil, errors.Wrapf(ErrPeerNotFound, "invalid node ID %s", nodeID)
+	}
+
+	var addrs []multiaddr.Multiaddr
+	if peerID == n.host.ID() {
+		addrs = n.host.Addrs()
+	} else {
+		addrs = n.host.Peerstore().Addrs(peerID)
+	}
+	if len(addrs) == 0 {
+		return nil, errors.Wrapf(ErrPeerNotFound, "no addresses known for node %s", nodeID)
+	}
+
+	return n.peerInfo(peerID, addrs), nil
+}
+
+// ListPeers returns this node followed by the peers it is currently connected to
+func (n *Network) ListPeers() []*PeerInfo {
+	peers := n.host.Network().Peers()
+	slices.SortFunc(peers, func(a, b peer.ID) int { return strings.Compare(a.String(), b.String()) })
+
+	infos := make([]*PeerInfo, 0, len(peers)+1)
+	infos = append(infos, n.peerInfo(n.host.ID(), n.host.Addrs()))
+	for _, peerID := range peers {
+		infos = append(infos, n.peerInfo(peerID, n.host.Peerstore().Addrs(peerID)))
+	}
+	return infos
+}
+
+func (n *Network) peerInfo(peerID peer.ID, addrs []multiaddr.Multiaddr) *PeerInfo {
+	info := &PeerInfo{
+		NodeID:    peerID.String(),
+		Addresses: make([]string, len(addrs)),
+		Connected: peerID == n.host.ID() || n.host.Network().Connectedness(peerID) == network.Connected,
+	}
+	for i, addr := range addrs {
+		info.Addresses[i] = addr.String()
+	}
+	return info
+}
+
+// Connect dials a node at the given addresses, e.g. the ones returned by GetPeerInfo
+func (n *Network) Connect(ctx context.Context, nodeID string, addrs []string) error {
+	peerID, err := peer.Decode(nodeID)
+	if err != nil {
+		return errors.Wrapf(err, "invalid node ID %s", nodeID)
+	}
+
+	maddrs, err := convertAddrs(addrs)
+	if err != nil {
+		return errors.Wrap(err, "invalid node addresses")
+	}
+
+	return n.host.Connect(ctx, peer.AddrInfo{ID: peerID, Addrs: maddrs})
+}
+
+// PrivateKey returns the node's P2P identity key
+func (n *Network) PrivateKey() crypto.PrivKey {
+	return n.host.Peerstore().PrivKey(n.host.ID())
+}
+
 // GetHostID returns the peer ID of the host.
 func (n *Network) GetHostID() string {
 	return n.host.ID().String()
@@ -0,0 +1,249 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/security"
+)
+
+func newTestHost(t testing.TB) host.Host {
+	t.Helper()
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = h.Close() })
+	return h
+}
+
+func TestHandleStreamResetsOversizedFrame(t *testing.T) {
+	receiver := newTestHost(t)
+	sender := newTestHost(t)
+
+	n := &Network{
+		host:   receiver,
+		logger: zap.NewNop(),
+		cfg:    &Config{MaxMessageBytes: 1024},
+	}
+	receiver.SetStreamHandler(TssPartyProtocolID, n.handleStream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, sender.Connect(ctx, peer.AddrInfo{ID: receiver.ID(), Addrs: receiver.Addrs()}))
+
+	stream, err := sender.NewStream(ctx, receiver.ID(), TssPartyProtocolID)
+	require.NoError(t, err)
+	require.NoError(t, stream.SetDeadline(time.Now().Add(5*time.Second)))
+
+	// A frame just over the limit must be rejected before it is buffered
+	require.NoError(t, msgio.NewWriter(stream).WriteMsg(bytes.Repeat([]byte{'x'}, 2048)))
+
+	// The receiver resets the stream, so reads on our side fail instead of hanging
+	_, err = stream.Read(make([]byte, 1))
+	require.Error(t, err)
+	require.ErrorContains(t, err, "reset")
+}
+
+func TestGetPeerInfo(t *testing.T) {
+	local := newTestHost(t)
+	remote := newTestHost(t)
+	n := &Network{host: local, logger: zap.NewNop()}
+
+	// Unknown node
+	_, err := n.GetPeerInfo(remote.ID().String())
+	require.ErrorIs(t, err, ErrPeerNotFound)
+
+	// Invalid node ID
+	_, err = n.GetPeerInfo("not-a-peer-id")
+	require.ErrorIs(t, err, ErrPeerNotFound)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, local.Connect(ctx, peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+
+	info, err := n.GetPeerInfo(remote.ID().String())
+	require.NoError(t, err)
+	require.Equal(t, remote.ID().String(), info.NodeID)
+	require.True(t, info.Connected)
+	require.NotEmpty(t, info.Addresses)
+}
+
+func TestListPeers(t *testing.T) {
+	local := newTestHost(t)
+	remote := newTestHost(t)
+	n := &Network{host: local, logger: zap.NewNop()}
+
+	peers := n.ListPeers()
+	require.Len(t, peers, 1)
+	require.Equal(t, local.ID().String(), peers[0].NodeID)
+	require.True(t, peers[0].Connected)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, local.Connect(ctx, peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+
+	peers = n.ListPeers()
+	require.Len(t, peers, 2)
+	require.Equal(t, local.ID().String(), peers[0].NodeID)
+	require.Equal(t, remote.ID().String(), peers[1].NodeID)
+	require.True(t, peers[1].Connected)
+	require.NotEmpty(t, peers[1].Addresses)
+}
+
+func TestSendMessageContinuesPastFailedRecipient(t *testing.T) {
+	local := newTestHostWithKey(t, crypto.Secp256k1)
+	remote := newTestHostWithKey(t, crypto.Secp256k1)
+	// RSA keys are not inlined in the peer ID, so the message cannot be encrypted for it
+	unknownKey := newTestHostWithKey(t, crypto.RSA)
+
+	received := make(chan []byte, 1)
+	remote.SetStreamHandler(TssPartyProtocolID, func(stream network.Stream) {
+		defer stream.Close()
+		data, err := msgio.NewReader(stream).ReadMsg()
+		if err == nil {
+			received <- data
+		}
+	})
+
+	encryption, err := security.NewMessageEncryption(&security.EncryptionConfig{
+		PrivateKey: local.Peerstore().PrivKey(local.ID()),
+		Peerstore:  local.Peerstore(),
+		Mode:       security.EncryptionRequired,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	n := &Network{
+		host:              local,
+		logger:            zap.NewNop(),
+		cfg:               &Config{},
+		streamManager:     NewStreamManager(local, TssPartyProtocolID, common.CompressionNone),
+		messageEncryption: encryption,
+	}
+	local.Peerstore().AddAddrs(remote.ID(), remote.Addrs(), time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = n.SendMessage(ctx, &Message{
+		Type: "test",
+		From: local.ID().String(),
+		To:   []string{unknownKey.ID().String(), remote.ID().String(), "not-a-peer-id"},
+		Data: []byte("hello"),
+	})
+	var sendErr *SendError
+	require.ErrorAs(t, err, &sendErr)
+	require.ElementsMatch(t, []string{unknownKey.ID().String(), "not-a-peer-id"}, sendErr.FailedPeers())
+	require.ErrorContains(t, sendErr.Failed[unknownKey.ID().String()], "failed to encrypt message")
+
+	select {
+	case data := <-received:
+		var msg Message
+		require.NoError(t, msg.Decompresses(data, 0))
+		require.True(t, msg.Encrypted)
+	case <-ctx.Done():
+		t.Fatal("message was not delivered to the reachable recipient")
+	}
+}
+
+// newBroadcastNetwork returns a network connected to parties receiving hosts that read every
+// message sent to them, limiting concurrent sends to sendConcurrency
+func newBroadcastNetwork(t testing.TB, parties, sendConcurrency int) (*Network, []string, chan struct{}) {
+	t.Helper()
+	// Peer encryption needs a key type it can encrypt with, even when disabled
+	local := newTestHostWithKey(t, crypto.Secp256k1)
+	encryption, err := security.NewMessageEncryption(&security.EncryptionConfig{
+		PrivateKey: local.Peerstore().PrivKey(local.ID()),
+		Peerstore:  local.Peerstore(),
+		Mode:       security.EncryptionDisabled,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	n := &Network{
+		host:              local,
+		logger:            zap.NewNop(),
+		cfg:               &Config{SendConcurrency: sendConcurrency},
+		streamManager:     NewStreamManager(local, TssPartyProtocolID, common.CompressionNone),
+		messageEncryption: encryption,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	received := make(chan struct{}, parties)
+	targets := make([]string, parties)
+	for i := range targets {
+		remote := newTestHost(t)
+		remote.SetStreamHandler(TssPartyProtocolID, func(stream network.Stream) {
+			defer stream.Close()
+			reader := msgio.NewReader(stream)
+			for {
+				if _, err := reader.ReadMsg(); err != nil {
+					return
+				}
+				received <- struct{}{}
+			}
+		})
+		require.NoError(t, local.Connect(ctx, peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}))
+		targets[i] = remote.ID().String()
+	}
+	return n, targets, received
+}
+
+func TestSendMessageBoundedConcurrency(t *testing.T) {
+	n, targets, received := newBroadcastNetwork(t, 5, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, n.SendMessage(ctx, &Message{Type: "test", From: n.GetHostID(), To: targets, Data: []byte("hello")}))
+	for range targets {
+		select {
+		case <-received:
+		case <-ctx.Done():
+			t.Fatal("message was not delivered to every recipient")
+		}
+	}
+
+	// Recipients still waiting for a slot fail once the context is done
+	canceled, cancelSend := context.WithCancel(context.Background())
+	cancelSend()
+	err := n.SendMessage(canceled, &Message{Type: "test", From: n.GetHostID(), To: targets, Data: []byte("hello")})
+	var sendErr *SendError
+	require.ErrorAs(t, err, &sendErr)
+	require.NotEmpty(t, sendErr.FailedPeers())
+}
+
+func BenchmarkBroadcast(b *testing.B) {
+	const parties = 20
+	for _, bc := range []struct {
+		name            string
+		sendConcurrency int
+	}{
+		{"unbounded", 0},
+		{"bounded-4", 4},
+		{"bounded-8", 8},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			n, targets, received := newBroadcastNetwork(b, parties, bc.sendConcurrency)
+			msg := &Message{Type: "test", From: n.GetHostID(), To: targets, Data: bytes.Repeat([]byte{'x'}, 4096)}
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := n.SendMessage(ctx, msg); err != nil {
+					b.Fatal(err)
+				}
+				for range targets {
+					<-received
+				}
+			}
+		})
+	}
+}
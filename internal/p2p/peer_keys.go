@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/security"
+)
+
+// peerFinder is implemented by discovery backends that can look up a single peer
+type peerFinder interface {
+	FindPeer(ctx context.Context, id peer.ID) (peer.AddrInfo, error)
+}
+
+// EnsurePeerKeys makes sure the peerstore holds the secp256k1 public key of every node,
+// which peer encryption needs to exchange messages with it. Missing keys are fetched by
+// connecting to the node, looking up its addresses through discovery if none are known.
+// Nodes that do not encrypt messages need no peer keys and skip the check.
+func (n *Network) EnsurePeerKeys(ctx context.Context, nodeIDs []string) error {
+	if n.encryptionDisabled() {
+		return nil
+	}
+
+	var missing []string
+	for _, nodeID := range nodeIDs {
+		peerID, err := peer.Decode(nodeID)
+		if err != nil {
+			return errors.Wrapf(err, "invalid node ID %s", nodeID)
+		}
+		if peerID == n.host.ID() {
+			continue
+		}
+
+		// Keys small enough to be inlined in the peer ID are extracted by the peerstore
+		if n.host.Peerstore().PubKey(peerID) == nil {
+			n.fetchPeerKey(ctx, peerID)
+		}
+
+		pubKey := n.host.Peerstore().PubKey(peerID)
+		switch {
+		case pubKey == nil:
+			missing = append(missing, nodeID)
+		case pubKey.Type() != crypto.Secp256k1:
+			return errors.Wrapf(ErrPeerKeyUnsupported, "node %s uses a %s key, peer encryption requires secp256k1",
+				nodeID, pubKey.Type())
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Wrapf(ErrPeerKeyMissing, "no public key known for %s, the nodes must be reachable to exchange identities",
+			strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// encryptionDisabled reports whether the node sends P2P messages unencrypted
+func (n *Network) encryptionDisabled() bool {
+	if n.cfg == nil {
+		return false
+	}
+	mode, err := security.ParseEncryptionMode(n.cfg.Encryption)
+	return err == nil && mode == security.EncryptionDisabled
+}
+
+// fetchPeerKey connects to a peer so that the handshake and identify store its public key
+func (n *Network) fetchPeerKey(ctx context.Context, peerID peer.ID) {
+	n.ensurePeerAddrs(ctx, peerID)
+	info := peer.AddrInfo{ID: peerID, Addrs: n.host.Peerstore().Addrs(peerID)}
+	if len(info.Addrs) == 0 {
+		return
+	}
+
+	if err := n.host.Connect(ctx, info); err != nil {
+		n.logger.Warn("Failed to connect to peer to fetch its public key",
+			zap.String("peer_id", peerID.String()), zap.Error(err))
+	}
+}
+
+// ensurePeerAddrs looks up the addresses of a peer through discovery when the peerstore
+// has none, so that it can be dialed. Discovery without lookups leaves the peerstore as is.
+func (n *Network) ensurePeerAddrs(ctx context.Context, peerID peer.ID) {
+	if len(n.host.Peerstore().Addrs(peerID)) > 0 {
+		return
+	}
+
+	finder, ok := n.peerDiscovery.(peerFinder)
+	if !ok {
+		n.logger.Debug("No addresses known for peer and discovery cannot look it up",
+			zap.String("peer_id", peerID.String()))
+		return
+	}
+
+	found, err := finder.FindPeer(ctx, peerID)
+	if err != nil {
+		n.logger.Warn("Failed to look up peer", zap.String("peer_id", peerID.String()), zap.Error(err))
+		return
+	}
+	n.host.Peerstore().AddAddrs(peerID, found.Addrs, peerstore.TempAddrTTL)
+}
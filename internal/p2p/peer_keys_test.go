@@ -0,0 +1,55 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestHostWithKey(t testing.TB, keyType int) host.Host {
+	t.Helper()
+	privKey, _, err := crypto.GenerateKeyPairWithReader(keyType, 2048, rand.Reader)
+	require.NoError(t, err)
+	h, err := libp2p.New(libp2p.Identity(privKey), libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = h.Close() })
+	return h
+}
+
+func TestEnsurePeerKeys(t *testing.T) {
+	local := newTestHostWithKey(t, crypto.Secp256k1)
+	n := &Network{host: local, logger: zap.NewNop()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A fresh participant that never talked to us, its secp256k1 key is inlined in the peer ID
+	fresh := newTestHostWithKey(t, crypto.Secp256k1)
+	require.NoError(t, n.EnsurePeerKeys(ctx, []string{local.ID().String(), fresh.ID().String()}))
+	require.NotNil(t, local.Peerstore().PubKey(fresh.ID()))
+
+	// RSA keys are not inlined, without addresses the key cannot be fetched
+	rsaHost := newTestHostWithKey(t, crypto.RSA)
+	err := n.EnsurePeerKeys(ctx, []string{fresh.ID().String(), rsaHost.ID().String()})
+	require.ErrorIs(t, err, ErrPeerKeyMissing)
+	require.ErrorContains(t, err, rsaHost.ID().String())
+
+	// Once its addresses are known the key is fetched by connecting, but it is unusable
+	local.Peerstore().AddAddrs(rsaHost.ID(), rsaHost.Addrs(), time.Minute)
+	err = n.EnsurePeerKeys(ctx, []string{rsaHost.ID().String()})
+	require.ErrorIs(t, err, ErrPeerKeyUnsupported)
+	require.NotNil(t, local.Peerstore().PubKey(rsaHost.ID()))
+
+	require.Error(t, n.EnsurePeerKeys(ctx, []string{"not-a-peer-id"}))
+
+	// Without peer encryption the key type does not matter
+	n.cfg = &Config{Encryption: "disabled"}
+	require.NoError(t, n.EnsurePeerKeys(ctx, []string{rsaHost.ID().String()}))
+}
@@ -0,0 +1,210 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// QueryProtocolID is the protocol ID for queries, single request/response exchanges
+	// between two nodes outside of TSS operations
+	QueryProtocolID = "/dknet/query/0.0.1"
+	// maxQueryBytes bounds the size of a query and of its response
+	maxQueryBytes = 64 << 10
+	// queryStreamTimeout bounds how long an incoming query stream may stay open
+	queryStreamTimeout = 30 * time.Second
+)
+
+// ErrQueryFailed is returned when the queried node could not answer a query
+var ErrQueryFailed = errors.New("query failed")
+
+// Query is a request to a single node, Type selects how the node answers it
+type Query struct {
+	Type string `json:"type"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// queryResponse is the answer to a Query, Error is set when it could not be answered
+type queryResponse struct {
+	Data  []byte `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// QueryHandler answers queries of other nodes. This interface is implemented by the
+// application layer (e.g., TSS service).
+type QueryHandler interface {
+	// HandleQuery returns the answer to query sent by node from
+	HandleQuery(ctx context.Context, from string, query *Query) ([]byte, error)
+}
+
+// Querier is implemented by transports that can query single nodes, e.g. to look up which
+// nodes hold a key
+type Querier interface {
+	// Peers returns the node IDs of the other nodes this node is connected to
+	Peers() []string
+	// Query sends query to nodeID and waits for its answer
+	Query(ctx context.Context, nodeID string, query *Query) ([]byte, error)
+	// SetQueryHandler sets the handler answering queries of other nodes
+	SetQueryHandler(handler QueryHandler)
+}
+
+var (
+	_ Querier = (*Network)(nil)
+	_ Querier = (*MemoryTransport)(nil)
+)
+
+// answerQuery answers query with handler, nodes without a handler answer no queries
+func answerQuery(ctx context.Context, handler QueryHandler, from string, query *Query) *queryResponse {
+	if handler == nil {
+		return &queryResponse{Error: "queries are not served"}
+	}
+	data, err := handler.HandleQuery(ctx, from, query)
+	if err != nil {
+		return &queryResponse{Error: err.Error()}
+	}
+	return &queryResponse{Data: data}
+}
+
+// result returns the data of the response, or its error wrapped in ErrQueryFailed
+func (r *queryResponse) result() ([]byte, error) {
+	if r.Error != "" {
+		return nil, errors.Wrap(ErrQueryFailed, r.Error)
+	}
+	return r.Data, nil
+}
+
+// Peers implements Querier
+func (n *Network) Peers() []string {
+	peers := n.host.Network().Peers()
+	nodeIDs := make([]string, len(peers))
+	for i, peerID := range peers {
+		nodeIDs[i] = peerID.String()
+	}
+	slices.Sort(nodeIDs)
+	return nodeIDs
+}
+
+// SetQueryHandler implements Querier
+func (n *Network) SetQueryHandler(handler QueryHandler) {
+	n.queryHandler = handler
+}
+
+// Query implements Querier. Peers without known addresses are looked up through peer
+// discovery before dialing.
+func (n *Network) Query(ctx context.Context, nodeID string, query *Query) ([]byte, error) {
+	peerID, err := peer.Decode(nodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid node ID %s", nodeID)
+	}
+	request, err := json.Marshal(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal query")
+	}
+
+	n.ensurePeerAddrs(ctx, peerID)
+	stream, err := n.host.NewStream(ctx, peerID, QueryProtocolID)
+	if err != nil {
+		return nil, errors.Wrapf(ErrPeerNotConnected, "%s: %v", nodeID, err)
+	}
+	defer func() { _ = stream.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if err := msgio.NewWriter(stream).WriteMsg(request); err != nil {
+		_ = stream.Reset()
+		return nil, errors.Wrap(err, "failed to send query")
+	}
+	data, err := msgio.NewReaderSize(stream, maxQueryBytes).ReadMsg()
+	if err != nil {
+		_ = stream.Reset()
+		return nil, errors.Wrap(err, "failed to read query response")
+	}
+
+	var response queryResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal query response")
+	}
+	return response.result()
+}
+
+// handleQueryStream answers the query of an incoming stream
+func (n *Network) handleQueryStream(stream network.Stream) {
+	defer func() { _ = stream.Close() }()
+
+	remotePeerID := stream.Conn().RemotePeer()
+	if n.scorer.Banned(remotePeerID) {
+		_ = stream.Reset()
+		return
+	}
+	_ = stream.SetDeadline(time.Now().Add(queryStreamTimeout))
+
+	data, err := msgio.NewReaderSize(stream, maxQueryBytes).ReadMsg()
+	if err != nil {
+		n.logger.Debug("Failed to read query", zap.String("peer", remotePeerID.String()), zap.Error(err))
+		_ = stream.Reset()
+		if errors.Is(err, msgio.ErrMsgTooLarge) {
+			n.recordViolation(remotePeerID, "query too large")
+		}
+		return
+	}
+	var query Query
+	if err := json.Unmarshal(data, &query); err != nil {
+		_ = stream.Reset()
+		n.recordViolation(remotePeerID, "malformed query")
+		return
+	}
+
+	response, err := json.Marshal(answerQuery(context.Background(), n.queryHandler, remotePeerID.String(), &query))
+	if err != nil {
+		n.logger.Error("Failed to marshal query response", zap.Error(err))
+		_ = stream.Reset()
+		return
+	}
+	if err := msgio.NewWriter(stream).WriteMsg(response); err != nil {
+		n.logger.Debug("Failed to send query response", zap.String("peer", remotePeerID.String()), zap.Error(err))
+	}
+}
+
+// Peers implements Querier, every other transport on the hub counts as connected
+func (t *MemoryTransport) Peers() []string {
+	t.hub.mutex.RLock()
+	defer t.hub.mutex.RUnlock()
+
+	nodeIDs := make([]string, 0, len(t.hub.transports))
+	for nodeID := range t.hub.transports {
+		if nodeID != t.id {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	slices.Sort(nodeIDs)
+	return nodeIDs
+}
+
+// SetQueryHandler implements Querier
+func (t *MemoryTransport) SetQueryHandler(handler QueryHandler) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.queryHandler = handler
+}
+
+// Query implements Querier by calling the query handler of the node directly
+func (t *MemoryTransport) Query(ctx context.Context, nodeID string, query *Query) ([]byte, error) {
+	recipient := t.hub.transport(nodeID)
+	if recipient == nil {
+		return nil, errors.Wrapf(ErrPeerNotConnected, "%s", nodeID)
+	}
+
+	recipient.mutex.RLock()
+	handler := recipient.queryHandler
+	recipient.mutex.RUnlock()
+	return answerQuery(ctx, handler, t.id, query).result()
+}
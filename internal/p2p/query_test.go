@@ -0,0 +1,73 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// echoQueryHandler answers "echo" queries with their data and fails all others
+type echoQueryHandler struct{}
+
+func (echoQueryHandler) HandleQuery(_ context.Context, from string, query *Query) ([]byte, error) {
+	if query.Type != "echo" {
+		return nil, errors.New("unknown query type")
+	}
+	return append([]byte(from+":"), query.Data...), nil
+}
+
+func TestNetworkQuery(t *testing.T) {
+	localHost := newTestHost(t)
+	remoteHost := newTestHost(t)
+	local := &Network{host: localHost, logger: zap.NewNop()}
+	remote := &Network{host: remoteHost, logger: zap.NewNop()}
+	remoteHost.SetStreamHandler(QueryProtocolID, remote.handleQueryStream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, localHost.Connect(ctx, peer.AddrInfo{ID: remoteHost.ID(), Addrs: remoteHost.Addrs()}))
+	require.Equal(t, []string{remoteHost.ID().String()}, local.Peers())
+
+	// Without a handler the remote answers no queries
+	_, err := local.Query(ctx, remoteHost.ID().String(), &Query{Type: "echo"})
+	require.ErrorIs(t, err, ErrQueryFailed)
+
+	remote.SetQueryHandler(echoQueryHandler{})
+	answer, err := local.Query(ctx, remoteHost.ID().String(), &Query{Type: "echo", Data: []byte("hello")})
+	require.NoError(t, err)
+	require.Equal(t, localHost.ID().String()+":hello", string(answer))
+
+	_, err = local.Query(ctx, remoteHost.ID().String(), &Query{Type: "other"})
+	require.ErrorIs(t, err, ErrQueryFailed)
+	require.ErrorContains(t, err, "unknown query type")
+
+	_, err = local.Query(ctx, "not-a-peer-id", &Query{Type: "echo"})
+	require.Error(t, err)
+}
+
+func TestMemoryTransportQuery(t *testing.T) {
+	hub := NewMemoryHub()
+	local, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	remote, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	remote.SetQueryHandler(echoQueryHandler{})
+
+	require.Equal(t, []string{remote.GetHostID()}, local.Peers())
+
+	answer, err := local.Query(context.Background(), remote.GetHostID(), &Query{Type: "echo", Data: []byte("hello")})
+	require.NoError(t, err)
+	require.Equal(t, local.GetHostID()+":hello", string(answer))
+
+	_, err = remote.Query(context.Background(), local.GetHostID(), &Query{Type: "echo"})
+	require.ErrorIs(t, err, ErrQueryFailed)
+
+	require.NoError(t, remote.Stop())
+	_, err = local.Query(context.Background(), remote.GetHostID(), &Query{Type: "echo"})
+	require.ErrorIs(t, err, ErrPeerNotConnected)
+}
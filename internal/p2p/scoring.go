@@ -0,0 +1,140 @@
+package p2p
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrProtocolViolation marks errors caused by a peer not following the protocol, such as
+// malformed or undecryptable messages. Message handlers wrap it so the violation counts
+// against the sending peer.
+var ErrProtocolViolation = errors.New("protocol violation")
+
+// PeerScoring configures the banning of peers violating the protocol
+type PeerScoring struct {
+	// ViolationThreshold is the number of violations within Window after which a peer is
+	// banned, 0 disables banning
+	ViolationThreshold int
+	// Window is the period over which violations are counted
+	Window time.Duration
+	// BanDuration is how long a banned peer's connections are refused
+	BanDuration time.Duration
+}
+
+// BannedPeer is a peer whose connections are refused for violating the protocol
+type BannedPeer struct {
+	NodeID string
+	Until  time.Time
+	// Reason is the violation that caused the ban
+	Reason string
+}
+
+// PeerScorer tracks protocol violations per peer and bans peers exceeding the configured
+// threshold. A nil PeerScorer never bans.
+type PeerScorer struct {
+	mu         sync.Mutex
+	cfg        PeerScoring
+	violations map[peer.ID][]time.Time
+	bans       map[peer.ID]BannedPeer
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewPeerScorer returns a scorer for cfg, nil when banning is disabled
+func NewPeerScorer(cfg PeerScoring, logger *zap.Logger) *PeerScorer {
+	if cfg.ViolationThreshold <= 0 {
+		return nil
+	}
+	return &PeerScorer{
+		cfg:        cfg,
+		violations: make(map[peer.ID][]time.Time),
+		bans:       make(map[peer.ID]BannedPeer),
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// RecordViolation counts a violation of peerID and reports whether it got the peer banned
+func (s *PeerScorer) RecordViolation(peerID peer.ID, reason string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if _, banned := s.activeBanLocked(peerID, now); banned {
+		return false
+	}
+
+	// Only violations within the window count
+	recent := slices.DeleteFunc(s.violations[peerID], func(at time.Time) bool {
+		return now.Sub(at) >= s.cfg.Window
+	})
+	recent = append(recent, now)
+	if len(recent) < s.cfg.ViolationThreshold {
+		s.violations[peerID] = recent
+		return false
+	}
+
+	delete(s.violations, peerID)
+	s.bans[peerID] = BannedPeer{NodeID: peerID.String(), Until: now.Add(s.cfg.BanDuration), Reason: reason}
+	s.logger.Warn("Banning peer for protocol violations",
+		zap.String("peer", peerID.String()),
+		zap.Int("violations", len(recent)),
+		zap.Duration("ban_duration", s.cfg.BanDuration),
+		zap.String("reason", reason))
+	return true
+}
+
+// Banned reports whether connections of peerID are currently refused
+func (s *PeerScorer) Banned(peerID peer.ID) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, banned := s.activeBanLocked(peerID, s.now())
+	return banned
+}
+
+// BannedPeers returns the currently banned peers sorted by node ID
+func (s *PeerScorer) BannedPeers() []BannedPeer {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	banned := make([]BannedPeer, 0, len(s.bans))
+	for peerID := range s.bans {
+		if ban, ok := s.activeBanLocked(peerID, now); ok {
+			banned = append(banned, ban)
+		}
+	}
+	slices.SortFunc(banned, func(a, b BannedPeer) int { return strings.Compare(a.NodeID, b.NodeID) })
+	return banned
+}
+
+// activeBanLocked returns the ban of peerID if it has not expired at now, dropping an
+// expired one. The caller must hold the lock.
+func (s *PeerScorer) activeBanLocked(peerID peer.ID, now time.Time) (BannedPeer, bool) {
+	ban, ok := s.bans[peerID]
+	if !ok {
+		return BannedPeer{}, false
+	}
+	if !now.Before(ban.Until) {
+		delete(s.bans, peerID)
+		s.logger.Info("Peer ban expired", zap.String("peer", peerID.String()))
+		return BannedPeer{}, false
+	}
+	return ban, true
+}
@@ -0,0 +1,127 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-msgio"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/security"
+)
+
+func TestPeerScorerBansAfterThreshold(t *testing.T) {
+	scorer := NewPeerScorer(PeerScoring{ViolationThreshold: 3, Window: time.Minute, BanDuration: 10 * time.Minute}, zap.NewNop())
+	now := time.Now()
+	scorer.now = func() time.Time { return now }
+	offender, other := peer.ID("offender"), peer.ID("other")
+
+	// Violations older than the window no longer count
+	require.False(t, scorer.RecordViolation(offender, "malformed message"))
+	now = now.Add(61 * time.Second)
+	require.False(t, scorer.RecordViolation(offender, "malformed message"))
+	require.False(t, scorer.RecordViolation(offender, "malformed message"))
+	require.False(t, scorer.Banned(offender))
+
+	require.True(t, scorer.RecordViolation(offender, "undecryptable message"))
+	require.True(t, scorer.Banned(offender))
+	require.False(t, scorer.Banned(other))
+	require.Equal(t, []BannedPeer{{NodeID: offender.String(), Until: now.Add(10 * time.Minute), Reason: "undecryptable message"}},
+		scorer.BannedPeers())
+
+	// Violations while banned do not extend the ban, it expires after the ban duration
+	require.False(t, scorer.RecordViolation(offender, "malformed message"))
+	now = now.Add(10 * time.Minute)
+	require.False(t, scorer.Banned(offender))
+	require.Empty(t, scorer.BannedPeers())
+	require.False(t, scorer.RecordViolation(offender, "malformed message"))
+
+	// A disabled scorer never bans
+	var disabled *PeerScorer
+	require.Nil(t, NewPeerScorer(PeerScoring{}, zap.NewNop()))
+	require.False(t, disabled.RecordViolation(offender, "malformed message"))
+	require.False(t, disabled.Banned(offender))
+	require.Empty(t, disabled.BannedPeers())
+}
+
+// violationHandler rejects every message as a protocol violation
+type violationHandler struct{}
+
+func (violationHandler) HandleMessage(context.Context, *Message) error {
+	return fmt.Errorf("%w: unknown sender", ErrProtocolViolation)
+}
+
+func (violationHandler) Stop() {}
+
+func TestNetworkBansMisbehavingPeer(t *testing.T) {
+	scorer := NewPeerScorer(PeerScoring{ViolationThreshold: 4, Window: time.Minute, BanDuration: time.Minute}, zap.NewNop())
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 0)
+	require.NoError(t, err)
+	receiver, err := libp2p.New(
+		libp2p.Identity(privKey),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.ConnectionGater(NewConnectionGater(nil, false, scorer, zap.NewNop())),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = receiver.Close() })
+	sender := newTestHost(t)
+
+	encryption, err := security.NewMessageEncryption(&security.EncryptionConfig{
+		PrivateKey: receiver.Peerstore().PrivKey(receiver.ID()),
+		Peerstore:  receiver.Peerstore(),
+		Mode:       security.EncryptionDisabled,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	n := &Network{
+		host:              receiver,
+		logger:            zap.NewNop(),
+		cfg:               &Config{},
+		messageHandler:    violationHandler{},
+		messageEncryption: encryption,
+		scorer:            scorer,
+	}
+	receiver.SetStreamHandler(TssPartyProtocolID, n.handleStream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receiverInfo := peer.AddrInfo{ID: receiver.ID(), Addrs: receiver.Addrs()}
+	require.NoError(t, sender.Connect(ctx, receiverInfo))
+
+	stream, err := sender.NewStream(ctx, receiver.ID(), TssPartyProtocolID)
+	require.NoError(t, err)
+	writer := msgio.NewWriter(stream)
+
+	// Two malformed frames and two messages the handler rejects
+	require.NoError(t, writer.WriteMsg([]byte("not a message")))
+	require.NoError(t, writer.WriteMsg([]byte("still not a message")))
+	valid, err := (&Message{Type: "test", From: sender.ID().String(), To: []string{receiver.ID().String()},
+		SenderPeerID: sender.ID().String()}).Compresses(common.CompressionNone)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteMsg(valid))
+	require.NoError(t, writer.WriteMsg(valid))
+
+	require.Eventually(t, func() bool { return scorer.Banned(sender.ID()) }, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, n.BannedPeers(), 1)
+	require.Equal(t, sender.ID().String(), n.BannedPeers()[0].NodeID)
+
+	// The banned peer is disconnected and cannot reconnect
+	require.Eventually(t, func() bool {
+		return receiver.Network().Connectedness(sender.ID()) != network.Connected
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, sender.Network().ClosePeer(receiver.ID()))
+
+	// The dialer may finish its handshake before the receiver's gater refuses the
+	// connection, no stream can be opened over it
+	_ = sender.Connect(ctx, receiverInfo)
+	_, err = sender.NewStream(ctx, receiver.ID(), TssPartyProtocolID)
+	require.Error(t, err)
+	require.NotEqual(t, network.Connected, receiver.Network().Connectedness(sender.ID()))
+}
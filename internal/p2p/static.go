@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// staticPeers dials a fixed list of bootstrap peers and delegates any further discovery,
+// it replaces the DHT when the DHT is disabled
+type staticPeers struct {
+	h         host.Host
+	peers     []peer.AddrInfo
+	discovery PeerDiscovery
+	logger    *zap.Logger
+	ticker    *time.Ticker
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// NewStaticPeers creates a peer discovery that keeps connections to the bootstrap peers.
+// Invalid bootstrap peers are logged and skipped. discovery may be nil.
+func NewStaticPeers(h host.Host, bootstrapPeers []string, discovery PeerDiscovery, logger *zap.Logger) PeerDiscovery {
+	peers := make([]peer.AddrInfo, 0, len(bootstrapPeers))
+	for _, addr := range bootstrapPeers {
+		peerinfo, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			logger.Warn("Failed to parse bootstrap peer", zap.String("addr", addr), zap.Error(err))
+			continue
+		}
+		peers = append(peers, *peerinfo)
+	}
+	return &staticPeers{h: h, peers: peers, discovery: discovery, logger: logger}
+}
+
+// Start records the bootstrap peers in the address book and dials them
+func (n *staticPeers) Start() error {
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+
+	// Permanent addresses keep the peers dialable after a disconnect
+	for _, p := range n.peers {
+		n.h.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.PermanentAddrTTL)
+	}
+
+	if n.discovery != nil {
+		if err := n.discovery.Start(); err != nil {
+			n.cancel()
+			return err
+		}
+	}
+
+	go n.connectPeers()
+	n.ticker = time.NewTicker(1 * time.Minute)
+	go func() {
+		for {
+			select {
+			case <-n.ticker.C:
+				n.connectPeers()
+			case <-n.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	n.logger.Info("Static peer discovery started, DHT disabled", zap.Int("bootstrap_peers", len(n.peers)))
+	return nil
+}
+
+// FindPeer returns the addresses of a bootstrap peer
+func (n *staticPeers) FindPeer(_ context.Context, id peer.ID) (peer.AddrInfo, error) {
+	for _, p := range n.peers {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return peer.AddrInfo{}, errors.Wrapf(ErrPeerNotFound, "%s is not a bootstrap peer", id)
+}
+
+// Rediscover implements PeerDiscovery
+func (n *staticPeers) Rediscover() {
+	if n.ctx == nil {
+		return
+	}
+	go n.connectPeers()
+	if n.discovery != nil {
+		n.discovery.Rediscover()
+	}
+}
+
+// connectPeers dials the bootstrap peers that are not connected
+func (n *staticPeers) connectPeers() {
+	for _, p := range n.peers {
+		if p.ID == n.h.ID() || n.h.Network().Connectedness(p.ID) == network.Connected {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(n.ctx, 10*time.Second)
+		if err := n.h.Connect(ctx, p); err != nil {
+			n.logger.Warn("Failed to connect to bootstrap peer",
+				zap.String("peer", p.ID.String()), zap.Error(err))
+		} else {
+			n.logger.Info("Connected to bootstrap peer", zap.String("peer", p.ID.String()))
+		}
+		cancel()
+	}
+}
+
+// Stop implements PeerDiscovery
+func (n *staticPeers) Stop() {
+	if n.ticker != nil {
+		n.ticker.Stop()
+	}
+	if n.cancel != nil {
+		n.cancel()
+	}
+	if n.discovery != nil {
+		n.discovery.Stop()
+	}
+	n.logger.Info("Static peer discovery stopped")
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-msgio"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/security"
+)
+
+func TestNewPeerDiscoveryDHTMode(t *testing.T) {
+	h := newTestHost(t)
+	logger := zap.NewNop()
+
+	require.IsType(t, &mdnsNet{}, NewPeerDiscovery(h, logger, &Config{NetMod: "mdns", DHTMode: DHTModeDisabled}))
+	require.IsType(t, &dhtNet{}, NewPeerDiscovery(h, logger, &Config{NetMod: "dht", DHTMode: DHTModeClient}))
+
+	discovery := NewPeerDiscovery(h, logger, &Config{NetMod: "dht", DHTMode: DHTModeDisabled})
+	require.IsType(t, &staticPeers{}, discovery)
+	require.IsType(t, &mdnsNet{}, discovery.(*staticPeers).discovery)
+}
+
+func TestStaticPeersConnectsBootstrapPeers(t *testing.T) {
+	local := newTestHost(t)
+	remote := newTestHost(t)
+	unknown := newTestHost(t)
+
+	bootstrap := fmt.Sprintf("%s/p2p/%s", remote.Addrs()[0], remote.ID())
+	discovery := NewStaticPeers(local, []string{"not-a-multiaddr", bootstrap}, nil, zap.NewNop())
+	require.NoError(t, discovery.Start())
+	t.Cleanup(discovery.Stop)
+
+	require.Eventually(t, func() bool {
+		return local.Network().Connectedness(remote.ID()) == network.Connected
+	}, 10*time.Second, 50*time.Millisecond)
+
+	finder, ok := discovery.(peerFinder)
+	require.True(t, ok)
+	info, err := finder.FindPeer(context.Background(), remote.ID())
+	require.NoError(t, err)
+	require.Equal(t, remote.ID(), info.ID)
+
+	_, err = finder.FindPeer(context.Background(), unknown.ID())
+	require.ErrorIs(t, err, ErrPeerNotFound)
+}
+
+func TestSendMessageWithDHTDisabled(t *testing.T) {
+	local := newTestHostWithKey(t, crypto.Secp256k1)
+	remote := newTestHost(t)
+
+	received := make(chan []byte, 1)
+	remote.SetStreamHandler(TssPartyProtocolID, func(stream network.Stream) {
+		defer stream.Close()
+		data, err := msgio.NewReader(stream).ReadMsg()
+		if err == nil {
+			received <- data
+		}
+	})
+
+	encryption, err := security.NewMessageEncryption(&security.EncryptionConfig{
+		PrivateKey: local.Peerstore().PrivKey(local.ID()),
+		Peerstore:  local.Peerstore(),
+		Mode:       security.EncryptionDisabled,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	// The discovery is not started, the remote is only known as a static bootstrap peer
+	bootstrap := fmt.Sprintf("%s/p2p/%s", remote.Addrs()[0], remote.ID())
+	n := &Network{
+		host:              local,
+		logger:            zap.NewNop(),
+		cfg:               &Config{NetMod: "dht", DHTMode: DHTModeDisabled},
+		streamManager:     NewStreamManager(local, TssPartyProtocolID, common.CompressionNone),
+		messageEncryption: encryption,
+		peerDiscovery:     NewStaticPeers(local, []string{bootstrap}, nil, zap.NewNop()),
+	}
+	require.Empty(t, local.Peerstore().Addrs(remote.ID()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, n.SendMessage(ctx, &Message{
+		SessionID: "session",
+		Type:      "test",
+		From:      local.ID().String(),
+		To:        []string{remote.ID().String()},
+		Data:      []byte("hello"),
+	}))
+
+	select {
+	case data := <-received:
+		var msg Message
+		require.NoError(t, msg.Decompresses(data, 0))
+		require.Equal(t, []byte("hello"), msg.Data)
+		require.Equal(t, local.ID().String(), msg.SenderPeerID)
+	case <-ctx.Done():
+		t.Fatal("message was not delivered")
+	}
+}
@@ -16,19 +16,21 @@ import (
 
 // StreamManager manages reusable streams to peers.
 type StreamManager struct {
-	host     host.Host
-	protocol protocol.ID
-	streams  *common.SafeMap[peer.ID, network.Stream]
-	logger   *zap.Logger
+	host        host.Host
+	protocol    protocol.ID
+	compression common.CompressionAlgorithm
+	streams     *common.SafeMap[peer.ID, network.Stream]
+	logger      *zap.Logger
 }
 
 // NewStreamManager creates a new StreamManager.
-func NewStreamManager(h host.Host, p protocol.ID) *StreamManager {
+func NewStreamManager(h host.Host, p protocol.ID, compression common.CompressionAlgorithm) *StreamManager {
 	return &StreamManager{
-		host:     h,
-		protocol: p,
-		streams:  common.New[peer.ID, network.Stream](),
-		logger:   zap.L().Named("stream-manager"),
+		host:        h,
+		protocol:    p,
+		compression: compression,
+		streams:     common.New[peer.ID, network.Stream](),
+		logger:      zap.L().Named("stream-manager"),
 	}
 }
 
@@ -60,7 +62,7 @@ func (sm *StreamManager) sendMessage(ctx context.Context, peerID peer.ID, msg *M
 		return err
 	}
 
-	msgBytes, err := msg.Compresses()
+	msgBytes, err := msg.Compresses(sm.compression)
 	if err != nil {
 		return errors.Wrap(err, "failed to compress message")
 	}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/zap"
@@ -37,18 +38,20 @@ type Message struct {
 	SenderPeerID string `json:"sender_peer_id,omitempty"` // actual P2P peer ID of original sender
 }
 
-// Compresses serializes and compresses the message
-func (m *Message) Compresses() ([]byte, error) {
+// Compresses serializes and compresses the message with the given algorithm
+func (m *Message) Compresses(alg common.CompressionAlgorithm) ([]byte, error) {
 	raw, err := json.Marshal(m)
 	if err != nil {
 		return nil, err
 	}
-	return common.Gzip(raw)
+	return common.Compress(alg, raw)
 }
 
-// Decompresses decompresses and deserializes the message
-func (m *Message) Decompresses(data []byte) error {
-	decompressed, err := common.UnGzip(data)
+// Decompresses decompresses and deserializes the message.
+// The algorithm is detected from the payload, so peers with different settings interoperate.
+// maxSize bounds the decompressed size; zero means unlimited.
+func (m *Message) Decompresses(data []byte, maxSize int) error {
+	decompressed, err := common.DecompressLimit(data, maxSize)
 	if err != nil {
 		return err
 	}
@@ -73,19 +76,40 @@ type MessageHandler interface {
 	Stop()
 }
 
+// Transport delivers messages between nodes. It is implemented by Network over libp2p
+// and by MemoryTransport within a single process.
+type Transport interface {
+	// GetHostID returns the node ID of this node
+	GetHostID() string
+	// PrivateKey returns the private key of this node's identity
+	PrivateKey() crypto.PrivKey
+	// SendMessage sends a message to each node in msg.To, skipping this node
+	SendMessage(ctx context.Context, msg *Message) error
+	// SetMessageHandler sets the handler of incoming messages
+	SetMessageHandler(handler MessageHandler)
+	// EnsurePeerKeys checks that messages can be exchanged with every node in nodeIDs
+	EnsurePeerKeys(ctx context.Context, nodeIDs []string) error
+}
+
 // PeerDiscovery is the interface for the network layer
 type PeerDiscovery interface {
 	// Start starts the peer discovery
 	Start() error
 	// Stop stops the peer discovery
 	Stop()
+	// Rediscover runs a discovery round immediately instead of waiting for the next tick
+	Rediscover()
 }
 
-// NewPeerDiscovery creates a new peer discovery instance based on the configuration
+// NewPeerDiscovery creates a new peer discovery instance based on the configuration.
+// With the DHT disabled the bootstrap peers are dialed directly and mDNS finds the rest.
 func NewPeerDiscovery(h host.Host, logger *zap.Logger, conf *Config) PeerDiscovery {
 	mod := strings.ToLower(conf.NetMod)
-	if mod == "dht" {
-		return NewDHT(h, conf.BootstrapPeers, logger)
+	if mod != "dht" {
+		return NewMDNS(h, conf.MDNSRediscovery, logger)
+	}
+	if strings.ToLower(conf.DHTMode) == DHTModeDisabled {
+		return NewStaticPeers(h, conf.BootstrapPeers, NewMDNS(h, conf.MDNSRediscovery, logger), logger)
 	}
-	return NewMDNS(h, logger)
+	return NewDHT(h, conf.BootstrapPeers, conf.DHTMode, logger)
 }
@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrValidationUnavailable is returned without calling the validation service while its
+// circuit breaker is open. Like other validation errors, the signing request is then
+// rejected or signed depending on the on_error policy of the validation service.
+var ErrValidationUnavailable = errors.New("validation service unavailable")
+
+// BreakerState is the state of a validation service circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed passes every request to the validation service
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects requests without calling the validation service
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single probe request through to test whether the service recovered
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// circuitBreaker stops calling a validation service that keeps failing. After threshold
+// consecutive failures it opens for cooldown, then lets one probe through: a success closes
+// it, a failure opens it for another cooldown. A nil breaker never opens.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mutex    sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newCircuitBreaker creates a breaker opening after threshold consecutive failures, nil
+// when threshold is not positive
+func newCircuitBreaker(threshold int, cooldown time.Duration, logger *zap.Logger) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		logger:    logger,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+}
+
+// allow returns ErrValidationUnavailable when a request must not be sent. Every allowed
+// request must be followed by record.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrValidationUnavailable
+		}
+		b.setState(BreakerHalfOpen)
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		// Only one probe at a time, the others are rejected until it settled the state
+		if b.probing {
+			return ErrValidationUnavailable
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record reports the outcome of an allowed request. ok is false when the service could not
+// be reached, timed out or returned an invalid response, a rejection is a success.
+func (b *circuitBreaker) record(ok bool) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+		if ok {
+			b.failures = 0
+			b.setState(BreakerClosed)
+		} else {
+			b.openedAt = b.now()
+			b.setState(BreakerOpen)
+		}
+		return
+	}
+
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerClosed && b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// release gives up the probe slot of an allowed request whose outcome says nothing about
+// the service, such as a request canceled by the caller
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	b.probing = false
+	b.mutex.Unlock()
+}
+
+// currentState returns the state, closed for a nil breaker
+func (b *circuitBreaker) currentState() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+// setState changes the state and logs the transition, the caller holds the mutex
+func (b *circuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+	fields := []zap.Field{zap.String("from", string(b.state)), zap.String("to", string(state))}
+	if state == BreakerOpen {
+		b.logger.Warn("Validation service circuit breaker opened, rejecting signing requests",
+			append(fields, zap.Int("consecutive_failures", b.failures), zap.Duration("cooldown", b.cooldown))...)
+	} else {
+		b.logger.Info("Validation service circuit breaker state changed", fields...)
+	}
+	b.state = state
+}
@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+)
+
+func TestValidationCircuitBreaker(t *testing.T) {
+	var (
+		down  atomic.Bool
+		calls atomic.Int64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&ValidationResponse{Approved: false, Reason: "denied"})
+	}))
+	t.Cleanup(server.Close)
+
+	service := NewHTTPValidationService(&config.ValidationServiceConfig{
+		URL:                     server.URL,
+		TimeoutSeconds:          5,
+		BreakerFailureThreshold: 3,
+		BreakerCooldownSeconds:  30,
+	}, "node", nil, zap.NewNop())
+	now := time.Now()
+	service.breaker.now = func() time.Time { return now }
+
+	validate := func() error {
+		_, err := service.ValidateSigningRequest(context.Background(), &ValidationRequest{Message: []byte("hello"), KeyID: "key"})
+		return err
+	}
+
+	// A rejection is an answer, it does not count as a failure
+	require.NoError(t, validate())
+	require.Equal(t, BreakerClosed, service.BreakerState())
+
+	// The service goes down, the breaker opens after 3 consecutive failures
+	down.Store(true)
+	for range 3 {
+		err := validate()
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrValidationUnavailable)
+	}
+	require.Equal(t, BreakerOpen, service.BreakerState())
+
+	// While open requests fail closed without calling the service
+	before := calls.Load()
+	require.ErrorIs(t, validate(), ErrValidationUnavailable)
+	require.Equal(t, before, calls.Load())
+
+	// After the cooldown a failed probe opens the breaker again
+	now = now.Add(31 * time.Second)
+	require.NotErrorIs(t, validate(), ErrValidationUnavailable)
+	require.Equal(t, before+1, calls.Load())
+	require.Equal(t, BreakerOpen, service.BreakerState())
+	require.ErrorIs(t, validate(), ErrValidationUnavailable)
+
+	// The service recovers, the next probe closes the breaker
+	down.Store(false)
+	now = now.Add(31 * time.Second)
+	require.NoError(t, validate())
+	require.Equal(t, BreakerClosed, service.BreakerState())
+	require.NoError(t, validate())
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	breaker := newCircuitBreaker(1, time.Minute, zap.NewNop())
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	require.NoError(t, breaker.allow())
+	breaker.record(false)
+	require.Equal(t, BreakerOpen, breaker.currentState())
+
+	// Only one probe is let through while it is in flight
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.allow())
+	require.Equal(t, BreakerHalfOpen, breaker.currentState())
+	require.ErrorIs(t, breaker.allow(), ErrValidationUnavailable)
+
+	// A probe canceled by its caller frees the slot for another one
+	breaker.release()
+	require.NoError(t, breaker.allow())
+	breaker.record(true)
+	require.Equal(t, BreakerClosed, breaker.currentState())
+
+	// A disabled breaker never opens
+	var disabled *circuitBreaker
+	require.Nil(t, newCircuitBreaker(0, time.Minute, zap.NewNop()))
+	disabled.record(false)
+	require.NoError(t, disabled.allow())
+	require.Equal(t, BreakerClosed, disabled.currentState())
+}
@@ -1,31 +1,120 @@
 package plugin
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
-
-	"golang.org/x/crypto/pbkdf2"
+	"sync"
 )
 
-// KeyCipher handles encryption/decryption of TSS keys
+// macKeyLabel separates the MAC key of records from the encryption key it is derived from
+const macKeyLabel = "dknet record mac v1"
+
+// maxDerivedKeys bounds the keys cached for blobs written with other parameters or salts
+const maxDerivedKeys = 16
+
+// ErrInvalidMAC is returned when data does not match its integrity tag
+var ErrInvalidMAC = errors.New("integrity check failed")
+
+// KeyCipher handles encryption/decryption of TSS keys.
+// Encrypted blobs start with a header recording the key derivation function, its
+// costs and salt, so blobs written with other parameters or by the legacy PBKDF2
+// derivation still decrypt after the parameters changed. Integrity tags carry the
+// same header.
 type KeyCipher struct {
-	gcm cipher.AEAD
+	password []byte
+	kdf      KDFParams
+	header   []byte
+	gcm      cipher.AEAD
+	macKey   []byte
+
+	// Keys of blobs derived with other parameters or salts, keyed by their header
+	mu      sync.Mutex
+	derived map[string]*derivedKey
+	legacy  cipher.AEAD
 }
 
-// NewKeyCipher creates a new key encryption service
-func NewKeyCipher(password string) (*KeyCipher, error) {
+// derivedKey holds what is derived from the storage key of one header
+type derivedKey struct {
+	gcm    cipher.AEAD
+	macKey []byte
+}
+
+// newDerivedKey creates the AEAD and the MAC key of a storage key
+func newDerivedKey(key []byte) (*derivedKey, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(macKeyLabel))
+	return &derivedKey{gcm: gcm, macKey: mac.Sum(nil)}, nil
+}
+
+// NewKeyCipher creates a new key encryption service deriving its key with the given
+// key derivation function and a random salt, zero parameters select argon2id with
+// default costs
+func NewKeyCipher(password string, kdf KDFParams) (*KeyCipher, error) {
+	salt, err := NewKDFSalt()
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyCipherWithSalt(password, kdf, salt)
+}
+
+// NewKDFSalt returns a random salt for NewKeyCipherWithSalt
+func NewKDFSalt() ([]byte, error) {
+	salt := make([]byte, kdfSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return salt, nil
+}
+
+// NewKeyCipherWithSalt creates a key encryption service like NewKeyCipher, deriving its
+// key with salt. The salt is recorded in the header of every blob the cipher encrypts,
+// reusing it across restarts spares deriving the key of every blob again.
+func NewKeyCipherWithSalt(password string, kdf KDFParams, salt []byte) (*KeyCipher, error) {
 	if password == "" {
 		return nil, fmt.Errorf("encryption password cannot be empty")
 	}
+	if len(salt) != kdfSaltSize {
+		return nil, fmt.Errorf("salt must be %d bytes", kdfSaltSize)
+	}
+
+	kdf = kdf.withDefaults()
+	if err := kdf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid key derivation parameters: %w", err)
+	}
+
+	key, err := kdf.deriveKey([]byte(password), salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	derived, err := newDerivedKey(key)
+	if err != nil {
+		return nil, err
+	}
 
-	// Derive key from password using PBKDF2
-	salt := []byte("dknet-tss-key-salt-v1") // Fixed salt for deterministic key derivation
-	key := pbkdf2.Key([]byte(password), salt, 100000, 32, sha256.New)
+	header := encodeKDFHeader(kdf, salt)
+	return &KeyCipher{
+		password: []byte(password),
+		kdf:      kdf,
+		header:   header,
+		gcm:      derived.gcm,
+		macKey:   derived.macKey,
+		derived:  map[string]*derivedKey{string(header): derived},
+	}, nil
+}
 
+// newGCM creates an AES-256-GCM AEAD from the key
+func newGCM(key []byte) (cipher.AEAD, error) {
 	// Create AES cipher
 	block, err := aes.NewCipher(key)
 	if err != nil {
@@ -37,10 +126,7 @@ func NewKeyCipher(password string) (*KeyCipher, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GCM: %w", err)
 	}
-
-	return &KeyCipher{
-		gcm: gcm,
-	}, nil
+	return gcm, nil
 }
 
 // Encrypt encrypts the given data
@@ -51,15 +137,23 @@ func (ke *KeyCipher) Encrypt(plaintext []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	// Encrypt the data
-	ciphertext := ke.gcm.Seal(nonce, nonce, plaintext, nil)
-	return ciphertext, nil
+	// Encrypt the data behind the header, authenticating the header with it
+	blob := make([]byte, 0, len(ke.header)+len(nonce)+len(plaintext)+ke.gcm.Overhead())
+	blob = append(blob, ke.header...)
+	blob = append(blob, nonce...)
+	return ke.gcm.Seal(blob, nonce, plaintext, ke.header), nil
 }
 
 // Decrypt decrypts the given data
 func (ke *KeyCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, header, err := ke.aeadFor(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext = ciphertext[len(header):]
+
 	// Check minimum size (nonce + at least some data)
-	nonceSize := ke.gcm.NonceSize()
+	nonceSize := gcm.NonceSize()
 	if len(ciphertext) < nonceSize {
 		return nil, fmt.Errorf("ciphertext too short")
 	}
@@ -68,10 +162,107 @@ func (ke *KeyCipher) Decrypt(ciphertext []byte) ([]byte, error) {
 	nonce, encryptedData := ciphertext[:nonceSize], ciphertext[nonceSize:]
 
 	// Decrypt the data
-	plaintext, err := ke.gcm.Open(nil, nonce, encryptedData, nil)
+	plaintext, err := gcm.Open(nil, nonce, encryptedData, header)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt data: %w", err)
 	}
 
 	return plaintext, nil
 }
+
+// Current reports whether the blob was encrypted with the configured key derivation
+// function, costs and salt, other blobs should be re-encrypted to migrate them
+func (ke *KeyCipher) Current(ciphertext []byte) bool {
+	params, salt, ok := decodeKDFHeader(ciphertext)
+	return ok && params.sameCost(ke.kdf) && bytes.Equal(salt, ke.header[kdfHeaderSize-kdfSaltSize:])
+}
+
+// MAC returns a tag authenticating data, made of the key derivation header followed by
+// an HMAC-SHA256 keyed with a key derived from the storage key
+func (ke *KeyCipher) MAC(data []byte) []byte {
+	mac := hmac.New(sha256.New, ke.macKey)
+	mac.Write(data)
+	return mac.Sum(append([]byte(nil), ke.header...))
+}
+
+// VerifyMAC checks that tag was returned by MAC for data, with any key derivation
+// parameters the same password was used with. It returns ErrInvalidMAC otherwise.
+func (ke *KeyCipher) VerifyMAC(data, tag []byte) error {
+	if len(tag) != kdfHeaderSize+sha256.Size {
+		return ErrInvalidMAC
+	}
+	ke.mu.Lock()
+	derived, header, err := ke.derivedLocked(tag)
+	ke.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return ErrInvalidMAC
+	}
+
+	mac := hmac.New(sha256.New, derived.macKey)
+	mac.Write(data)
+	if !hmac.Equal(mac.Sum(nil), tag[kdfHeaderSize:]) {
+		return ErrInvalidMAC
+	}
+	return nil
+}
+
+// aeadFor returns the AEAD that decrypts the blob and the header in front of it,
+// deriving and caching the key of blobs written with other parameters
+func (ke *KeyCipher) aeadFor(blob []byte) (cipher.AEAD, []byte, error) {
+	ke.mu.Lock()
+	defer ke.mu.Unlock()
+
+	derived, header, err := ke.derivedLocked(blob)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header != nil {
+		return derived.gcm, header, nil
+	}
+
+	if ke.legacy == nil {
+		gcm, err := newGCM(deriveLegacyKey(ke.password))
+		if err != nil {
+			return nil, nil, err
+		}
+		ke.legacy = gcm
+	}
+	return ke.legacy, nil, nil
+}
+
+// derivedLocked returns the keys derived for the header in front of blob and the header,
+// deriving and caching them on first use. The header is nil when blob has none. At most
+// maxDerivedKeys are cached, the key of the cipher itself stays. The caller must hold
+// the lock.
+func (ke *KeyCipher) derivedLocked(blob []byte) (*derivedKey, []byte, error) {
+	params, salt, ok := decodeKDFHeader(blob)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	header := blob[:kdfHeaderSize]
+	if derived, ok := ke.derived[string(header)]; ok {
+		return derived, header, nil
+	}
+	key, err := params.deriveKey(ke.password, salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	derived, err := newDerivedKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ke.derived) >= maxDerivedKeys {
+		for cached := range ke.derived {
+			if cached != string(ke.header) {
+				delete(ke.derived, cached)
+				break
+			}
+		}
+	}
+	ke.derived[string(header)] = derived
+	return derived, header, nil
+}
@@ -0,0 +1,150 @@
+package plugin
+
+import (
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testKDFProfiles = map[string]KDFParams{
+	"argon2id": {Algorithm: KDFArgon2id, Time: 1, Memory: 64, Threads: 1},
+	"scrypt":   {Algorithm: KDFScrypt, N: 1 << 10, R: 8, P: 1},
+}
+
+// legacyEncrypt encrypts like the key cipher did before the KDF was selectable
+func legacyEncrypt(t *testing.T, password string, plaintext []byte) []byte {
+	t.Helper()
+
+	gcm, err := newGCM(deriveLegacyKey([]byte(password)))
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	require.NoError(t, err)
+	return gcm.Seal(nonce, nonce, plaintext, nil)
+}
+
+func TestKeyCipherProfiles(t *testing.T) {
+	for name, kdf := range testKDFProfiles {
+		t.Run(name, func(t *testing.T) {
+			cipher, err := NewKeyCipher("test-password", kdf)
+			require.NoError(t, err)
+
+			blob, err := cipher.Encrypt([]byte("secret share"))
+			require.NoError(t, err)
+			require.True(t, cipher.Current(blob))
+
+			plaintext, err := cipher.Decrypt(blob)
+			require.NoError(t, err)
+			require.Equal(t, "secret share", string(plaintext))
+
+			// A cipher with another salt still reads the blob but migrates it
+			other, err := NewKeyCipher("test-password", kdf)
+			require.NoError(t, err)
+			plaintext, err = other.Decrypt(blob)
+			require.NoError(t, err)
+			require.Equal(t, "secret share", string(plaintext))
+			require.False(t, other.Current(blob))
+
+			// A restarted node deriving with the same salt keeps it
+			restarted, err := NewKeyCipherWithSalt("test-password", kdf, cipher.header[kdfHeaderSize-kdfSaltSize:])
+			require.NoError(t, err)
+			require.True(t, restarted.Current(blob))
+
+			wrong, err := NewKeyCipher("other-password", kdf)
+			require.NoError(t, err)
+			_, err = wrong.Decrypt(blob)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestKeyCipherChangedProfile(t *testing.T) {
+	old, err := NewKeyCipher("test-password", testKDFProfiles["scrypt"])
+	require.NoError(t, err)
+	blob, err := old.Encrypt([]byte("secret share"))
+	require.NoError(t, err)
+
+	cipher, err := NewKeyCipher("test-password", testKDFProfiles["argon2id"])
+	require.NoError(t, err)
+	require.False(t, cipher.Current(blob))
+
+	plaintext, err := cipher.Decrypt(blob)
+	require.NoError(t, err)
+	require.Equal(t, "secret share", string(plaintext))
+
+	// Tampering with the recorded parameters fails authentication
+	tampered := append([]byte(nil), blob...)
+	tampered[len(kdfMagic)+2+7] ^= 1 // scrypt r
+	_, err = cipher.Decrypt(tampered)
+	require.Error(t, err)
+}
+
+func TestKeyCipherLegacyBlob(t *testing.T) {
+	blob := legacyEncrypt(t, "test-password", []byte("secret share"))
+
+	cipher, err := NewKeyCipher("test-password", testKDFProfiles["argon2id"])
+	require.NoError(t, err)
+	require.False(t, cipher.Current(blob))
+
+	plaintext, err := cipher.Decrypt(blob)
+	require.NoError(t, err)
+	require.Equal(t, "secret share", string(plaintext))
+}
+
+func TestKeyCipherDerivedKeyCacheBounded(t *testing.T) {
+	cipher, err := NewKeyCipher("test-password", testKDFProfiles["argon2id"])
+	require.NoError(t, err)
+
+	for range maxDerivedKeys * 2 {
+		other, err := NewKeyCipher("test-password", testKDFProfiles["argon2id"])
+		require.NoError(t, err)
+		blob, err := other.Encrypt([]byte("secret share"))
+		require.NoError(t, err)
+		_, err = cipher.Decrypt(blob)
+		require.NoError(t, err)
+	}
+	require.Len(t, cipher.derived, maxDerivedKeys)
+	require.Contains(t, cipher.derived, string(cipher.header))
+}
+
+func TestKeyCipherMAC(t *testing.T) {
+	cipher, err := NewKeyCipher("test-password", testKDFProfiles["scrypt"])
+	require.NoError(t, err)
+	tag := cipher.MAC([]byte("record"))
+	require.NoError(t, cipher.VerifyMAC([]byte("record"), tag))
+	require.ErrorIs(t, cipher.VerifyMAC([]byte("recorc"), tag), ErrInvalidMAC)
+
+	// A restarted node or one with other KDF parameters still verifies the tag
+	restarted, err := NewKeyCipher("test-password", testKDFProfiles["argon2id"])
+	require.NoError(t, err)
+	require.NoError(t, restarted.VerifyMAC([]byte("record"), tag))
+
+	wrong, err := NewKeyCipher("other-password", testKDFProfiles["scrypt"])
+	require.NoError(t, err)
+	require.ErrorIs(t, wrong.VerifyMAC([]byte("record"), tag), ErrInvalidMAC)
+	require.ErrorIs(t, cipher.VerifyMAC([]byte("record"), tag[:len(tag)-1]), ErrInvalidMAC)
+}
+
+func TestKDFParamsValidate(t *testing.T) {
+	require.NoError(t, KDFParams{}.withDefaults().Validate())
+	require.Equal(t, KDFArgon2id, KDFParams{}.withDefaults().Algorithm)
+	require.NoError(t, KDFParams{Algorithm: KDFScrypt}.withDefaults().Validate())
+
+	require.Error(t, KDFParams{Algorithm: "pbkdf2"}.Validate())
+	require.Error(t, KDFParams{Algorithm: KDFArgon2id, Time: 1, Memory: maxArgon2Memory + 1, Threads: 1}.Validate())
+	require.Error(t, KDFParams{Algorithm: KDFScrypt, N: 1000, R: 8, P: 1}.Validate())
+	require.Error(t, KDFParams{Algorithm: KDFScrypt, N: maxScryptN, R: maxScryptR, P: 1}.Validate())
+	require.NoError(t, KDFParams{Algorithm: KDFScrypt, N: maxScryptN, R: 8, P: 1}.Validate())
+
+	_, err := NewKeyCipher("test-password", KDFParams{Algorithm: "bcrypt"})
+	require.Error(t, err)
+	_, err = NewKeyCipherWithSalt("test-password", KDFParams{}, []byte("short"))
+	require.Error(t, err)
+
+	// Blobs asking for an excessive derivation cost are not derived
+	header := encodeKDFHeader(KDFParams{Algorithm: KDFScrypt, N: maxScryptN * 2, R: 8, P: 1}, make([]byte, kdfSaltSize))
+	_, _, ok := decodeKDFHeader(header)
+	require.False(t, ok)
+}
@@ -0,0 +1,227 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Key derivation functions the key cipher can derive its storage key with
+const (
+	KDFArgon2id = "argon2id"
+	KDFScrypt   = "scrypt"
+)
+
+// Default key derivation cost parameters, argon2id follows the second recommended
+// profile of RFC 9106 and scrypt the interactive profile of RFC 7914
+const (
+	DefaultArgon2Time    = 3
+	DefaultArgon2Memory  = 64 * 1024
+	DefaultArgon2Threads = 4
+	DefaultScryptN       = 1 << 15
+	DefaultScryptR       = 8
+	DefaultScryptP       = 1
+)
+
+// Upper bounds of cost parameters, they stop a stored blob from forcing an
+// arbitrarily expensive key derivation when it is decrypted. Either function may use
+// up to 1 GiB, sixteen times the default.
+const (
+	maxArgon2Time   = 16
+	maxArgon2Memory = 1024 * 1024
+	maxScryptN      = 1 << 20
+	maxScryptR      = 16
+	maxScryptP      = 4
+	maxScryptMemory = 1 << 30
+)
+
+const (
+	kdfKeySize  = 32
+	kdfSaltSize = 16
+
+	// kdfHeaderVersion is the version of the header written in front of encrypted blobs
+	kdfHeaderVersion = 1
+)
+
+// kdfMagic starts the header of blobs encrypted with a selectable key derivation
+// function, blobs without it were encrypted with the legacy PBKDF2 derivation
+var kdfMagic = []byte("dkkdf")
+
+// Algorithm identifiers stored in the blob header
+const (
+	kdfIDArgon2id byte = 1
+	kdfIDScrypt   byte = 2
+)
+
+// kdfHeaderSize is the size of the header: magic, version, algorithm, three cost
+// parameters and the salt
+var kdfHeaderSize = len(kdfMagic) + 2 + 3*4 + kdfSaltSize
+
+// Legacy PBKDF2 derivation used before the key derivation function was selectable
+const (
+	legacyKDFSalt       = "dknet-tss-key-salt-v1"
+	legacyKDFIterations = 100000
+)
+
+// KDFParams selects the key derivation function deriving the storage key from the
+// encryption password. Zero cost parameters take the defaults of the algorithm.
+type KDFParams struct {
+	// Algorithm is argon2id or scrypt, argon2id when empty
+	Algorithm string
+	// Time, Memory (in KiB) and Threads are the argon2id costs
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	// N, R and P are the scrypt cost, block size and parallelism
+	N uint32
+	R uint32
+	P uint32
+}
+
+// withDefaults returns the parameters with unset values replaced by defaults
+func (p KDFParams) withDefaults() KDFParams {
+	if p.Algorithm == "" {
+		p.Algorithm = KDFArgon2id
+	}
+	switch p.Algorithm {
+	case KDFArgon2id:
+		if p.Time == 0 {
+			p.Time = DefaultArgon2Time
+		}
+		if p.Memory == 0 {
+			p.Memory = DefaultArgon2Memory
+		}
+		if p.Threads == 0 {
+			p.Threads = DefaultArgon2Threads
+		}
+	case KDFScrypt:
+		if p.N == 0 {
+			p.N = DefaultScryptN
+		}
+		if p.R == 0 {
+			p.R = DefaultScryptR
+		}
+		if p.P == 0 {
+			p.P = DefaultScryptP
+		}
+	}
+	return p
+}
+
+// Validate checks the algorithm is supported and its cost parameters are in range
+func (p KDFParams) Validate() error {
+	switch p.Algorithm {
+	case KDFArgon2id:
+		if p.Time < 1 || p.Time > maxArgon2Time {
+			return fmt.Errorf("argon2id time must be between 1 and %d", maxArgon2Time)
+		}
+		if p.Threads < 1 {
+			return errors.New("argon2id threads must be at least 1")
+		}
+		if p.Memory < 8*uint32(p.Threads) || p.Memory > maxArgon2Memory {
+			return fmt.Errorf("argon2id memory must be between %d and %d KiB", 8*uint32(p.Threads), maxArgon2Memory)
+		}
+	case KDFScrypt:
+		if p.N < 2 || p.N > maxScryptN || p.N&(p.N-1) != 0 {
+			return fmt.Errorf("scrypt N must be a power of two between 2 and %d", maxScryptN)
+		}
+		if p.R < 1 || p.R > maxScryptR {
+			return fmt.Errorf("scrypt r must be between 1 and %d", maxScryptR)
+		}
+		if p.P < 1 || p.P > maxScryptP {
+			return fmt.Errorf("scrypt p must be between 1 and %d", maxScryptP)
+		}
+		// scrypt uses 128 * N * r bytes
+		if 128*uint64(p.N)*uint64(p.R) > maxScryptMemory {
+			return fmt.Errorf("scrypt N * r must not exceed %d", maxScryptMemory/128)
+		}
+	default:
+		return fmt.Errorf("unsupported key derivation function: %s", p.Algorithm)
+	}
+	return nil
+}
+
+// sameCost reports whether both parameters derive keys with the same algorithm and costs
+func (p KDFParams) sameCost(other KDFParams) bool {
+	if p.Algorithm != other.Algorithm {
+		return false
+	}
+	if p.Algorithm == KDFArgon2id {
+		return p.Time == other.Time && p.Memory == other.Memory && p.Threads == other.Threads
+	}
+	return p.N == other.N && p.R == other.R && p.P == other.P
+}
+
+// deriveKey derives the storage key from the password and salt
+func (p KDFParams) deriveKey(password, salt []byte) ([]byte, error) {
+	switch p.Algorithm {
+	case KDFArgon2id:
+		return argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, kdfKeySize), nil
+	case KDFScrypt:
+		return scrypt.Key(password, salt, int(p.N), int(p.R), int(p.P), kdfKeySize)
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function: %s", p.Algorithm)
+	}
+}
+
+// deriveLegacyKey derives the storage key of blobs written before the key derivation
+// function was selectable
+func deriveLegacyKey(password []byte) []byte {
+	return pbkdf2.Key(password, []byte(legacyKDFSalt), legacyKDFIterations, kdfKeySize, sha256.New)
+}
+
+// encodeKDFHeader encodes the header recording how the key of a blob was derived
+func encodeKDFHeader(p KDFParams, salt []byte) []byte {
+	header := make([]byte, 0, kdfHeaderSize)
+	header = append(header, kdfMagic...)
+	header = append(header, kdfHeaderVersion)
+	switch p.Algorithm {
+	case KDFArgon2id:
+		header = append(header, kdfIDArgon2id)
+		header = binary.BigEndian.AppendUint32(header, p.Time)
+		header = binary.BigEndian.AppendUint32(header, p.Memory)
+		header = binary.BigEndian.AppendUint32(header, uint32(p.Threads))
+	case KDFScrypt:
+		header = append(header, kdfIDScrypt)
+		header = binary.BigEndian.AppendUint32(header, p.N)
+		header = binary.BigEndian.AppendUint32(header, p.R)
+		header = binary.BigEndian.AppendUint32(header, p.P)
+	}
+	return append(header, salt...)
+}
+
+// decodeKDFHeader decodes the header in front of a blob, ok is false when the blob
+// has no valid header
+func decodeKDFHeader(blob []byte) (params KDFParams, salt []byte, ok bool) {
+	if len(blob) < kdfHeaderSize || string(blob[:len(kdfMagic)]) != string(kdfMagic) {
+		return KDFParams{}, nil, false
+	}
+	rest := blob[len(kdfMagic):]
+	if rest[0] != kdfHeaderVersion {
+		return KDFParams{}, nil, false
+	}
+	id := rest[1]
+	a := binary.BigEndian.Uint32(rest[2:6])
+	b := binary.BigEndian.Uint32(rest[6:10])
+	c := binary.BigEndian.Uint32(rest[10:14])
+	switch id {
+	case kdfIDArgon2id:
+		if c > 255 {
+			return KDFParams{}, nil, false
+		}
+		params = KDFParams{Algorithm: KDFArgon2id, Time: a, Memory: b, Threads: uint8(c)}
+	case kdfIDScrypt:
+		params = KDFParams{Algorithm: KDFScrypt, N: a, R: b, P: c}
+	default:
+		return KDFParams{}, nil, false
+	}
+	if params.Validate() != nil {
+		return KDFParams{}, nil, false
+	}
+	return params, rest[14 : 14+kdfSaltSize], true
+}
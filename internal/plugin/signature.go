@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/dreamer-zq/DKNet/pkg/validation"
+)
+
+// requestSigner signs validation request bodies with an HMAC secret or a P2P key, see
+// the validation package for how validation services verify them
+type requestSigner struct {
+	secret  []byte
+	privKey crypto.PrivKey
+}
+
+func newRequestSigner(secret string, privKey crypto.PrivKey) *requestSigner {
+	if secret != "" {
+		return &requestSigner{secret: []byte(secret)}
+	}
+	return &requestSigner{privKey: privKey}
+}
+
+// signRequest signs body and sets the signature headers on header
+func (s *requestSigner) signRequest(header http.Header, nodeID string, body []byte) error {
+	var (
+		algorithm string
+		signature []byte
+	)
+
+	switch {
+	case len(s.secret) > 0:
+		algorithm = validation.SignatureAlgorithmHMACSHA256
+		signature = validation.HMACSHA256(s.secret, body)
+	case s.privKey != nil:
+		algorithm = validation.SignatureAlgorithmLibp2p
+		var err error
+		if signature, err = s.privKey.Sign(body); err != nil {
+			return err
+		}
+	default:
+		return errors.New("no signing secret or private key available")
+	}
+
+	header.Set(validation.HeaderNodeID, nodeID)
+	header.Set(validation.HeaderSignatureAlgorithm, algorithm)
+	header.Set(validation.HeaderSignature, base64.StdEncoding.EncodeToString(signature))
+	return nil
+}
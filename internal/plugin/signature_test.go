@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/pkg/validation"
+)
+
+// captureValidator records the last request and approves everything
+func captureValidator(t *testing.T) (*httptest.Server, *http.Header, *[]byte) {
+	t.Helper()
+
+	var (
+		header http.Header
+		body   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		_ = json.NewEncoder(w).Encode(&ValidationResponse{Approved: true})
+	}))
+	t.Cleanup(server.Close)
+	return server, &header, &body
+}
+
+func TestValidationRequestSignedWithP2PKey(t *testing.T) {
+	privKey, _, err := crypto.GenerateKeyPairWithReader(crypto.Secp256k1, 2048, rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	server, header, body := captureValidator(t)
+	service := NewHTTPValidationService(&config.ValidationServiceConfig{
+		URL:            server.URL,
+		TimeoutSeconds: 5,
+		SignRequests:   true,
+	}, peerID.String(), privKey, zap.NewNop())
+
+	_, err = service.ValidateSigningRequest(context.Background(), &ValidationRequest{Message: []byte("hello"), KeyID: "key"})
+	require.NoError(t, err)
+
+	require.Equal(t, validation.SignatureAlgorithmLibp2p, header.Get(validation.HeaderSignatureAlgorithm))
+	nodeID, err := validation.VerifyRequest(*header, *body, nil)
+	require.NoError(t, err)
+	require.Equal(t, peerID.String(), nodeID)
+
+	// A tampered body must not verify
+	tampered := append([]byte{}, *body...)
+	tampered[len(tampered)-2] ^= 1
+	_, err = validation.VerifyRequest(*header, tampered, nil)
+	require.ErrorIs(t, err, validation.ErrInvalidRequestSignature)
+
+	// Claiming another node's ID must not verify
+	otherKey, _, err := crypto.GenerateKeyPairWithReader(crypto.Secp256k1, 2048, rand.Reader)
+	require.NoError(t, err)
+	otherID, err := peer.IDFromPrivateKey(otherKey)
+	require.NoError(t, err)
+	spoofed := header.Clone()
+	spoofed.Set(validation.HeaderNodeID, otherID.String())
+	_, err = validation.VerifyRequest(spoofed, *body, nil)
+	require.ErrorIs(t, err, validation.ErrInvalidRequestSignature)
+}
+
+func TestValidationRequestSignedWithSecret(t *testing.T) {
+	server, header, body := captureValidator(t)
+	service := NewHTTPValidationService(&config.ValidationServiceConfig{
+		URL:            server.URL,
+		TimeoutSeconds: 5,
+		SignRequests:   true,
+		SigningSecret:  "shared-secret",
+	}, "node1", nil, zap.NewNop())
+
+	_, err := service.ValidateSigningRequest(context.Background(), &ValidationRequest{Message: []byte("hello"), KeyID: "key"})
+	require.NoError(t, err)
+
+	require.Equal(t, validation.SignatureAlgorithmHMACSHA256, header.Get(validation.HeaderSignatureAlgorithm))
+	nodeID, err := validation.VerifyRequest(*header, *body, []byte("shared-secret"))
+	require.NoError(t, err)
+	require.Equal(t, "node1", nodeID)
+
+	_, err = validation.VerifyRequest(*header, *body, []byte("wrong-secret"))
+	require.ErrorIs(t, err, validation.ErrInvalidRequestSignature)
+	_, err = validation.VerifyRequest(*header, *body, nil)
+	require.ErrorIs(t, err, validation.ErrInvalidRequestSignature)
+}
+
+func TestValidationRequestUnsigned(t *testing.T) {
+	server, header, body := captureValidator(t)
+	service := NewHTTPValidationService(&config.ValidationServiceConfig{
+		URL:            server.URL,
+		TimeoutSeconds: 5,
+	}, "node1", nil, zap.NewNop())
+
+	_, err := service.ValidateSigningRequest(context.Background(), &ValidationRequest{Message: []byte("hello")})
+	require.NoError(t, err)
+
+	require.Empty(t, header.Get(validation.HeaderSignature))
+	_, err = validation.VerifyRequest(*header, *body, nil)
+	require.ErrorIs(t, err, validation.ErrInvalidRequestSignature)
+}
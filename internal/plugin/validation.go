@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"go.uber.org/zap"
 
 	"github.com/dreamer-zq/DKNet/internal/config"
@@ -26,6 +27,8 @@ type ValidationService interface {
 type ValidationRequest struct {
 	// Message to be signed
 	Message []byte `json:"message"`
+	// EIP-712 typed data to be signed, set instead of Message
+	TypedData json.RawMessage `json:"typed_data,omitempty"`
 	// Key ID being used for signing
 	KeyID string `json:"key_id"`
 	// List of participant node IDs
@@ -54,10 +57,21 @@ type HTTPValidationService struct {
 	client *http.Client
 	logger *zap.Logger
 	nodeID string
+	signer *requestSigner // nil when request signing is disabled
+
+	// Stops calling the service while it keeps failing, nil when disabled
+	breaker *circuitBreaker
 }
 
-// NewHTTPValidationService creates a new HTTP validation service client
-func NewHTTPValidationService(cfg *config.ValidationServiceConfig, nodeID string, logger *zap.Logger) *HTTPValidationService {
+// NewHTTPValidationService creates a new HTTP validation service client.
+// privKey is the node's P2P key, used to sign requests when sign_requests is
+// enabled and no signing secret is configured.
+func NewHTTPValidationService(
+	cfg *config.ValidationServiceConfig,
+	nodeID string,
+	privKey crypto.PrivKey,
+	logger *zap.Logger,
+) *HTTPValidationService {
 	// Create HTTP client with timeout and TLS configuration
 	client := &http.Client{
 		Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
@@ -71,12 +85,18 @@ func NewHTTPValidationService(cfg *config.ValidationServiceConfig, nodeID string
 		client.Transport = tr
 	}
 
-	return &HTTPValidationService{
+	service := &HTTPValidationService{
 		config: cfg,
 		client: client,
 		logger: logger,
 		nodeID: nodeID,
+		breaker: newCircuitBreaker(cfg.BreakerFailureThreshold,
+			time.Duration(cfg.BreakerCooldownSeconds)*time.Second, logger),
+	}
+	if cfg.SignRequests {
+		service.signer = newRequestSigner(cfg.SigningSecret, privKey)
 	}
+	return service
 }
 
 // ValidateSigningRequest validates a signing request with external service
@@ -112,7 +132,37 @@ func (v *HTTPValidationService) ValidateSigningRequest(ctx context.Context, req
 		httpReq.Header.Set(key, value)
 	}
 
-	// Send request
+	// Sign the request body so the validation service can authenticate this node
+	if v.signer != nil {
+		if err := v.signer.signRequest(httpReq.Header, v.nodeID, reqBody); err != nil {
+			return nil, fmt.Errorf("failed to sign validation request: %w", err)
+		}
+	}
+
+	if err := v.breaker.allow(); err != nil {
+		return nil, fmt.Errorf("%w: circuit breaker is open after repeated failures", err)
+	}
+	validationResp, err := v.send(httpReq)
+	switch {
+	case err == nil:
+		v.breaker.record(true)
+	case ctx.Err() != nil:
+		// Canceled by the caller, the service may be fine
+		v.breaker.release()
+	default:
+		v.breaker.record(false)
+	}
+	return validationResp, err
+}
+
+// BreakerState returns the state of the circuit breaker, closed when it is disabled
+func (v *HTTPValidationService) BreakerState() BreakerState {
+	return v.breaker.currentState()
+}
+
+// send sends a prepared validation request and parses the response. Every error means the
+// service could not be reached or did not answer properly.
+func (v *HTTPValidationService) send(httpReq *http.Request) (*ValidationResponse, error) {
 	resp, err := v.client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send validation request: %w", err)
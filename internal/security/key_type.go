@@ -0,0 +1,32 @@
+package security
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	cryptopb "github.com/libp2p/go-libp2p/core/crypto/pb"
+)
+
+// ErrUnsupportedKeyType is returned for P2P key types peer encryption has no implementation for
+var ErrUnsupportedKeyType = errors.New("unsupported key type for peer encryption")
+
+// CheckKeyType returns ErrUnsupportedKeyType unless peer encryption supports keys of
+// keyType. Only secp256k1 keys are supported, nodes with other keys cannot exchange
+// encrypted messages.
+func CheckKeyType(keyType cryptopb.KeyType) error {
+	if keyType != crypto.Secp256k1 {
+		return fmt.Errorf("%w: %s", ErrUnsupportedKeyType, keyType)
+	}
+	return nil
+}
+
+// GenerateNodeKey generates a P2P private key, a secp256k1 key as peer encryption requires
+func GenerateNodeKey() (crypto.PrivKey, error) {
+	privKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secp256k1 key: %w", err)
+	}
+	return privKey, nil
+}
@@ -0,0 +1,33 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	cryptopb "github.com/libp2p/go-libp2p/core/crypto/pb"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGenerateNodeKey(t *testing.T) {
+	peerstore, err := pstoremem.NewPeerstore()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = peerstore.Close() })
+
+	// Generated keys are of the type peer encryption accepts
+	privKey, err := GenerateNodeKey()
+	require.NoError(t, err)
+	require.Equal(t, cryptopb.KeyType_Secp256k1, privKey.Type())
+
+	_, err = NewMessageEncryption(&EncryptionConfig{PrivateKey: privKey, Peerstore: peerstore}, zap.NewNop())
+	require.NoError(t, err)
+}
+
+func TestNewMessageEncryptionRejectsUnsupportedKeys(t *testing.T) {
+	privKey, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	_, err = NewMessageEncryption(&EncryptionConfig{PrivateKey: privKey}, zap.NewNop())
+	require.ErrorIs(t, err, ErrUnsupportedKeyType)
+}
@@ -1,30 +1,68 @@
 package security
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/libp2p/go-libp2p/core/crypto"
 	"go.uber.org/zap"
 )
 
+// EncryptionMode controls whether P2P messages are end-to-end encrypted
+type EncryptionMode string
+
+const (
+	// EncryptionRequired encrypts every outgoing message and rejects plaintext messages
+	EncryptionRequired EncryptionMode = "required"
+	// EncryptionOptional encrypts outgoing messages when possible and accepts plaintext messages
+	EncryptionOptional EncryptionMode = "optional"
+	// EncryptionDisabled sends plaintext messages, for trusted networks only
+	EncryptionDisabled EncryptionMode = "disabled"
+)
+
+// ErrPlaintextMessage is returned when an unencrypted message is received while encryption is required
+var ErrPlaintextMessage = errors.New("unencrypted message rejected")
+
+// ParseEncryptionMode parses an encryption mode name, defaulting to required when empty
+func ParseEncryptionMode(name string) (EncryptionMode, error) {
+	switch mode := EncryptionMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return EncryptionRequired, nil
+	case EncryptionRequired, EncryptionOptional, EncryptionDisabled:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported p2p encryption mode: %s", name)
+	}
+}
+
 // messageEncryption implements the unified encryption interface
 type messageEncryption struct {
 	logger         *zap.Logger
+	mode           EncryptionMode
 	peerEncryption PeerEncryption
 }
 
 // NewMessageEncryption creates a new unified message encryption instance
 func NewMessageEncryption(config *EncryptionConfig, logger *zap.Logger) (MessageEncryption, error) {
-	if config.PrivateKey.Type() != crypto.Secp256k1 {
-		return nil, fmt.Errorf("unsupported key type for peer encryption: %s", config.PrivateKey.Type())
+	mode, err := ParseEncryptionMode(string(config.Mode))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckKeyType(config.PrivateKey.Type()); err != nil {
+		return nil, err
 	}
 
 	me := &messageEncryption{
 		logger:         logger,
+		mode:           mode,
 		peerEncryption: NewSecp256k1PeerEncryption(config.PrivateKey, config.Peerstore),
 	}
 
-	logger.Info("Secp256k1 peer encryption initialized")
+	logger.Info("Secp256k1 peer encryption initialized", zap.String("mode", string(mode)))
+	if mode == EncryptionDisabled {
+		logger.Warn("P2P message encryption is disabled, messages are sent in plaintext")
+	}
 	return me, nil
 }
 
@@ -35,6 +73,10 @@ func (me *messageEncryption) Encrypt(msg *MessageEncryptionContext) error {
 		return nil
 	}
 
+	if me.mode == EncryptionDisabled {
+		return nil
+	}
+
 	me.logger.Debug("Attempting peer encryption",
 		zap.String("target_peer", msg.Recipient),
 		zap.String("sender_peer", msg.SenderPeerID),
@@ -42,8 +84,14 @@ func (me *messageEncryption) Encrypt(msg *MessageEncryptionContext) error {
 
 	encryptedData, err := me.peerEncryption.EncryptForPeer(msg.Recipient, msg.Data)
 	if err != nil {
-		// Fallback to session encryption if peer encryption fails
-		me.logger.Warn("Peer encryption failed, falling back to session encryption",
+		if me.mode == EncryptionOptional {
+			me.logger.Warn("Peer encryption failed, sending message unencrypted",
+				zap.String("target_peer", msg.Recipient),
+				zap.String("sender_peer", msg.SenderPeerID),
+				zap.Error(err))
+			return nil
+		}
+		me.logger.Warn("Peer encryption failed",
 			zap.String("target_peer", msg.Recipient),
 			zap.String("sender_peer", msg.SenderPeerID),
 			zap.Error(err))
@@ -63,8 +111,11 @@ func (me *messageEncryption) Encrypt(msg *MessageEncryptionContext) error {
 
 // Decrypt decrypts a message using the appropriate strategy
 func (me *messageEncryption) Decrypt(msg *MessageEncryptionContext) error {
-	// Skip if not encrypted
+	// Plaintext is only accepted when encryption is not required
 	if !msg.Encrypted {
+		if me.mode == EncryptionRequired {
+			return fmt.Errorf("%w from peer %s", ErrPlaintextMessage, msg.SenderPeerID)
+		}
 		return nil
 	}
 
@@ -0,0 +1,132 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testMessage is the message a MessageEncryptionContext updates
+type testMessage struct {
+	data      []byte
+	encrypted bool
+}
+
+func (m *testMessage) context(sender, recipient string) *MessageEncryptionContext {
+	return &MessageEncryptionContext{
+		Data:         m.data,
+		Encrypted:    m.encrypted,
+		Recipient:    recipient,
+		SenderPeerID: sender,
+		SetData:      func(data []byte) { m.data = data },
+		SetEncrypted: func(encrypted bool) { m.encrypted = encrypted },
+	}
+}
+
+func newTestPeer(t *testing.T) (crypto.PrivKey, string) {
+	t.Helper()
+	priv, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return priv, pid.String()
+}
+
+func newTestMessageEncryption(t *testing.T, priv crypto.PrivKey, mode EncryptionMode) MessageEncryption {
+	t.Helper()
+	ps, err := pstoremem.NewPeerstore()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ps.Close() })
+
+	me, err := NewMessageEncryption(&EncryptionConfig{PrivateKey: priv, Peerstore: ps, Mode: mode}, zap.NewNop())
+	require.NoError(t, err)
+	return me
+}
+
+func TestParseEncryptionMode(t *testing.T) {
+	mode, err := ParseEncryptionMode("")
+	require.NoError(t, err)
+	require.Equal(t, EncryptionRequired, mode)
+
+	mode, err = ParseEncryptionMode("Optional")
+	require.NoError(t, err)
+	require.Equal(t, EncryptionOptional, mode)
+
+	_, err = ParseEncryptionMode("sometimes")
+	require.Error(t, err)
+}
+
+func TestMessageEncryptionModes(t *testing.T) {
+	privA, idA := newTestPeer(t)
+	privB, idB := newTestPeer(t)
+	payload := []byte("tss round message")
+
+	// A plaintext message as sent by a node with encryption disabled
+	sendPlaintext := func() *testMessage {
+		msg := &testMessage{data: payload}
+		require.NoError(t, newTestMessageEncryption(t, privA, EncryptionDisabled).Encrypt(msg.context(idA, idB)))
+		require.False(t, msg.encrypted)
+		require.Equal(t, payload, msg.data)
+		return msg
+	}
+
+	t.Run("required", func(t *testing.T) {
+		sender := newTestMessageEncryption(t, privA, EncryptionRequired)
+		receiver := newTestMessageEncryption(t, privB, EncryptionRequired)
+
+		msg := &testMessage{data: payload}
+		require.NoError(t, sender.Encrypt(msg.context(idA, idB)))
+		require.True(t, msg.encrypted)
+		require.NotEqual(t, payload, msg.data)
+
+		require.NoError(t, receiver.Decrypt(msg.context(idA, idB)))
+		require.False(t, msg.encrypted)
+		require.Equal(t, payload, msg.data)
+
+		// A peer claiming Encrypted:false is rejected
+		require.ErrorIs(t, receiver.Decrypt(sendPlaintext().context(idA, idB)), ErrPlaintextMessage)
+
+		// Messages that cannot be encrypted are not sent
+		require.Error(t, sender.Encrypt((&testMessage{data: payload}).context(idA, "not-a-peer-id")))
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		sender := newTestMessageEncryption(t, privA, EncryptionOptional)
+		receiver := newTestMessageEncryption(t, privB, EncryptionOptional)
+
+		msg := &testMessage{data: payload}
+		require.NoError(t, sender.Encrypt(msg.context(idA, idB)))
+		require.True(t, msg.encrypted)
+		require.NoError(t, receiver.Decrypt(msg.context(idA, idB)))
+		require.Equal(t, payload, msg.data)
+
+		// Plaintext is accepted
+		plain := sendPlaintext()
+		require.NoError(t, receiver.Decrypt(plain.context(idA, idB)))
+		require.Equal(t, payload, plain.data)
+
+		// Falls back to plaintext when the recipient cannot be encrypted for
+		fallback := &testMessage{data: payload}
+		require.NoError(t, sender.Encrypt(fallback.context(idA, "not-a-peer-id")))
+		require.False(t, fallback.encrypted)
+		require.Equal(t, payload, fallback.data)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		receiver := newTestMessageEncryption(t, privB, EncryptionDisabled)
+
+		plain := sendPlaintext()
+		require.NoError(t, receiver.Decrypt(plain.context(idA, idB)))
+		require.Equal(t, payload, plain.data)
+
+		// Encrypted messages from peers that still encrypt are understood
+		msg := &testMessage{data: payload}
+		require.NoError(t, newTestMessageEncryption(t, privA, EncryptionRequired).Encrypt(msg.context(idA, idB)))
+		require.NoError(t, receiver.Decrypt(msg.context(idA, idB)))
+		require.Equal(t, payload, msg.data)
+	})
+}
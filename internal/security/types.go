@@ -52,6 +52,8 @@ type EncryptionConfig struct {
 	PrivateKey crypto.PrivKey
 	// Peerstore for peer public keys
 	Peerstore peerstore.Peerstore
+	// Mode controls whether messages are encrypted, required when empty
+	Mode EncryptionMode
 }
 
 // encryptWithAESGCM encrypts data using AES-GCM
@@ -9,6 +9,9 @@ var (
 	// ErrStorageClosed is returned when attempting to use a closed storage
 	ErrStorageClosed = errors.New("storage is closed")
 
+	// ErrReadOnly is returned when writing to storage opened read-only
+	ErrReadOnly = errors.New("storage is read-only")
+
 	// ErrInvalidKey is returned when a key is invalid
 	ErrInvalidKey = errors.New("invalid key")
 )
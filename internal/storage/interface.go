@@ -24,3 +24,10 @@ type Storage interface {
 	// Close closes the storage
 	Close() error
 }
+
+// Sizer is implemented by storage backends that can report the space they use
+// without reading every value
+type Sizer interface {
+	// ApproximateSize returns the approximate number of bytes used by the stored data
+	ApproximateSize(ctx context.Context) (int64, error)
+}
@@ -2,8 +2,10 @@ package storage
 
 import (
 	"context"
+	"errors"
 
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -24,29 +26,40 @@ func NewLevelDBStorage(path string) (*LevelDBStorage, error) {
 	}, nil
 }
 
+// NewReadOnlyLevelDBStorage opens an existing LevelDB database for reading, writes fail
+// with ErrReadOnly. Other processes may open the same database read-only at the same time.
+func NewReadOnlyLevelDBStorage(path string) (*LevelDBStorage, error) {
+	db, err := leveldb.OpenFile(path, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LevelDBStorage{
+		db: db,
+	}, nil
+}
+
 // Save stores a key-value pair
 func (s *LevelDBStorage) Save(ctx context.Context, key string, value []byte) error {
-	return s.db.Put([]byte(key), value, nil)
+	return convertLevelDBError(s.db.Put([]byte(key), value, nil))
 }
 
 // Load retrieves the value for a given key
 func (s *LevelDBStorage) Load(ctx context.Context, key string) ([]byte, error) {
 	data, err := s.db.Get([]byte(key), nil)
 	if err != nil {
-		if err == leveldb.ErrNotFound {
-			return nil, ErrNotFound
-		}
-		return nil, err
+		return nil, convertLevelDBError(err)
 	}
 	return data, nil
 }
 
-// Delete removes a key-value pair
+// Delete removes a key-value pair, deleting a missing key is not an error
 func (s *LevelDBStorage) Delete(ctx context.Context, key string) error {
-	return s.db.Delete([]byte(key), nil)
+	return convertLevelDBError(s.db.Delete([]byte(key), nil))
 }
 
-// List returns all keys with the given prefix
+// List returns all keys with the given prefix, in lexical order. Scanning stops
+// early when ctx is done.
 func (s *LevelDBStorage) List(ctx context.Context, prefix string) ([]string, error) {
 	var keys []string
 
@@ -54,11 +67,14 @@ func (s *LevelDBStorage) List(ctx context.Context, prefix string) ([]string, err
 	defer iter.Release()
 
 	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		keys = append(keys, string(iter.Key()))
 	}
 
 	if err := iter.Error(); err != nil {
-		return nil, err
+		return nil, convertLevelDBError(err)
 	}
 
 	return keys, nil
@@ -67,10 +83,35 @@ func (s *LevelDBStorage) List(ctx context.Context, prefix string) ([]string, err
 // Exists checks if a key exists
 func (s *LevelDBStorage) Exists(ctx context.Context, key string) (bool, error) {
 	has, err := s.db.Has([]byte(key), nil)
-	return has, err
+	return has, convertLevelDBError(err)
+}
+
+// ApproximateSize returns the size of the table files holding the data. Recent writes
+// still in the journal are not included until they are compacted into a table.
+func (s *LevelDBStorage) ApproximateSize(ctx context.Context) (int64, error) {
+	// Storage keys are printable strings, so 0xff sorts after all of them
+	sizes, err := s.db.SizeOf([]util.Range{{Limit: []byte{0xff}}})
+	if err != nil {
+		return 0, convertLevelDBError(err)
+	}
+	return sizes.Sum(), nil
 }
 
 // Close closes the storage
 func (s *LevelDBStorage) Close() error {
 	return s.db.Close()
 }
+
+// convertLevelDBError maps LevelDB errors to the storage package errors shared by all backends
+func convertLevelDBError(err error) error {
+	switch {
+	case errors.Is(err, leveldb.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, leveldb.ErrClosed):
+		return ErrStorageClosed
+	case errors.Is(err, leveldb.ErrReadOnly):
+		return ErrReadOnly
+	default:
+		return err
+	}
+}
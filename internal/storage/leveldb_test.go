@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func TestLevelDBStorage(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "db")
+	s, err := NewLevelDBStorage(path)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Save(ctx, "operation:b", []byte("value")))
+	require.NoError(t, s.Save(ctx, "operation:a", []byte("other")))
+	require.NoError(t, s.Save(ctx, "0xkey", []byte("key")))
+
+	loaded, err := s.Load(ctx, "operation:b")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), loaded)
+
+	keys, err := s.List(ctx, "operation:")
+	require.NoError(t, err)
+	require.Equal(t, []string{"operation:a", "operation:b"}, keys)
+
+	keys, err = s.List(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"0xkey", "operation:a", "operation:b"}, keys)
+
+	keys, err = s.List(ctx, "alias:")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	exists, err := s.Exists(ctx, "0xkey")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, s.Delete(ctx, "0xkey"))
+	require.NoError(t, s.Delete(ctx, "0xkey"))
+	_, err = s.Load(ctx, "0xkey")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// A done context stops listing
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.List(canceled, "operation:")
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Entries survive reopening the database
+	require.NoError(t, s.Close())
+	_, err = s.Load(ctx, "operation:a")
+	require.ErrorIs(t, err, ErrStorageClosed)
+
+	s, err = NewLevelDBStorage(path)
+	require.NoError(t, err)
+	defer func() { _ = s.Close() }()
+	keys, err = s.List(ctx, "operation:")
+	require.NoError(t, err)
+	require.Equal(t, []string{"operation:a", "operation:b"}, keys)
+}
+
+func TestLevelDBStorageApproximateSize(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLevelDBStorage(filepath.Join(t.TempDir(), "db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	size, err := s.ApproximateSize(ctx)
+	require.NoError(t, err)
+	require.Zero(t, size)
+
+	value := make([]byte, 64*1024)
+	for i := range 16 {
+		require.NoError(t, s.Save(ctx, fmt.Sprintf("operation:%02d", i), value))
+	}
+	// Flush the journal into table files
+	require.NoError(t, s.db.CompactRange(util.Range{}))
+
+	size, err = s.ApproximateSize(ctx)
+	require.NoError(t, err)
+	require.Positive(t, size)
+}
+
+func TestReadOnlyLevelDBStorage(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "db")
+
+	_, err := NewReadOnlyLevelDBStorage(path)
+	require.Error(t, err, "a missing database is not created")
+
+	s, err := NewLevelDBStorage(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Save(ctx, "0xkey", []byte("key")))
+	require.NoError(t, s.Close())
+
+	// Read-only handles share the database
+	replica, err := NewReadOnlyLevelDBStorage(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = replica.Close() })
+	other, err := NewReadOnlyLevelDBStorage(path)
+	require.NoError(t, err)
+	require.NoError(t, other.Close())
+
+	loaded, err := replica.Load(ctx, "0xkey")
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), loaded)
+
+	require.ErrorIs(t, replica.Save(ctx, "0xother", []byte("other")), ErrReadOnly)
+	require.ErrorIs(t, replica.Delete(ctx, "0xkey"), ErrReadOnly)
+}
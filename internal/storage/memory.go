@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryStorage implements Storage interface with an in-memory map.
+// It is safe for concurrent use and intended for tests, embedding and ephemeral nodes;
+// all data is lost when the process exits.
+type MemoryStorage struct {
+	mutex  sync.RWMutex
+	data   map[string][]byte
+	closed bool
+}
+
+// NewMemoryStorage creates a new in-memory storage instance
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		data: make(map[string][]byte),
+	}
+}
+
+// Save stores a key-value pair
+func (s *MemoryStorage) Save(ctx context.Context, key string, value []byte) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
+	}
+	s.data[key] = slices.Clone(value)
+	return nil
+}
+
+// Load retrieves the value for a given key
+func (s *MemoryStorage) Load(ctx context.Context, key string) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+	value, ok := s.data[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(value), nil
+}
+
+// Delete removes a key-value pair
+func (s *MemoryStorage) Delete(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrStorageClosed
+	}
+	delete(s.data, key)
+	return nil
+}
+
+// List returns all keys with the given prefix, in lexical order like LevelDBStorage
+func (s *MemoryStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return nil, ErrStorageClosed
+	}
+
+	var keys []string
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// Exists checks if a key exists
+func (s *MemoryStorage) Exists(ctx context.Context, key string) (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return false, ErrStorageClosed
+	}
+	_, ok := s.data[key]
+	return ok, nil
+}
+
+// ApproximateSize returns the total length of all keys and values
+func (s *MemoryStorage) ApproximateSize(ctx context.Context) (int64, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return 0, ErrStorageClosed
+	}
+
+	var size int64
+	for key, value := range s.data {
+		size += int64(len(key) + len(value))
+	}
+	return size, nil
+}
+
+// Close closes the storage
+func (s *MemoryStorage) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	s.data = nil
+	return nil
+}
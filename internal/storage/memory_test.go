@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	value := []byte("value")
+	require.NoError(t, s.Save(ctx, "operation:b", value))
+	require.NoError(t, s.Save(ctx, "operation:a", []byte("other")))
+	require.NoError(t, s.Save(ctx, "0xkey", []byte("key")))
+
+	// Stored values are copies
+	value[0] = 'X'
+	loaded, err := s.Load(ctx, "operation:b")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), loaded)
+
+	keys, err := s.List(ctx, "operation:")
+	require.NoError(t, err)
+	require.Equal(t, []string{"operation:a", "operation:b"}, keys)
+
+	exists, err := s.Exists(ctx, "0xkey")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, s.Delete(ctx, "0xkey"))
+	require.NoError(t, s.Delete(ctx, "0xkey"))
+	_, err = s.Load(ctx, "0xkey")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, s.Close())
+	_, err = s.Load(ctx, "operation:a")
+	require.ErrorIs(t, err, ErrStorageClosed)
+}
+
+func TestMemoryStorageApproximateSize(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	require.NoError(t, s.Save(ctx, "key", []byte("value")))
+	require.NoError(t, s.Save(ctx, "other", []byte("v")))
+	size, err := s.ApproximateSize(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, len("key")+len("value")+len("other")+len("v"), size)
+
+	require.NoError(t, s.Close())
+	_, err = s.ApproximateSize(ctx)
+	require.ErrorIs(t, err, ErrStorageClosed)
+}
@@ -0,0 +1,181 @@
+package tss
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// operationPriority orders queued operations, higher values are admitted first.
+// Signing is short and latency sensitive, so it goes ahead of keygen and resharing.
+func operationPriority(opType OperationType) int {
+	switch opType {
+	case OperationSigning:
+		return 2
+	case OperationKeygen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// admissionWaiter is an operation queued for admission
+type admissionWaiter struct {
+	priority int
+	seq      uint64
+	index    int
+	ready    chan struct{}
+}
+
+// admissionQueue is a max-heap of waiters by priority, FIFO within a priority
+type admissionQueue []*admissionWaiter
+
+func (q admissionQueue) Len() int { return len(q) }
+
+func (q admissionQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q admissionQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *admissionQueue) Push(x any) {
+	w := x.(*admissionWaiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *admissionQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*q = old[:len(old)-1]
+	return w
+}
+
+// admission limits the number of concurrently running operations. Operations this node
+// initiated beyond the limit wait in a priority queue and are admitted as running operations
+// finish. Synced operations are never queued: a participant waiting for a slot could hold
+// its own slot for an operation stuck behind another one on a peer, and the nodes would
+// deadlock until the operations time out.
+type admission struct {
+	limit   int
+	mutex   sync.Mutex
+	running int
+	seq     uint64
+	queue   admissionQueue
+	// reserved are the synced operations admitted by reserve whose run has not started yet
+	reserved map[string]struct{}
+}
+
+func newAdmission(limit int) *admission {
+	return &admission{limit: limit, reserved: make(map[string]struct{})}
+}
+
+// reserve admits a synced operation if a slot is free right away, it never queues. The
+// reserved slot is taken over by claim once the operation runs, or freed by unreserve.
+func (a *admission) reserve(operationID string) bool {
+	if a == nil {
+		return true
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.limit > 0 && (a.running >= a.limit || len(a.queue) > 0) {
+		return false
+	}
+	a.running++
+	a.reserved[operationID] = struct{}{}
+	return true
+}
+
+// claim reports whether the operation already holds a slot from reserve. The slot must
+// then be freed with release like one from acquire.
+func (a *admission) claim(operationID string) bool {
+	if a == nil {
+		return false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if _, ok := a.reserved[operationID]; !ok {
+		return false
+	}
+	delete(a.reserved, operationID)
+	return true
+}
+
+// unreserve frees the slot of a reserved operation that could not be created
+func (a *admission) unreserve(operationID string) {
+	if a.claim(operationID) {
+		a.release()
+	}
+}
+
+// acquire blocks until the operation may run or ctx is done. Every successful acquire
+// must be paired with a release. A nil admission or a limit of 0 admits everything immediately.
+func (a *admission) acquire(ctx context.Context, priority int) error {
+	if a == nil {
+		return nil
+	}
+	a.mutex.Lock()
+	if a.limit <= 0 || (a.running < a.limit && len(a.queue) == 0) {
+		a.running++
+		a.mutex.Unlock()
+		return nil
+	}
+	a.seq++
+	w := &admissionWaiter{priority: priority, seq: a.seq, ready: make(chan struct{})}
+	heap.Push(&a.queue, w)
+	a.mutex.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if w.index >= 0 {
+		heap.Remove(&a.queue, w.index)
+	} else {
+		// Admitted while the context ended, hand the slot on
+		a.releaseLocked()
+	}
+	return ctx.Err()
+}
+
+// release frees the slot of a finished operation and admits the next queued one
+func (a *admission) release() {
+	if a == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.releaseLocked()
+}
+
+func (a *admission) releaseLocked() {
+	a.running--
+	if len(a.queue) > 0 && (a.limit <= 0 || a.running < a.limit) {
+		w := heap.Pop(&a.queue).(*admissionWaiter)
+		a.running++
+		close(w.ready)
+	}
+}
+
+// stats returns the number of running and queued operations
+func (a *admission) stats() (running, queued int) {
+	if a == nil {
+		return 0, 0
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.running, len(a.queue)
+}
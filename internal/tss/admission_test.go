@@ -0,0 +1,148 @@
+package tss
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionSigningJumpsQueuedKeygens(t *testing.T) {
+	a := newAdmission(1)
+	require.NoError(t, a.acquire(context.Background(), operationPriority(OperationResharing)))
+
+	admitted := make(chan OperationType, 3)
+
+	for _, opType := range []OperationType{OperationKeygen, OperationKeygen, OperationSigning} {
+		queuedBefore := queuedCount(a)
+		go func(opType OperationType) {
+			if err := a.acquire(context.Background(), operationPriority(opType)); err == nil {
+				admitted <- opType
+			}
+		}(opType)
+		require.Eventually(t, func() bool { return queuedCount(a) == queuedBefore+1 }, time.Second, time.Millisecond)
+	}
+
+	running, queued := a.stats()
+	require.Equal(t, 1, running)
+	require.Equal(t, 3, queued)
+
+	// Each release admits exactly one waiter, the signing request first
+	var order []OperationType
+	for range 3 {
+		a.release()
+		select {
+		case opType := <-admitted:
+			order = append(order, opType)
+		case <-time.After(time.Second):
+			t.Fatal("no operation admitted after release")
+		}
+	}
+	require.Equal(t, []OperationType{OperationSigning, OperationKeygen, OperationKeygen}, order)
+
+	a.release()
+	running, queued = a.stats()
+	require.Zero(t, running)
+	require.Zero(t, queued)
+}
+
+func TestAdmissionCanceledWaiterLeavesQueue(t *testing.T) {
+	a := newAdmission(1)
+	require.NoError(t, a.acquire(context.Background(), 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- a.acquire(ctx, operationPriority(OperationSigning)) }()
+	require.Eventually(t, func() bool { return queuedCount(a) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+	require.Zero(t, queuedCount(a))
+
+	// The slot is free again once the running operation finishes
+	a.release()
+	require.NoError(t, a.acquire(context.Background(), 0))
+}
+
+func TestAdmissionUnlimited(t *testing.T) {
+	a := newAdmission(0)
+	for range 10 {
+		require.NoError(t, a.acquire(context.Background(), 0))
+	}
+	running, queued := a.stats()
+	require.Equal(t, 10, running)
+	require.Zero(t, queued)
+}
+
+func queuedCount(a *admission) int {
+	_, queued := a.stats()
+	return queued
+}
+
+func TestAdmissionReserveNeverQueues(t *testing.T) {
+	a := newAdmission(1)
+	require.True(t, a.reserve("op-1"))
+	require.False(t, a.reserve("op-2"))
+	running, queued := a.stats()
+	require.Equal(t, 1, running)
+	require.Zero(t, queued)
+
+	// The operation takes its reserved slot over once, and frees it like an acquired one
+	require.True(t, a.claim("op-1"))
+	require.False(t, a.claim("op-1"))
+	a.release()
+
+	// A synced operation that could not be created gives its slot back
+	require.True(t, a.reserve("op-3"))
+	a.unreserve("op-3")
+	a.unreserve("op-unknown")
+	running, _ = a.stats()
+	require.Zero(t, running)
+}
+
+func TestConcurrentInitiatorsDoNotDeadlock(t *testing.T) {
+	nodes := newTestNetwork(t, 2, func(cfg *Config) {
+		cfg.MaxConcurrentOperations = 1
+		cfg.SyncAckTimeout = 10 * time.Second
+	})
+	participants := []string{nodes[0].nodeID, nodes[1].nodeID}
+
+	// Each node admits its own keygen and receives the sync of the other one. Queuing the
+	// synced operation would leave both waiting for each other until the keygen timeout.
+	ops := make([]*Operation, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, s := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ops[i], errs[i] = s.StartKeygen(context.Background(), "", 1, participants, "", "", "", OperationOptions{})
+		}()
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	for i, op := range ops {
+		require.NoError(t, errs[i])
+		_, err := op.Await(ctx)
+		require.NotErrorIs(t, err, context.DeadlineExceeded, "operation %d is stuck", i)
+		if err != nil {
+			require.ErrorIs(t, err, ErrParticipantBusy)
+		}
+	}
+
+	// Every slot was freed, a keygen started now runs on both nodes
+	for _, s := range nodes {
+		require.Eventually(t, func() bool {
+			running, queued := s.OperationStats()
+			return running == 0 && queued == 0
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	op, err := nodes[0].StartKeygen(context.Background(), "", 1, participants, "", "", "", OperationOptions{})
+	require.NoError(t, err)
+	_, err = op.Await(ctx)
+	require.NoError(t, err)
+}
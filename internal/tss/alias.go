@@ -0,0 +1,132 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+var (
+	// keyAliasPattern restricts aliases to short, shell friendly names
+	keyAliasPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+	// keyAddressPattern matches Ethereum addresses, the key IDs of keys stored under their address
+	keyAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	// keyIDPattern matches key IDs, an Ethereum address or a UUID, see KeyIDFormatUUID
+	keyIDPattern = regexp.MustCompile(`^(0x[0-9a-fA-F]{40}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+)
+
+// keyAliasStorageKey returns the storage key of the alias -> key ID index entry
+func keyAliasStorageKey(alias string) string {
+	return fmt.Sprintf("alias:%s", alias)
+}
+
+// validateKeyAlias checks that alias is well formed and cannot be mistaken for a key ID
+func validateKeyAlias(alias string) error {
+	if !keyAliasPattern.MatchString(alias) {
+		return fmt.Errorf("%w: %q must be 1-64 letters, digits, '.', '_' or '-' and start with a letter or digit",
+			ErrInvalidKeyAlias, alias)
+	}
+	if keyIDPattern.MatchString(alias) {
+		return fmt.Errorf("%w: %q looks like a key ID", ErrInvalidKeyAlias, alias)
+	}
+	return nil
+}
+
+// ResolveKeyID returns the key ID for a key ID, a key's Ethereum address or a key alias
+func (s *Service) ResolveKeyID(ctx context.Context, keyIDOrAlias string) (string, error) {
+	if keyAddressPattern.MatchString(keyIDOrAlias) {
+		return s.resolveKeyAddress(ctx, keyIDOrAlias)
+	}
+	if keyIDPattern.MatchString(keyIDOrAlias) {
+		return keyIDOrAlias, nil
+	}
+
+	keyID, err := s.lookupKeyAlias(ctx, keyIDOrAlias)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", fmt.Errorf("%w: %s", ErrKeyAliasNotFound, keyIDOrAlias)
+	}
+	return keyID, err
+}
+
+// lookupKeyAlias loads the key ID an alias points to
+func (s *Service) lookupKeyAlias(ctx context.Context, alias string) (string, error) {
+	data, err := s.loadMetadata(ctx, keyAliasStorageKey(alias))
+	if err != nil {
+		return "", err
+	}
+
+	var keyID string
+	if err := json.Unmarshal(data, &keyID); err != nil {
+		return "", fmt.Errorf("failed to unmarshal key alias: %w", err)
+	}
+	return keyID, nil
+}
+
+// checkKeyAliasAvailable returns an error when alias is invalid or already in use
+func (s *Service) checkKeyAliasAvailable(ctx context.Context, alias string) error {
+	if err := validateKeyAlias(alias); err != nil {
+		return err
+	}
+
+	keyID, err := s.lookupKeyAlias(ctx, alias)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to look up key alias: %w", err)
+	default:
+		return fmt.Errorf("%w: %s is used by %s", ErrKeyAliasExists, alias, keyID)
+	}
+}
+
+// saveKeyAlias points alias at keyID. Re-registering the alias of the same key is a no-op,
+// so resharing can carry the alias over.
+func (s *Service) saveKeyAlias(ctx context.Context, alias, keyID string) error {
+	s.aliasMutex.Lock()
+	defer s.aliasMutex.Unlock()
+
+	existing, err := s.lookupKeyAlias(ctx, alias)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to look up key alias: %w", err)
+	case existing == keyID:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s is used by %s", ErrKeyAliasExists, alias, existing)
+	}
+
+	// Stored as JSON so loadMetadata can tell plaintext from encrypted entries
+	data, err := json.Marshal(keyID)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key alias: %w", err)
+	}
+	if err := s.saveMetadata(ctx, keyAliasStorageKey(alias), data); err != nil {
+		return fmt.Errorf("failed to save key alias: %w", err)
+	}
+
+	s.logger.Info("Registered key alias", zap.String("alias", alias), zap.String("key_id", keyID))
+	return nil
+}
+
+// registerKeyAlias registers the alias of a newly stored key. A key share must never be lost
+// over its alias, so on failure the key is kept without one and the empty alias is returned.
+func (s *Service) registerKeyAlias(ctx context.Context, alias, keyID string) string {
+	if alias == "" {
+		return ""
+	}
+	if err := s.saveKeyAlias(ctx, alias, keyID); err != nil {
+		s.logger.Error("Failed to register key alias, storing key without it",
+			zap.String("alias", alias),
+			zap.String("key_id", keyID),
+			zap.Error(err))
+		return ""
+	}
+	return alias
+}
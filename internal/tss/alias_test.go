@@ -0,0 +1,125 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+func TestValidateKeyAlias(t *testing.T) {
+	for _, alias := range []string{"treasury", "hot-wallet.v2", "Key_1"} {
+		require.NoError(t, validateKeyAlias(alias), alias)
+	}
+	for _, alias := range []string{"", "-leading", "has space", "0x1111111111111111111111111111111111111111", string(make([]byte, 65))} {
+		require.ErrorIs(t, validateKeyAlias(alias), ErrInvalidKeyAlias, alias)
+	}
+}
+
+func TestKeygenStoresAlias(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, true)
+
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	_, keyID, err := encodePublicKey(pub)
+	require.NoError(t, err)
+
+	result := keygen.NewLocalPartySaveData(1)
+	result.ECDSAPub = pub
+	op := &Operation{
+		ID:      "op-alias",
+		Type:    OperationKeygen,
+		Request: &KeygenRequest{Threshold: 1, Participants: []string{"node1", "node2"}, Alias: "treasury"},
+	}
+	require.NoError(t, s.saveKeygenResult(ctx, op, &result))
+	require.Equal(t, "treasury", op.Result.(*KeygenResult).Alias)
+
+	// The alias resolves to the key, key IDs resolve to themselves
+	resolved, err := s.ResolveKeyID(ctx, "treasury")
+	require.NoError(t, err)
+	require.Equal(t, keyID, resolved)
+	resolved, err = s.ResolveKeyID(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, keyID, resolved)
+
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, "treasury", metadata.Alias)
+
+	_, err = s.ResolveKeyID(ctx, "unknown")
+	require.ErrorIs(t, err, ErrKeyAliasNotFound)
+
+	// Resharing the same key keeps the alias
+	require.NoError(t, s.saveKeyAlias(ctx, "treasury", keyID))
+}
+
+func TestDuplicateKeyAliasRejected(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	require.NoError(t, s.saveKeyAlias(ctx, "treasury", "0x1111111111111111111111111111111111111111"))
+	require.ErrorIs(t, s.saveKeyAlias(ctx, "treasury", "0x2222222222222222222222222222222222222222"), ErrKeyAliasExists)
+
+	_, err := s.StartKeygen(ctx, "", 1, []string{"node1", "node2"}, "treasury", "", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrKeyAliasExists)
+
+	// Participants refuse to join a keygen whose alias they already use
+	data, err := json.Marshal(&KeygenSyncData{
+		OperationSyncData: OperationSyncData{
+			OperationID:   "op-dup",
+			OperationType: OperationKeygen,
+			SessionID:     "session-dup",
+			Threshold:     1,
+			Parties:       2,
+			Participants:  []string{"node1", "node2"},
+		},
+		Alias: "treasury",
+	})
+	require.NoError(t, err)
+	err = s.createSyncedKeygenOperation(ctx, &p2p.Message{Data: data})
+	require.ErrorIs(t, err, ErrKeyAliasExists)
+	_, exists := s.GetOperation("op-dup")
+	require.False(t, exists)
+}
+
+func TestHasKey(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	saveKey := func(keyID string, participants ...string) {
+		require.NoError(t, s.saveKeyRecord(ctx, keyID, &keyData{Threshold: 1, Participants: participants}))
+	}
+	shared := "0x1111111111111111111111111111111111111111"
+	known := "0x2222222222222222222222222222222222222222"
+	saveKey(shared, "node1", "node2")
+	saveKey(known, "node2", "node3")
+	require.NoError(t, s.saveKeyAlias(ctx, "treasury", shared))
+
+	presence, err := s.HasKey(ctx, shared)
+	require.NoError(t, err)
+	require.Equal(t, KeyPresence{Known: true, HasShare: true}, presence)
+
+	presence, err = s.HasKey(ctx, "treasury")
+	require.NoError(t, err)
+	require.Equal(t, KeyPresence{Known: true, HasShare: true}, presence)
+
+	// Knowing a key's metadata is not holding a share of it
+	presence, err = s.HasKey(ctx, known)
+	require.NoError(t, err)
+	require.Equal(t, KeyPresence{Known: true}, presence)
+
+	for _, unknown := range []string{"0x3333333333333333333333333333333333333333", "unknown"} {
+		presence, err = s.HasKey(ctx, unknown)
+		require.NoError(t, err)
+		require.Equal(t, KeyPresence{}, presence)
+	}
+}
@@ -0,0 +1,52 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextBoundDigest(t *testing.T) {
+	digest := hashMessageForEthereum([]byte("hello"))
+	bound := ContextBoundDigest("0xabc", "session-1", digest)
+	require.Len(t, bound, 32)
+	require.Equal(t, bound, ContextBoundDigest("0xabc", "session-1", digest))
+
+	// Any change of the context or the message gives another digest
+	for _, other := range [][]byte{
+		ContextBoundDigest("0xabd", "session-1", digest),
+		ContextBoundDigest("0xabc", "session-2", digest),
+		ContextBoundDigest("0xabc", "session-1", hashMessageForEthereum([]byte("hellO"))),
+		// Fields are length prefixed, moving bytes between them changes the digest
+		ContextBoundDigest("0xabcs", "ession-1", digest),
+		digest,
+	} {
+		require.NotEqual(t, bound, other)
+	}
+}
+
+func TestContextBoundSigningResult(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	op := &Operation{
+		ID:        "op-bound",
+		Type:      OperationSigning,
+		SessionID: "session-1",
+		Request:   &SigningRequest{Message: []byte("hello"), KeyID: "0xabc", ContextBound: true},
+	}
+	require.NoError(t, s.saveSigningResult(context.Background(), op, &common.SignatureData{
+		R:                 bytes.Repeat([]byte{0x11}, 32),
+		S:                 bytes.Repeat([]byte{0x22}, 32),
+		SignatureRecovery: []byte{0},
+	}))
+
+	result := op.Result.(*SigningResult)
+	want := ContextBoundDigest("0xabc", "session-1", hashMessageForEthereum([]byte("hello")))
+	require.Equal(t, "0x"+hex.EncodeToString(want), result.MessageDigest)
+	require.Equal(t, HashModeEIP191, result.HashMode)
+	require.Equal(t, &SigningContext{KeyID: "0xabc", SessionID: "session-1"}, result.Context)
+}
@@ -0,0 +1,86 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+// CancelOperation cancels an active operation on this node and asks the other
+// participants to cancel their party for it as well
+func (s *Service) CancelOperation(ctx context.Context, operationID, reason string) error {
+	s.mutex.RLock()
+	operation, exists := s.operations[operationID]
+	s.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrOperationNotFound, operationID)
+	}
+
+	operation.RLock()
+	status := operation.Status
+	operation.RUnlock()
+	if status != StatusPending && status != StatusInProgress {
+		return fmt.Errorf("%w: %s is %s", ErrOperationFinished, operationID, status)
+	}
+
+	s.logger.Info("Canceling operation",
+		zap.String("operation_id", operationID),
+		zap.String("reason", reason))
+	operation.cancel()
+
+	cancelData := &OperationCancelData{
+		OperationID:  operationID,
+		Reason:       reason,
+		Participants: operation.participantIDs(),
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := s.broadcastOperationMessage(syncCtx, OperationCancel, cancelData); err != nil {
+		return fmt.Errorf("failed to broadcast operation cancel: %w", err)
+	}
+	return nil
+}
+
+// handleOperationCancel cancels the local party of an operation canceled by another participant
+func (s *Service) handleOperationCancel(msg *p2p.Message) error {
+	var cancelData OperationCancelData
+	if err := json.Unmarshal(msg.Data, &cancelData); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal operation cancel data: %w", p2p.ErrProtocolViolation, err)
+	}
+
+	s.mutex.RLock()
+	operation, exists := s.operations[cancelData.OperationID]
+	s.mutex.RUnlock()
+
+	if !exists {
+		s.logger.Debug("Ignoring cancel for unknown or finished operation",
+			zap.String("operation_id", cancelData.OperationID),
+			zap.String("from", msg.From))
+		return nil
+	}
+
+	// Only a participant of the operation may cancel it. The transport guarantees
+	// SenderPeerID is the peer we received the message from.
+	if msg.From != msg.SenderPeerID || !slices.Contains(operation.participantIDs(), msg.From) {
+		s.logger.Warn("Rejecting operation cancel from non-participant",
+			zap.String("operation_id", cancelData.OperationID),
+			zap.String("from", msg.From),
+			zap.String("sender_peer_id", msg.SenderPeerID))
+		return fmt.Errorf("node %s is not a participant of operation %s", msg.From, cancelData.OperationID)
+	}
+
+	s.logger.Info("Operation canceled by participant",
+		zap.String("operation_id", cancelData.OperationID),
+		zap.String("from", msg.From),
+		zap.String("reason", cancelData.Reason))
+	operation.cancel()
+	return nil
+}
@@ -0,0 +1,104 @@
+package tss
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// newTestNode starts a TSS service on hub
+func newTestNode(t *testing.T, hub *p2p.MemoryHub) *Service {
+	t.Helper()
+
+	transport, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	store := storage.NewMemoryStorage()
+	service, err := NewService(&Config{PeerID: transport.GetHostID(), KDF: testKDF}, store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = transport.Stop()
+		_ = store.Close()
+	})
+	return service
+}
+
+// addTestOperation registers an in-progress operation and returns its context
+func addTestOperation(s *Service, operationID string, participants ...string) context.Context {
+	parties := make([]*tss.PartyID, len(participants))
+	for i, id := range participants {
+		parties[i] = tss.NewPartyID(id, id, big.NewInt(int64(i+1)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	op := &Operation{
+		ID:           operationID,
+		Type:         OperationSigning,
+		Participants: parties,
+		Status:       StatusInProgress,
+		cancel:       cancel,
+	}
+	// The operation is in progress, so it passed admission
+	close(op.admittedCh())
+	s.mutex.Lock()
+	s.operations[operationID] = op
+	s.mutex.Unlock()
+	return ctx
+}
+
+func TestCancelOperationPropagatesToPeers(t *testing.T) {
+	hub := p2p.NewMemoryHub()
+	initiator := newTestNode(t, hub)
+	peer := newTestNode(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	initiatorCtx := addTestOperation(initiator, "op-1", initiator.nodeID, peer.nodeID)
+	peerCtx := addTestOperation(peer, "op-1", initiator.nodeID, peer.nodeID)
+
+	require.NoError(t, initiator.CancelOperation(ctx, "op-1", "user request"))
+	require.Error(t, initiatorCtx.Err())
+
+	select {
+	case <-peerCtx.Done():
+	case <-ctx.Done():
+		t.Fatal("peer operation was not canceled")
+	}
+
+	// Unknown operations cannot be canceled
+	require.ErrorIs(t, initiator.CancelOperation(ctx, "op-unknown", ""), ErrOperationNotFound)
+}
+
+func TestHandleOperationCancelRejectsNonParticipant(t *testing.T) {
+	s, _ := newTestService(t, false)
+	opCtx := addTestOperation(s, "op-1", "node-a", "node-b")
+
+	cancelMsg := func(from string) *p2p.Message {
+		return &p2p.Message{
+			Type:         string(OperationCancel),
+			From:         from,
+			SenderPeerID: from,
+			Data:         []byte(`{"operation_id":"op-1"}`),
+		}
+	}
+
+	require.Error(t, s.handleOperationCancel(cancelMsg("node-c")))
+	require.NoError(t, opCtx.Err())
+
+	// The claimed sender must match the transport sender
+	spoofed := cancelMsg("node-a")
+	spoofed.SenderPeerID = "node-c"
+	require.Error(t, s.handleOperationCancel(spoofed))
+	require.NoError(t, opCtx.Err())
+
+	require.NoError(t, s.handleOperationCancel(cancelMsg("node-a")))
+	require.Error(t, opCtx.Err())
+}
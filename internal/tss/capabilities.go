@@ -0,0 +1,26 @@
+package tss
+
+// CurveSecp256k1 is the elliptic curve of all keys
+const CurveSecp256k1 = "secp256k1"
+
+// Capabilities describes the keys and signatures a node supports
+type Capabilities struct {
+	Curves         []string
+	Algorithms     []SignatureAlgorithm
+	ChainFamilies  []ChainFamily
+	AddressFormats []AddressFormat
+	// KeyIDFormat is the format of the IDs of new keys
+	KeyIDFormat string
+}
+
+// Capabilities returns what keygen and signing support on this node. Schnorr keys are
+// left out until keygen accepts them, see AlgorithmSchnorr.
+func (s *Service) Capabilities() Capabilities {
+	return Capabilities{
+		Curves:         []string{CurveSecp256k1},
+		Algorithms:     []SignatureAlgorithm{AlgorithmECDSA},
+		ChainFamilies:  []ChainFamily{ChainFamilyEthereum, ChainFamilyBitcoin},
+		AddressFormats: []AddressFormat{AddressFormatEthereum, AddressFormatBTCP2PKH, AddressFormatBTCP2WPKH},
+		KeyIDFormat:    s.KeyIDFormat(),
+	}
+}
@@ -0,0 +1,139 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"golang.org/x/crypto/sha3"
+)
+
+// ChainFamily is the chain a key is intended for, it selects how messages are hashed
+// and how signatures are encoded
+type ChainFamily string
+
+const (
+	// ChainFamilyEthereum signs the EIP-191 hash of messages and returns R || S || V signatures
+	ChainFamilyEthereum ChainFamily = "ethereum"
+	// ChainFamilyBitcoin signs the double SHA-256 of messages, e.g. a sighash preimage,
+	// and returns DER signatures followed by the sighash type
+	ChainFamilyBitcoin ChainFamily = "bitcoin"
+)
+
+// AddressFormat names an address derived from a key's public key
+type AddressFormat string
+
+const (
+	// AddressFormatEthereum is the Keccak-256 based Ethereum address
+	AddressFormatEthereum AddressFormat = "ethereum"
+	// AddressFormatBTCP2PKH is a Bitcoin mainnet pay-to-pubkey-hash address
+	AddressFormatBTCP2PKH AddressFormat = "btc_p2pkh"
+	// AddressFormatBTCP2WPKH is a Bitcoin mainnet pay-to-witness-pubkey-hash (bech32) address
+	AddressFormatBTCP2WPKH AddressFormat = "btc_p2wpkh"
+)
+
+// sighashAll is the Bitcoin sighash type appended to signatures
+const sighashAll = 0x01
+
+// parseChainFamily returns the chain family of a keygen request, keys are Ethereum keys
+// unless requested otherwise
+func parseChainFamily(family string) (ChainFamily, error) {
+	switch ChainFamily(family) {
+	case "", ChainFamilyEthereum:
+		return ChainFamilyEthereum, nil
+	case ChainFamilyBitcoin:
+		return ChainFamilyBitcoin, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedChainFamily, family)
+}
+
+// Family returns the chain family of the key, keys stored before chain families
+// existed are Ethereum keys
+func (k *keyData) Family() ChainFamily {
+	if k.ChainFamily == "" {
+		return ChainFamilyEthereum
+	}
+	return k.ChainFamily
+}
+
+// KeyAddresses returns the addresses of every supported format for a key, derived from
+// its group public key
+func (s *Service) KeyAddresses(ctx context.Context, keyID string) (map[AddressFormat]string, error) {
+	_, localParty, err := s.loadKeyData(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return keyAddresses(localParty.ECDSAPub)
+}
+
+// keyAddresses derives the addresses of every supported format from a public key
+func keyAddresses(pub *crypto.ECPoint) (map[AddressFormat]string, error) {
+	if pub == nil {
+		return nil, fmt.Errorf("public key is missing")
+	}
+
+	// Coordinates are padded to 32 bytes, big.Int drops leading zeros
+	uncompressed := make([]byte, 65)
+	uncompressed[0] = 0x04
+	pub.X().FillBytes(uncompressed[1:33])
+	pub.Y().FillBytes(uncompressed[33:])
+	pubKey, err := btcec.ParsePubKey(uncompressed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+	}
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(uncompressed[1:])
+	ethAddress := "0x" + hex.EncodeToString(hasher.Sum(nil)[12:])
+
+	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+	p2pkh, err := btcutil.NewAddressPubKeyHash(pubKeyHash, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive P2PKH address: %w", err)
+	}
+	p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive P2WPKH address: %w", err)
+	}
+
+	return map[AddressFormat]string{
+		AddressFormatEthereum:  ethAddress,
+		AddressFormatBTCP2PKH:  p2pkh.EncodeAddress(),
+		AddressFormatBTCP2WPKH: p2wpkh.EncodeAddress(),
+	}, nil
+}
+
+// hashMessageForBitcoin returns the double SHA-256 of a message, the digest Bitcoin signs
+// for a sighash preimage
+func hashMessageForBitcoin(message []byte) []byte {
+	first := sha256.Sum256(message)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// bitcoinSignature encodes R and S as a strict DER signature with low S, as required by
+// Bitcoin's standardness rules, followed by the SIGHASH_ALL type byte. It also returns the
+// 32 byte low S value and whether S was negated, which flips the recovery ID.
+func bitcoinSignature(r, s []byte) (signature, lowS []byte, negated bool, err error) {
+	var rScalar, sScalar btcec.ModNScalar
+	if overflow := rScalar.SetByteSlice(r); overflow || rScalar.IsZero() {
+		return nil, nil, false, fmt.Errorf("invalid signature R value")
+	}
+	if overflow := sScalar.SetByteSlice(s); overflow || sScalar.IsZero() {
+		return nil, nil, false, fmt.Errorf("invalid signature S value")
+	}
+	if sScalar.IsOverHalfOrder() {
+		sScalar.Negate()
+		negated = true
+	}
+	sBytes := sScalar.Bytes()
+
+	der := btcecdsa.NewSignature(&rScalar, &sScalar).Serialize()
+	return append(der, sighashAll), sBytes[:], negated, nil
+}
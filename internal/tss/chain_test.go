@@ -0,0 +1,159 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChainFamily(t *testing.T) {
+	for input, want := range map[string]ChainFamily{
+		"":         ChainFamilyEthereum,
+		"ethereum": ChainFamilyEthereum,
+		"bitcoin":  ChainFamilyBitcoin,
+	} {
+		family, err := parseChainFamily(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, family)
+	}
+	_, err := parseChainFamily("solana")
+	require.ErrorIs(t, err, ErrUnsupportedChainFamily)
+}
+
+func TestKeyAddresses(t *testing.T) {
+	// The generator point is the public key of private key 1
+	addresses, err := keyAddresses(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1)))
+	require.NoError(t, err)
+	require.Equal(t, map[AddressFormat]string{
+		AddressFormatEthereum:  "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf",
+		AddressFormatBTCP2PKH:  "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		AddressFormatBTCP2WPKH: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+	}, addresses)
+}
+
+func TestSigningDigestBitcoin(t *testing.T) {
+	message := []byte("sighash preimage")
+	first := sha256.Sum256(message)
+	want := sha256.Sum256(first[:])
+
+	digest, err := signingDigest(ChainFamilyBitcoin, message, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, want[:], digest)
+
+	_, err = signingDigest(ChainFamilyBitcoin, nil, []byte(mailTypedData), 0)
+	require.ErrorIs(t, err, ErrChainFamilyMismatch)
+	_, err = signingDigest(ChainFamilyBitcoin, message, nil, 1)
+	require.ErrorIs(t, err, ErrChainFamilyMismatch)
+}
+
+func TestBitcoinSignatureIsLowSDER(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	hash := hashMessageForBitcoin([]byte("transaction"))
+
+	// btcec always produces low S signatures
+	der := btcecdsa.Sign(privKey, hash).Serialize()
+	r, s := derRS(t, der)
+	highS := new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(s)).FillBytes(make([]byte, 32))
+
+	for _, tc := range []struct {
+		name    string
+		s       []byte
+		negated bool
+	}{
+		{name: "low S", s: s, negated: false},
+		{name: "high S", s: highS, negated: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			signature, lowS, negated, err := bitcoinSignature(r, tc.s)
+			require.NoError(t, err)
+			require.Equal(t, tc.negated, negated)
+			require.Equal(t, s, lowS)
+			require.Equal(t, byte(sighashAll), signature[len(signature)-1])
+			require.Equal(t, der, signature[:len(signature)-1])
+
+			parsed, err := btcecdsa.ParseDERSignature(signature[:len(signature)-1])
+			require.NoError(t, err)
+			require.True(t, parsed.Verify(hash, privKey.PubKey()))
+		})
+	}
+}
+
+func TestSaveBitcoinSigningResult(t *testing.T) {
+	s, _ := newTestService(t, false)
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	hash := hashMessageForBitcoin([]byte("transaction"))
+	r, sValue := derRS(t, btcecdsa.Sign(privKey, hash).Serialize())
+
+	op := &Operation{
+		ID:      "op-btc",
+		Type:    OperationSigning,
+		Request: &SigningRequest{KeyID: "0xabc", Message: []byte("transaction"), ChainFamily: ChainFamilyBitcoin},
+	}
+	require.NoError(t, s.saveSigningResult(context.Background(), op, &common.SignatureData{
+		R: r, S: sValue, SignatureRecovery: []byte{1},
+	}))
+
+	result := op.Result.(*SigningResult)
+	signature, err := hex.DecodeString(result.Signature[2:])
+	require.NoError(t, err)
+	require.Equal(t, byte(sighashAll), signature[len(signature)-1])
+	parsed, err := btcecdsa.ParseDERSignature(signature[:len(signature)-1])
+	require.NoError(t, err)
+	require.True(t, parsed.Verify(hash, privKey.PubKey()))
+	require.Equal(t, 1, result.V)
+	require.Equal(t, "0x"+hex.EncodeToString(hash), result.MessageDigest)
+	require.Equal(t, HashModeSHA256d, result.HashMode)
+}
+
+func TestKeyChainFamilyStored(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, true)
+
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(1))
+	result := keygen.NewLocalPartySaveData(1)
+	result.ECDSAPub = pub
+	op := &Operation{
+		ID:      "op-btc-keygen",
+		Type:    OperationKeygen,
+		Request: &KeygenRequest{Threshold: 1, Participants: []string{"node1", "node2"}, ChainFamily: ChainFamilyBitcoin},
+	}
+	require.NoError(t, s.saveKeygenResult(ctx, op, &result))
+	keyID := op.Result.(*KeygenResult).KeyID
+
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, ChainFamilyBitcoin, metadata.Family())
+
+	addresses, err := s.KeyAddresses(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", addresses[AddressFormatBTCP2WPKH])
+
+	// Keys stored before chain families existed are Ethereum keys
+	require.Equal(t, ChainFamilyEthereum, (&KeyMetadata{}).Family())
+
+	_, err = s.StartKeygen(ctx, "", 1, []string{"node1", "node2"}, "", "solana", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedChainFamily)
+}
+
+// derRS returns the 32 byte R and S values of a DER signature
+func derRS(t *testing.T, der []byte) (r, s []byte) {
+	t.Helper()
+	require.Equal(t, byte(0x30), der[0])
+	rLen := int(der[3])
+	rValue := new(big.Int).SetBytes(der[4 : 4+rLen])
+	sLen := int(der[5+rLen])
+	sValue := new(big.Int).SetBytes(der[6+rLen : 6+rLen+sLen])
+	return rValue.FillBytes(make([]byte, 32)), sValue.FillBytes(make([]byte, 32))
+}
@@ -0,0 +1,111 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// signingDedupEntry records the operation started for a signing content hash
+type signingDedupEntry struct {
+	operationID string
+	createdAt   time.Time
+}
+
+// signingContentHash returns a hash identifying a signing request of owner by its content:
+// the key, the message digest, the (order independent) participant set and the client
+// metadata, which the validation service may decide on. Requests of different owners are
+// never merged, an operation is only visible to its owner.
+func signingContentHash(owner, keyID string, message []byte, participants []string, metadata map[string]string) (string, error) {
+	participantsHash, err := participantSetHash(participants)
+	if err != nil {
+		return "", err
+	}
+
+	messageDigest := sha256.Sum256(message)
+	h := sha256.New()
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(owner))))
+	h.Write([]byte(owner))
+	h.Write([]byte(keyID))
+	h.Write([]byte{'\n'})
+	h.Write(messageDigest[:])
+	h.Write([]byte{'\n'})
+	h.Write([]byte(participantsHash))
+	// Length prefixes keep distinct metadata from encoding the same
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		for _, field := range []string{key, metadata[key]} {
+			h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field))))
+			h.Write([]byte(field))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// reserveSigningContent returns the live operation already started for contentHash
+// within the dedup TTL. Otherwise it records operationID for contentHash and returns nil.
+// A reservation whose operation is not created yet belongs to a request still being
+// started, ErrSigningInProgress is returned for it.
+func (s *Service) reserveSigningContent(ctx context.Context, contentHash, operationID string) (*Operation, error) {
+	now := time.Now()
+
+	s.mutex.Lock()
+	for hash, entry := range s.signingDedup {
+		if now.Sub(entry.createdAt) > s.signingDedupTTL {
+			delete(s.signingDedup, hash)
+		}
+	}
+	entry, exists := s.signingDedup[contentHash]
+	if !exists {
+		s.signingDedup[contentHash] = signingDedupEntry{operationID: operationID, createdAt: now}
+		s.mutex.Unlock()
+		return nil, nil
+	}
+	s.mutex.Unlock()
+
+	existingOp, err := s.checkIdempotency(ctx, entry.operationID)
+	if err != nil {
+		s.logger.Debug("Deduplicated signing operation not found",
+			zap.String("operation_id", entry.operationID),
+			zap.Error(err))
+		return nil, nil
+	}
+	if existingOp == nil {
+		return nil, fmt.Errorf("%w: operation %s", ErrSigningInProgress, entry.operationID)
+	}
+
+	existingOp.RLock()
+	status := existingOp.Status
+	existingOp.RUnlock()
+
+	// Failed, canceled or timed out operations must not block a retry
+	if status == StatusFailed || status == StatusCancelled || status == StatusTimedOut {
+		s.mutex.Lock()
+		if current, ok := s.signingDedup[contentHash]; ok && current.operationID == entry.operationID {
+			s.signingDedup[contentHash] = signingDedupEntry{operationID: operationID, createdAt: now}
+		}
+		s.mutex.Unlock()
+		return nil, nil
+	}
+
+	s.logger.Info("Returning existing signing operation with identical content",
+		zap.String("operation_id", existingOp.ID),
+		zap.String("status", string(status)))
+	return existingOp, nil
+}
+
+// releaseSigningContent forgets the reservation made for operationID, if it is still current
+func (s *Service) releaseSigningContent(contentHash, operationID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if entry, ok := s.signingDedup[contentHash]; ok && entry.operationID == operationID {
+		delete(s.signingDedup, contentHash)
+	}
+}
@@ -0,0 +1,148 @@
+package tss
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningContentHash(t *testing.T) {
+	h1, err := signingContentHash("", "key", []byte("msg"), []string{"a", "b"}, nil)
+	require.NoError(t, err)
+	h2, err := signingContentHash("", "key", []byte("msg"), []string{"b", "a"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, h1, h2)
+
+	h3, err := signingContentHash("", "key", []byte("other"), []string{"a", "b"}, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h3)
+
+	h4, err := signingContentHash("", "other", []byte("msg"), []string{"a", "b"}, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h4)
+
+	// Requests differing only in metadata are not merged, the order of entries is irrelevant
+	h5, err := signingContentHash("", "key", []byte("msg"), []string{"a", "b"}, map[string]string{"ticket": "1", "team": "a"})
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h5)
+	h6, err := signingContentHash("", "key", []byte("msg"), []string{"a", "b"}, map[string]string{"team": "a", "ticket": "1"})
+	require.NoError(t, err)
+	require.Equal(t, h5, h6)
+	h7, err := signingContentHash("", "key", []byte("msg"), []string{"a", "b"}, map[string]string{"ticket": "1team", "": "a"})
+	require.NoError(t, err)
+	require.NotEqual(t, h5, h7)
+
+	// Identical requests of different owners are not merged
+	h8, err := signingContentHash("alice", "key", []byte("msg"), []string{"a", "b"}, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h8)
+	h9, err := signingContentHash("bob", "key", []byte("msg"), []string{"a", "b"}, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, h8, h9)
+}
+
+func TestReserveSigningContent(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.signingDedupTTL = time.Minute
+	s.signingDedup = make(map[string]signingDedupEntry)
+
+	// First request reserves the content hash
+	existing, err := s.reserveSigningContent(ctx, "hash", "op-1")
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// Identical content is rejected while the first request has not created its operation
+	existing, err = s.reserveSigningContent(ctx, "hash", "op-2")
+	require.ErrorIs(t, err, ErrSigningInProgress)
+	require.Nil(t, existing)
+
+	// Identical content returns the in-flight operation
+	s.operations["op-1"] = &Operation{ID: "op-1", Type: OperationSigning, Status: StatusInProgress}
+	existing, err = s.reserveSigningContent(ctx, "hash", "op-2")
+	require.NoError(t, err)
+	require.Equal(t, "op-1", existing.ID)
+
+	// A failed operation does not block a retry
+	s.operations["op-1"].Status = StatusFailed
+	existing, err = s.reserveSigningContent(ctx, "hash", "op-3")
+	require.NoError(t, err)
+	require.Nil(t, existing)
+	require.Equal(t, "op-3", s.signingDedup["hash"].operationID)
+
+	// Releasing the reservation forgets the content hash
+	s.releaseSigningContent("hash", "op-3")
+	require.NotContains(t, s.signingDedup, "hash")
+}
+
+func TestReserveSigningContentExpires(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.signingDedupTTL = time.Minute
+	s.signingDedup = map[string]signingDedupEntry{
+		"hash": {operationID: "op-1", createdAt: time.Now().Add(-2 * time.Minute)},
+	}
+	s.operations["op-1"] = &Operation{ID: "op-1", Type: OperationSigning, Status: StatusCompleted}
+
+	existing, err := s.reserveSigningContent(ctx, "hash", "op-2")
+	require.NoError(t, err)
+	require.Nil(t, existing)
+	require.Equal(t, "op-2", s.signingDedup["hash"].operationID)
+}
+
+func TestConcurrentDuplicateSigning(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+	s.network = &recordingTransport{recipients: make(map[string]bool)}
+	s.signingDedupTTL = time.Minute
+	s.signingDedup = make(map[string]signingDedupEntry)
+
+	// The Paillier and range proof parameters of a real share keep the first signing round
+	// from failing, the operation then waits for node2, which never answers
+	share := newExternalKeyShare(t, s, 1, "node1", "node2")
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(2)
+	require.NoError(t, err)
+	fixture := fixtures[0]
+	share.Share.LocalPreParams = fixture.LocalPreParams
+	share.Share.NTildej, share.Share.H1j, share.Share.H2j = fixture.NTildej[:2], fixture.H1j[:2], fixture.H2j[:2]
+	share.Share.PaillierPKs = fixture.PaillierPKs[:2]
+	result, err := s.ImportKeyShare(ctx, share)
+	require.NoError(t, err)
+
+	// Duplicates racing the first request get its operation or a conflict, never a panic
+	const requests = 8
+	var (
+		wg   sync.WaitGroup
+		ops  = make([]*Operation, requests)
+		errs = make([]error, requests)
+	)
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ops[i], errs[i] = s.StartSigning(ctx, "", []byte("message"), result.KeyID, []string{"node1", "node2"}, 0, SigningOptions{})
+		}()
+	}
+	wg.Wait()
+
+	started := make(map[string]*Operation)
+	for i := range requests {
+		if errs[i] != nil {
+			require.ErrorIs(t, errs[i], ErrSigningInProgress)
+			continue
+		}
+		started[ops[i].ID] = ops[i]
+	}
+	require.Len(t, started, 1)
+	for _, op := range started {
+		t.Cleanup(op.cancel)
+		// The duplicates were rejected while the operation was live
+		require.Eventually(t, func() bool { return op.Snapshot().Status == StatusInProgress }, 10*time.Second, 10*time.Millisecond)
+		require.Never(t, func() bool { return op.Snapshot().IsCompleted() }, 500*time.Millisecond, 50*time.Millisecond)
+	}
+}
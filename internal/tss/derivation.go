@@ -0,0 +1,141 @@
+package tss
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// maxDerivationDepth bounds the number of child indices of a derivation path
+const maxDerivationDepth = 32
+
+// parseDerivationPath parses a BIP32 derivation path like m/0/1 into its child indices.
+// Hardened indices need the master private key, which no node has, and are rejected.
+func parseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] == "m" {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || len(segments) > maxDerivationDepth {
+		return nil, fmt.Errorf("%w: %q must have 1-%d child indices", ErrInvalidDerivationPath, path, maxDerivationDepth)
+	}
+
+	indices := make([]uint32, len(segments))
+	for i, segment := range segments {
+		if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H") {
+			return nil, fmt.Errorf("%w: hardened index %s is not supported", ErrInvalidDerivationPath, segment)
+		}
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil || index >= ckd.HardenedKeyStart {
+			return nil, fmt.Errorf("%w: invalid child index %q", ErrInvalidDerivationPath, segment)
+		}
+		indices[i] = uint32(index)
+	}
+	return indices, nil
+}
+
+// normalizeDerivationPath validates path and returns it in the m/0/1 form, an empty path
+// selects the master key and is returned as is
+func normalizeDerivationPath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil
+	}
+	indices, err := parseDerivationPath(path)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range indices {
+		b.WriteString("/")
+		b.WriteString(strconv.FormatUint(uint64(index), 10))
+	}
+	return b.String(), nil
+}
+
+// masterChainCode returns the BIP32 chain code of a master key. Keys generated by TSS have
+// no seed to take it from, so it is the SHA-256 hash of the compressed master public key
+// and every participant computes the same chain code.
+func masterChainCode(master *crypto.ECPoint) []byte {
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 + byte(master.Y().Bit(0))
+	master.X().FillBytes(compressed[1:])
+	chainCode := sha256.Sum256(compressed)
+	return chainCode[:]
+}
+
+// deriveChildKey derives the public key at path from the master public key. It also returns
+// the key derivation delta, the child private key is the master private key plus the delta.
+func deriveChildKey(master *crypto.ECPoint, path string) (*big.Int, *crypto.ECPoint, error) {
+	indices, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	curve := tss.S256()
+	extended := &ckd.ExtendedKey{
+		PublicKey: *master.ToECDSAPubKey(),
+		ChainCode: masterChainCode(master),
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+	delta, child, err := ckd.DeriveChildKeyFromHierarchy(indices, extended, curve.Params().N, curve)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive child key at %s: %w", path, err)
+	}
+	childKey, err := crypto.NewECPoint(curve, child.X, child.Y)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive child key at %s: %w", path, err)
+	}
+	return delta, childKey, nil
+}
+
+// deriveSigningKey returns a copy of key whose public key and public shares are those of the
+// child key at path, and the key derivation delta the signing party adds to its private share
+func deriveSigningKey(key *keygen.LocalPartySaveData, path string) (*keygen.LocalPartySaveData, *big.Int, error) {
+	delta, childKey, err := deriveChildKey(key.ECDSAPub, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	derived := []keygen.LocalPartySaveData{*key}
+	// The public shares are replaced in the copy only
+	derived[0].BigXj = slices.Clone(key.BigXj)
+	if err := signing.UpdatePublicKeyAndAdjustBigXj(delta, derived, childKey.ToECDSAPubKey(), tss.S256()); err != nil {
+		return nil, nil, fmt.Errorf("failed to adjust key shares for %s: %w", path, err)
+	}
+	return &derived[0], delta, nil
+}
+
+// setChildKey records the child key a signing operation signed with in its result, if any.
+// The address is the Ethereum address, or the P2WPKH address for Bitcoin keys.
+func setChildKey(result *SigningResult, operation *Operation) error {
+	req, ok := operation.Request.(*SigningRequest)
+	if operation.childKey == nil || !ok {
+		return nil
+	}
+
+	publicKey, address, err := encodePublicKey(operation.childKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode child public key: %w", err)
+	}
+	if req.ChainFamily == ChainFamilyBitcoin {
+		addresses, err := keyAddresses(operation.childKey)
+		if err != nil {
+			return fmt.Errorf("failed to encode child public key: %w", err)
+		}
+		address = addresses[AddressFormatBTCP2WPKH]
+	}
+
+	result.DerivationPath, result.PublicKey, result.Address = req.DerivationPath, publicKey, address
+	return nil
+}
@@ -0,0 +1,128 @@
+package tss
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDerivationPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"":                "",
+		"m/0/1":           "m/0/1",
+		" 0/1 ":           "m/0/1",
+		"m/44/60/0/0/007": "m/44/60/0/0/7",
+		"m/2147483647":    "m/2147483647",
+	} {
+		normalized, err := normalizeDerivationPath(path)
+		require.NoError(t, err, path)
+		require.Equal(t, want, normalized, path)
+	}
+
+	for _, path := range []string{"m", "m/", "m/44'/60", "m/1h", "M/1", "m/-1", "m/abc", "m/2147483648"} {
+		_, err := normalizeDerivationPath(path)
+		require.ErrorIs(t, err, ErrInvalidDerivationPath, path)
+	}
+	_, err := normalizeDerivationPath("m" + strings.Repeat("/0", maxDerivationDepth+1))
+	require.ErrorIs(t, err, ErrInvalidDerivationPath)
+
+}
+
+func TestDeriveChildKeyMatchesBIP32(t *testing.T) {
+	curve := tss.S256()
+	masterPriv, ok := new(big.Int).SetString("5f1e3a9c27b84d0e6a1c93f57d2b08e4c6a3f19d8e7b52c04a6f9e13d27b8c51", 16)
+	require.True(t, ok)
+	master := crypto.ScalarBaseMult(curve, masterPriv)
+
+	// Reference derivation by a regular BIP32 implementation from the master private key
+	// and the chain code the nodes use
+	extended := hdkeychain.NewExtendedKey(chaincfg.MainNetParams.HDPrivateKeyID[:],
+		masterPriv.FillBytes(make([]byte, 32)), masterChainCode(master), []byte{0, 0, 0, 0}, 0, 0, true)
+	indices := []uint32{44, 60, 0, 0, 5}
+	for _, index := range indices {
+		var err error
+		extended, err = extended.Derive(index)
+		require.NoError(t, err)
+	}
+	childPriv, err := extended.ECPrivKey()
+	require.NoError(t, err)
+
+	delta, childKey, err := deriveChildKey(master, "m/44/60/0/0/5")
+	require.NoError(t, err)
+
+	// The nodes derive the same public key and address without the private key
+	_, address, err := encodePublicKey(childKey)
+	require.NoError(t, err)
+	expected := childPriv.ToECDSA()
+	require.Zero(t, expected.X.Cmp(childKey.X()))
+	require.Zero(t, expected.Y.Cmp(childKey.Y()))
+	require.Equal(t, strings.ToLower(ethcrypto.PubkeyToAddress(expected.PublicKey).Hex()), address)
+
+	// The child private key is the master private key plus the delta
+	sum := new(big.Int).Add(masterPriv, delta)
+	require.Zero(t, sum.Mod(sum, curve.Params().N).Cmp(expected.D))
+}
+
+func TestDeriveSigningKey(t *testing.T) {
+	curve := tss.S256()
+	shares := []*big.Int{big.NewInt(11), big.NewInt(22), big.NewInt(33)}
+	key := &keygen.LocalPartySaveData{}
+	key.ECDSAPub = crypto.ScalarBaseMult(curve, big.NewInt(1234))
+	for _, share := range shares {
+		key.BigXj = append(key.BigXj, crypto.ScalarBaseMult(curve, share))
+	}
+	publicShares := append([]*crypto.ECPoint(nil), key.BigXj...)
+
+	derived, delta, err := deriveSigningKey(key, "m/7/8")
+	require.NoError(t, err)
+	_, childKey, err := deriveChildKey(key.ECDSAPub, "m/7/8")
+	require.NoError(t, err)
+	require.True(t, derived.ECDSAPub.Equals(childKey))
+
+	// Every public share moves by the delta, the stored key is left alone
+	for i, share := range shares {
+		adjusted := crypto.ScalarBaseMult(curve, new(big.Int).Add(share, delta))
+		require.True(t, derived.BigXj[i].Equals(adjusted), i)
+		require.Same(t, publicShares[i], key.BigXj[i])
+	}
+	require.True(t, key.ECDSAPub.Equals(crypto.ScalarBaseMult(curve, big.NewInt(1234))))
+
+	_, _, err = deriveSigningKey(key, "m/0'")
+	require.ErrorIs(t, err, ErrInvalidDerivationPath)
+}
+
+func TestSetChildKey(t *testing.T) {
+	_, childKey, err := deriveChildKey(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1234)), "m/1/2")
+	require.NoError(t, err)
+	addresses, err := keyAddresses(childKey)
+	require.NoError(t, err)
+
+	for family, format := range map[ChainFamily]AddressFormat{
+		ChainFamilyEthereum: AddressFormatEthereum,
+		ChainFamilyBitcoin:  AddressFormatBTCP2WPKH,
+	} {
+		operation := &Operation{
+			Request:  &SigningRequest{DerivationPath: "m/1/2", ChainFamily: family},
+			childKey: childKey,
+		}
+		result := &SigningResult{}
+		require.NoError(t, setChildKey(result, operation))
+		require.Equal(t, "m/1/2", result.DerivationPath, family)
+		require.NotEmpty(t, result.PublicKey, family)
+		require.Equal(t, addresses[format], result.Address, family)
+	}
+
+	// Results of master key signatures are left alone
+	result := &SigningResult{}
+	require.NoError(t, setChildKey(result, &Operation{Request: &SigningRequest{}}))
+	require.Empty(t, result.DerivationPath)
+	require.Empty(t, result.Address)
+}
@@ -0,0 +1,102 @@
+package tss
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+// maxEarlyMessages bounds the number of buffered early messages across all sessions
+const maxEarlyMessages = 1024
+
+// earlyMessage is a TSS wire message received before the operation of its session existed
+type earlyMessage struct {
+	msg      *p2p.Message
+	received time.Time
+}
+
+// bufferEarlyMessage returns the operation of the message's session if it exists.
+// Otherwise the message is held for earlyMessageWindow so it can be replayed once the
+// sync message creates the operation, and buffered is true.
+func (s *Service) bufferEarlyMessage(msg *p2p.Message) (operation *Operation, buffered bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Checked under the write lock so the operation cannot be stored and replayed in between
+	if operation = s.findOperationLocked(msg.SessionID); operation != nil {
+		return operation, false
+	}
+
+	now := time.Now()
+	s.pruneEarlyMessagesLocked(now)
+	if s.earlyMessageCount >= maxEarlyMessages {
+		s.logger.Warn("Early message buffer is full, dropping message",
+			zap.String("session_id", msg.SessionID),
+			zap.String("from", msg.From))
+		return nil, false
+	}
+
+	s.earlyMessages[msg.SessionID] = append(s.earlyMessages[msg.SessionID], earlyMessage{msg: msg, received: now})
+	s.earlyMessageCount++
+
+	s.logger.Debug("Buffered message for session without operation",
+		zap.String("session_id", msg.SessionID),
+		zap.String("from", msg.From),
+		zap.Int("buffered", len(s.earlyMessages[msg.SessionID])))
+	return nil, true
+}
+
+// replayEarlyMessages delivers the messages that arrived before the operation was created
+func (s *Service) replayEarlyMessages(ctx context.Context, operation *Operation) {
+	s.mutex.Lock()
+	s.pruneEarlyMessagesLocked(time.Now())
+	pending := s.earlyMessages[operation.SessionID]
+	delete(s.earlyMessages, operation.SessionID)
+	s.earlyMessageCount -= len(pending)
+	s.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	s.logger.Info("Replaying messages received before operation was created",
+		zap.String("operation_id", operation.ID),
+		zap.String("session_id", operation.SessionID),
+		zap.Int("count", len(pending)))
+	for _, early := range pending {
+		if err := s.HandleMessage(ctx, early.msg); err != nil {
+			s.logger.Warn("Failed to replay early message",
+				zap.String("operation_id", operation.ID),
+				zap.String("from", early.msg.From),
+				zap.Error(err))
+		}
+	}
+}
+
+// pruneEarlyMessagesLocked drops buffered messages older than earlyMessageWindow.
+// The caller must hold the write lock.
+func (s *Service) pruneEarlyMessagesLocked(now time.Time) {
+	for sessionID, messages := range s.earlyMessages {
+		kept := messages[:0]
+		for _, early := range messages {
+			if now.Sub(early.received) < s.earlyMessageWindow {
+				kept = append(kept, early)
+			}
+		}
+		if dropped := len(messages) - len(kept); dropped > 0 {
+			s.logger.Warn("Dropping messages for session without operation",
+				zap.String("session_id", sessionID),
+				zap.Int("count", dropped),
+				zap.Duration("window", s.earlyMessageWindow))
+			s.earlyMessageCount -= dropped
+		}
+		if len(kept) == 0 {
+			delete(s.earlyMessages, sessionID)
+			continue
+		}
+		s.earlyMessages[sessionID] = kept
+	}
+}
@@ -0,0 +1,118 @@
+package tss
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+// recordingParty is a tss.Party that records the wire messages it is updated with
+type recordingParty struct {
+	tss.Party
+	updates chan []byte
+}
+
+func (p *recordingParty) Start() *tss.Error {
+	return nil
+}
+
+func (p *recordingParty) UpdateFromBytes(wireBytes []byte, _ *tss.PartyID, _ bool) (bool, *tss.Error) {
+	p.updates <- wireBytes
+	return true, nil
+}
+
+func newRecordingOperation(sessionID string, participants ...string) (*Operation, *recordingParty) {
+	parties := make([]*tss.PartyID, len(participants))
+	for i, id := range participants {
+		parties[i] = tss.NewPartyID(id, id, big.NewInt(int64(i+1)))
+	}
+
+	party := &recordingParty{updates: make(chan []byte, 10)}
+	return &Operation{
+		ID:           "op-" + sessionID,
+		Type:         OperationKeygen,
+		SessionID:    sessionID,
+		Participants: parties,
+		Party:        party,
+		EndCh:        make(chan any, 10),
+	}, party
+}
+
+func TestWireMessageBeforeSyncIsReplayed(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+	s.earlyMessageWindow = 5 * time.Second
+
+	// The first round message arrives before the sync message created the operation
+	msg := &p2p.Message{
+		SessionID: "session-early", Type: string(OperationKeygen), From: "node2", SenderPeerID: "node2", Data: []byte("round1"),
+	}
+	require.NoError(t, s.HandleMessage(context.Background(), msg))
+	require.Equal(t, 1, s.earlyMessageCount)
+
+	operation, party := newRecordingOperation("session-early", "node1", "node2")
+	s.mutex.Lock()
+	s.operations[operation.ID] = operation
+	s.mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.runOperation(ctx, operation)
+
+	select {
+	case data := <-party.updates:
+		require.Equal(t, []byte("round1"), data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("buffered message was not replayed")
+	}
+	require.Zero(t, s.earlyMessageCount)
+	require.Empty(t, s.earlyMessages)
+
+	// Once the operation exists messages are delivered directly
+	require.NoError(t, s.HandleMessage(context.Background(), &p2p.Message{
+		SessionID: "session-early", Type: string(OperationKeygen), From: "node2", SenderPeerID: "node2", Data: []byte("round2"),
+	}))
+	select {
+	case data := <-party.updates:
+		require.Equal(t, []byte("round2"), data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not delivered")
+	}
+	require.Zero(t, s.earlyMessageCount)
+}
+
+func TestEarlyMessagesExpire(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+	s.earlyMessageWindow = 50 * time.Millisecond
+
+	msg := &p2p.Message{
+		SessionID: "session-stale", Type: string(OperationKeygen), From: "node2", SenderPeerID: "node2", Data: []byte("round1"),
+	}
+	require.NoError(t, s.HandleMessage(context.Background(), msg))
+	require.Equal(t, 1, s.earlyMessageCount)
+
+	time.Sleep(100 * time.Millisecond)
+
+	operation, party := newRecordingOperation("session-stale", "node1", "node2")
+	s.replayEarlyMessages(context.Background(), operation)
+	require.Empty(t, party.updates)
+	require.Zero(t, s.earlyMessageCount)
+}
+
+func TestEarlyMessageBufferIsBounded(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.earlyMessageWindow = time.Minute
+
+	for range maxEarlyMessages {
+		require.NoError(t, s.HandleMessage(context.Background(), &p2p.Message{SessionID: "session-flood", From: "node2"}))
+	}
+	require.Error(t, s.HandleMessage(context.Background(), &p2p.Message{SessionID: "session-flood", From: "node2"}))
+	require.Equal(t, maxEarlyMessages, s.earlyMessageCount)
+}
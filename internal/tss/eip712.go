@@ -0,0 +1,160 @@
+package tss
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// eip712DomainType is the reserved type name of the EIP-712 domain
+const eip712DomainType = "EIP712Domain"
+
+// hashTypedData validates EIP-712 typed data JSON and returns the digest to sign,
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+func hashTypedData(data []byte) ([]byte, error) {
+	typedData, err := parseTypedData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTypedData, err)
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTypedData, err)
+	}
+	return hash, nil
+}
+
+// parseTypedData decodes typed data and checks it strictly: unknown fields, undeclared or
+// missing struct members and domain fields that do not match EIP712Domain are rejected
+func parseTypedData(data []byte) (*apitypes.TypedData, error) {
+	var raw struct {
+		Types       apitypes.Types           `json:"types"`
+		PrimaryType string                   `json:"primaryType"`
+		Domain      apitypes.TypedDataDomain `json:"domain"`
+		Message     json.RawMessage          `json:"message"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid typed data JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after typed data JSON")
+	}
+
+	// Keep integers exact, the encoder parses decimal strings but only small float64 values
+	msgDec := json.NewDecoder(bytes.NewReader(raw.Message))
+	msgDec.UseNumber()
+	var message map[string]any
+	if err := msgDec.Decode(&message); err != nil {
+		return nil, fmt.Errorf("invalid typed data message: %w", err)
+	}
+	if message == nil {
+		return nil, fmt.Errorf("typed data message is required")
+	}
+
+	if _, ok := raw.Types[eip712DomainType]; !ok {
+		return nil, fmt.Errorf("types must define %s", eip712DomainType)
+	}
+	if raw.PrimaryType == "" || raw.PrimaryType == eip712DomainType {
+		return nil, fmt.Errorf("invalid primary type %q", raw.PrimaryType)
+	}
+	if _, ok := raw.Types[raw.PrimaryType]; !ok {
+		return nil, fmt.Errorf("primary type %q is not defined", raw.PrimaryType)
+	}
+
+	typedData := &apitypes.TypedData{
+		Types:       raw.Types,
+		PrimaryType: raw.PrimaryType,
+		Domain:      raw.Domain,
+		Message:     numbersToStrings(message).(map[string]any),
+	}
+
+	if err := checkStructFields(typedData.Types, eip712DomainType, typedData.Domain.Map()); err != nil {
+		return nil, fmt.Errorf("domain: %w", err)
+	}
+	if err := checkStructFields(typedData.Types, typedData.PrimaryType, typedData.Message); err != nil {
+		return nil, fmt.Errorf("message: %w", err)
+	}
+	return typedData, nil
+}
+
+// checkStructFields verifies that data holds exactly the members declared for typeName,
+// descending into nested struct values
+func checkStructFields(types apitypes.Types, typeName string, data map[string]any) error {
+	fields := types[typeName]
+	for name := range data {
+		if !slices.ContainsFunc(fields, func(f apitypes.Type) bool { return f.Name == name }) {
+			return fmt.Errorf("field %q is not declared in type %s", name, typeName)
+		}
+	}
+
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return fmt.Errorf("field %q of type %s is missing", field.Name, typeName)
+		}
+
+		baseType, _, isArray := strings.Cut(field.Type, "[")
+		if _, isStruct := types[baseType]; !isStruct {
+			continue
+		}
+
+		values := []any{value}
+		if isArray {
+			if values, ok = flattenArray(value); !ok {
+				return fmt.Errorf("field %q of type %s must be an array", field.Name, typeName)
+			}
+		}
+		for _, v := range values {
+			nested, ok := v.(map[string]any)
+			if !ok {
+				return fmt.Errorf("field %q of type %s must be a %s object", field.Name, typeName, baseType)
+			}
+			if err := checkStructFields(types, baseType, nested); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flattenArray returns the elements of a possibly multi-dimensional array
+func flattenArray(v any) ([]any, bool) {
+	items, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+
+	var out []any
+	for _, item := range items {
+		if nested, ok := item.([]any); ok {
+			flat, _ := flattenArray(nested)
+			out = append(out, flat...)
+			continue
+		}
+		out = append(out, item)
+	}
+	return out, true
+}
+
+// numbersToStrings replaces json.Number values with their decimal string form
+func numbersToStrings(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]any:
+		for k, item := range v {
+			v[k] = numbersToStrings(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = numbersToStrings(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,89 @@
+package tss
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mailTypedData is the example from the EIP-712 specification
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestHashTypedData(t *testing.T) {
+	hash, err := hashTypedData([]byte(mailTypedData))
+	require.NoError(t, err)
+	require.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	digest, err := signingDigest(ChainFamilyEthereum, nil, []byte(mailTypedData), 0)
+	require.NoError(t, err)
+	require.Equal(t, hash, digest)
+}
+
+func TestHashTypedDataRejectsMalformed(t *testing.T) {
+	cases := map[string]string{
+		"unknown top level field": strings.Replace(mailTypedData, `"primaryType"`, `"extra": 1, "primaryType"`, 1),
+		"undeclared field":        strings.Replace(mailTypedData, `"contents": "Hello, Bob!"`, `"contents": "Hello, Bob!", "cc": "Alice"`, 1),
+		"renamed field":           strings.Replace(mailTypedData, `"wallet": "0xCD2a`, `"wallet2": "0xCD2a`, 1),
+		"missing field": strings.Replace(mailTypedData,
+			`"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},`, "", 1),
+		"undeclared nested field": strings.Replace(mailTypedData, `{"name": "Bob", `, `{"name": "Bob", "age": 3, `, 1),
+		"undefined primary type":  strings.Replace(mailTypedData, `"primaryType": "Mail"`, `"primaryType": "Letter"`, 1),
+		"domain primary type":     strings.Replace(mailTypedData, `"primaryType": "Mail"`, `"primaryType": "EIP712Domain"`, 1),
+		"missing domain type":     strings.Replace(mailTypedData, `"EIP712Domain"`, `"Domain"`, 1),
+		"undefined reference":     strings.Replace(mailTypedData, `"type": "Person"}`, `"type": "Human"}`, 1),
+		"invalid address":         strings.Replace(mailTypedData, `0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB`, `0xbB`, 1),
+		"trailing data":           mailTypedData + `{}`,
+		"not json":                `types: {}`,
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := hashTypedData([]byte(data))
+			require.ErrorIs(t, err, ErrInvalidTypedData)
+		})
+	}
+}
+
+func TestSigningDigestRequiresOneMessage(t *testing.T) {
+	_, err := signingDigest(ChainFamilyEthereum, nil, nil, 0)
+	require.Error(t, err)
+
+	_, err = signingDigest(ChainFamilyEthereum, []byte("message"), []byte(mailTypedData), 0)
+	require.Error(t, err)
+
+	digest, err := signingDigest(ChainFamilyEthereum, []byte("message"), nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, hashMessageForEthereum([]byte("message")), digest)
+}
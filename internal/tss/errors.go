@@ -0,0 +1,125 @@
+package tss
+
+import "errors"
+
+var (
+	// ErrPublicKeyChanged is returned when a resharing produces a group public key
+	// that differs from the key that was reshared
+	ErrPublicKeyChanged = errors.New("public key changed after resharing")
+
+	// ErrParticipantSetMismatch is returned when the locally built participant set
+	// differs from the one the initiator announced
+	ErrParticipantSetMismatch = errors.New("participant set mismatch")
+
+	// ErrInvalidTypedData is returned for malformed EIP-712 typed data
+	ErrInvalidTypedData = errors.New("invalid typed data")
+
+	// ErrSyncNotAcknowledged is returned when participants did not acknowledge an
+	// operation sync message in time
+	ErrSyncNotAcknowledged = errors.New("operation sync not acknowledged")
+
+	// ErrJoinQuorumNotReached is returned when too few participants joined a keygen
+	// operation within the join timeout
+	ErrJoinQuorumNotReached = errors.New("join quorum not reached")
+
+	// ErrParticipantBusy is returned when a participant rejected an operation because it
+	// already runs as many operations as its concurrency limit allows
+	ErrParticipantBusy = errors.New("participant at its concurrent operation limit")
+
+	// ErrSigningInProgress is returned for a signing request whose identical content another
+	// request is still starting, the client may retry once the first request returned
+	ErrSigningInProgress = errors.New("identical signing request is being started")
+
+	// ErrOperationNotFound is returned when an operation is not active on this node
+	ErrOperationNotFound = errors.New("operation not found")
+
+	// ErrOperationFinished is returned when canceling an operation that already finished
+	ErrOperationFinished = errors.New("operation already finished")
+
+	// ErrOperationCancelled is returned when awaiting an operation that was canceled
+	ErrOperationCancelled = errors.New("operation canceled")
+
+	// ErrOperationTimedOut is returned when awaiting an operation that did not finish within
+	// its timeout
+	ErrOperationTimedOut = errors.New("operation timed out")
+
+	// ErrInvalidKeyAlias is returned for malformed key aliases
+	ErrInvalidKeyAlias = errors.New("invalid key alias")
+
+	// ErrKeyAliasExists is returned when a key alias is already used by another key
+	ErrKeyAliasExists = errors.New("key alias already exists")
+
+	// ErrKeyExists is returned when importing a key share of a key this node already holds,
+	// or when a keygen would store its key under the ID of one
+	ErrKeyExists = errors.New("key already exists")
+
+	// ErrInvalidKeyShare is returned for imported key shares that are malformed or
+	// inconsistent with the claimed key
+	ErrInvalidKeyShare = errors.New("invalid key share")
+
+	// ErrKeyAliasNotFound is returned when no key is registered under an alias
+	ErrKeyAliasNotFound = errors.New("key alias not found")
+
+	// ErrUnsupportedChainFamily is returned for keygen requests with an unknown chain family
+	ErrUnsupportedChainFamily = errors.New("unsupported chain family")
+
+	// ErrUnsupportedAlgorithm is returned for keygen requests with a signature algorithm
+	// that is unknown or not provided by the TSS library
+	ErrUnsupportedAlgorithm = errors.New("unsupported signature algorithm")
+
+	// ErrChainFamilyMismatch is returned for signing requests that do not apply to the
+	// key's chain family, such as typed data or a chain ID for a Bitcoin key
+	ErrChainFamilyMismatch = errors.New("request does not match the key's chain family")
+
+	// ErrInvalidSigningMetadata is returned when client supplied signing metadata exceeds its bounds
+	ErrInvalidSigningMetadata = errors.New("invalid signing metadata")
+
+	// ErrInvalidDerivationPath is returned for malformed or hardened BIP32 derivation paths
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+	// ErrInvalidLabels is returned for operation labels or label selectors that exceed their
+	// bounds or contain invalid characters
+	ErrInvalidLabels = errors.New("invalid operation labels")
+
+	// ErrInvalidPageToken is returned for a ListOperations page token no listing returned
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	// ErrMessageTooLarge is returned for signing requests exceeding the configured message size
+	ErrMessageTooLarge = errors.New("message too large")
+
+	// ErrNotParticipant is returned when a request that this node would take part in does
+	// not list it as a participant, e.g. when a client sends it to the wrong node
+	ErrNotParticipant = errors.New("this node is not a participant")
+
+	// ErrKeyNotHeld is returned for signing requests for a key this node holds no share of
+	ErrKeyNotHeld = errors.New("key not held by this node")
+
+	// ErrInvalidParticipants is returned when the participants of a request name the same node
+	// twice, cannot sign with the key: too few or too many, or not holding a share, or are
+	// too few for the new threshold of a resharing
+	ErrInvalidParticipants = errors.New("invalid participants")
+
+	// ErrThresholdMismatch is returned when the threshold an initiator announced for a signing
+	// operation differs from the threshold of the stored key
+	ErrThresholdMismatch = errors.New("threshold mismatch")
+
+	// ErrMaintenanceMode is returned for new operations while the node is in maintenance mode
+	ErrMaintenanceMode = errors.New("node is in maintenance mode")
+
+	// ErrReadOnly is returned for operations and other writes on a read-only replica
+	ErrReadOnly = errors.New("node is a read-only replica")
+
+	// ErrInvalidKeyPolicy is returned for key policies with unknown hash modes, malformed
+	// roles or validation URL, or negative limits
+	ErrInvalidKeyPolicy = errors.New("invalid key policy")
+
+	// ErrKeyPolicyViolation is returned for signing requests the policy of the key forbids
+	ErrKeyPolicyViolation = errors.New("signing request violates the key policy")
+
+	// ErrKeyDataTampered is returned for stored key data that does not match its integrity tag
+	ErrKeyDataTampered = errors.New("key data failed integrity check")
+
+	// ErrEncryptionPasswordMismatch is returned at startup when the encryption password
+	// cannot decrypt the data the node stored with it
+	ErrEncryptionPasswordMismatch = errors.New("encryption password does not match stored keys")
+)
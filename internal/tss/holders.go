@@ -0,0 +1,213 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+const (
+	// keyHoldersQuery is the type of queries asking a node what it holds of a key
+	keyHoldersQuery = "key_holders"
+	// keyHoldersQueryTimeout bounds how long FindKeyHolders waits for a single node
+	keyHoldersQueryTimeout = 5 * time.Second
+)
+
+// keyHoldersRequest is the data of a key holders query
+type keyHoldersRequest struct {
+	KeyID string `json:"key_id"`
+}
+
+// keyHoldersAnswer is what a node holds of a key, with the committee it stores for the key
+type keyHoldersAnswer struct {
+	Known        bool     `json:"known"`
+	HasShare     bool     `json:"has_share"`
+	KeyID        string   `json:"key_id,omitempty"`
+	Threshold    int      `json:"threshold,omitempty"`
+	Participants []string `json:"participants,omitempty"`
+}
+
+// KeyHolders describes which nodes hold a share of a key, as far as they could be reached
+type KeyHolders struct {
+	// KeyID is the ID of the key, empty when no reachable node knows it
+	KeyID string
+	// Threshold and Participants describe the committee of the key
+	Threshold    int
+	Participants []string
+	// Holders are the reachable participants holding a share, in participant order
+	Holders []string
+	// Unreachable are the participants that did not answer
+	Unreachable []string
+}
+
+// QuorumAvailable reports whether enough holders are reachable to sign with the key
+func (h *KeyHolders) QuorumAvailable() bool {
+	return h.KeyID != "" && len(h.Holders) > h.Threshold
+}
+
+// answerKeyHolders returns what this node holds of the key keyIDOrAlias
+func (s *Service) answerKeyHolders(ctx context.Context, keyIDOrAlias string) (*keyHoldersAnswer, error) {
+	keyID, err := s.ResolveKeyID(ctx, keyIDOrAlias)
+	if errors.Is(err, ErrKeyAliasNotFound) {
+		return &keyHoldersAnswer{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &keyHoldersAnswer{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyHoldersAnswer{
+		Known:        true,
+		HasShare:     slices.Contains(metadata.Participants, s.nodeID),
+		KeyID:        keyID,
+		Threshold:    metadata.Threshold,
+		Participants: metadata.Participants,
+	}, nil
+}
+
+// HandleQuery implements p2p.QueryHandler
+func (s *Service) HandleQuery(ctx context.Context, from string, query *p2p.Query) ([]byte, error) {
+	switch query.Type {
+	case keyHoldersQuery:
+		var req keyHoldersRequest
+		if err := json.Unmarshal(query.Data, &req); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal key holders query: %w", err)
+		}
+		answer, err := s.answerKeyHolders(ctx, req.KeyID)
+		if err != nil {
+			s.logger.Warn("Failed to answer key holders query", zap.String("from", from), zap.Error(err))
+			return nil, fmt.Errorf("failed to look up key")
+		}
+		return json.Marshal(answer)
+	default:
+		return nil, fmt.Errorf("unknown query type %q", query.Type)
+	}
+}
+
+// FindKeyHolders asks this node and the nodes it is connected to, as well as the key's
+// participants, what they hold of the key keyIDOrAlias. The committee of the key is taken
+// from this node when it knows the key, otherwise from the first node that does.
+func (s *Service) FindKeyHolders(ctx context.Context, keyIDOrAlias string) (*KeyHolders, error) {
+	local, err := s.answerKeyHolders(ctx, keyIDOrAlias)
+	if err != nil {
+		return nil, err
+	}
+	answers := map[string]*keyHoldersAnswer{s.nodeID: local}
+	failed := make(map[string]error)
+
+	if querier, ok := s.network.(p2p.Querier); ok {
+		if local.Known {
+			keyIDOrAlias = local.KeyID
+		}
+		s.queryKeyHolders(ctx, querier, slices.Concat(querier.Peers(), local.Participants), keyIDOrAlias, answers, failed)
+
+		// Participants of a key this node does not know are only learned from the answers
+		if committee := keyCommittee(answers); committee != nil && !local.Known {
+			s.queryKeyHolders(ctx, querier, committee.Participants, committee.KeyID, answers, failed)
+		}
+	}
+
+	committee := local
+	if !local.Known {
+		committee = keyCommittee(answers)
+	}
+	if committee == nil {
+		return &KeyHolders{}, nil
+	}
+	holders := &KeyHolders{
+		KeyID:        committee.KeyID,
+		Threshold:    committee.Threshold,
+		Participants: committee.Participants,
+	}
+	for _, participant := range committee.Participants {
+		if answer, ok := answers[participant]; ok {
+			if answer.HasShare && answer.KeyID == committee.KeyID {
+				holders.Holders = append(holders.Holders, participant)
+			}
+		} else if _, ok := failed[participant]; ok {
+			holders.Unreachable = append(holders.Unreachable, participant)
+		}
+	}
+	return holders, nil
+}
+
+// keyCommittee returns the answer of the first node in node ID order that knows the key,
+// nil when no node knows it
+func keyCommittee(answers map[string]*keyHoldersAnswer) *keyHoldersAnswer {
+	for _, nodeID := range slices.Sorted(maps.Keys(answers)) {
+		if answers[nodeID].Known {
+			return answers[nodeID]
+		}
+	}
+	return nil
+}
+
+// queryKeyHolders queries the nodes not asked yet for the key keyID, recording their answers
+// in answers and the nodes that could not be queried in failed
+func (s *Service) queryKeyHolders(
+	ctx context.Context,
+	querier p2p.Querier,
+	nodeIDs []string,
+	keyID string,
+	answers map[string]*keyHoldersAnswer,
+	failed map[string]error,
+) {
+	data, err := json.Marshal(&keyHoldersRequest{KeyID: keyID})
+	if err != nil {
+		return
+	}
+	query := &p2p.Query{Type: keyHoldersQuery, Data: data}
+
+	pending := slices.DeleteFunc(common.Distinct(nodeIDs), func(nodeID string) bool {
+		_, answered := answers[nodeID]
+		_, queried := failed[nodeID]
+		return answered || queried
+	})
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+	)
+	for _, nodeID := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queryCtx, cancel := context.WithTimeout(ctx, keyHoldersQueryTimeout)
+			defer cancel()
+
+			var answer keyHoldersAnswer
+			response, err := querier.Query(queryCtx, nodeID, query)
+			if err == nil {
+				err = json.Unmarshal(response, &answer)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				s.logger.Debug("Failed to query key holders", zap.String("node_id", nodeID), zap.Error(err))
+				failed[nodeID] = err
+				return
+			}
+			answers[nodeID] = &answer
+		}()
+	}
+	wg.Wait()
+}
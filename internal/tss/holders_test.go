@@ -0,0 +1,65 @@
+package tss
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+func TestFindKeyHolders(t *testing.T) {
+	ctx := context.Background()
+	hub := p2p.NewMemoryHub()
+
+	var (
+		services   []*Service
+		transports []*p2p.MemoryTransport
+	)
+	for range 4 {
+		transport, err := hub.NewTransport(zap.NewNop())
+		require.NoError(t, err)
+		store := storage.NewMemoryStorage()
+		t.Cleanup(func() { _ = store.Close() })
+		s, err := NewService(&Config{PeerID: transport.GetHostID(), KDF: testKDF}, store, transport, zap.NewNop(), "test-password")
+		require.NoError(t, err)
+		services = append(services, s)
+		transports = append(transports, transport)
+	}
+
+	// The first three nodes hold a 2-of-3 key, the fourth knows nothing of it
+	keyID := "0x1111111111111111111111111111111111111111"
+	participants := []string{transports[0].GetHostID(), transports[1].GetHostID(), transports[2].GetHostID()}
+	for _, s := range services[:3] {
+		require.NoError(t, s.saveKeyData(ctx, keyID, keyID, &keygen.LocalPartySaveData{}, 1, participants, 0, "treasury", "", nil))
+		require.NoError(t, s.saveKeyAlias(ctx, "treasury", keyID))
+	}
+
+	holders, err := services[3].FindKeyHolders(ctx, "treasury")
+	require.NoError(t, err)
+	require.Equal(t, keyID, holders.KeyID)
+	require.Equal(t, 1, holders.Threshold)
+	require.Equal(t, participants, holders.Participants)
+	require.Equal(t, participants, holders.Holders)
+	require.Empty(t, holders.Unreachable)
+	require.True(t, holders.QuorumAvailable())
+
+	// With two participants gone the remaining one cannot sign alone
+	require.NoError(t, transports[1].Stop())
+	require.NoError(t, transports[2].Stop())
+	holders, err = services[0].FindKeyHolders(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, participants[:1], holders.Holders)
+	require.Equal(t, participants[1:], holders.Unreachable)
+	require.False(t, holders.QuorumAvailable())
+
+	// No reachable node knows the key
+	holders, err = services[3].FindKeyHolders(ctx, "0x2222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	require.Empty(t, holders.KeyID)
+	require.False(t, holders.QuorumAvailable())
+}
@@ -0,0 +1,265 @@
+package tss
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// ExternalKeyShare is this node's share of a key generated by another threshold signature
+// system. The share is the JSON encoding of the tss-lib v2 ECDSA keygen save data. Shares
+// are bound to the party keys they were generated for, they must have been generated (or
+// reshared in the other system) for the party keys DKNet derives from the peer IDs.
+type ExternalKeyShare struct {
+	// Threshold is the polynomial degree, threshold+1 parties sign
+	Threshold int `json:"threshold"`
+	// Participants are the peer IDs or node names of every party holding a share
+	Participants []string `json:"participants"`
+	// PublicKey is the hex encoded group public key, uncompressed with or without the 04
+	// prefix or compressed
+	PublicKey string `json:"public_key"`
+	// Alias and ChainFamily are registered for the key as for a generated key
+	Alias       string      `json:"alias,omitempty"`
+	ChainFamily ChainFamily `json:"chain_family,omitempty"`
+	// Policy is stored with the key, see KeyPolicy
+	Policy *KeyPolicy `json:"policy,omitempty"`
+	// Share is the keygen save data of this node's party
+	Share *keygen.LocalPartySaveData `json:"share"`
+}
+
+// ImportKeyShare validates a key share generated by another threshold signature system and
+// stores it encrypted under the key ID derived from its public key
+func (s *Service) ImportKeyShare(ctx context.Context, share *ExternalKeyShare) (*KeygenResult, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+	participants, err := s.resolveParticipants(share.Participants)
+	if err != nil {
+		return nil, err
+	}
+	chainFamily, err := parseChainFamily(string(share.ChainFamily))
+	if err != nil {
+		return nil, err
+	}
+	policy, err := share.Policy.normalize()
+	if err != nil {
+		return nil, err
+	}
+	if share.Alias != "" {
+		if err := s.checkKeyAliasAvailable(ctx, share.Alias); err != nil {
+			return nil, err
+		}
+	}
+
+	pub, err := parseExternalPublicKey(share.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateExternalKeyShare(share.Share, share.Threshold, participants, pub); err != nil {
+		return nil, err
+	}
+	// Shares of systems that do not record the group key get the claimed one
+	share.Share.ECDSAPub = pub
+
+	publicKeyHex, address, err := encodePublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	existingID, err := s.resolveKeyAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.storage.Load(ctx, existingID); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeyExists, existingID)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check for existing key: %w", err)
+	}
+	keyID := s.newKeyID()
+	if keyID == "" {
+		keyID = address
+	}
+
+	if share.Alias != "" {
+		if err := s.saveKeyAlias(ctx, share.Alias, keyID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.saveKeyData(ctx, keyID, address, share.Share, share.Threshold, participants, 0, share.Alias, chainFamily, policy); err != nil {
+		if share.Alias != "" {
+			if delErr := s.storage.Delete(ctx, keyAliasStorageKey(share.Alias)); delErr != nil {
+				s.logger.Warn("Failed to remove alias of unsaved key", zap.String("alias", share.Alias), zap.Error(delErr))
+			}
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Imported external key share",
+		zap.String("key_id", keyID),
+		zap.Int("threshold", share.Threshold),
+		zap.Strings("participants", participants))
+
+	return &KeygenResult{
+		PublicKey: publicKeyHex,
+		KeyID:     keyID,
+		Alias:     share.Alias,
+		Address:   address,
+	}, nil
+}
+
+// parseExternalPublicKey parses a hex encoded secp256k1 public key
+func parseExternalPublicKey(publicKey string) (*crypto.ECPoint, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key is not hex encoded", ErrInvalidKeyShare)
+	}
+	// The X||Y encoding of key results lacks the uncompressed prefix
+	if len(raw) == 64 {
+		raw = append([]byte{0x04}, raw...)
+	}
+	key, err := btcec.ParsePubKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid public key: %w", ErrInvalidKeyShare, err)
+	}
+	pub, err := crypto.NewECPoint(tss.S256(), key.X(), key.Y())
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid public key: %w", ErrInvalidKeyShare, err)
+	}
+	return pub, nil
+}
+
+// validateExternalKeyShare checks the share belongs to this node, is consistent with the
+// claimed public key and can be used with the party keys of the participants
+func (s *Service) validateExternalKeyShare(
+	share *keygen.LocalPartySaveData,
+	threshold int,
+	participants []string,
+	pub *crypto.ECPoint,
+) error {
+	n := len(participants)
+	switch {
+	case share == nil:
+		return fmt.Errorf("%w: share is missing", ErrInvalidKeyShare)
+	case n < 2:
+		return fmt.Errorf("%w: at least two participants are required", ErrInvalidKeyShare)
+	case threshold < 1 || threshold >= n:
+		return fmt.Errorf("%w: threshold must be between 1 and %d", ErrInvalidKeyShare, n-1)
+	case !slices.Contains(participants, s.nodeID):
+		return fmt.Errorf("%w: this node (%s) is not a participant", ErrInvalidKeyShare, s.nodeID)
+	case !share.ValidateWithProof():
+		return fmt.Errorf("%w: share lacks the Paillier key or range proof parameters", ErrInvalidKeyShare)
+	}
+	if len(share.Ks) != n || len(share.NTildej) != n || len(share.H1j) != n || len(share.H2j) != n ||
+		len(share.BigXj) != n || len(share.PaillierPKs) != n {
+		return fmt.Errorf("%w: share does not hold the parameters of %d participants", ErrInvalidKeyShare, n)
+	}
+
+	// Signing derives the party keys from the peer IDs, the share must use the same ones.
+	// Every participant's key is matched once, so the keys are distinct modulo N.
+	curveN := tss.S256().Params().N
+	partyKey := func(k *big.Int) string {
+		return string(new(big.Int).Mod(k, curveN).Bytes())
+	}
+	expected := make(map[string]string, n)
+	for _, peerID := range participants {
+		expected[partyKey(s.generateDeterministicKey(peerID))] = peerID
+	}
+	own := -1
+	for j, k := range share.Ks {
+		if k == nil {
+			return fmt.Errorf("%w: party key %d is missing", ErrInvalidKeyShare, j)
+		}
+		peerID, ok := expected[partyKey(k)]
+		if !ok {
+			return fmt.Errorf("%w: party key %d does not belong to a participant, "+
+				"reshare the key to the participants' party keys first", ErrInvalidKeyShare, j)
+		}
+		delete(expected, partyKey(k))
+		if peerID == s.nodeID {
+			own = j
+		}
+		if share.NTildej[j] == nil || share.H1j[j] == nil || share.H2j[j] == nil ||
+			share.PaillierPKs[j] == nil || share.PaillierPKs[j].N == nil {
+			return fmt.Errorf("%w: range proof parameters of party %d are missing", ErrInvalidKeyShare, j)
+		}
+	}
+	if own == -1 || share.ShareID == nil || share.ShareID.Cmp(share.Ks[own]) != 0 {
+		return fmt.Errorf("%w: share ID is not this node's party key", ErrInvalidKeyShare)
+	}
+
+	// This node's public parameters must be those of its secrets
+	if share.PaillierSK.N == nil || share.PaillierPKs[own].N.Cmp(share.PaillierSK.N) != 0 || share.NTildej[own].Cmp(share.NTildei) != 0 ||
+		share.H1j[own].Cmp(share.H1i) != 0 || share.H2j[own].Cmp(share.H2i) != 0 {
+		return fmt.Errorf("%w: public parameters of this node do not match its secrets", ErrInvalidKeyShare)
+	}
+
+	bigXj := make([]*crypto.ECPoint, n)
+	for j, point := range share.BigXj {
+		if !point.ValidateBasic() {
+			return fmt.Errorf("%w: public key share %d is not a secp256k1 point", ErrInvalidKeyShare, j)
+		}
+		var err error
+		if bigXj[j], err = crypto.NewECPoint(tss.S256(), point.X(), point.Y()); err != nil {
+			return fmt.Errorf("%w: public key share %d is not a secp256k1 point", ErrInvalidKeyShare, j)
+		}
+	}
+	if share.Xi == nil || share.Xi.Sign() <= 0 || share.Xi.Cmp(curveN) >= 0 {
+		return fmt.Errorf("%w: secret share is out of range", ErrInvalidKeyShare)
+	}
+	if !crypto.ScalarBaseMult(tss.S256(), share.Xi).Equals(bigXj[own]) {
+		return fmt.Errorf("%w: secret share does not match its public key share", ErrInvalidKeyShare)
+	}
+	if share.ECDSAPub != nil && (!share.ECDSAPub.ValidateBasic() ||
+		share.ECDSAPub.X().Cmp(pub.X()) != 0 || share.ECDSAPub.Y().Cmp(pub.Y()) != 0) {
+		return fmt.Errorf("%w: share is of another public key", ErrInvalidKeyShare)
+	}
+
+	// The public key shares must lie on one polynomial of degree threshold whose value at
+	// zero is the public key. Interpolate it from the first threshold+1 shares.
+	modN := common.ModInt(curveN)
+	basis := share.Ks[:threshold+1]
+	interpolate := func(x *big.Int) (*crypto.ECPoint, error) {
+		var sum *crypto.ECPoint
+		for i, ki := range basis {
+			coefficient := big.NewInt(1)
+			for m, km := range basis {
+				if m == i {
+					continue
+				}
+				coefficient = modN.Mul(coefficient, modN.Mul(modN.Sub(x, km), modN.ModInverse(modN.Sub(ki, km))))
+			}
+			term := bigXj[i].ScalarMult(coefficient)
+			if sum == nil {
+				sum = term
+				continue
+			}
+			var err error
+			if sum, err = sum.Add(term); err != nil {
+				return nil, err
+			}
+		}
+		return sum, nil
+	}
+
+	if point, err := interpolate(big.NewInt(0)); err != nil || !point.Equals(pub) {
+		return fmt.Errorf("%w: public key shares do not interpolate to the public key", ErrInvalidKeyShare)
+	}
+	for j := threshold + 1; j < n; j++ {
+		if point, err := interpolate(share.Ks[j]); err != nil || !point.Equals(bigXj[j]) {
+			return fmt.Errorf("%w: public key share %d is inconsistent with the threshold", ErrInvalidKeyShare, j)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package tss
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+)
+
+// newExternalKeyShare deals Shamir shares of a random key to the participants and returns
+// the share of the first one. The Paillier and range proof parameters are placeholders,
+// import only checks their presence and consistency.
+func newExternalKeyShare(t *testing.T, s *Service, threshold int, participants ...string) *ExternalKeyShare {
+	t.Helper()
+
+	curveN := tss.S256().Params().N
+	modN := common.ModInt(curveN)
+	coefficients := make([]*big.Int, threshold+1)
+	for i := range coefficients {
+		coefficients[i] = common.GetRandomPositiveInt(rand.Reader, curveN)
+	}
+	evaluate := func(x *big.Int) *big.Int {
+		y := big.NewInt(0)
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			y = modN.Add(modN.Mul(y, x), coefficients[i])
+		}
+		return y
+	}
+
+	n := len(participants)
+	save := keygen.NewLocalPartySaveData(n)
+	for j, peerID := range participants {
+		save.Ks[j] = s.generateDeterministicKey(peerID)
+		save.BigXj[j] = crypto.ScalarBaseMult(tss.S256(), evaluate(save.Ks[j]))
+		save.NTildej[j], save.H1j[j], save.H2j[j] = big.NewInt(int64(100+j)), big.NewInt(2), big.NewInt(3)
+		save.PaillierPKs[j] = &paillier.PublicKey{N: big.NewInt(int64(200 + j))}
+	}
+	save.Xi = evaluate(save.Ks[0])
+	save.ShareID = save.Ks[0]
+	save.PaillierSK = &paillier.PrivateKey{PublicKey: *save.PaillierPKs[0], P: big.NewInt(5), Q: big.NewInt(7)}
+	save.NTildei, save.H1i, save.H2i = save.NTildej[0], save.H1j[0], save.H2j[0]
+	save.Alpha, save.Beta, save.P, save.Q = big.NewInt(1), big.NewInt(1), big.NewInt(1), big.NewInt(1)
+
+	pub := crypto.ScalarBaseMult(tss.S256(), coefficients[0])
+	publicKey := append(pub.X().FillBytes(make([]byte, 32)), pub.Y().FillBytes(make([]byte, 32))...)
+	return &ExternalKeyShare{
+		Threshold:    threshold,
+		Participants: participants,
+		PublicKey:    hex.EncodeToString(publicKey),
+		Share:        &save,
+	}
+}
+
+func TestImportKeyShare(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	share := newExternalKeyShare(t, s, 1, "node1", "node2", "node3")
+	share.Alias = "migrated"
+
+	// The documented format is the JSON encoding of the share
+	data, err := json.Marshal(share)
+	require.NoError(t, err)
+	var decoded ExternalKeyShare
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	result, err := s.ImportKeyShare(ctx, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, share.PublicKey, result.PublicKey)
+	require.Equal(t, "migrated", result.Alias)
+
+	metadata, saved, err := s.loadKeyData(ctx, result.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, 1, metadata.Threshold)
+	require.Equal(t, []string{"node1", "node2", "node3"}, metadata.Participants)
+	require.Zero(t, saved.Xi.Cmp(share.Share.Xi))
+	require.NotNil(t, saved.ECDSAPub)
+
+	keyID, err := s.ResolveKeyID(ctx, "migrated")
+	require.NoError(t, err)
+	require.Equal(t, result.KeyID, keyID)
+
+	share.Alias = ""
+	_, err = s.ImportKeyShare(ctx, share)
+	require.ErrorIs(t, err, ErrKeyExists)
+}
+
+func TestImportKeyShareRejectsInconsistentShares(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	tests := []struct {
+		name   string
+		tamper func(share *ExternalKeyShare)
+	}{
+		{"other public key", func(share *ExternalKeyShare) {
+			other := newExternalKeyShare(t, s, 1, "node1", "node2", "node3")
+			share.PublicKey = other.PublicKey
+		}},
+		{"secret share", func(share *ExternalKeyShare) {
+			share.Share.Xi = new(big.Int).Add(share.Share.Xi, big.NewInt(1))
+		}},
+		{"public key share of another party", func(share *ExternalKeyShare) {
+			share.Share.BigXj[2] = share.Share.BigXj[1]
+		}},
+		{"threshold", func(share *ExternalKeyShare) {
+			share.Threshold = 2
+			share.Share.BigXj[2] = crypto.ScalarBaseMult(tss.S256(), big.NewInt(42))
+		}},
+		{"foreign party keys", func(share *ExternalKeyShare) {
+			share.Share.Ks[1] = big.NewInt(2)
+		}},
+		{"not a participant", func(share *ExternalKeyShare) {
+			share.Participants = []string{"node2", "node3", "node4"}
+		}},
+		{"missing preparams", func(share *ExternalKeyShare) {
+			share.Share.Alpha = nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			share := newExternalKeyShare(t, s, 1, "node1", "node2", "node3")
+			tt.tamper(share)
+			_, err := s.ImportKeyShare(context.Background(), share)
+			require.ErrorIs(t, err, ErrInvalidKeyShare)
+		})
+	}
+}
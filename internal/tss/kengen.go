@@ -8,6 +8,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 
@@ -25,15 +26,33 @@ type keygenOperationParams struct {
 	SessionID    string
 	Threshold    int
 	Participants []string
+	Alias        string
+	ChainFamily  ChainFamily
+	Policy       *KeyPolicy
+	// KeyID is the ID the key is stored under, empty for its address
+	KeyID        string
+	Labels       map[string]string
 	UsePreParams bool // Whether to use pre-computed parameters for faster keygen
+	// Timeout bounds the operation, defaultKeygenTimeout when zero
+	Timeout time.Duration
+	// Owner is the authenticated client that started the operation, if any
+	Owner string
+	// RequestID is the ID of the client request that started the operation, if any
+	RequestID string
 }
 
-// StartKeygen starts a new keygen operation
+// StartKeygen starts a new keygen operation, alias optionally names the generated key.
+// chainFamily selects how the key signs, empty for Ethereum. algorithm selects the
+// signature scheme, empty for ECDSA.
 func (s *Service) StartKeygen(
 	ctx context.Context,
 	operationID string,
 	threshold int,
 	participants []string,
+	alias string,
+	chainFamily ChainFamily,
+	algorithm SignatureAlgorithm,
+	opts OperationOptions,
 ) (*Operation, error) {
 	// Check for existing operation (idempotency)
 	existingOp, err := s.checkIdempotency(ctx, operationID)
@@ -45,9 +64,39 @@ func (s *Service) StartKeygen(
 		return existingOp, nil
 	}
 
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	if alias != "" {
+		if err := s.checkKeyAliasAvailable(ctx, alias); err != nil {
+			return nil, err
+		}
+	}
+	if chainFamily, err = parseChainFamily(string(chainFamily)); err != nil {
+		return nil, err
+	}
+	if _, err := parseSignatureAlgorithm(string(algorithm)); err != nil {
+		return nil, err
+	}
+	policy, err := opts.Policy.normalize()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOperationLabels(opts.Labels); err != nil {
+		return nil, err
+	}
+	if participants, err = s.resolveParticipants(participants); err != nil {
+		return nil, err
+	}
+	if err := s.checkParticipant(participants); err != nil {
+		return nil, err
+	}
+
 	// Generate or use provided operation ID
 	operationID = s.generateOrUseOperationID(operationID)
 	sessionID := uuid.New().String()
+	keyID := s.newKeyID()
 
 	// Create the keygen operation using common logic
 	operation, err := s.createAndStartKeygenOperation(&keygenOperationParams{
@@ -55,24 +104,56 @@ func (s *Service) StartKeygen(
 		SessionID:    sessionID,
 		Threshold:    threshold,
 		Participants: participants,
+		Alias:        alias,
+		ChainFamily:  chainFamily,
+		Policy:       policy,
+		KeyID:        keyID,
+		Labels:       opts.Labels,
 		UsePreParams: false, // Don't use pre-computed parameters for standard keygen
+		Timeout:      s.operationTimeout(opts.Timeout, defaultKeygenTimeout),
+		Owner:        operationOwner(ctx),
+		RequestID:    requestID(ctx),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Track acknowledgements before the sync goes out to confirm the participants joined
+	var waiter *syncAckWaiter
+	if s.joinTimeout > 0 {
+		remote := s.remoteParticipants(participants)
+		waiter = s.registerSyncAcks(operationID, remote, s.joinQuorum(threshold, remote))
+	}
+
 	// Broadcast keygen operation sync message to other participants
 	common.SafeGo(operation.EndCh, func() any {
-		return s.syncKeygenOperation(operationID, sessionID, threshold, participants)
+		// Participants never queue, the sync waits until the operation may run here
+		if !operation.awaitAdmission() {
+			return nil
+		}
+		return s.syncKeygenOperation(operationID, sessionID, operation.RequestID, threshold, participants, alias, chainFamily, policy, keyID)
 	})
 
+	if waiter != nil {
+		if err := s.awaitJoin(operation, waiter); err != nil {
+			return nil, err
+		}
+	}
 	return operation, nil
 }
 
+// generatePreParams returns the safe primes and Paillier key of a new keygen or resharing party
+func (s *Service) generatePreParams() (*keygen.LocalPreParams, error) {
+	if s.preParams != nil {
+		return s.preParams()
+	}
+	return keygen.GeneratePreParams(1 * time.Minute)
+}
+
 // createAndStartKeygenOperation creates a keygen operation with shared logic
 func (s *Service) createAndStartKeygenOperation(params *keygenOperationParams) (*Operation, error) {
 	// Create participant list
-	participantList, err := s.createParticipantList(params.Participants)
+	participantList, err := s.createParticipantList(params.Participants, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create participant list: %w", err)
 	}
@@ -97,14 +178,14 @@ func (s *Service) createAndStartKeygenOperation(params *keygenOperationParams) (
 	tssParams := tss.NewParameters(tss.S256(), peerCtx, ourPartyID, len(params.Participants), params.Threshold)
 
 	// Create channels
-	outCh := make(chan tss.Message, 100)
+	outCh := make(chan tss.Message, outChannelSize(len(params.Participants)))
 	endCh := make(chan *keygen.LocalPartySaveData, 1)
 
 	// Create keygen party - with or without pre-computed parameters
 	var party tss.Party
-	if params.UsePreParams {
+	if params.UsePreParams || s.preParams != nil {
 		// Pre-compute parameters for faster keygen (used in sync operations)
-		preParams, err := keygen.GeneratePreParams(1 * time.Minute)
+		preParams, err := s.generatePreParams()
 		if err != nil {
 			s.logger.Error("Failed to generate pre-params for synced operation", zap.Error(err))
 			return nil, fmt.Errorf("failed to generate pre-params: %w", err)
@@ -116,14 +197,21 @@ func (s *Service) createAndStartKeygenOperation(params *keygenOperationParams) (
 	}
 
 	// Create operation context with cancellation - use background context to avoid HTTP timeout
-	// Set a longer timeout for keygen operations (10 minutes)
-	operationCtx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	timeout := params.Timeout
+	if timeout == 0 {
+		timeout = defaultKeygenTimeout
+	}
+	operationCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	// Create request for storage
 	req := &KeygenRequest{
 		OperationID:  params.OperationID,
 		Threshold:    params.Threshold,
 		Participants: params.Participants,
+		Alias:        params.Alias,
+		ChainFamily:  params.ChainFamily,
+		Policy:       params.Policy,
+		KeyID:        params.KeyID,
 	}
 
 	operation := &Operation{
@@ -137,6 +225,9 @@ func (s *Service) createAndStartKeygenOperation(params *keygenOperationParams) (
 		Status:       StatusPending,
 		CreatedAt:    time.Now(),
 		Request:      req, // Store the request for persistence
+		Labels:       params.Labels,
+		Owner:        params.Owner,
+		RequestID:    params.RequestID,
 		cancel:       cancel,
 	}
 
@@ -161,15 +252,21 @@ func (s *Service) createAndStartKeygenOperation(params *keygenOperationParams) (
 }
 
 func (s *Service) syncKeygenOperation(
-	operationID, sessionID string,
+	operationID, sessionID, requestID string,
 	threshold int,
 	participants []string,
+	alias string,
+	chainFamily ChainFamily,
+	policy *KeyPolicy,
+	keyID string,
 ) error {
 	s.logger.Info("Broadcast keygen operation",
 		zap.String("operation_id", operationID),
+		zap.String("request_id", requestID),
 		zap.String("session_id", sessionID),
 		zap.Int("threshold", threshold),
 		zap.Int("parties", len(participants)),
+		zap.String("alias", alias),
 	)
 
 	syncCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -177,12 +274,17 @@ func (s *Service) syncKeygenOperation(
 	syncData := &KeygenSyncData{
 		OperationSyncData: OperationSyncData{
 			OperationID:   operationID,
+			RequestID:     requestID,
 			OperationType: "keygen",
 			SessionID:     sessionID,
 			Threshold:     threshold,
 			Parties:       len(participants),
 			Participants:  participants,
 		},
+		Alias:       alias,
+		ChainFamily: chainFamily,
+		Policy:      policy,
+		KeyID:       keyID,
 	}
 
 	if err := s.syncOperation(syncCtx, syncData); err != nil {
@@ -198,24 +300,55 @@ func (s *Service) syncKeygenOperation(
 
 // saveKeygenResult saves keygen result with encryption
 func (s *Service) saveKeygenResult(ctx context.Context, operation *Operation, result *keygen.LocalPartySaveData) error {
-	// Generate public key bytes and Ethereum address in one go
-	xBytes := result.ECDSAPub.X().Bytes()
-	yBytes := result.ECDSAPub.Y().Bytes()
-	xBytes = append(xBytes, yBytes...)
-	pubKeyBytes := xBytes
-
-	// Generate Ethereum address using Keccak-256
-	hasher := sha3.NewLegacyKeccak256()
-	_, err := hasher.Write(pubKeyBytes)
+	publicKeyHex, address, err := encodePublicKey(result.ECDSAPub)
 	if err != nil {
-		return fmt.Errorf("failed to write public key bytes: %w", err)
+		return err
 	}
-	hash := hasher.Sum(nil)
-	keyID := "0x" + hex.EncodeToString(hash[12:]) // Take last 20 bytes for address
 
-	// Prepare all data for storage and result
-	publicKeyHex := hex.EncodeToString(pubKeyBytes)
+	// Get original threshold from operation request
+	originalReq := operation.Request.(*KeygenRequest)
+	keyID := originalReq.KeyID
+	if keyID == "" {
+		keyID = address
+	}
+
+	alias := s.registerKeyAlias(ctx, originalReq.Alias, keyID)
+	if err := s.saveKeyData(ctx, keyID, address, result, originalReq.Threshold, originalReq.Participants, 0, alias,
+		originalReq.ChainFamily, originalReq.Policy); err != nil {
+		if alias != "" {
+			if delErr := s.storage.Delete(ctx, keyAliasStorageKey(alias)); delErr != nil {
+				s.logger.Warn("Failed to remove alias of unsaved key", zap.String("alias", alias), zap.Error(delErr))
+			}
+		}
+		return err
+	}
+
+	// Create and store result
+	operation.Lock()
+	operation.Result = &KeygenResult{
+		PublicKey: publicKeyHex,
+		KeyID:     keyID,
+		Alias:     alias,
+		Address:   address,
+	}
+	operation.Unlock()
+	return nil
+}
 
+// saveKeyData encrypts the local party save data and stores it under keyID, indexed by
+// the key's address
+func (s *Service) saveKeyData(
+	ctx context.Context,
+	keyID string,
+	address string,
+	result *keygen.LocalPartySaveData,
+	threshold int,
+	participants []string,
+	generation int,
+	alias string,
+	chainFamily ChainFamily,
+	policy *KeyPolicy,
+) error {
 	// Serialize key data (this contains the private key shares)
 	keyDataBytes, err := json.Marshal(result)
 	if err != nil {
@@ -228,36 +361,34 @@ func (s *Service) saveKeygenResult(ctx context.Context, operation *Operation, re
 		return fmt.Errorf("failed to encrypt key data: %w", err)
 	}
 
-	// Get original threshold from operation request
-	originalReq := operation.Request.(*KeygenRequest)
-
 	// Store key data with encrypted KeyData field
 	keyDataStruct := &keyData{
 		Moniker:      s.moniker,
-		KeyData:      encryptedKeyData,      // Store encrypted data
-		Threshold:    originalReq.Threshold, // Store the original threshold from request
-		Participants: originalReq.Participants,
-	}
-
-	keyDataStorageBytes, err := json.Marshal(keyDataStruct)
-	if err != nil {
-		return fmt.Errorf("failed to marshal key data struct: %w", err)
+		KeyData:      encryptedKeyData, // Store encrypted data
+		Threshold:    threshold,        // Store the original threshold from request
+		Participants: participants,
+		Alias:        alias,
+		Generation:   generation,
+		ChainFamily:  chainFamily,
+		Policy:       policy,
+		Address:      address,
 	}
 
-	if err := s.storage.Save(ctx, keyID, keyDataStorageBytes); err != nil {
-		return fmt.Errorf("failed to save key data: %w", err)
+	if err := s.saveKeyRecord(ctx, keyID, keyDataStruct); err != nil {
+		return err
 	}
 
-	// Create and store result
-	operation.Lock()
-	operation.Result = &KeygenResult{
-		PublicKey: publicKeyHex,
-		KeyID:     keyID,
+	// A key share must never be lost over its index entry, the key stays reachable by its ID
+	if err := s.saveKeyAddress(ctx, address, keyID); err != nil {
+		s.logger.Error("Failed to index key by address",
+			zap.String("key_id", keyID),
+			zap.String("address", address),
+			zap.Error(err))
 	}
-	operation.Unlock()
 
-	s.logger.Info("Saved encrypted keygen result",
+	s.logger.Info("Saved encrypted key data",
 		zap.String("key_id", keyID),
+		zap.String("address", address),
 		zap.Int("encrypted_size", len(encryptedKeyData)),
 		zap.Int("original_size", len(keyDataBytes)),
 	)
@@ -265,13 +396,36 @@ func (s *Service) saveKeygenResult(ctx context.Context, operation *Operation, re
 	return nil
 }
 
+// encodePublicKey returns the hex encoded public key and its Ethereum address
+func encodePublicKey(pub *crypto.ECPoint) (publicKeyHex, address string, err error) {
+	if pub == nil {
+		return "", "", fmt.Errorf("public key is missing")
+	}
+
+	// Generate public key bytes and Ethereum address in one go
+	xBytes := pub.X().Bytes()
+	yBytes := pub.Y().Bytes()
+	xBytes = append(xBytes, yBytes...)
+	pubKeyBytes := xBytes
+
+	// Generate Ethereum address using Keccak-256
+	hasher := sha3.NewLegacyKeccak256()
+	if _, err := hasher.Write(pubKeyBytes); err != nil {
+		return "", "", fmt.Errorf("failed to write public key bytes: %w", err)
+	}
+	hash := hasher.Sum(nil)
+	address = "0x" + hex.EncodeToString(hash[12:]) // Take last 20 bytes for address
+
+	return hex.EncodeToString(pubKeyBytes), address, nil
+}
+
 // createSyncedKeygenOperation creates a keygen operation from a sync message
 func (s *Service) createSyncedKeygenOperation(ctx context.Context, msg *p2p.Message) error {
 	// Parse operation sync data from message data
 	var syncData KeygenSyncData
 	if err := json.Unmarshal(msg.Data, &syncData); err != nil {
 		s.logger.Error("Failed to unmarshal keygen sync data", zap.Error(err))
-		return fmt.Errorf("failed to unmarshal keygen sync data: %w", err)
+		return fmt.Errorf("%w: failed to unmarshal keygen sync data: %w", p2p.ErrProtocolViolation, err)
 	}
 
 	s.logger.Info("Creating synced keygen operation",
@@ -281,13 +435,42 @@ func (s *Service) createSyncedKeygenOperation(ctx context.Context, msg *p2p.Mess
 		zap.Int("parties", syncData.Parties),
 		zap.Strings("participants", syncData.Participants))
 
+	// Refuse before joining, the initiator then fails on the missing acknowledgement
+	if syncData.Alias != "" {
+		if err := s.checkKeyAliasAvailable(ctx, syncData.Alias); err != nil {
+			s.logger.Error("Rejecting keygen with unavailable alias",
+				zap.String("operation_id", syncData.OperationID),
+				zap.String("alias", syncData.Alias),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	if err := s.checkNewKeyID(ctx, syncData.KeyID); err != nil {
+		return err
+	}
+
+	chainFamily, err := parseChainFamily(string(syncData.ChainFamily))
+	if err != nil {
+		return err
+	}
+	policy, err := syncData.Policy.normalize()
+	if err != nil {
+		return err
+	}
+
 	// Create the keygen operation using common logic with pre-computed parameters
-	_, err := s.createAndStartKeygenOperation(&keygenOperationParams{
+	_, err = s.createAndStartKeygenOperation(&keygenOperationParams{
 		OperationID:  syncData.OperationID,
 		SessionID:    syncData.SessionID,
 		Threshold:    syncData.Threshold,
 		Participants: syncData.Participants,
+		Alias:        syncData.Alias,
+		ChainFamily:  chainFamily,
+		Policy:       policy,
+		KeyID:        syncData.KeyID,
 		UsePreParams: false, // Use pre-computed parameters for sync operations
+		RequestID:    syncData.RequestID,
 	})
 	if err != nil {
 		s.logger.Error("Failed to create synced keygen operation", zap.Error(err))
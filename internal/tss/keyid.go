@@ -0,0 +1,110 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// Formats of the IDs of generated and imported keys
+const (
+	// KeyIDFormatAddress uses the Ethereum address of the group public key as key ID, as
+	// keys generated before key IDs and addresses were separated
+	KeyIDFormatAddress = "address"
+	// KeyIDFormatUUID gives keys a random UUID as ID, independent of their address
+	KeyIDFormatUUID = "uuid"
+)
+
+// newKeyID returns the ID of a key about to be generated or imported, empty when the key
+// is stored under its address
+func (s *Service) newKeyID() string {
+	if s.keyIDFormat == KeyIDFormatUUID {
+		return uuid.New().String()
+	}
+	return ""
+}
+
+// checkNewKeyID returns an error when a synced keygen would store its key under an invalid
+// ID or overwrite a key this node holds. An empty ID stores the key under its address.
+func (s *Service) checkNewKeyID(ctx context.Context, keyID string) error {
+	if keyID == "" {
+		return nil
+	}
+	if !keyIDPattern.MatchString(keyID) {
+		return fmt.Errorf("%w: invalid key ID %q", p2p.ErrProtocolViolation, keyID)
+	}
+	if _, err := s.storage.Load(ctx, keyID); err == nil {
+		return fmt.Errorf("%w: %s", ErrKeyExists, keyID)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to check for existing key: %w", err)
+	}
+	return nil
+}
+
+// keyAddressStorageKey returns the storage key of the address -> key ID index entry
+func keyAddressStorageKey(address string) string {
+	return fmt.Sprintf("address:%s", strings.ToLower(address))
+}
+
+// lookupKeyAddress loads the ID of the key with address
+func (s *Service) lookupKeyAddress(ctx context.Context, address string) (string, error) {
+	data, err := s.loadMetadata(ctx, keyAddressStorageKey(address))
+	if err != nil {
+		return "", err
+	}
+
+	var keyID string
+	if err := json.Unmarshal(data, &keyID); err != nil {
+		return "", fmt.Errorf("failed to unmarshal key address: %w", err)
+	}
+	return keyID, nil
+}
+
+// resolveKeyAddress returns the ID of the key with address, keys without an index entry
+// are stored under their address
+func (s *Service) resolveKeyAddress(ctx context.Context, address string) (string, error) {
+	keyID, err := s.lookupKeyAddress(ctx, address)
+	if errors.Is(err, storage.ErrNotFound) {
+		return address, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up key address: %w", err)
+	}
+	return keyID, nil
+}
+
+// saveKeyAddress indexes the key keyID by its address, keys stored under their address
+// need no entry
+func (s *Service) saveKeyAddress(ctx context.Context, address, keyID string) error {
+	if keyID == address {
+		return nil
+	}
+
+	// Stored as JSON so loadMetadata can tell plaintext from encrypted entries
+	data, err := json.Marshal(keyID)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key address: %w", err)
+	}
+	if err := s.saveMetadata(ctx, keyAddressStorageKey(address), data); err != nil {
+		return fmt.Errorf("failed to save key address: %w", err)
+	}
+
+	s.logger.Debug("Indexed key address", zap.String("address", address), zap.String("key_id", keyID))
+	return nil
+}
+
+// KeyIDFormat returns the format of the IDs of new keys
+func (s *Service) KeyIDFormat() string {
+	if s.keyIDFormat == KeyIDFormatUUID {
+		return KeyIDFormatUUID
+	}
+	return KeyIDFormatAddress
+}
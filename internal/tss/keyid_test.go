@@ -0,0 +1,111 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+func TestKeygenStoresKeyUnderUUID(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, true)
+	s.keyIDFormat = KeyIDFormatUUID
+
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(11))
+	_, address, err := encodePublicKey(pub)
+	require.NoError(t, err)
+
+	keyID := s.newKeyID()
+	require.Regexp(t, keyIDPattern, keyID)
+	require.NotEqual(t, address, keyID)
+
+	result := keygen.NewLocalPartySaveData(1)
+	result.ECDSAPub = pub
+	op := &Operation{
+		ID:      "op-uuid",
+		Type:    OperationKeygen,
+		Request: &KeygenRequest{Threshold: 1, Participants: []string{"node1", "node2"}, KeyID: keyID},
+	}
+	require.NoError(t, s.saveKeygenResult(ctx, op, &result))
+	require.Equal(t, keyID, op.Result.(*KeygenResult).KeyID)
+	require.Equal(t, address, op.Result.(*KeygenResult).Address)
+
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, address, metadata.Address)
+
+	// The key ID resolves to itself, the address to the key ID
+	for _, id := range []string{keyID, address} {
+		resolved, err := s.ResolveKeyID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, keyID, resolved, id)
+	}
+
+	stats, err := s.StorageStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Keys)
+}
+
+func TestKeygenStoresKeyUnderAddress(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	require.Empty(t, s.newKeyID())
+
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(13))
+	_, address, err := encodePublicKey(pub)
+	require.NoError(t, err)
+
+	result := keygen.NewLocalPartySaveData(1)
+	result.ECDSAPub = pub
+	op := &Operation{
+		ID:      "op-address",
+		Type:    OperationKeygen,
+		Request: &KeygenRequest{Threshold: 1, Participants: []string{"node1", "node2"}},
+	}
+	require.NoError(t, s.saveKeygenResult(ctx, op, &result))
+	require.Equal(t, address, op.Result.(*KeygenResult).KeyID)
+
+	// Keys stored under their address have no index entry
+	resolved, err := s.ResolveKeyID(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, address, resolved)
+	_, err = s.lookupKeyAddress(ctx, address)
+	require.Error(t, err)
+}
+
+func TestSyncedKeygenKeyIDChecked(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	existing := "0b4b4a3e-8d8c-4f6e-9f0a-0c5d2c1c7e11"
+	require.NoError(t, s.saveKeyData(ctx, existing, "0x1111111111111111111111111111111111111111",
+		&keygen.LocalPartySaveData{}, 1, []string{"node1", "node2"}, 0, "", "", nil))
+
+	sync := func(keyID string) error {
+		data, err := json.Marshal(&KeygenSyncData{
+			OperationSyncData: OperationSyncData{
+				OperationID:   "op-" + keyID,
+				OperationType: OperationKeygen,
+				SessionID:     "session-" + keyID,
+				Threshold:     1,
+				Participants:  []string{"node2", "node1"},
+			},
+			KeyID: keyID,
+		})
+		require.NoError(t, err)
+		return s.handleOperationSync(ctx, &p2p.Message{Type: string(OperationSync), From: "node2", Data: data})
+	}
+
+	// A keygen must neither overwrite a key nor store one outside the key ID namespace
+	require.ErrorIs(t, sync(existing), ErrKeyExists)
+	require.ErrorIs(t, sync("alias:treasury"), p2p.ErrProtocolViolation)
+}
@@ -0,0 +1,125 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+)
+
+// keyDataMACContent returns the bytes the MAC of the key data stored under keyID covers:
+// the key ID, so records cannot be swapped between keys, and the record without its MAC
+func keyDataMACContent(keyID string, record *keyData) ([]byte, error) {
+	unsigned := *record
+	unsigned.MAC = nil
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key data struct: %w", err)
+	}
+	return append([]byte(keyID+"\x00"), data...), nil
+}
+
+// saveKeyRecord authenticates the key data and stores it under keyID
+func (s *Service) saveKeyRecord(ctx context.Context, keyID string, record *keyData) error {
+	content, err := keyDataMACContent(keyID, record)
+	if err != nil {
+		return err
+	}
+
+	sealed := *record
+	sealed.MAC = s.encryption.MAC(content)
+	data, err := json.Marshal(&sealed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key data struct: %w", err)
+	}
+	if err := s.storage.Save(ctx, keyID, data); err != nil {
+		return fmt.Errorf("failed to save key data: %w", err)
+	}
+	return nil
+}
+
+// loadKeyRecord loads the key data stored under keyID and checks its MAC. Records without
+// a MAC are rejected like tampered ones, a stripped MAC would otherwise pass as a record
+// stored before records were authenticated. migrateKeyMACs tags such records.
+func (s *Service) loadKeyRecord(ctx context.Context, keyID string) (*keyData, error) {
+	data, err := s.storage.Load(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key data: %w", err)
+	}
+
+	var record keyData
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key data struct: %w", err)
+	}
+
+	if len(record.MAC) == 0 {
+		s.logger.Error("Key data has no integrity tag", zap.String("key_id", keyID))
+		return nil, fmt.Errorf("%w: %s has no integrity tag", ErrKeyDataTampered, keyID)
+	}
+
+	content, err := keyDataMACContent(keyID, &record)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.encryption.VerifyMAC(content, record.MAC); err != nil {
+		if errors.Is(err, plugin.ErrInvalidMAC) {
+			s.logger.Error("Key data failed integrity check", zap.String("key_id", keyID))
+			return nil, fmt.Errorf("%w: %s", ErrKeyDataTampered, keyID)
+		}
+		return nil, fmt.Errorf("failed to verify key data: %w", err)
+	}
+	return &record, nil
+}
+
+// keyMACMigrationStorageKey records that the key records of the node were tagged
+const keyMACMigrationStorageKey = "node:key_mac_migrated"
+
+// migrateKeyMACs adds a MAC to the key records stored before records were authenticated.
+// It runs once at startup, after the encryption password was verified. Records found
+// without a MAC later were stripped of it and stay rejected.
+func (s *Service) migrateKeyMACs(ctx context.Context) error {
+	if s.readOnly {
+		return nil
+	}
+	migrated, err := s.storage.Exists(ctx, keyMACMigrationStorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to load key MAC migration marker: %w", err)
+	}
+	if migrated {
+		return nil
+	}
+
+	// Key shares are stored under their key ID, an address or a UUID without common prefix
+	candidates, err := s.storage.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+	for _, keyID := range candidates {
+		if !keyIDPattern.MatchString(keyID) {
+			continue
+		}
+		data, err := s.storage.Load(ctx, keyID)
+		if err != nil {
+			return fmt.Errorf("failed to load key data: %w", err)
+		}
+		var record keyData
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("failed to unmarshal key data struct: %w", err)
+		}
+		if len(record.MAC) > 0 {
+			continue
+		}
+		if err := s.saveKeyRecord(ctx, keyID, &record); err != nil {
+			return fmt.Errorf("failed to add integrity tag to key %s: %w", keyID, err)
+		}
+		s.logger.Warn("Added integrity tag to key data", zap.String("key_id", keyID))
+	}
+	if err := s.storage.Save(ctx, keyMACMigrationStorageKey, []byte{1}); err != nil {
+		return fmt.Errorf("failed to save key MAC migration marker: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/stretchr/testify/require"
+)
+
+const testMACKeyID = "0x2222222222222222222222222222222222222222"
+
+func TestKeyDataTamperDetected(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, false)
+	require.NoError(t, s.saveKeyData(ctx, testMACKeyID, testMACKeyID,
+		&keygen.LocalPartySaveData{}, 1, []string{"node1", "node2"}, 0, "", "", nil))
+
+	metadata, err := s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.NoError(t, err)
+	require.Equal(t, 1, metadata.Threshold)
+
+	// Lower the stored threshold by flipping a byte of the plaintext metadata
+	data, err := store.Load(ctx, testMACKeyID)
+	require.NoError(t, err)
+	index := bytes.Index(data, []byte(`"threshold":1`))
+	require.Positive(t, index)
+	data[index+len(`"threshold":`)] = '0'
+	require.NoError(t, store.Save(ctx, testMACKeyID, data))
+
+	_, err = s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+	_, _, err = s.loadKeyData(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+
+	// Nor can a record be moved under another key ID
+	other := "0x3333333333333333333333333333333333333333"
+	require.NoError(t, s.saveKeyData(ctx, other, other,
+		&keygen.LocalPartySaveData{}, 1, []string{"node1", "node2"}, 0, "", "", nil))
+	data, err = store.Load(ctx, other)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, testMACKeyID, data))
+	_, err = s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+}
+
+func TestKeyDataWithoutMACRejected(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, false)
+	require.NoError(t, s.saveKeyData(ctx, testMACKeyID, testMACKeyID,
+		&keygen.LocalPartySaveData{}, 1, []string{"node1", "node2"}, 0, "", "", nil))
+
+	// Stripping the MAC does not make an altered record pass as one stored before records
+	// were authenticated
+	data, err := store.Load(ctx, testMACKeyID)
+	require.NoError(t, err)
+	var record keyData
+	require.NoError(t, json.Unmarshal(data, &record))
+	record.MAC = nil
+	record.Threshold = 0
+	data, err = json.Marshal(&record)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, testMACKeyID, data))
+
+	_, err = s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+	_, _, err = s.loadKeyData(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+
+	// Nor is the record tagged when it is read
+	data, err = store.Load(ctx, testMACKeyID)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &record))
+	require.Empty(t, record.MAC)
+}
+
+func TestMigrateKeyMACs(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, false)
+
+	// Records stored before they were authenticated have no MAC
+	data, err := json.Marshal(&keyData{Threshold: 1, Participants: []string{"node1", "node2"}})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, testMACKeyID, data))
+	_, err = s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+
+	require.NoError(t, s.migrateKeyMACs(ctx))
+	metadata, err := s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.NoError(t, err)
+	require.Equal(t, 1, metadata.Threshold)
+
+	// Tagged records are left alone
+	data, err = store.Load(ctx, testMACKeyID)
+	require.NoError(t, err)
+	require.NoError(t, s.migrateKeyMACs(ctx))
+	migrated, err := store.Load(ctx, testMACKeyID)
+	require.NoError(t, err)
+	require.Equal(t, data, migrated)
+
+	// The migration runs once, a MAC stripped afterwards is not restored
+	var record keyData
+	require.NoError(t, json.Unmarshal(data, &record))
+	record.MAC = nil
+	data, err = json.Marshal(&record)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, testMACKeyID, data))
+	require.NoError(t, s.migrateKeyMACs(ctx))
+	_, err = s.LoadKeyMetadata(ctx, testMACKeyID)
+	require.ErrorIs(t, err, ErrKeyDataTampered)
+}
@@ -0,0 +1,174 @@
+package tss
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxOperationLabels bounds the number of labels of an operation
+	maxOperationLabels = 16
+	// operationLabelPrefix prefixes the label -> operation index entries
+	operationLabelPrefix = "operation_label:"
+	// operationPrefix prefixes persisted operations
+	operationPrefix = "operation:"
+	// defaultOperationPageSize is the ListOperations page size when the client requests none
+	defaultOperationPageSize = 100
+	// maxOperationPageSize caps the ListOperations page size
+	maxOperationPageSize = 1000
+)
+
+var (
+	// labelKeyPattern allows DNS-like label keys with an optional prefix, e.g. example.com/team
+	labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]{0,61}[A-Za-z0-9])?$`)
+	// labelValuePattern allows empty values and the characters of label keys except '/'
+	labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// validateOperationLabels checks that labels are bounded and only use characters that are
+// safe in storage keys, in particular neither ':' nor '='
+func validateOperationLabels(labels map[string]string) error {
+	if len(labels) > maxOperationLabels {
+		return fmt.Errorf("%w: %d labels, maximum is %d", ErrInvalidLabels, len(labels), maxOperationLabels)
+	}
+	for key, value := range labels {
+		if !labelKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: key %q must be 1-63 letters, digits, '.', '_', '-' or '/' "+
+				"and start and end with a letter or digit", ErrInvalidLabels, key)
+		}
+		if !labelValuePattern.MatchString(value) {
+			return fmt.Errorf("%w: value %q of %q must be at most 63 letters, digits, '.', '_' or '-' "+
+				"and start and end with a letter or digit", ErrInvalidLabels, value, key)
+		}
+	}
+	return nil
+}
+
+// operationLabelPrefixKey returns the storage key prefix of the operations with a label
+func operationLabelPrefixKey(key, value string) string {
+	return fmt.Sprintf("%s%s=%s:", operationLabelPrefix, key, value)
+}
+
+// matchLabels returns true when labels contain every key/value pair of the selector
+func matchLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// saveOperationLabels indexes a persisted operation under each of its labels. Index entries
+// carry no data, the operation itself is loaded when listing.
+func (s *Service) saveOperationLabels(ctx context.Context, operationID string, labels map[string]string) error {
+	for key, value := range labels {
+		if err := s.storage.Save(ctx, operationLabelPrefixKey(key, value)+operationID, []byte{}); err != nil {
+			return fmt.Errorf("failed to index operation label %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// ListOperations returns a page of the operations whose labels match every key/value pair
+// of the selector, active and persisted operations together in ID order. An empty selector
+// lists all operations. pageSize bounds the page, 0 for the default, and pageToken continues
+// after the page it was returned with. The returned token is empty on the last page.
+func (s *Service) ListOperations(
+	ctx context.Context,
+	selector map[string]string,
+	pageSize int,
+	pageToken string,
+) ([]*OperationData, string, error) {
+	if err := validateOperationLabels(selector); err != nil {
+		return nil, "", err
+	}
+	after, err := decodeOperationPageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if pageSize <= 0 {
+		pageSize = defaultOperationPageSize
+	}
+	pageSize = min(pageSize, maxOperationPageSize)
+
+	s.mutex.RLock()
+	ids := make([]string, 0, len(s.operations))
+	for id, op := range s.operations {
+		if matchLabels(op.Labels, selector) {
+			ids = append(ids, id)
+		}
+	}
+	s.mutex.RUnlock()
+
+	persisted, err := s.persistedOperationIDs(ctx, selector)
+	if err != nil {
+		return nil, "", err
+	}
+	ids = append(ids, persisted...)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	// Only the operations of the page are loaded, the IDs before the token are skipped
+	start, _ := slices.BinarySearch(ids, after)
+	if start < len(ids) && ids[start] == after {
+		start++
+	}
+
+	var (
+		operations    []*OperationData
+		nextPageToken string
+	)
+	for i := start; i < len(ids) && len(operations) < pageSize; i++ {
+		opData, err := s.GetOperationData(ctx, ids[i])
+		if err != nil {
+			// The index may point at an operation removed by hand, skip it
+			s.logger.Warn("Failed to load listed operation", zap.String("operation_id", ids[i]), zap.Error(err))
+			continue
+		}
+		if !matchLabels(opData.Labels, selector) {
+			continue
+		}
+		operations = append(operations, opData)
+		if len(operations) == pageSize && i+1 < len(ids) {
+			nextPageToken = base64.RawURLEncoding.EncodeToString([]byte(ids[i]))
+		}
+	}
+	return operations, nextPageToken, nil
+}
+
+// decodeOperationPageToken returns the ID of the last operation of the previous page
+func decodeOperationPageToken(pageToken string) (string, error) {
+	after, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPageToken, pageToken)
+	}
+	return string(after), nil
+}
+
+// persistedOperationIDs returns the IDs of persisted operations that may match the selector,
+// using the label index of one selector entry. Callers still have to match every label.
+func (s *Service) persistedOperationIDs(ctx context.Context, selector map[string]string) ([]string, error) {
+	prefix := operationPrefix
+	if len(selector) > 0 {
+		key := slices.Min(slices.Collect(maps.Keys(selector)))
+		prefix = operationLabelPrefixKey(key, selector[key])
+	}
+
+	keys, err := s.storage.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, strings.TrimPrefix(key, prefix))
+	}
+	return ids, nil
+}
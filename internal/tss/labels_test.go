@@ -0,0 +1,156 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOperationLabels(t *testing.T) {
+	require.NoError(t, validateOperationLabels(nil))
+	require.NoError(t, validateOperationLabels(map[string]string{
+		"team":                "payments",
+		"example.com/project": "treasury-v2",
+		"empty":               "",
+	}))
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= maxOperationLabels; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	for name, labels := range map[string]map[string]string{
+		"too many labels":        tooMany,
+		"empty key":              {"": "value"},
+		"key too long":           {strings.Repeat("k", 64): "value"},
+		"colon in key":           {"team:a": "value"},
+		"equals in value":        {"team": "a=b"},
+		"slash in value":         {"team": "a/b"},
+		"value too long":         {"team": strings.Repeat("v", 64)},
+		"non alphanumeric start": {"team": "-payments"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, validateOperationLabels(labels), ErrInvalidLabels)
+		})
+	}
+}
+
+func TestListOperationsByLabel(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, true)
+
+	persist := func(id string, labels map[string]string) {
+		completedAt := time.Now()
+		require.NoError(t, s.saveOperation(ctx, &Operation{
+			ID:          id,
+			Type:        OperationSigning,
+			Status:      StatusCompleted,
+			CreatedAt:   time.Now(),
+			CompletedAt: &completedAt,
+			Request:     &SigningRequest{Message: []byte("message"), KeyID: "0xabc"},
+			Result:      &SigningResult{Signature: "0x01", V: 27},
+			Labels:      labels,
+		}))
+	}
+	persist("op-payments-prod", map[string]string{"team": "payments", "env": "prod"})
+	persist("op-payments-dev", map[string]string{"team": "payments", "env": "dev"})
+	persist("op-unlabeled", nil)
+
+	// Active operations are listed along with persisted ones
+	s.operations["op-active"] = &Operation{
+		ID:     "op-active",
+		Type:   OperationKeygen,
+		Status: StatusInProgress,
+		Labels: map[string]string{"team": "payments", "env": "prod"},
+	}
+
+	ids := func(selector map[string]string) []string {
+		t.Helper()
+		operations, nextPageToken, err := s.ListOperations(ctx, selector, 0, "")
+		require.NoError(t, err)
+		require.Empty(t, nextPageToken)
+		ids := make([]string, len(operations))
+		for i, op := range operations {
+			ids[i] = op.ID
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"op-active", "op-payments-dev", "op-payments-prod", "op-unlabeled"}, ids(nil))
+	require.Equal(t, []string{"op-active", "op-payments-dev", "op-payments-prod"}, ids(map[string]string{"team": "payments"}))
+	require.Equal(t, []string{"op-active", "op-payments-prod"}, ids(map[string]string{"team": "payments", "env": "prod"}))
+	require.Empty(t, ids(map[string]string{"team": "treasury"}))
+
+	// Labels survive persistence
+	opData, err := s.GetOperationData(ctx, "op-payments-prod")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "payments", "env": "prod"}, opData.Labels)
+
+	_, _, err = s.ListOperations(ctx, map[string]string{"team": "a:b"}, 0, "")
+	require.ErrorIs(t, err, ErrInvalidLabels)
+}
+
+func TestListOperationsPages(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+
+	for _, id := range []string{"op-1", "op-3", "op-5"} {
+		completedAt := time.Now()
+		require.NoError(t, s.saveOperation(ctx, &Operation{
+			ID:          id,
+			Type:        OperationSigning,
+			Status:      StatusCompleted,
+			CreatedAt:   time.Now(),
+			CompletedAt: &completedAt,
+			Request:     &SigningRequest{Message: []byte("message"), KeyID: "0xabc"},
+			Result:      &SigningResult{Signature: "0x01", V: 27},
+		}))
+	}
+	for _, id := range []string{"op-2", "op-4"} {
+		s.operations[id] = &Operation{ID: id, Type: OperationKeygen, Status: StatusInProgress}
+	}
+
+	// Pages of two walk active and persisted operations in ID order
+	var (
+		listed    []string
+		pageToken string
+	)
+	for range 3 {
+		operations, nextPageToken, err := s.ListOperations(ctx, nil, 2, pageToken)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(operations), 2)
+		for _, op := range operations {
+			listed = append(listed, op.ID)
+		}
+		pageToken = nextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+	require.Empty(t, pageToken)
+	require.Equal(t, []string{"op-1", "op-2", "op-3", "op-4", "op-5"}, listed)
+
+	// A page ending on the last operation has no next page
+	operations, nextPageToken, err := s.ListOperations(ctx, nil, 5, "")
+	require.NoError(t, err)
+	require.Len(t, operations, 5)
+	require.Empty(t, nextPageToken)
+
+	_, _, err = s.ListOperations(ctx, nil, 2, "not a token!")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestStartRejectsInvalidLabels(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	invalid := map[string]string{"team": "a=b"}
+
+	_, err := s.StartKeygen(ctx, "", 1, []string{"node1", "node2"}, "", "", "", OperationOptions{Labels: invalid})
+	require.ErrorIs(t, err, ErrInvalidLabels)
+	_, err = s.StartResharing(ctx, "", "0xabc", 1, []string{"node1", "node2"}, OperationOptions{Labels: invalid})
+	require.ErrorIs(t, err, ErrInvalidLabels)
+}
@@ -0,0 +1,76 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// maintenanceStorageKey is the storage key of the persisted maintenance mode flag. It is
+// kept in storage so a node restarted during maintenance does not start accepting work.
+const maintenanceStorageKey = "node:maintenance"
+
+// MaintenanceMode reports whether this node rejects new operations started by clients
+func (s *Service) MaintenanceMode() bool {
+	return s.maintenance.Load()
+}
+
+// SetMaintenanceMode pauses or resumes accepting new operations started by clients on this
+// node. Running operations, operations synced from other nodes and queries are not affected.
+// The flag is persisted and restored when the service is created.
+func (s *Service) SetMaintenanceMode(ctx context.Context, enabled bool) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(enabled)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance mode: %w", err)
+	}
+	if err := s.storage.Save(ctx, maintenanceStorageKey, data); err != nil {
+		return fmt.Errorf("failed to save maintenance mode: %w", err)
+	}
+
+	if s.maintenance.Swap(enabled) != enabled {
+		s.logger.Info("Maintenance mode changed", zap.Bool("enabled", enabled))
+	}
+	return nil
+}
+
+// loadMaintenanceMode restores the persisted maintenance mode flag
+func (s *Service) loadMaintenanceMode(ctx context.Context) error {
+	data, err := s.storage.Load(ctx, maintenanceStorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load maintenance mode: %w", err)
+	}
+
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err != nil {
+		return fmt.Errorf("failed to unmarshal maintenance mode: %w", err)
+	}
+	s.maintenance.Store(enabled)
+	if enabled {
+		s.logger.Warn("Node is in maintenance mode, new operations are rejected until it is disabled")
+	}
+	return nil
+}
+
+// checkMaintenance returns ErrReadOnly on read-only replicas and ErrMaintenanceMode while
+// new operations are paused
+func (s *Service) checkMaintenance() error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	if s.maintenance.Load() {
+		return fmt.Errorf("%w: node %s is not accepting new operations, retry later or use another node",
+			ErrMaintenanceMode, s.nodeID)
+	}
+	return nil
+}
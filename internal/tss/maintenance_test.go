@@ -0,0 +1,64 @@
+package tss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+func TestMaintenanceModeRejectsNewOperations(t *testing.T) {
+	ctx := context.Background()
+	hub := p2p.NewMemoryHub()
+	transport, err := hub.NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+	s, err := NewService(&Config{PeerID: transport.GetHostID(), KDF: testKDF}, store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+	require.False(t, s.MaintenanceMode())
+
+	done := &Operation{
+		ID:        "op-done",
+		Type:      OperationSigning,
+		Status:    StatusCompleted,
+		CreatedAt: time.Now(),
+		Request:   &SigningRequest{Message: []byte("hello"), KeyID: "0x1111111111111111111111111111111111111111"},
+		Result:    &SigningResult{Signature: "0x01", V: 27},
+	}
+	require.NoError(t, s.saveOperation(ctx, done))
+
+	require.NoError(t, s.SetMaintenanceMode(ctx, true))
+	require.True(t, s.MaintenanceMode())
+
+	participants := []string{transport.GetHostID(), "peer2"}
+	keyID := "0x1111111111111111111111111111111111111111"
+	_, err = s.StartKeygen(ctx, "", 1, participants, "", "", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrMaintenanceMode)
+	_, err = s.StartSigning(ctx, "", []byte("hello"), keyID, participants, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrMaintenanceMode)
+	_, err = s.StartResharing(ctx, "", keyID, 1, participants, OperationOptions{})
+	require.ErrorIs(t, err, ErrMaintenanceMode)
+	_, err = s.RefreshShares(ctx, "", keyID, OperationOptions{})
+	require.ErrorIs(t, err, ErrMaintenanceMode)
+
+	// Reads keep working
+	data, err := s.GetOperationData(ctx, "op-done")
+	require.NoError(t, err)
+	require.Equal(t, StatusCompleted, data.Status)
+
+	// The flag survives a restart on the same storage
+	restarted, err := NewService(&Config{PeerID: transport.GetHostID(), KDF: testKDF}, store, transport, zap.NewNop(), "test-password")
+	require.NoError(t, err)
+	require.True(t, restarted.MaintenanceMode())
+
+	require.NoError(t, restarted.SetMaintenanceMode(ctx, false))
+	require.False(t, restarted.MaintenanceMode())
+	_, err = restarted.StartKeygen(ctx, "", 1, participants, "not an alias!", "", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrInvalidKeyAlias)
+}
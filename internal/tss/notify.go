@@ -0,0 +1,52 @@
+package tss
+
+import (
+	"time"
+
+	"github.com/dreamer-zq/DKNet/internal/notify"
+)
+
+// notifyOperation sends a notification about an operation that reached a terminal state
+func (s *Service) notifyOperation(op *Operation) {
+	op.RLock()
+	event := &notify.Operation{
+		ID:     op.ID,
+		Type:   string(op.Type),
+		Status: string(op.Status),
+		KeyID:  operationKeyID(op),
+	}
+	for _, party := range op.Participants {
+		event.Participants = append(event.Participants, party.Id)
+	}
+	if op.Error != nil {
+		event.Error = op.Error.Error()
+	}
+	if op.CompletedAt != nil {
+		event.Duration = op.CompletedAt.Sub(op.CreatedAt)
+	} else {
+		event.Duration = time.Since(op.CreatedAt)
+	}
+	status := op.Status
+	op.RUnlock()
+
+	switch status {
+	case StatusCompleted:
+		s.notifier.OperationCompleted(event)
+	case StatusFailed, StatusCancelled, StatusTimedOut:
+		s.notifier.OperationFailed(event)
+	}
+}
+
+// operationKeyID returns the ID of the key an operation used or generated, if known
+func operationKeyID(op *Operation) string {
+	switch r := op.Request.(type) {
+	case *SigningRequest:
+		return r.KeyID
+	case *ResharingRequest:
+		return r.KeyID
+	}
+	if result, ok := op.Result.(*KeygenResult); ok {
+		return result.KeyID
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package tss
+
+import (
+	"container/list"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// operationCache is an LRU cache of finished operations read from or written to storage,
+// so clients polling a finished operation do not load and decode it on every request.
+// Finished operations never change, an entry is only replaced when the operation is saved
+// again. A nil cache caches nothing.
+type operationCache struct {
+	size    int
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used first, values are *OperationData
+}
+
+// newOperationCache creates a cache of up to size operations, nil when size is not positive
+func newOperationCache(size int) *operationCache {
+	if size <= 0 {
+		return nil
+	}
+	return &operationCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// get returns a copy of the cached operation
+func (c *operationCache) get(operationID string) (*OperationData, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[operationID]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*OperationData).clone(), true
+}
+
+// put caches a copy of data, evicting the least recently used operation when full
+func (c *operationCache) put(data *OperationData) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[data.ID]; ok {
+		elem.Value = data.clone()
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[data.ID] = c.order.PushFront(data.clone())
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*OperationData).ID)
+	}
+}
+
+// clone copies the operation data as Snapshot does, the request and result are shared
+func (d *OperationData) clone() *OperationData {
+	copied := *d
+	copied.Participants = slices.Clone(d.Participants)
+	copied.Labels = maps.Clone(d.Labels)
+	copied.Events = slices.Clone(d.Events)
+	copied.RoundTimings = slices.Clone(d.RoundTimings)
+	if d.CompletedAt != nil {
+		completedAt := *d.CompletedAt
+		copied.CompletedAt = &completedAt
+	}
+	return &copied
+}
@@ -0,0 +1,125 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// countingStorage counts the loads of the storage it wraps
+type countingStorage struct {
+	storage.Storage
+	loads atomic.Int64
+}
+
+func (c *countingStorage) Load(ctx context.Context, key string) ([]byte, error) {
+	c.loads.Add(1)
+	return c.Storage.Load(ctx, key)
+}
+
+// newCachedTestService returns a test service with an operation cache of size over a
+// storage counting its loads
+func newCachedTestService(t testing.TB, size int) (*Service, *countingStorage) {
+	s, store := newTestService(t, false)
+	counting := &countingStorage{Storage: store}
+	s.storage = counting
+	s.operationCache = newOperationCache(size)
+	return s, counting
+}
+
+func saveCompletedOperation(t testing.TB, s *Service, id string) {
+	t.Helper()
+	completedAt := time.Now()
+	require.NoError(t, s.saveOperation(context.Background(), &Operation{
+		ID:          id,
+		Type:        OperationSigning,
+		Status:      StatusCompleted,
+		CreatedAt:   completedAt.Add(-time.Second),
+		CompletedAt: &completedAt,
+		Labels:      map[string]string{"team": "payments"},
+		Request:     &SigningRequest{Message: []byte("hello"), KeyID: "0x1111111111111111111111111111111111111111"},
+		Result:      &SigningResult{Signature: "0x01", V: 27},
+	}))
+}
+
+func TestOperationCacheServesFinishedOperations(t *testing.T) {
+	ctx := context.Background()
+	s, store := newCachedTestService(t, 2)
+
+	saveCompletedOperation(t, s, "op-1")
+	saveCompletedOperation(t, s, "op-2")
+
+	// Saved operations are cached, polling them does not read storage
+	for range 3 {
+		data, err := s.GetOperationData(ctx, "op-1")
+		require.NoError(t, err)
+		require.Equal(t, StatusCompleted, data.Status)
+		require.Equal(t, &SigningResult{Signature: "0x01", V: 27}, data.Result)
+	}
+	require.Zero(t, store.loads.Load())
+
+	// Callers get copies they may modify
+	data, err := s.GetOperationData(ctx, "op-1")
+	require.NoError(t, err)
+	data.Labels["team"] = "changed"
+	data.Status = StatusFailed
+	data, err = s.GetOperationData(ctx, "op-1")
+	require.NoError(t, err)
+	require.Equal(t, "payments", data.Labels["team"])
+	require.Equal(t, StatusCompleted, data.Status)
+
+	// op-2 is the least recently used and evicted, it is loaded and cached again
+	saveCompletedOperation(t, s, "op-3")
+	_, err = s.GetOperationData(ctx, "op-2")
+	require.NoError(t, err)
+	_, err = s.GetOperationData(ctx, "op-2")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), store.loads.Load())
+
+	// Operations that do not exist are not cached
+	_, err = s.GetOperationData(ctx, "op-missing")
+	require.Error(t, err)
+}
+
+func TestOperationCacheDisabled(t *testing.T) {
+	ctx := context.Background()
+	s, store := newCachedTestService(t, 0)
+	require.Nil(t, s.operationCache)
+
+	saveCompletedOperation(t, s, "op-1")
+	for range 3 {
+		_, err := s.GetOperationData(ctx, "op-1")
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(3), store.loads.Load())
+}
+
+// BenchmarkGetOperationPolling polls a few finished operations, as clients waiting for
+// results do, and reports the storage reads per poll with and without the cache
+func BenchmarkGetOperationPolling(b *testing.B) {
+	for _, size := range []int{0, 256} {
+		b.Run(fmt.Sprintf("cache=%d", size), func(b *testing.B) {
+			ctx := context.Background()
+			s, store := newCachedTestService(b, size)
+			ids := make([]string, 8)
+			for i := range ids {
+				ids[i] = fmt.Sprintf("op-%d", i)
+				saveCompletedOperation(b, s, ids[i])
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.GetOperationData(ctx, ids[i%len(ids)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(store.loads.Load())/float64(b.N), "reads/op")
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package tss
+
+import "time"
+
+// OperationOptions are the optional parameters of keygen and resharing operations
+type OperationOptions struct {
+	// Labels are key/value pairs ListOperations can filter by
+	Labels map[string]string
+	// Timeout asks the operation to time out after it instead of the default of its type.
+	// It is only applied if it lies within the configured bounds and only on the initiating
+	// node, zero keeps the default.
+	Timeout time.Duration
+	// Policy is stored with a generated key, nil for none. Resharing keeps the policy of
+	// the key and ignores it.
+	Policy *KeyPolicy
+}
+
+// SigningOptions are the optional parameters of signing operations
+type SigningOptions struct {
+	// Labels are key/value pairs ListOperations can filter by
+	Labels map[string]string
+	// Timeout asks the operation to time out after it instead of the default, see
+	// OperationOptions.Timeout
+	Timeout time.Duration
+	// Metadata is client context forwarded to the validation service
+	Metadata map[string]string
+	// DerivationPath signs with the BIP32 child key at the path (e.g. m/0/1) of the key
+	// instead of the key itself. Only non-hardened paths are supported.
+	DerivationPath string
+	// ContextBound signs a context-bound digest, see ContextBoundDigest. The signature is
+	// then only valid for the key and session it was produced in, a coordinator replaying
+	// the request under another operation gets a signature over another digest.
+	ContextBound bool
+}
@@ -0,0 +1,18 @@
+package tss
+
+import "context"
+
+// operationOwnerKey is the context key of the client starting an operation
+type operationOwnerKey struct{}
+
+// WithOperationOwner returns a context that records owner, the authenticated client,
+// on operations started with it. The owner is kept on the initiating node only.
+func WithOperationOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, operationOwnerKey{}, owner)
+}
+
+// operationOwner returns the owner requested by WithOperationOwner, empty if none
+func operationOwner(ctx context.Context) string {
+	owner, _ := ctx.Value(operationOwnerKey{}).(string)
+	return owner
+}
@@ -0,0 +1,131 @@
+package tss
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// resolveParticipants replaces node names in participants with the peer IDs they are
+// configured for. Entries that are not node names are kept as given, so peer IDs keep
+// working. Names are case insensitive and this node's moniker resolves to its own peer ID.
+//
+// Names are resolved on the initiating node only, participants are always synced and
+// stored as peer IDs, the same identity existing keys were generated with.
+func (s *Service) resolveParticipants(participants []string) ([]string, error) {
+	resolved := make([]string, 0, len(participants))
+	seen := make(map[string]string, len(participants))
+	var duplicates []string
+	for _, participant := range participants {
+		peerID := s.resolveNodeName(participant)
+		if previous, ok := seen[peerID]; ok {
+			if previous == participant {
+				duplicates = append(duplicates, fmt.Sprintf("%q is given twice", participant))
+			} else {
+				duplicates = append(duplicates, fmt.Sprintf("%q and %q are the same node %s", previous, participant, peerID))
+			}
+			continue
+		}
+		seen[peerID] = participant
+		resolved = append(resolved, peerID)
+	}
+	if len(duplicates) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidParticipants, strings.Join(duplicates, ", "))
+	}
+	return resolved, nil
+}
+
+// checkDuplicateParticipants returns ErrInvalidParticipants listing the peer IDs that occur
+// more than once. A duplicate would be counted as two parties and skew the threshold.
+func checkDuplicateParticipants(peerIDs []string) error {
+	counts := make(map[string]int, len(peerIDs))
+	var duplicates []string
+	for _, peerID := range peerIDs {
+		counts[peerID]++
+		if counts[peerID] == 2 {
+			duplicates = append(duplicates, peerID)
+		}
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%w: duplicate participants %s", ErrInvalidParticipants, strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// checkParticipant returns ErrNotParticipant when this node is not one of the participants
+func (s *Service) checkParticipant(participants []string) error {
+	if !slices.Contains(participants, s.nodeID) {
+		return fmt.Errorf("%w: %s is not among %v", ErrNotParticipant, s.nodeID, participants)
+	}
+	return nil
+}
+
+// checkSigningParties checks a signing participant set and the threshold announced by the
+// initiator (0 when initiating) against the stored key, so a mismatched set is rejected up
+// front instead of failing mid-protocol
+func checkSigningParties(key *keyData, participants []string, announcedThreshold int) error {
+	if announcedThreshold != 0 && announcedThreshold != key.Threshold {
+		return fmt.Errorf("%w: initiator signs with threshold %d, the key has threshold %d",
+			ErrThresholdMismatch, announcedThreshold, key.Threshold)
+	}
+	if len(participants) < key.Threshold+1 {
+		return fmt.Errorf("%w: %d participants cannot sign with threshold %d, at least %d are required",
+			ErrInvalidParticipants, len(participants), key.Threshold, key.Threshold+1)
+	}
+	if len(participants) > len(key.Participants) {
+		return fmt.Errorf("%w: %d participants given, the key has %d",
+			ErrInvalidParticipants, len(participants), len(key.Participants))
+	}
+	for _, participant := range participants {
+		if !slices.Contains(key.Participants, participant) {
+			return fmt.Errorf("%w: %s holds no share of the key", ErrInvalidParticipants, participant)
+		}
+	}
+	return nil
+}
+
+// trimSigningQuorum returns the threshold+1 parties that sign for participants: this node and
+// the first other participants in the order they were listed. Smaller sets are returned as is.
+func trimSigningQuorum(participants []string, threshold int, nodeID string) []string {
+	if len(participants) <= threshold+1 {
+		return participants
+	}
+
+	quorum := make([]string, 0, threshold+1)
+	others := threshold
+	for _, participant := range participants {
+		if participant == nodeID {
+			quorum = append(quorum, participant)
+		} else if others > 0 {
+			quorum = append(quorum, participant)
+			others--
+		}
+	}
+	return quorum
+}
+
+// resolveNodeName returns the peer ID of a node name, or participant itself if it is not a
+// node name. Peer IDs are never looked up, a name cannot shadow another node's peer ID.
+func (s *Service) resolveNodeName(participant string) string {
+	if _, err := peer.Decode(participant); err == nil {
+		return participant
+	}
+	if peerID, ok := s.nodeNames[strings.ToLower(participant)]; ok {
+		return peerID
+	}
+	if s.moniker != "" && strings.EqualFold(participant, s.moniker) {
+		return s.nodeID
+	}
+	return participant
+}
+
+// NodeName returns the moniker of this node for its own peer ID and the configured node
+// name for other peer IDs, or an empty string if the node has no name for peerID.
+func (s *Service) NodeName(peerID string) string {
+	if peerID == s.nodeID {
+		return s.moniker
+	}
+	return s.peerNames[peerID]
+}
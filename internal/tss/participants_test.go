@@ -0,0 +1,188 @@
+package tss
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+func TestResolveParticipants(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID, s.moniker = "peer-self", "node1"
+	s.nodeNames = map[string]string{"alice": "peer-alice", "bob": "peer-bob"}
+
+	participants, err := s.resolveParticipants([]string{"Node1", "ALICE", "bob", "peer-carol"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"peer-self", "peer-alice", "peer-bob", "peer-carol"}, participants)
+
+	// A node named both by name and by peer ID is a duplicate
+	_, err = s.resolveParticipants([]string{"alice", "peer-alice"})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+
+	// Keys stored by peer ID resolve to themselves
+	participants, err = s.resolveParticipants([]string{"peer-self", "peer-alice"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"peer-self", "peer-alice"}, participants)
+
+	// Names cannot shadow real peer IDs
+	peerID := "12D3KooWGZCnvk6cX2UUhc1SHhkGvdfJNZicx4uXEb3niyHHN7ch"
+	s.nodeNames[strings.ToLower(peerID)] = "peer-alice"
+	participants, err = s.resolveParticipants([]string{peerID})
+	require.NoError(t, err)
+	require.Equal(t, []string{peerID}, participants)
+}
+
+func TestDuplicateParticipantsRejected(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID, s.moniker = "node1", "self"
+	keyID := "0x1111111111111111111111111111111111111111"
+
+	_, err := s.StartKeygen(ctx, "", 1, []string{"node1", "node1", "node2"}, "", "", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+	require.ErrorContains(t, err, `"node1" is given twice`)
+
+	_, err = s.StartSigning(ctx, "", []byte("message"), keyID, []string{"node1", "node2", "node2"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+	require.ErrorContains(t, err, `"node2" is given twice`)
+
+	// Every duplicate is listed, including a node given by name and by peer ID
+	_, err = s.StartResharing(ctx, "", keyID, 1, []string{"node1", "self", "node2", "node2"}, OperationOptions{})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+	require.ErrorContains(t, err, `"node1" and "self" are the same node node1, "node2" is given twice`)
+
+	// Participants synced from other nodes, such as the old and new participants of a
+	// resharing, are checked when the party set is built
+	_, err = s.createParticipantList([]string{"node1", "node2", "node1", "node3", "node2"}, 0)
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+	require.ErrorContains(t, err, "duplicate participants node1, node2")
+	parties, err := s.createParticipantList([]string{"node2", "node1"}, 0)
+	require.NoError(t, err)
+	require.Len(t, parties, 2)
+}
+
+func TestStartRejectsRequestsForOtherNodes(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	// Requests that do not list this node were sent to the wrong node
+	_, err := s.StartKeygen(ctx, "", 1, []string{"node2", "node3"}, "", "", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrNotParticipant)
+	_, err = s.StartSigning(ctx, "", []byte("message"), "0x1111111111111111111111111111111111111111",
+		[]string{"node2", "node3"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrNotParticipant)
+
+	// Keys this node never stored are not held
+	_, err = s.StartSigning(ctx, "", []byte("message"), "0x1111111111111111111111111111111111111111",
+		[]string{"node1", "node2"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+
+	// Neither are keys it knows of without being one of their participants
+	result := keygen.NewLocalPartySaveData(1)
+	require.NoError(t, s.saveKeyData(ctx, "0x2222222222222222222222222222222222222222", "0x2222222222222222222222222222222222222222",
+		&result, 1, []string{"node2", "node3"}, 0, "", "", nil))
+	_, err = s.StartSigning(ctx, "", []byte("message"), "0x2222222222222222222222222222222222222222",
+		[]string{"node1", "node2"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+}
+
+func TestCheckSigningParties(t *testing.T) {
+	key := &keyData{Threshold: 1, Participants: []string{"node1", "node2", "node3"}}
+
+	require.NoError(t, checkSigningParties(key, []string{"node1", "node2"}, 0))
+	require.NoError(t, checkSigningParties(key, []string{"node3", "node1", "node2"}, 1))
+
+	for name, participants := range map[string][]string{
+		"below threshold":  {"node1"},
+		"more than shares": {"node1", "node2", "node3", "node4"},
+		"not a key holder": {"node1", "node4"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, checkSigningParties(key, participants, 0), ErrInvalidParticipants)
+		})
+	}
+
+	// Participants of a synced operation reject an initiator with another threshold
+	require.ErrorIs(t, checkSigningParties(key, []string{"node1", "node2"}, 2), ErrThresholdMismatch)
+}
+
+func TestStartSigningChecksPartiesAgainstKey(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	keyID := "0x3333333333333333333333333333333333333333"
+	result := keygen.NewLocalPartySaveData(3)
+	require.NoError(t, s.saveKeyData(ctx, keyID, keyID, &result, 2, []string{"node1", "node2", "node3"}, 0, "", "", nil))
+
+	_, err := s.StartSigning(ctx, "", []byte("message"), keyID, []string{"node1", "node2"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+	require.ErrorContains(t, err, "at least 3 are required")
+}
+
+func TestTrimSigningQuorum(t *testing.T) {
+	participants := []string{"node3", "node1", "node2", "node4"}
+	require.Equal(t, []string{"node3", "node1"}, trimSigningQuorum(participants, 1, "node1"))
+	require.Equal(t, []string{"node3", "node2"}, trimSigningQuorum(participants, 1, "node2"))
+	require.Equal(t, []string{"node3", "node1", "node2"}, trimSigningQuorum(participants, 2, "node2"))
+	require.Equal(t, participants, trimSigningQuorum(participants, 3, "node1"))
+}
+
+// recordingTransport records the recipients of every message sent
+type recordingTransport struct {
+	p2p.Transport
+	mu         sync.Mutex
+	recipients map[string]bool
+}
+
+func (r *recordingTransport) SendMessage(_ context.Context, msg *p2p.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, to := range msg.To {
+		r.recipients[to] = true
+	}
+	return nil
+}
+
+func (r *recordingTransport) sentTo() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.recipients))
+}
+
+func TestStartSigningTrimsQuorum(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+	transport := &recordingTransport{recipients: make(map[string]bool)}
+	s.network = transport
+	s.trimSigningQuorum = true
+
+	result, err := s.ImportKeyShare(ctx, newExternalKeyShare(t, s, 1, "node1", "node2", "node3"))
+	require.NoError(t, err)
+
+	op, err := s.StartSigning(ctx, "op-trimmed", []byte("message"), result.KeyID,
+		[]string{"node3", "node2", "node1"}, 0, SigningOptions{})
+	require.NoError(t, err)
+	t.Cleanup(op.cancel)
+
+	// Only this node and the first other listed participant sign
+	require.Equal(t, []string{"node3", "node1"}, op.Request.(*SigningRequest).Participants)
+	require.Len(t, op.Participants, 2)
+	require.Eventually(t, func() bool {
+		return slices.Equal([]string{"node3"}, transport.sentTo())
+	}, time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool {
+		return slices.Contains(transport.sentTo(), "node2")
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
@@ -0,0 +1,120 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+const (
+	// passwordCheckStorageKey is the storage key of a value encrypted with the encryption
+	// password, decrypted at startup so a wrong password is noticed before keys are used
+	passwordCheckStorageKey = "node:password_check"
+	// passwordCheckPlaintext is the plaintext of the password check value
+	passwordCheckPlaintext = "dknet-password-check"
+	// kdfSaltStorageKey is the storage key of the salt the storage key is derived with
+	kdfSaltStorageKey = "node:kdf_salt"
+)
+
+// loadKDFSalt returns the salt the node derives its storage key with, generating and
+// storing it on first start. Read-only nodes without a stored salt use a random one.
+func loadKDFSalt(ctx context.Context, store storage.Storage, readOnly bool) ([]byte, error) {
+	salt, err := store.Load(ctx, kdfSaltStorageKey)
+	if err == nil {
+		return salt, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load key derivation salt: %w", err)
+	}
+
+	if salt, err = plugin.NewKDFSalt(); err != nil {
+		return nil, err
+	}
+	if readOnly {
+		return salt, nil
+	}
+	if err := store.Save(ctx, kdfSaltStorageKey, salt); err != nil {
+		return nil, fmt.Errorf("failed to save key derivation salt: %w", err)
+	}
+	return salt, nil
+}
+
+// verifyEncryptionPassword checks that the encryption password decrypts what this node
+// stored before. Nodes created before the check was introduced are verified against one
+// of their key shares instead. The check value is written when it is missing, so fresh
+// nodes are verified on every later start.
+func (s *Service) verifyEncryptionPassword(ctx context.Context) error {
+	data, err := s.storage.Load(ctx, passwordCheckStorageKey)
+	if err == nil {
+		plaintext, err := s.encryption.Decrypt(data)
+		if err != nil || !bytes.Equal(plaintext, []byte(passwordCheckPlaintext)) {
+			return fmt.Errorf("%w: failed to decrypt the password check value", ErrEncryptionPasswordMismatch)
+		}
+		if !s.readOnly && !s.encryption.Current(data) {
+			// Follow changes of the key derivation parameters like key data does
+			return s.savePasswordCheck(ctx)
+		}
+		return nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to load password check value: %w", err)
+	}
+
+	if err := s.verifyPasswordWithKey(ctx); err != nil {
+		return err
+	}
+	if s.readOnly {
+		return nil
+	}
+	return s.savePasswordCheck(ctx)
+}
+
+// verifyPasswordWithKey decrypts the share of the first stored key, nodes without keys
+// pass the check
+func (s *Service) verifyPasswordWithKey(ctx context.Context) error {
+	// Key shares are stored under their key ID, an address or a UUID without common prefix
+	candidates, err := s.storage.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+	for _, keyID := range candidates {
+		if !keyIDPattern.MatchString(keyID) {
+			continue
+		}
+		// The record is read directly, its integrity tag is derived from the password as well
+		// and would fail without telling a wrong password apart
+		data, err := s.storage.Load(ctx, keyID)
+		if err != nil {
+			return fmt.Errorf("failed to load key data: %w", err)
+		}
+		var record keyData
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("failed to unmarshal key data struct: %w", err)
+		}
+		if _, err := s.encryption.Decrypt(record.KeyData); err != nil {
+			return fmt.Errorf("%w: failed to decrypt key %s", ErrEncryptionPasswordMismatch, keyID)
+		}
+		s.logger.Info("Verified encryption password against a stored key", zap.String("key_id", keyID))
+		return nil
+	}
+	return nil
+}
+
+// savePasswordCheck stores the password check value encrypted with the current password
+func (s *Service) savePasswordCheck(ctx context.Context) error {
+	encrypted, err := s.encryption.Encrypt([]byte(passwordCheckPlaintext))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt password check value: %w", err)
+	}
+	if err := s.storage.Save(ctx, passwordCheckStorageKey, encrypted); err != nil {
+		return fmt.Errorf("failed to save password check value: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package tss
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// newPasswordTestService creates a service over store with the encryption password
+func newPasswordTestService(t *testing.T, store storage.Storage, password string) (*Service, error) {
+	t.Helper()
+	transport, err := p2p.NewMemoryHub().NewTransport(zap.NewNop())
+	require.NoError(t, err)
+	return NewService(&Config{PeerID: transport.GetHostID(), KDF: testKDF}, store, transport, zap.NewNop(), password)
+}
+
+func TestEncryptionPasswordCheckOnFreshNode(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+
+	// The first start stores the check value
+	_, err := newPasswordTestService(t, store, "test-password")
+	require.NoError(t, err)
+	check, err := store.Load(ctx, passwordCheckStorageKey)
+	require.NoError(t, err)
+
+	_, err = newPasswordTestService(t, store, "test-passw0rd")
+	require.ErrorIs(t, err, ErrEncryptionPasswordMismatch)
+
+	// The salt is kept across restarts, the check value stays current
+	s, err := newPasswordTestService(t, store, "test-password")
+	require.NoError(t, err)
+	require.True(t, s.encryption.Current(check))
+	restartedCheck, err := store.Load(ctx, passwordCheckStorageKey)
+	require.NoError(t, err)
+	require.Equal(t, check, restartedCheck)
+}
+
+func TestEncryptionPasswordCheckWithStoredKeys(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+
+	s, err := newPasswordTestService(t, store, "test-password")
+	require.NoError(t, err)
+	keyID := "0x1111111111111111111111111111111111111111"
+	require.NoError(t, s.saveKeyData(ctx, keyID, keyID, &keygen.LocalPartySaveData{}, 1, []string{"a", "b"}, 0, "", "", nil))
+
+	// A node from before the check has keys but no check value, the password is verified
+	// against a key share and the stored data is left alone
+	require.NoError(t, store.Delete(ctx, passwordCheckStorageKey))
+	stored, err := store.Load(ctx, keyID)
+	require.NoError(t, err)
+
+	_, err = newPasswordTestService(t, store, "test-passw0rd")
+	require.ErrorIs(t, err, ErrEncryptionPasswordMismatch)
+	require.ErrorContains(t, err, keyID)
+	unchanged, err := store.Load(ctx, keyID)
+	require.NoError(t, err)
+	require.Equal(t, stored, unchanged)
+	_, err = store.Load(ctx, passwordCheckStorageKey)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// The right password passes and stores the check value for later starts
+	_, err = newPasswordTestService(t, store, "test-password")
+	require.NoError(t, err)
+	_, err = store.Load(ctx, passwordCheckStorageKey)
+	require.NoError(t, err)
+	_, err = newPasswordTestService(t, store, "test-passw0rd")
+	require.ErrorIs(t, err, ErrEncryptionPasswordMismatch)
+}
@@ -0,0 +1,206 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// KeyPolicy restricts how a key signs. It is stored with the key on every participant and
+// enforced before, and in addition to, the node's validation service. The zero policy
+// allows everything the node allows.
+type KeyPolicy struct {
+	// MaxMessageBytes caps the size of the message or typed data, 0 for no key specific limit
+	MaxMessageBytes int `json:"max_message_bytes,omitempty"`
+	// AllowedHashModes lists the hash modes the key signs with, e.g. only eip712 for typed
+	// data, empty for all
+	AllowedHashModes []HashMode `json:"allowed_hash_modes,omitempty"`
+	// RequiredRoles lists the roles of which the client starting a signing operation needs
+	// at least one, empty when any client may sign. Only the initiating node knows the
+	// client, so only it checks the roles.
+	RequiredRoles []string `json:"required_roles,omitempty"`
+	// ValidationURL is a validation service every participant consults for this key in
+	// addition to the node's validation service, empty for none
+	ValidationURL string `json:"validation_url,omitempty"`
+}
+
+// defaultKeyValidationTimeoutSeconds bounds per-key validation requests when the node has
+// no validation service configured
+const defaultKeyValidationTimeoutSeconds = 30
+
+// callerRolesKey is the context key of the roles of the client starting an operation
+type callerRolesKey struct{}
+
+// WithCallerRoles returns a context that records roles, the roles of the authenticated
+// client, for the key policies of signing operations started with it
+func WithCallerRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, callerRolesKey{}, roles)
+}
+
+// callerRoles returns the roles recorded by WithCallerRoles, nil if none
+func callerRoles(ctx context.Context) []string {
+	roles, _ := ctx.Value(callerRolesKey{}).([]string)
+	return roles
+}
+
+// normalize validates the policy and returns it, nil when it allows everything
+func (p *KeyPolicy) normalize() (*KeyPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if p.MaxMessageBytes < 0 {
+		return nil, fmt.Errorf("%w: max message bytes cannot be negative", ErrInvalidKeyPolicy)
+	}
+	for i, mode := range p.AllowedHashModes {
+		if mode != HashModeEIP191 && mode != HashModeEIP712 && mode != HashModeSHA256d {
+			return nil, fmt.Errorf("%w: unknown hash mode %q, expected %s, %s or %s",
+				ErrInvalidKeyPolicy, mode, HashModeEIP191, HashModeEIP712, HashModeSHA256d)
+		}
+		if slices.Contains(p.AllowedHashModes[:i], mode) {
+			return nil, fmt.Errorf("%w: hash mode %s is given twice", ErrInvalidKeyPolicy, mode)
+		}
+	}
+	for i, role := range p.RequiredRoles {
+		if role == "" || strings.TrimSpace(role) != role {
+			return nil, fmt.Errorf("%w: invalid role %q", ErrInvalidKeyPolicy, role)
+		}
+		if slices.Contains(p.RequiredRoles[:i], role) {
+			return nil, fmt.Errorf("%w: role %s is given twice", ErrInvalidKeyPolicy, role)
+		}
+	}
+	if p.ValidationURL != "" {
+		u, err := url.Parse(p.ValidationURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: validation URL must be an absolute http or https URL", ErrInvalidKeyPolicy)
+		}
+	}
+
+	if p.MaxMessageBytes == 0 && len(p.AllowedHashModes) == 0 && len(p.RequiredRoles) == 0 && p.ValidationURL == "" {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// enforceKeyPolicy rejects a signing request the policy of key does not allow. The roles
+// of the client are checked when initiating, participants joining an operation do not know
+// the client.
+func (s *Service) enforceKeyPolicy(ctx context.Context, key *keyData, req *SigningRequest, initiating bool) error {
+	policy := key.Policy
+	if policy == nil {
+		return nil
+	}
+
+	if policy.MaxMessageBytes > 0 {
+		if size := max(len(req.Message), len(req.TypedData)); size > policy.MaxMessageBytes {
+			return fmt.Errorf("%w: message is %d bytes, the key allows %d", ErrKeyPolicyViolation, size, policy.MaxMessageBytes)
+		}
+	}
+	if len(policy.AllowedHashModes) > 0 {
+		if mode := signingHashMode(key.Family(), req.TypedData); !slices.Contains(policy.AllowedHashModes, mode) {
+			return fmt.Errorf("%w: the key does not sign %s requests", ErrKeyPolicyViolation, mode)
+		}
+	}
+	if initiating && len(policy.RequiredRoles) > 0 {
+		roles := callerRoles(ctx)
+		if !slices.ContainsFunc(policy.RequiredRoles, func(role string) bool { return slices.Contains(roles, role) }) {
+			return fmt.Errorf("%w: signing requires one of the roles %s", ErrKeyPolicyViolation,
+				strings.Join(policy.RequiredRoles, ", "))
+		}
+	}
+
+	if policy.ValidationURL == "" {
+		return nil
+	}
+	// The key's validation service fails closed whatever the node's on_error policy
+	resp, err := s.keyValidator(policy.ValidationURL).ValidateSigningRequest(ctx, validationRequest(req))
+	if err != nil {
+		s.logger.Error("Key validation service call failed",
+			zap.Error(err),
+			zap.String("key_id", req.KeyID),
+			zap.String("url", policy.ValidationURL))
+		return fmt.Errorf("key validation service call failed: %w", err)
+	}
+	if !resp.Approved {
+		s.logger.Warn("Signing request rejected by key validation service",
+			zap.String("key_id", req.KeyID),
+			zap.String("reason", resp.Reason))
+		return fmt.Errorf("%w: rejected by the key's validation service: %s", ErrKeyPolicyViolation, resp.Reason)
+	}
+	return nil
+}
+
+// keyValidator returns the validation service of a key policy's validation URL. It signs
+// requests and breaks the circuit as the node's validation service does, but does not send
+// its headers, which may hold credentials meant for the node's service only.
+func (s *Service) keyValidator(validationURL string) plugin.ValidationService {
+	s.validationMutex.Lock()
+	defer s.validationMutex.Unlock()
+
+	if validator, ok := s.keyValidators[validationURL]; ok {
+		return validator
+	}
+
+	cfg := &config.ValidationServiceConfig{
+		Enabled:        true,
+		URL:            validationURL,
+		TimeoutSeconds: defaultKeyValidationTimeoutSeconds,
+	}
+	if node := s.validationConfig; node != nil {
+		if node.TimeoutSeconds > 0 {
+			cfg.TimeoutSeconds = node.TimeoutSeconds
+		}
+		cfg.SignRequests = node.SignRequests
+		cfg.SigningSecret = node.SigningSecret
+		cfg.BreakerFailureThreshold = node.BreakerFailureThreshold
+		cfg.BreakerCooldownSeconds = node.BreakerCooldownSeconds
+	}
+	validator := plugin.NewHTTPValidationService(cfg, s.nodeID, s.network.PrivateKey(), s.logger)
+	if s.keyValidators == nil {
+		s.keyValidators = make(map[string]plugin.ValidationService)
+	}
+	s.keyValidators[validationURL] = validator
+	return validator
+}
+
+// UpdateKeyPolicy replaces the policy of a key on this node, keyID may also be a key alias.
+// An empty policy removes it. Every participant enforces its own copy, the policy must be
+// updated on each of them.
+func (s *Service) UpdateKeyPolicy(ctx context.Context, keyID string, policy *KeyPolicy) (string, *KeyPolicy, error) {
+	if err := s.checkWritable(); err != nil {
+		return "", nil, err
+	}
+	policy, err := policy.normalize()
+	if err != nil {
+		return "", nil, err
+	}
+	if keyID, err = s.ResolveKeyID(ctx, keyID); err != nil {
+		return "", nil, err
+	}
+
+	s.keyPolicyMutex.Lock()
+	defer s.keyPolicyMutex.Unlock()
+
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil, fmt.Errorf("%w: %s", ErrKeyNotHeld, keyID)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	metadata.Policy = policy
+	if err := s.saveKeyRecord(ctx, keyID, metadata); err != nil {
+		return "", nil, err
+	}
+
+	s.logger.Info("Key policy updated", zap.String("key_id", keyID), zap.Any("policy", policy))
+	return keyID, policy, nil
+}
@@ -0,0 +1,120 @@
+package tss
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+func TestKeyPolicyNormalize(t *testing.T) {
+	policy, err := (&KeyPolicy{}).normalize()
+	require.NoError(t, err)
+	require.Nil(t, policy)
+
+	policy, err = (&KeyPolicy{AllowedHashModes: []HashMode{HashModeEIP712}, RequiredRoles: []string{"signer"}}).normalize()
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+
+	for name, invalid := range map[string]*KeyPolicy{
+		"negative size":      {MaxMessageBytes: -1},
+		"unknown hash mode":  {AllowedHashModes: []HashMode{"sha3"}},
+		"repeated hash mode": {AllowedHashModes: []HashMode{HashModeEIP191, HashModeEIP191}},
+		"empty role":         {RequiredRoles: []string{""}},
+		"relative URL":       {ValidationURL: "/validate"},
+		"unsupported scheme": {ValidationURL: "ftp://validator/validate"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := invalid.normalize()
+			require.ErrorIs(t, err, ErrInvalidKeyPolicy)
+		})
+	}
+}
+
+func TestStartKeygenRejectsInvalidKeyPolicy(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	opts := OperationOptions{Policy: &KeyPolicy{MaxMessageBytes: -1}}
+	_, err := s.StartKeygen(context.Background(), "", 1, []string{"node1", "node2"}, "", "", "", opts)
+	require.ErrorIs(t, err, ErrInvalidKeyPolicy)
+}
+
+func TestStartSigningEnforcesKeyPolicy(t *testing.T) {
+	ctx := context.Background()
+	s := newTestNode(t, p2p.NewMemoryHub())
+
+	var validated atomic.Int64
+	validator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		validated.Add(1)
+		_, _ = w.Write([]byte(`{"approved": false, "reason": "outside business hours"}`))
+	}))
+	defer validator.Close()
+
+	// Two signers are too few for the key, requests the policy allows fail on the parties
+	keyID := "0x4444444444444444444444444444444444444444"
+	participants := []string{s.nodeID, "node2", "node3"}
+	result := keygen.NewLocalPartySaveData(3)
+	require.NoError(t, s.saveKeyData(ctx, keyID, keyID, &result, 2, participants, 0, "", "", &KeyPolicy{
+		MaxMessageBytes:  1024,
+		AllowedHashModes: []HashMode{HashModeEIP191},
+		RequiredRoles:    []string{"signer", "admin"},
+	}))
+	signers := participants[:2]
+	signerCtx := WithCallerRoles(ctx, []string{"operator", "signer"})
+
+	_, err := s.StartSigning(signerCtx, "", []byte("short"), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+
+	_, err = s.StartSigning(signerCtx, "", make([]byte, 1025), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+	require.ErrorContains(t, err, "the key allows 1024")
+
+	_, err = s.StartTypedDataSigning(signerCtx, "", []byte(mailTypedData), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+	require.ErrorContains(t, err, "eip712")
+
+	// Clients without one of the roles, or unauthenticated, may not sign
+	_, err = s.StartSigning(WithCallerRoles(ctx, []string{"operator"}), "", []byte("short"), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+	_, err = s.StartSigning(ctx, "", []byte("short"), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+
+	// Participants joining an operation do not know the client and skip the roles
+	req := &SigningRequest{Message: []byte("short"), KeyID: keyID, Participants: signers}
+	key, err := s.LoadKeyMetadata(ctx, keyID)
+	require.NoError(t, err)
+	require.NoError(t, s.enforceKeyPolicy(ctx, key, req, false))
+
+	// The key's validation service is consulted in addition to the node's
+	resolvedKeyID, policy, err := s.UpdateKeyPolicy(ctx, keyID, &KeyPolicy{ValidationURL: validator.URL})
+	require.NoError(t, err)
+	require.Equal(t, keyID, resolvedKeyID)
+	require.Equal(t, validator.URL, policy.ValidationURL)
+
+	_, err = s.StartSigning(ctx, "", []byte("short"), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+	require.ErrorContains(t, err, "outside business hours")
+	require.Equal(t, int64(1), validated.Load())
+
+	// An empty policy removes it
+	_, policy, err = s.UpdateKeyPolicy(ctx, keyID, &KeyPolicy{})
+	require.NoError(t, err)
+	require.Nil(t, policy)
+	key, err = s.LoadKeyMetadata(ctx, keyID)
+	require.NoError(t, err)
+	require.Nil(t, key.Policy)
+	require.Equal(t, 2, key.Threshold)
+
+	_, err = s.StartSigning(ctx, "", []byte("short"), keyID, signers, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrInvalidParticipants)
+	require.Equal(t, int64(1), validated.Load())
+
+	_, _, err = s.UpdateKeyPolicy(ctx, "0x5555555555555555555555555555555555555555", &KeyPolicy{MaxMessageBytes: 1})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+}
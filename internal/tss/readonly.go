@@ -0,0 +1,25 @@
+package tss
+
+import "fmt"
+
+// Modes a node runs in
+const (
+	// ModeFull starts and joins operations
+	ModeFull = "full"
+	// ModeReadOnly only serves queries from storage it does not write to, it neither starts
+	// nor joins operations
+	ModeReadOnly = "readonly"
+)
+
+// ReadOnly reports whether this node is a read-only replica
+func (s *Service) ReadOnly() bool {
+	return s.readOnly
+}
+
+// checkWritable returns ErrReadOnly on read-only replicas
+func (s *Service) checkWritable() error {
+	if s.readOnly {
+		return fmt.Errorf("%w: node %s only serves queries, use another node", ErrReadOnly, s.nodeID)
+	}
+	return nil
+}
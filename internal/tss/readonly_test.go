@@ -0,0 +1,72 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+func TestReadOnlyServesQueriesAndRefusesWrites(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, true)
+	s.nodeID = "node1"
+
+	imported, err := s.ImportKeyShare(ctx, newExternalKeyShare(t, s, 1, "node1", "node2", "node3"))
+	require.NoError(t, err)
+	require.NoError(t, s.saveOperation(ctx, &Operation{
+		ID:        "op-done",
+		Type:      OperationSigning,
+		Status:    StatusCompleted,
+		CreatedAt: time.Now(),
+		Request:   &SigningRequest{Message: []byte("hello"), KeyID: imported.KeyID},
+		Result:    &SigningResult{Signature: "0x01", V: 27},
+	}))
+
+	s.readOnly = true
+	require.True(t, s.ReadOnly())
+
+	// Reads keep working
+	data, err := s.GetOperationData(ctx, "op-done")
+	require.NoError(t, err)
+	require.Equal(t, StatusCompleted, data.Status)
+	metadata, err := s.LoadKeyMetadata(ctx, imported.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"node1", "node2", "node3"}, metadata.Participants)
+
+	// Writes are refused
+	participants := []string{"node1", "node2"}
+	_, err = s.StartKeygen(ctx, "", 1, participants, "", "", "", OperationOptions{})
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = s.StartSigning(ctx, "", []byte("hello"), imported.KeyID, participants, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = s.StartResharing(ctx, "", imported.KeyID, 1, participants, OperationOptions{})
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = s.RefreshShares(ctx, "", imported.KeyID, OperationOptions{})
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = s.ImportKeyShare(ctx, newExternalKeyShare(t, s, 1, "node1", "node2"))
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, _, err = s.UpdateKeyPolicy(ctx, imported.KeyID, &KeyPolicy{MaxMessageBytes: 32})
+	require.ErrorIs(t, err, ErrReadOnly)
+	require.ErrorIs(t, s.SetMaintenanceMode(ctx, true), ErrReadOnly)
+
+	// Operations started by other nodes are not joined
+	syncData, err := json.Marshal(&KeygenSyncData{
+		OperationSyncData: OperationSyncData{
+			OperationID:   "op-remote",
+			OperationType: OperationKeygen,
+			SessionID:     "session-remote",
+			Threshold:     1,
+			Participants:  []string{"node2", "node1"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.HandleMessage(ctx, &p2p.Message{Type: string(OperationSync), From: "node2", Data: syncData}))
+	require.Empty(t, s.operations)
+	_, err = s.GetOperationData(ctx, "op-remote")
+	require.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package tss
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// requestIDKey is the context key of the ID of the client request starting an operation
+type requestIDKey struct{}
+
+// WithRequestID returns a context that records requestID on operations started with it.
+// The ID is synced to the other participants and included in operation logs, so a
+// client request can be traced across nodes.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestID returns the request ID recorded by WithRequestID, empty if none
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// operationLogger returns the logger of an operation, annotated with its ID and the ID
+// of the request that started it
+func (s *Service) operationLogger(operation *Operation) *zap.Logger {
+	logger := s.logger.With(zap.String("operation_id", operation.ID))
+	if operation.RequestID != "" {
+		logger = logger.With(zap.String("request_id", operation.RequestID))
+	}
+	return logger
+}
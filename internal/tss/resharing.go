@@ -3,6 +3,7 @@ package tss
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
@@ -15,8 +16,12 @@ import (
 
 	"github.com/dreamer-zq/DKNet/internal/common"
 	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
 )
 
+// peerKeyTimeout bounds how long resharing waits for missing participant public keys
+const peerKeyTimeout = 15 * time.Second
+
 // resharingOperationParams contains parameters for creating a resharing operation
 type resharingOperationParams struct {
 	OperationID     string
@@ -24,15 +29,23 @@ type resharingOperationParams struct {
 	KeyID           string
 	NewThreshold    int
 	NewParticipants []string
+	Labels          map[string]string
+	// Timeout bounds the operation, defaultResharingTimeout when zero
+	Timeout time.Duration
+	// Owner is the authenticated client that started the operation, if any
+	Owner string
+	// RequestID is the ID of the client request that started the operation, if any
+	RequestID string
 }
 
-// StartResharing starts a new resharing operation
+// StartResharing starts a new resharing operation, keyID may also be a key alias
 func (s *Service) StartResharing(
 	ctx context.Context,
 	operationID,
 	keyID string,
 	newThreshold int,
 	newParticipants []string,
+	opts OperationOptions,
 ) (*Operation, error) {
 	// Check for existing operation (idempotency)
 	existingOp, err := s.checkIdempotency(ctx, operationID)
@@ -44,10 +57,27 @@ func (s *Service) StartResharing(
 		return existingOp, nil
 	}
 
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	if err := validateOperationLabels(opts.Labels); err != nil {
+		return nil, err
+	}
+	if newParticipants, err = s.resolveParticipants(newParticipants); err != nil {
+		return nil, err
+	}
+	if err := checkResharingThreshold(newThreshold, len(newParticipants)); err != nil {
+		return nil, err
+	}
+	if keyID, err = s.ResolveKeyID(ctx, keyID); err != nil {
+		return nil, err
+	}
+
 	// Load key metadata to get old participants
-	keyData, err := s.LoadKeyMetadata(ctx, keyID)
+	keyData, err := s.loadInitiatorKeyMetadata(ctx, keyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load key metadata: %w", err)
+		return nil, err
 	}
 
 	// Generate or use provided operation ID
@@ -61,6 +91,10 @@ func (s *Service) StartResharing(
 		KeyID:           keyID,
 		NewThreshold:    newThreshold,
 		NewParticipants: newParticipants,
+		Labels:          opts.Labels,
+		Timeout:         s.operationTimeout(opts.Timeout, defaultResharingTimeout),
+		Owner:           operationOwner(ctx),
+		RequestID:       requestID(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -68,14 +102,24 @@ func (s *Service) StartResharing(
 
 	// Broadcast resharing operation sync message to other participants
 	common.SafeGo(operation.EndCh, func() any {
+		// Participants never queue, the sync waits until the operation may run here
+		if !operation.awaitAdmission() {
+			return nil
+		}
 		return s.syncResharingOperation(
 			operationID,
 			sessionID,
+			operation.RequestID,
 			keyID,
 			keyData.Threshold,
 			newThreshold,
 			keyData.Participants,
 			newParticipants,
+			keyData.Generation,
+			operation.Request.(*ResharingRequest).PublicKey,
+			keyData.Alias,
+			keyData.ChainFamily,
+			keyData.Policy,
 		)
 	})
 
@@ -87,15 +131,66 @@ func (s *Service) StartResharing(
 	return operation, nil
 }
 
+// RefreshShares reshares a key to its current participants and threshold, keyID may also
+// be a key alias. Every participant receives a fresh share of the same public key, and the
+// previous shares can no longer be combined with the new ones, which limits how long a
+// leaked share is useful.
+func (s *Service) RefreshShares(ctx context.Context, operationID, keyID string, opts OperationOptions) (*Operation, error) {
+	// Check for existing operation (idempotency)
+	existingOp, err := s.checkIdempotency(ctx, operationID)
+	if err != nil {
+		return nil, err
+	}
+	if existingOp != nil {
+		return existingOp, nil
+	}
+
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	if keyID, err = s.ResolveKeyID(ctx, keyID); err != nil {
+		return nil, err
+	}
+	keyData, err := s.loadInitiatorKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.StartResharing(ctx, operationID, keyID, keyData.Threshold, keyData.Participants, opts)
+}
+
+// loadInitiatorKeyMetadata loads the metadata of a key this node starts resharing, only
+// old participants hold the share the operation needs
+func (s *Service) loadInitiatorKeyMetadata(ctx context.Context, keyID string) (*keyData, error) {
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotHeld, keyID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key metadata: %w", err)
+	}
+	if !slices.Contains(metadata.Participants, s.nodeID) {
+		return nil, fmt.Errorf("%w: %s is not a participant of %s", ErrKeyNotHeld, s.nodeID, keyID)
+	}
+	return metadata, nil
+}
+
 func (s *Service) syncResharingOperation(
-	operationID, sessionID string,
+	operationID, sessionID, requestID string,
 	keyID string,
 	oldThreshold int,
 	newThreshold int,
 	oldParticipants, newParticipants []string,
+	oldGeneration int,
+	publicKey string,
+	alias string,
+	chainFamily ChainFamily,
+	policy *KeyPolicy,
 ) error {
 	s.logger.Info("Broadcast resharing operation",
 		zap.String("operation_id", operationID),
+		zap.String("request_id", requestID),
 		zap.String("session_id", sessionID),
 		zap.String("key_id", keyID),
 		zap.Int("old_threshold", oldThreshold),
@@ -110,6 +205,7 @@ func (s *Service) syncResharingOperation(
 	syncData := &ResharingSyncData{
 		OperationSyncData: OperationSyncData{
 			OperationID:   operationID,
+			RequestID:     requestID,
 			OperationType: "resharing",
 			SessionID:     sessionID,
 			Threshold:     newThreshold,
@@ -120,7 +216,12 @@ func (s *Service) syncResharingOperation(
 		NewThreshold:    newThreshold,
 		OldParticipants: oldParticipants,
 		NewParticipants: newParticipants,
+		OldGeneration:   oldGeneration,
 		KeyID:           keyID,
+		PublicKey:       publicKey,
+		Alias:           alias,
+		ChainFamily:     chainFamily,
+		Policy:          policy,
 	}
 
 	if err := s.syncOperation(syncCtx, syncData); err != nil {
@@ -150,38 +251,29 @@ func (s *Service) createResharingOperation(ctx context.Context, params *resharin
 		return nil, fmt.Errorf("only old participants can initiate resharing operations, node %s is not in old participants", s.nodeID)
 	}
 
+	publicKey, _, err := encodePublicKey(localParty.ECDSAPub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
 	s.logger.Info("Loaded existing key data for resharing initiation",
 		zap.String("node_id", s.nodeID),
 		zap.String("key_id", params.KeyID))
 
-	// Create old participant list from key metadata (not from params)
-	oldParticipantList, err := s.createParticipantList(keyMetadata.Participants)
+	// The old committee holds shares of the key's generation, the new one of the next
+	oldParticipantList, err := s.createParticipantList(keyMetadata.Participants, keyMetadata.Generation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create old participant list: %w", err)
 	}
 
-	newParticipantList, err := s.createParticipantList(params.NewParticipants)
+	newParticipantList, err := s.createParticipantList(params.NewParticipants, keyMetadata.Generation+1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new participant list: %w", err)
 	}
 
-	// Find our party ID in the old participant list (since this is always an old participant)
-	idx := slices.IndexFunc(oldParticipantList, func(p *tss.PartyID) bool {
-		return p.Id == s.nodeID
-	})
-
-	if idx == -1 {
-		return nil, fmt.Errorf("this node (%s) is not in old participant list", s.nodeID)
-	}
-	ourPartyID := oldParticipantList[idx]
-
 	// Additional validation for TSS parameters
-	if params.NewThreshold < 0 {
-		return nil, fmt.Errorf("new threshold cannot be negative: %d", params.NewThreshold)
-	}
-	if params.NewThreshold >= len(newParticipantList) {
-		return nil, fmt.Errorf("new threshold (%d) must be less than new party count (%d)",
-			params.NewThreshold, len(newParticipantList))
+	if err := checkResharingThreshold(params.NewThreshold, len(newParticipantList)); err != nil {
+		return nil, err
 	}
 	if keyMetadata.Threshold < 0 {
 		return nil, fmt.Errorf("old threshold cannot be negative: %d", keyMetadata.Threshold)
@@ -191,28 +283,14 @@ func (s *Service) createResharingOperation(ctx context.Context, params *resharin
 			keyMetadata.Threshold, len(oldParticipantList))
 	}
 
-	// Create TSS parameters for resharing
-	oldCtx := tss.NewPeerContext(oldParticipantList)
-	newCtx := tss.NewPeerContext(newParticipantList)
-
-	// Use ReSharingParameters instead of regular Parameters
-	tssParams := tss.NewReSharingParameters(
-		tss.S256(),              // curve
-		oldCtx,                  // old peer context
-		newCtx,                  // new peer context
-		ourPartyID,              // our party ID
-		len(oldParticipantList), // old party count
-		keyMetadata.Threshold,   // old threshold from key metadata (t, not t+1)
-		len(newParticipantList), // new party count
-		params.NewThreshold,     // new threshold (t, not t+1)
-	)
+	if err := s.ensureParticipantKeys(ctx, keyMetadata.Participants, params.NewParticipants); err != nil {
+		return nil, err
+	}
 
 	// Create channels
-	channelSize := len(newParticipantList) + len(oldParticipantList)
-	outCh := make(chan tss.Message, channelSize)
-	endCh := make(chan *keygen.LocalPartySaveData, channelSize)
+	outCh := make(chan tss.Message, outChannelSize(len(newParticipantList)+len(oldParticipantList)))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
 
-	// Create resharing party with additional validation
 	s.logger.Info("Creating resharing party",
 		zap.String("operation_id", params.OperationID),
 		zap.String("key_id", params.KeyID),
@@ -220,14 +298,21 @@ func (s *Service) createResharingOperation(ctx context.Context, params *resharin
 		zap.Int("new_parties", len(newParticipantList)),
 		zap.Int("old_threshold", keyMetadata.Threshold),
 		zap.Int("new_threshold", params.NewThreshold),
-		zap.String("our_party_id", ourPartyID.Id))
+		zap.Int("old_generation", keyMetadata.Generation))
 
-	// Create resharing party with existing key data (this node is always an old participant)
-	party := resharing.NewLocalParty(tssParams, *localParty, outCh, endCh)
+	// Create resharing parties with existing key data (this node is always an old participant)
+	party, oldParty, err := s.createResharingParties(oldParticipantList, newParticipantList,
+		keyMetadata.Threshold, params.NewThreshold, localParty, outCh, endCh)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create operation context with cancellation - use background context to avoid HTTP timeout
-	// Set a longer timeout for resharing operations (15 minutes)
-	operationCtx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	timeout := params.Timeout
+	if timeout == 0 {
+		timeout = defaultResharingTimeout
+	}
+	operationCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	// Create request for storage
 	req := &ResharingRequest{
@@ -237,20 +322,30 @@ func (s *Service) createResharingOperation(ctx context.Context, params *resharin
 		NewParties:      len(params.NewParticipants),
 		OldParticipants: keyMetadata.Participants, // Use participants from key metadata
 		NewParticipants: params.NewParticipants,
+		PublicKey:       publicKey,
+		Alias:           keyMetadata.Alias,
+		ChainFamily:     keyMetadata.ChainFamily,
+		Policy:          keyMetadata.Policy,
+		OldGeneration:   keyMetadata.Generation,
 	}
 
 	operation := &Operation{
-		ID:           params.OperationID,
-		Type:         OperationResharing,
-		SessionID:    params.SessionID,
-		Participants: newParticipantList, // Use new participants for message handling
-		Party:        party,
-		OutCh:        outCh,
-		EndCh:        common.ConvertToAnyCh(endCh), // Generic channel for interface{}
-		Status:       StatusPending,
-		CreatedAt:    time.Now(),
-		Request:      req, // Store the request for persistence
-		cancel:       cancel,
+		ID:              params.OperationID,
+		Type:            OperationResharing,
+		SessionID:       params.SessionID,
+		Participants:    newParticipantList, // Use new participants for message handling
+		Party:           party,
+		OutCh:           outCh,
+		EndCh:           common.ConvertToAnyCh(endCh), // Generic channel for interface{}
+		Status:          StatusPending,
+		CreatedAt:       time.Now(),
+		Request:         req, // Store the request for persistence
+		OldParticipants: oldParticipantList,
+		OldParty:        oldParty,
+		Labels:          params.Labels,
+		Owner:           params.Owner,
+		RequestID:       params.RequestID,
+		cancel:          cancel,
 	}
 
 	// Store operation
@@ -272,7 +367,10 @@ func (s *Service) createSyncedResharingOperation(ctx context.Context, msg *p2p.M
 	var syncData ResharingSyncData
 	if err := json.Unmarshal(msg.Data, &syncData); err != nil {
 		s.logger.Error("Failed to unmarshal resharing sync data", zap.Error(err))
-		return fmt.Errorf("failed to unmarshal resharing sync data: %w", err)
+		return fmt.Errorf("%w: failed to unmarshal resharing sync data: %w", p2p.ErrProtocolViolation, err)
+	}
+	if err := syncData.validate(); err != nil {
+		return err
 	}
 
 	s.logger.Info("Creating synced resharing operation",
@@ -290,83 +388,70 @@ func (s *Service) createSyncedResharingOperation(ctx context.Context, msg *p2p.M
 	isOldParticipant := slices.Contains(syncData.OldParticipants, s.nodeID)
 
 	// Load key data only if this node is an old participant
-	var localParty keygen.LocalPartySaveData
+	var localParty *keygen.LocalPartySaveData
+	// New participants can only learn the current public key and policy from the initiator
+	publicKey := syncData.PublicKey
+	policy, err := syncData.Policy.normalize()
+	if err != nil {
+		return err
+	}
 
 	if isOldParticipant {
 		// Old participant - load existing key data
-		_, party, err := s.loadKeyData(ctx, syncData.KeyID)
+		metadata, party, err := s.loadKeyData(ctx, syncData.KeyID)
 		if err != nil {
 			return fmt.Errorf("failed to load key data for old participant: %w", err)
 		}
+		// A share of another generation belongs to different party keys, it missed or
+		// took part in a resharing the initiator did not
+		if metadata.Generation != syncData.OldGeneration {
+			return fmt.Errorf("share of key %s is of generation %d, the initiator's of generation %d",
+				syncData.KeyID, metadata.Generation, syncData.OldGeneration)
+		}
 
-		localParty = *party
+		localParty = party
+		policy = metadata.Policy
+		if publicKey, _, err = encodePublicKey(party.ECDSAPub); err != nil {
+			return fmt.Errorf("failed to encode public key: %w", err)
+		}
 
 		s.logger.Info("Loaded existing key data for old participant",
 			zap.String("node_id", s.nodeID),
 			zap.String("key_id", syncData.KeyID))
 	} else {
-		localParty = keygen.NewLocalPartySaveData(len(syncData.NewParticipants))
 		s.logger.Info("New participant joining resharing",
 			zap.String("node_id", s.nodeID),
 			zap.String("key_id", syncData.KeyID))
 	}
 
 	// Create old participant list from sync data
-	oldParticipantList, err := s.createParticipantList(syncData.OldParticipants)
+	oldParticipantList, err := s.createParticipantList(syncData.OldParticipants, syncData.OldGeneration)
 	if err != nil {
 		return fmt.Errorf("failed to create old participant list: %w", err)
 	}
 
-	newParticipantList, err := s.createParticipantList(syncData.NewParticipants)
+	newParticipantList, err := s.createParticipantList(syncData.NewParticipants, syncData.OldGeneration+1)
 	if err != nil {
 		return fmt.Errorf("failed to create new participant list: %w", err)
 	}
 
-	// Find our party ID in the participant lists
-	var ourPartyID *tss.PartyID
-	if isOldParticipant {
-		idx := slices.IndexFunc(oldParticipantList, func(p *tss.PartyID) bool {
-			return p.Id == s.nodeID
-		})
-		if idx == -1 {
-			return fmt.Errorf("this node (%s) is not in old participant list", s.nodeID)
-		}
-		ourPartyID = oldParticipantList[idx]
-	} else {
-		idx := slices.IndexFunc(newParticipantList, func(p *tss.PartyID) bool {
-			return p.Id == s.nodeID
-		})
-		if idx == -1 {
-			return fmt.Errorf("this node (%s) is not in new participant list", s.nodeID)
-		}
-		ourPartyID = newParticipantList[idx]
-	}
-
-	// Create TSS parameters for resharing
-	oldCtx := tss.NewPeerContext(oldParticipantList)
-	newCtx := tss.NewPeerContext(newParticipantList)
-
-	// Use ReSharingParameters instead of regular Parameters
-	tssParams := tss.NewReSharingParameters(
-		tss.S256(),              // curve
-		oldCtx,                  // old peer context
-		newCtx,                  // new peer context
-		ourPartyID,              // our party ID
-		len(oldParticipantList), // old party count
-		syncData.OldThreshold,   // old threshold from sync data
-		len(newParticipantList), // new party count
-		syncData.NewThreshold,   // new threshold
-	)
+	// A new participant may not have exchanged identities with the committee yet
+	if err := s.ensureParticipantKeys(ctx, syncData.OldParticipants, syncData.NewParticipants); err != nil {
+		return err
+	}
 
-	channelSize := len(newParticipantList) + len(oldParticipantList)
 	// Create channels
-	outCh := make(chan tss.Message, channelSize)
-	endCh := make(chan *keygen.LocalPartySaveData, channelSize)
+	outCh := make(chan tss.Message, outChannelSize(len(newParticipantList)+len(oldParticipantList)))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
 
-	// Create resharing party
-	party := resharing.NewLocalParty(tssParams, localParty, outCh, endCh)
+	// Create resharing parties
+	party, oldParty, err := s.createResharingParties(oldParticipantList, newParticipantList,
+		syncData.OldThreshold, syncData.NewThreshold, localParty, outCh, endCh)
+	if err != nil {
+		return err
+	}
 	// Create operation context with cancellation
-	operationCtx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	operationCtx, cancel := context.WithTimeout(context.Background(), defaultResharingTimeout)
 
 	// Create request for storage
 	req := &ResharingRequest{
@@ -376,20 +461,28 @@ func (s *Service) createSyncedResharingOperation(ctx context.Context, msg *p2p.M
 		NewParties:      len(syncData.NewParticipants),
 		OldParticipants: syncData.OldParticipants,
 		NewParticipants: syncData.NewParticipants,
+		PublicKey:       publicKey,
+		Alias:           syncData.Alias,
+		ChainFamily:     syncData.ChainFamily,
+		Policy:          policy,
+		OldGeneration:   syncData.OldGeneration,
 	}
 
 	operation := &Operation{
-		ID:           syncData.OperationID,
-		Type:         OperationResharing,
-		SessionID:    syncData.SessionID,
-		Participants: newParticipantList, // Use new participants for message handling
-		Party:        party,
-		OutCh:        outCh,
-		EndCh:        common.ConvertToAnyCh(endCh), // Generic channel for interface{}
-		Status:       StatusPending,
-		CreatedAt:    time.Now(),
-		Request:      req, // Store the request for persistence
-		cancel:       cancel,
+		ID:              syncData.OperationID,
+		Type:            OperationResharing,
+		SessionID:       syncData.SessionID,
+		Participants:    newParticipantList, // Use new participants for message handling
+		Party:           party,
+		OutCh:           outCh,
+		EndCh:           common.ConvertToAnyCh(endCh), // Generic channel for interface{}
+		Status:          StatusPending,
+		CreatedAt:       time.Now(),
+		Request:         req, // Store the request for persistence
+		OldParticipants: oldParticipantList,
+		OldParty:        oldParty,
+		RequestID:       syncData.RequestID,
+		cancel:          cancel,
 	}
 
 	// Store operation
@@ -408,3 +501,173 @@ func (s *Service) createSyncedResharingOperation(ctx context.Context, msg *p2p.M
 
 	return nil
 }
+
+// ensureParticipantKeys makes sure the public keys of the old and new committee are known
+// before the party starts, without them resharing messages cannot be decrypted
+func (s *Service) ensureParticipantKeys(ctx context.Context, oldParticipants, newParticipants []string) error {
+	participants := slices.Clone(oldParticipants)
+	for _, id := range newParticipants {
+		if !slices.Contains(participants, id) {
+			participants = append(participants, id)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, peerKeyTimeout)
+	defer cancel()
+	if err := s.network.EnsurePeerKeys(ctx, participants); err != nil {
+		s.logger.Error("Resharing participants are not reachable for peer encryption", zap.Error(err))
+		return fmt.Errorf("resharing pre-flight failed: %w", err)
+	}
+	return nil
+}
+
+// createResharingParties creates the parties this node runs in a resharing from the old to
+// the new committee, key is the share of an old participant. The party is this node's new
+// committee party, or its old committee party if it only belongs to the old committee. A node
+// in both committees runs its old committee party as oldParty next to it, the shares of the
+// old party end with it and only the new party reports to endCh.
+func (s *Service) createResharingParties(
+	oldParticipants, newParticipants []*tss.PartyID,
+	oldThreshold, newThreshold int,
+	key *keygen.LocalPartySaveData,
+	outCh chan tss.Message,
+	endCh chan *keygen.LocalPartySaveData,
+) (party, oldParty tss.Party, err error) {
+	oldCtx := tss.NewPeerContext(oldParticipants)
+	newCtx := tss.NewPeerContext(newParticipants)
+	reSharingParameters := func(partyID *tss.PartyID) *tss.ReSharingParameters {
+		return tss.NewReSharingParameters(tss.S256(), oldCtx, newCtx, partyID,
+			len(oldParticipants), oldThreshold, len(newParticipants), newThreshold)
+	}
+	ownPartyID := func(participants []*tss.PartyID) *tss.PartyID {
+		idx := slices.IndexFunc(participants, func(p *tss.PartyID) bool {
+			return p.Id == s.nodeID
+		})
+		if idx == -1 {
+			return nil
+		}
+		return participants[idx]
+	}
+
+	oldPartyID, newPartyID := ownPartyID(oldParticipants), ownPartyID(newParticipants)
+	if oldPartyID != nil && key == nil {
+		return nil, nil, fmt.Errorf("this node (%s) holds no share of the key", s.nodeID)
+	}
+	if newPartyID == nil {
+		if oldPartyID == nil {
+			return nil, nil, fmt.Errorf("this node (%s) is in neither resharing committee", s.nodeID)
+		}
+		return resharing.NewLocalParty(reSharingParameters(oldPartyID), *key, outCh, endCh), nil, nil
+	}
+
+	// The new share needs a Paillier key and safe primes. A node keeping its share reuses
+	// those of the old share, otherwise tss-lib generates them in the protocol unless they
+	// are provided.
+	save := keygen.NewLocalPartySaveData(len(newParticipants))
+	switch {
+	case key != nil && key.LocalPreParams.ValidateWithProof():
+		save.LocalPreParams = key.LocalPreParams
+	case s.preParams != nil:
+		preParams, err := s.generatePreParams()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate pre-params: %w", err)
+		}
+		save.LocalPreParams = *preParams
+	}
+	party = resharing.NewLocalParty(reSharingParameters(newPartyID), save, outCh, endCh)
+
+	if oldPartyID != nil {
+		oldEndCh := make(chan *keygen.LocalPartySaveData, 1)
+		oldParty = resharing.NewLocalParty(reSharingParameters(oldPartyID), *key, outCh, oldEndCh)
+	}
+	return party, oldParty, nil
+}
+
+// saveResharingResult verifies that the group public key survived the resharing and
+// stores the new key share
+func (s *Service) saveResharingResult(ctx context.Context, operation *Operation, result *keygen.LocalPartySaveData) error {
+	req, ok := operation.Request.(*ResharingRequest)
+	if !ok {
+		return fmt.Errorf("invalid resharing request")
+	}
+
+	// Parties that only belong to the old committee do not receive a new share
+	if result.ECDSAPub == nil {
+		s.logger.Info("Resharing completed without a new key share for this node",
+			zap.String("operation_id", operation.ID),
+			zap.String("key_id", req.KeyID))
+
+		operation.Lock()
+		operation.Result = &KeygenResult{
+			PublicKey: req.PublicKey,
+			KeyID:     req.KeyID,
+			Alias:     req.Alias,
+		}
+		operation.Unlock()
+		return nil
+	}
+
+	publicKeyHex, address, err := encodePublicKey(result.ECDSAPub)
+	if err != nil {
+		return err
+	}
+
+	// The key keeps its ID, which is only the address for keys stored under it
+	keyID := req.KeyID
+	if publicKeyHex != req.PublicKey {
+		s.logger.Error("Public key changed after resharing",
+			zap.String("operation_id", operation.ID),
+			zap.String("key_id", req.KeyID),
+			zap.String("old_public_key", req.PublicKey),
+			zap.String("new_public_key", publicKeyHex))
+		return fmt.Errorf("%w: expected %s, got %s", ErrPublicKeyChanged, req.PublicKey, publicKeyHex)
+	}
+
+	alias := s.registerKeyAlias(ctx, req.Alias, keyID)
+	if err := s.saveKeyData(ctx, keyID, address, result, req.NewThreshold, req.NewParticipants, req.OldGeneration+1,
+		alias, req.ChainFamily, req.Policy); err != nil {
+		return err
+	}
+
+	operation.Lock()
+	operation.Result = &KeygenResult{
+		PublicKey: publicKeyHex,
+		KeyID:     keyID,
+		Alias:     alias,
+		Address:   address,
+	}
+	operation.Unlock()
+	return nil
+}
+
+// checkResharingThreshold returns ErrInvalidParticipants unless newThreshold is a valid
+// threshold for a new committee of newParties participants
+func checkResharingThreshold(newThreshold, newParties int) error {
+	if newThreshold < 0 {
+		return fmt.Errorf("%w: new threshold cannot be negative: %d", ErrInvalidParticipants, newThreshold)
+	}
+	if newThreshold >= newParties {
+		return fmt.Errorf("%w: new threshold (%d) must be less than new party count (%d)",
+			ErrInvalidParticipants, newThreshold, newParties)
+	}
+	return nil
+}
+
+// validate checks that the sync data describes a single new committee: the threshold and
+// party count of the embedded OperationSyncData must match the new threshold and
+// participants, as the initiator derives both from them
+func (r *ResharingSyncData) validate() error {
+	if r.Threshold != r.NewThreshold || r.Parties != len(r.NewParticipants) ||
+		!slices.Equal(r.Participants, r.NewParticipants) {
+		return fmt.Errorf("%w: resharing to %d of %d participants announced as %d of %d",
+			p2p.ErrProtocolViolation, r.NewThreshold, len(r.NewParticipants), r.Threshold, r.Parties)
+	}
+	if err := checkResharingThreshold(r.NewThreshold, len(r.NewParticipants)); err != nil {
+		return fmt.Errorf("%w: %w", p2p.ErrProtocolViolation, err)
+	}
+	if r.OldThreshold < 0 || r.OldThreshold >= len(r.OldParticipants) {
+		return fmt.Errorf("%w: old threshold (%d) must be less than old party count (%d)",
+			p2p.ErrProtocolViolation, r.OldThreshold, len(r.OldParticipants))
+	}
+	return nil
+}
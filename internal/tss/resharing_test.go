@@ -0,0 +1,205 @@
+package tss
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+func TestSaveResharingResultRejectsChangedPublicKey(t *testing.T) {
+	oldPub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	newPub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(11))
+
+	publicKey, keyID, err := encodePublicKey(oldPub)
+	require.NoError(t, err)
+
+	s := &Service{logger: zap.NewNop()}
+	op := &Operation{
+		ID:   "op-1",
+		Type: OperationResharing,
+		Request: &ResharingRequest{
+			KeyID:     keyID,
+			PublicKey: publicKey,
+		},
+	}
+
+	result := keygen.NewLocalPartySaveData(1)
+	result.ECDSAPub = newPub
+
+	err = s.saveResharingResult(context.Background(), op, &result)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrPublicKeyChanged))
+	require.Nil(t, op.Result)
+}
+
+func TestSaveResharingResultOldCommitteeOnly(t *testing.T) {
+	s := &Service{logger: zap.NewNop()}
+	op := &Operation{
+		ID:   "op-2",
+		Type: OperationResharing,
+		Request: &ResharingRequest{
+			KeyID:     "0xabc",
+			PublicKey: "04ab",
+		},
+	}
+
+	result := keygen.NewLocalPartySaveData(1)
+	require.NoError(t, s.saveResharingResult(context.Background(), op, &result))
+	require.Equal(t, &KeygenResult{PublicKey: "04ab", KeyID: "0xabc"}, op.Result)
+}
+
+func TestResharingRequiresHeldKey(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node3"
+	participants := []string{"node1", "node2", "node3"}
+
+	keyID := "0x1111111111111111111111111111111111111111"
+	_, err := s.RefreshShares(ctx, "", keyID, OperationOptions{})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+	_, err = s.StartResharing(ctx, "", keyID, 1, participants, OperationOptions{})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+
+	// Only old participants hold the share resharing starts from
+	share := keygen.NewLocalPartySaveData(2)
+	require.NoError(t, s.saveKeyData(ctx, keyID, keyID, &share, 1, []string{"node1", "node2"}, 0, "", "", nil))
+	_, err = s.RefreshShares(ctx, "", keyID, OperationOptions{})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+	_, err = s.StartResharing(ctx, "", keyID, 1, participants, OperationOptions{})
+	require.ErrorIs(t, err, ErrKeyNotHeld)
+}
+
+func TestRefreshSharesEndToEnd(t *testing.T) {
+	// The old parties send their first message right away, it may overtake the sync message
+	nodes := newTestNetwork(t, 2, func(cfg *Config) {
+		cfg.EarlyMessageWindow = 30 * time.Second
+	})
+	participants := []string{nodes[0].nodeID, nodes[1].nodeID}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	keygenOp, err := nodes[0].StartKeygen(context.Background(), "", 1, participants, "treasury", "", "", OperationOptions{})
+	require.NoError(t, err)
+	result, err := keygenOp.Await(ctx)
+	require.NoError(t, err)
+	key := result.(*KeygenResult)
+	oldShares := make([]*keygen.LocalPartySaveData, len(nodes))
+	for i, node := range nodes {
+		require.Eventually(t, func() bool {
+			_, oldShares[i], err = node.loadKeyData(ctx, key.KeyID)
+			return err == nil
+		}, time.Minute, 100*time.Millisecond)
+	}
+
+	_, err = nodes[0].RefreshShares(ctx, "", "missing", OperationOptions{})
+	require.Error(t, err)
+
+	// Every node runs an old and a new committee party, the key is reshared to its own committee
+	op, err := nodes[0].RefreshShares(context.Background(), "op-refresh", "treasury",
+		OperationOptions{Labels: map[string]string{"reason": "rotation"}})
+	require.NoError(t, err)
+	req := op.Request.(*ResharingRequest)
+	require.Equal(t, participants, req.OldParticipants)
+	require.Equal(t, participants, req.NewParticipants)
+	require.Equal(t, 1, req.NewThreshold)
+	require.Equal(t, map[string]string{"reason": "rotation"}, op.Labels)
+	require.NotNil(t, op.OldParty)
+	require.NotEqual(t, op.OldParty.PartyID().KeyInt(), op.Party.PartyID().KeyInt())
+
+	// Retries with the same operation ID return the running refresh
+	again, err := nodes[0].RefreshShares(context.Background(), "op-refresh", "treasury", OperationOptions{})
+	require.NoError(t, err)
+	require.Same(t, op, again)
+
+	result, err = op.Await(ctx)
+	require.NoError(t, err)
+	require.Equal(t, key.KeyID, result.(*KeygenResult).KeyID)
+	require.Equal(t, key.PublicKey, result.(*KeygenResult).PublicKey)
+
+	// Every participant holds a fresh share of the same key under the next party keys
+	for i, node := range nodes {
+		require.Eventually(t, func() bool {
+			metadata, err := node.LoadKeyMetadata(ctx, key.KeyID)
+			return err == nil && metadata.Generation == 1
+		}, time.Minute, 100*time.Millisecond)
+		_, share, err := node.loadKeyData(ctx, key.KeyID)
+		require.NoError(t, err)
+		require.NotZero(t, share.Xi.Cmp(oldShares[i].Xi))
+		require.True(t, share.ECDSAPub.Equals(oldShares[i].ECDSAPub))
+	}
+
+	// The refreshed shares sign for the key
+	signOp, err := nodes[1].StartSigning(context.Background(), "", []byte("refreshed"), key.KeyID, participants, 0, SigningOptions{})
+	require.NoError(t, err)
+	result, err = signOp.Await(ctx)
+	require.NoError(t, err)
+	signature := result.(*SigningResult)
+	digest, err := hex.DecodeString(strings.TrimPrefix(signature.MessageDigest, "0x"))
+	require.NoError(t, err)
+	r, ok := new(big.Int).SetString(strings.TrimPrefix(signature.R, "0x"), 16)
+	require.True(t, ok)
+	sigS, ok := new(big.Int).SetString(strings.TrimPrefix(signature.S, "0x"), 16)
+	require.True(t, ok)
+	require.True(t, ecdsa.Verify(oldShares[0].ECDSAPub.ToECDSAPubKey(), digest, r, sigS))
+}
+
+func TestResharingRejectsInconsistentCommittee(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+
+	for _, threshold := range []int{-1, 2} {
+		_, err := s.StartResharing(ctx, "", "0x1111111111111111111111111111111111111111",
+			threshold, []string{"node1", "node2"}, OperationOptions{})
+		require.ErrorIs(t, err, ErrInvalidParticipants, threshold)
+	}
+
+	sync := func(modify func(*ResharingSyncData)) error {
+		syncData := &ResharingSyncData{
+			OperationSyncData: OperationSyncData{
+				OperationID:   "op-resharing",
+				OperationType: OperationResharing,
+				SessionID:     "session-resharing",
+				Threshold:     1,
+				Parties:       2,
+				Participants:  []string{"node1", "node3"},
+			},
+			OldThreshold:    1,
+			NewThreshold:    1,
+			OldParticipants: []string{"node1", "node2"},
+			NewParticipants: []string{"node1", "node3"},
+			KeyID:           "0x1111111111111111111111111111111111111111",
+		}
+		modify(syncData)
+		data, err := json.Marshal(syncData)
+		require.NoError(t, err)
+		return s.handleOperationSync(ctx, &p2p.Message{Type: string(OperationSync), From: "node2", Data: data})
+	}
+
+	// The announced committee must agree with the new participants and threshold
+	for name, modify := range map[string]func(*ResharingSyncData){
+		"party count":      func(d *ResharingSyncData) { d.Parties = 3 },
+		"threshold":        func(d *ResharingSyncData) { d.Threshold = 0 },
+		"participants":     func(d *ResharingSyncData) { d.Participants = []string{"node1", "node2"} },
+		"new threshold":    func(d *ResharingSyncData) { d.Threshold, d.NewThreshold = 2, 2 },
+		"old threshold":    func(d *ResharingSyncData) { d.OldThreshold = 2 },
+		"negative old one": func(d *ResharingSyncData) { d.OldThreshold = -1 },
+	} {
+		require.ErrorIs(t, sync(modify), p2p.ErrProtocolViolation, name)
+	}
+	require.Empty(t, s.operations)
+}
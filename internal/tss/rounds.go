@@ -0,0 +1,45 @@
+package tss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RoundTiming is the time an operation spent in one protocol round, from the party
+// entering the round until it entered the next one or the operation ended. It includes
+// the local computation as well as waiting for the messages of the other participants.
+type RoundTiming struct {
+	Round    int           `json:"round"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+}
+
+// enterRound records that the party entered round, ending the round it was in
+func (o *Operation) enterRound(round int) {
+	o.Lock()
+	defer o.Unlock()
+
+	now := time.Now()
+	o.endRoundLocked(now)
+	o.RoundTimings = append(o.RoundTimings, RoundTiming{Round: round, Started: now})
+	o.Events = append(o.Events, OperationEvent{Time: now, From: o.Status, To: o.Status, Detail: fmt.Sprintf("round %d", round)})
+}
+
+// endRoundLocked ends the round the party is in at now, if any. The caller must hold
+// the lock.
+func (o *Operation) endRoundLocked(now time.Time) {
+	if n := len(o.RoundTimings); n > 0 && o.RoundTimings[n-1].Duration == 0 {
+		o.RoundTimings[n-1].Duration = max(now.Sub(o.RoundTimings[n-1].Started), time.Nanosecond)
+	}
+}
+
+// formatRoundTimings describes the time spent per round, such as
+// "round 1 1.2s, round 2 3.5s"
+func formatRoundTimings(timings []RoundTiming) string {
+	parts := make([]string, 0, len(timings))
+	for _, timing := range timings {
+		parts = append(parts, fmt.Sprintf("round %d %s", timing.Round, timing.Duration.Round(time.Millisecond)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,198 @@
+package tss
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+)
+
+// bip340Vectors are the BIP340 test vectors as collected by btcec, secret keys are only
+// given for vectors of valid signatures
+var bip340Vectors = []struct {
+	secretKey string
+	publicKey string
+	message   string
+	signature string
+	valid     bool
+}{
+	{
+		secretKey: "0000000000000000000000000000000000000000000000000000000000000003",
+		publicKey: "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		message:   "0000000000000000000000000000000000000000000000000000000000000000",
+		signature: "04E7F9037658A92AFEB4F25BAE5339E3DDCA81A353493827D26F16D92308E49E" +
+			"2A25E92208678A2DF86970DA91B03A8AF8815A8A60498B358DAF560B347AA557",
+		valid: true,
+	},
+	{
+		secretKey: "0000000000000000000000000000000000000000000000000000000000000003",
+		publicKey: "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		message:   "0000000000000000000000000000000000000000000000000000000000000000",
+		signature: "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA8215" +
+			"25F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		valid: true,
+	},
+	{
+		secretKey: "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE3341" +
+			"8906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		valid: true,
+	},
+	{
+		secretKey: "C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+		publicKey: "DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+		message:   "7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+		signature: "5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1B" +
+			"AB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+		valid: true,
+	},
+	{
+		secretKey: "0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+		publicKey: "25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+		message:   "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		signature: "7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC" +
+			"97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+		valid: true,
+	},
+	{
+		publicKey: "D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+		message:   "4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+		signature: "00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C63" +
+			"76AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+		valid: true,
+	},
+	{
+		publicKey: "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+			"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid: false,
+	},
+	{
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A1460297556" +
+			"3CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+		valid: false,
+	},
+	{
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "1FA62E331EDBC21C394792D2AB1100A7B432B013DF3F6FF4F99FCB33E0E1515F" +
+			"28890B3EDB6E7189B630448B515CE4F8622A954CFE545735AAEA5134FCCDB2BD",
+		valid: false,
+	},
+	{
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+			"961764B3AA9B2FFCB6EF947B6887A226E8D7C93E00C5ED0C1834FF0D0C2E6DA6",
+		valid: false,
+	},
+	{
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "0000000000000000000000000000000000000000000000000000000000000000" +
+			"123DDA8328AF9C23A94C1FEECFD123BA4FB73476F0D594DCB65C6425BD186051",
+		valid: false,
+	},
+	{
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "0000000000000000000000000000000000000000000000000000000000000001" +
+			"7615FBAF5AE28864013C099742DEADB4DBA87F11AC6754F93780D5A1837CF197",
+		valid: false,
+	},
+	{
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D" +
+			"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid: false,
+	},
+	{
+		publicKey: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769" +
+			"69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		valid: false,
+	},
+}
+
+func decodeTestHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestVerifyBIP340Signature(t *testing.T) {
+	for i, vector := range bip340Vectors {
+		err := VerifyBIP340Signature(
+			decodeTestHex(t, vector.publicKey),
+			decodeTestHex(t, vector.message),
+			decodeTestHex(t, vector.signature),
+		)
+		if vector.valid {
+			require.NoError(t, err, "vector %d", i)
+		} else {
+			require.Error(t, err, "vector %d", i)
+		}
+	}
+}
+
+func TestBIP340Encoding(t *testing.T) {
+	for i, vector := range bip340Vectors {
+		if vector.secretKey == "" {
+			continue
+		}
+
+		// The x-only key of the group public key matches the vector
+		secret := new(big.Int).SetBytes(decodeTestHex(t, vector.secretKey))
+		xOnly, err := XOnlyPublicKey(crypto.ScalarBaseMult(tss.S256(), secret))
+		require.NoError(t, err)
+		require.Equal(t, decodeTestHex(t, vector.publicKey), xOnly, "vector %d", i)
+
+		// R and s of a signature re-encode to a verifying BIP340 signature
+		privKey, _ := btcec.PrivKeyFromBytes(decodeTestHex(t, vector.secretKey))
+		message := decodeTestHex(t, vector.message)
+		sig, err := schnorr.Sign(privKey, message)
+		require.NoError(t, err)
+		serialized := sig.Serialize()
+		encoded, err := BIP340Signature(serialized[:32], serialized[32:])
+		require.NoError(t, err)
+		require.Equal(t, serialized, encoded)
+		require.NoError(t, VerifyBIP340Signature(xOnly, message, encoded))
+	}
+
+	// Values beyond the field and group order are rejected
+	overflow := decodeTestHex(t, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
+	_, err := BIP340Signature(overflow, make([]byte, 32))
+	require.Error(t, err)
+	_, err = BIP340Signature(make([]byte, 32), overflow)
+	require.Error(t, err)
+}
+
+func TestSignatureAlgorithm(t *testing.T) {
+	for _, algorithm := range []string{"", "ecdsa"} {
+		parsed, err := parseSignatureAlgorithm(algorithm)
+		require.NoError(t, err)
+		require.Equal(t, AlgorithmECDSA, parsed)
+	}
+	for _, algorithm := range []string{"schnorr", "eddsa"} {
+		_, err := parseSignatureAlgorithm(algorithm)
+		require.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+	}
+
+	s, _ := newTestService(t, false)
+	_, err := s.StartKeygen(context.Background(), "", 1, []string{"node1", "node2"}, "", "bitcoin", AlgorithmSchnorr, OperationOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
@@ -3,77 +3,246 @@ package tss
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"regexp"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	dkcommon "github.com/dreamer-zq/DKNet/internal/common"
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/notify"
 	"github.com/dreamer-zq/DKNet/internal/p2p"
 	"github.com/dreamer-zq/DKNet/internal/plugin"
 	"github.com/dreamer-zq/DKNet/internal/storage"
 )
 
+// messageRoundPattern extracts the round from tss-lib message type names
+var messageRoundPattern = regexp.MustCompile(`Round(\d+)Message`)
+
 // Service provides TSS operations
 type Service struct {
-	logger            *zap.Logger
-	storage           storage.Storage
-	network           *p2p.Network
-	encryption        *plugin.KeyCipher
-	validationService plugin.ValidationService // optional
-
-	operations map[string]*Operation
-	mutex      sync.RWMutex
-	nodeID     string
-	moniker    string
+	logger     *zap.Logger
+	storage    storage.Storage
+	network    p2p.Transport
+	encryption *plugin.KeyCipher
+
+	// Signing request validator (optional), guarded by validationMutex so it can be reloaded.
+	// Injected validators are never replaced. validationFailOpen allows signing requests the
+	// validator could not answer. keyValidators holds the validators of key policies by URL,
+	// built from validationConfig.
+	validationService  plugin.ValidationService
+	validationFailOpen bool
+	validationConfig   *config.ValidationServiceConfig
+	keyValidators      map[string]plugin.ValidationService
+	validationMutex    sync.RWMutex
+	customValidator    bool
+
+	// keyPolicyMutex serializes key policy updates
+	keyPolicyMutex sync.Mutex
+
+	operations      map[string]*Operation
+	mutex           sync.RWMutex
+	nodeID          string
+	moniker         string
+	encryptMetadata bool
+
+	// Serializes alias registration so two keys cannot claim the same alias
+	aliasMutex sync.Mutex
+
+	// Content based signing deduplication, guarded by mutex
+	signingDedupTTL time.Duration
+	signingDedup    map[string]signingDedupEntry
+
+	// Sync message delivery and acknowledgement, syncAcks is guarded by mutex
+	syncRetries       int
+	syncRetryInterval time.Duration
+	syncAckTimeout    time.Duration
+	syncAcks          map[string]*syncAckWaiter
+
+	// Join confirmation of keygen operations
+	joinTimeout    time.Duration
+	joinQuorumMode string
+
+	sessionLookupTimeout time.Duration
+
+	// Format of the IDs of new keys, KeyIDFormatUUID or KeyIDFormatAddress
+	keyIDFormat string
+	// Sign with only threshold+1 of the requested participants
+	trimSigningQuorum bool
+
+	// Wire messages received before their operation was created, guarded by mutex
+	earlyMessageWindow time.Duration
+	earlyMessages      map[string][]earlyMessage
+	earlyMessageCount  int
+
+	// Limits the number of concurrently running operations
+	admission *admission
+
+	// Returns the safe primes and Paillier key of new keygen parties, nil generates them
+	preParams func() (*keygen.LocalPreParams, error)
+
+	// Upper bound for the message or typed data of a signing request, 0 is unlimited
+	maxMessageBytes int
+	// Whether finished signing operations are persisted with their message, not just its hash
+	persistFullMessage bool
+
+	// Lower case node names participants may be given as, mapped to their peer IDs
+	nodeNames map[string]string
+	// Node names as configured, by peer ID
+	peerNames map[string]string
+
+	// Bounds of client requested operation timeouts
+	minOperationTimeout time.Duration
+	maxOperationTimeout time.Duration
+
+	// Receives notifications about finished operations
+	notifier notify.Notifier
+
+	// Recently finished operations, served without reading storage
+	operationCache *operationCache
+
+	// Rejects new operations started by clients while set
+	maintenance atomic.Bool
+	// Serves queries only, never writing to storage
+	readOnly bool
+
+	// Cached storage statistics, guarded by storageStatsMutex
+	storageStatsMutex sync.Mutex
+	storageStats      *StorageStats
 }
 
 // NewService creates a new TSS service
 func NewService(
 	cfg *Config,
 	store storage.Storage,
-	network *p2p.Network,
+	network p2p.Transport,
 	logger *zap.Logger,
 	encryptionPassword string,
 ) (*Service, error) {
 	// Initialize key encryption
-	keyEncryption, err := plugin.NewKeyCipher(encryptionPassword)
+	kdfSalt, err := loadKDFSalt(context.Background(), store, cfg.Mode == ModeReadOnly)
+	if err != nil {
+		return nil, err
+	}
+	keyEncryption, err := plugin.NewKeyCipherWithSalt(encryptionPassword, cfg.KDF, kdfSalt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize key encryption: %w", err)
 	}
 
 	service := &Service{
-		storage:    store,
-		network:    network,
-		logger:     logger,
-		encryption: keyEncryption,
-		operations: make(map[string]*Operation),
-		nodeID:     cfg.PeerID,
-		moniker:    cfg.Moniker,
+		storage:         store,
+		network:         network,
+		logger:          logger,
+		encryption:      keyEncryption,
+		operations:      make(map[string]*Operation),
+		nodeID:          cfg.PeerID,
+		moniker:         cfg.Moniker,
+		encryptMetadata: cfg.EncryptMetadata,
+		signingDedupTTL: cfg.SigningDedupTTL,
+		signingDedup:    make(map[string]signingDedupEntry),
+
+		syncRetries:       cfg.SyncRetries,
+		syncRetryInterval: cfg.SyncRetryInterval,
+		syncAckTimeout:    cfg.SyncAckTimeout,
+		syncAcks:          make(map[string]*syncAckWaiter),
+
+		joinTimeout:    cfg.JoinTimeout,
+		joinQuorumMode: cfg.JoinQuorum,
+
+		sessionLookupTimeout: cfg.SessionLookupTimeout,
+
+		keyIDFormat: cfg.KeyIDFormat,
+
+		trimSigningQuorum: cfg.TrimSigningQuorum,
+		readOnly:          cfg.Mode == ModeReadOnly,
+
+		earlyMessageWindow: cfg.EarlyMessageWindow,
+		earlyMessages:      make(map[string][]earlyMessage),
+
+		admission: newAdmission(cfg.MaxConcurrentOperations),
+
+		maxMessageBytes:    cfg.MaxMessageBytes,
+		persistFullMessage: cfg.PersistFullMessage,
+		nodeNames:          make(map[string]string, len(cfg.NodeNames)),
+		peerNames:          make(map[string]string, len(cfg.NodeNames)),
+
+		minOperationTimeout: cfg.MinOperationTimeout,
+		maxOperationTimeout: cfg.MaxOperationTimeout,
+
+		notifier: cfg.Notifier,
+
+		operationCache: newOperationCache(cfg.OperationCacheSize),
+	}
+	if service.notifier == nil {
+		service.notifier = notify.Nop{}
+	}
+	for name, peerID := range cfg.NodeNames {
+		service.nodeNames[strings.ToLower(name)] = peerID
+		// Several names may be given for a node, the first one in order names it
+		if previous, ok := service.peerNames[peerID]; !ok || name < previous {
+			service.peerNames[peerID] = name
+		}
 	}
 
-	// Check if validation service is configured and enabled
-	if cfg.ValidationService != nil && cfg.ValidationService.Enabled {
-		service.validationService = plugin.NewHTTPValidationService(cfg.ValidationService, cfg.PeerID, logger)
+	// Use the injected validator, otherwise check if validation service is configured and enabled
+	service.validationFailOpen = validationFailOpen(cfg.ValidationService)
+	service.validationConfig = cfg.ValidationService
+	if cfg.Validator != nil {
+		service.validationService = cfg.Validator
+		service.customValidator = true
+	} else if cfg.ValidationService != nil && cfg.ValidationService.Enabled {
+		service.validationService = plugin.NewHTTPValidationService(
+			cfg.ValidationService, cfg.PeerID, network.PrivateKey(), logger)
+	}
+
+	if err := service.verifyEncryptionPassword(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := service.migrateKeyMACs(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := service.loadMaintenanceMode(context.Background()); err != nil {
+		return nil, err
 	}
 
 	// Set this service as the message handler for the network
 	network.SetMessageHandler(service)
+	if querier, ok := network.(p2p.Querier); ok {
+		querier.SetQueryHandler(service)
+	}
 
 	logger.Info("TSS service initialized",
 		zap.String("peer_id", cfg.PeerID),
 		zap.String("moniker", cfg.Moniker))
+	if service.readOnly {
+		logger.Warn("Node is a read-only replica, it serves queries but does not start or join operations")
+	}
 
 	return service, nil
 }
 
+const (
+	// sessionLookupInitialDelay is the delay before the session lookup is retried, it doubles
+	// up to sessionLookupMaxDelay
+	sessionLookupInitialDelay = 50 * time.Millisecond
+	sessionLookupMaxDelay     = time.Second
+)
+
 // Stop is part of the MessageHandler interface.
 // Currently a no-op as operation lifecycles are tied to contexts.
 func (s *Service) Stop() {
@@ -82,7 +251,7 @@ func (s *Service) Stop() {
 
 // HandleMessage handles incoming TSS messages from the P2P network
 func (s *Service) HandleMessage(ctx context.Context, msg *p2p.Message) error {
-	s.logger.Info("Received incoming P2P message",
+	s.logger.Debug("Received incoming P2P message",
 		zap.String("session_id", msg.SessionID),
 		zap.String("type", msg.Type),
 		zap.String("from", msg.From),
@@ -95,14 +264,34 @@ func (s *Service) HandleMessage(ctx context.Context, msg *p2p.Message) error {
 		}
 	}()
 
+	// Replicas take no part in operations, initiators see the sync go unacknowledged
+	if s.readOnly {
+		s.logger.Debug("Ignoring message on read-only replica", zap.String("type", msg.Type), zap.String("from", msg.From))
+		return nil
+	}
+
 	// Handle operation synchronization messages
-	if msg.Type == string(OperationSync) {
+	switch msg.Type {
+	case string(OperationSync):
 		return s.handleOperationSync(ctx, msg)
+	case string(OperationSyncAck):
+		return s.handleOperationSyncAck(msg)
+	case string(OperationCancel):
+		return s.handleOperationCancel(msg)
 	}
 
 	// Handle regular TSS messages
-	// Find operation by session ID
-	operation := s.getOperation(msg.SessionID)
+	// Find operation by session ID. Messages racing ahead of the sync message are buffered
+	// and replayed once the operation exists, or waited for when buffering is disabled.
+	var operation *Operation
+	if s.earlyMessageWindow > 0 {
+		var buffered bool
+		if operation, buffered = s.bufferEarlyMessage(msg); buffered {
+			return nil
+		}
+	} else {
+		operation = s.getOperation(ctx, msg.SessionID)
+	}
 	if operation == nil {
 		s.logger.Warn("No operation found for session ID",
 			zap.String("session_id", msg.SessionID),
@@ -110,7 +299,7 @@ func (s *Service) HandleMessage(ctx context.Context, msg *p2p.Message) error {
 		return fmt.Errorf("no operation found for session ID: %s", msg.SessionID)
 	}
 
-	s.logger.Info("Found operation for incoming message",
+	s.logger.Debug("Found operation for incoming message",
 		zap.String("session_id", msg.SessionID),
 		zap.String("operation_id", operation.ID),
 		zap.String("from", msg.From))
@@ -125,19 +314,41 @@ func (s *Service) HandleMessage(ctx context.Context, msg *p2p.Message) error {
 		return nil
 	}
 
-	// Find sender party ID
-	idx := slices.IndexFunc(operation.Participants, func(op *tss.PartyID) bool {
+	// The transport guarantees SenderPeerID is the peer we received the message from, From
+	// is set by the sender and decides which party the message is credited to
+	if msg.From != msg.SenderPeerID {
+		s.logger.Error("Sender mismatch",
+			zap.String("from", msg.From),
+			zap.String("sender_peer_id", msg.SenderPeerID),
+			zap.String("session_id", msg.SessionID))
+		return fmt.Errorf("%w: sender mismatch: %s != %s", p2p.ErrProtocolViolation, msg.From, msg.SenderPeerID)
+	}
+
+	// Find sender party ID, resharing senders are in the committee of the party that sent it
+	senders := operation.Participants
+	if operation.Type == OperationResharing {
+		fromNew, err := fromNewCommittee(msg.Data)
+		if err != nil {
+			return fmt.Errorf("%w: invalid resharing message: %w", p2p.ErrProtocolViolation, err)
+		}
+		if !fromNew {
+			senders = operation.OldParticipants
+		}
+	}
+	idx := slices.IndexFunc(senders, func(op *tss.PartyID) bool {
 		return op.Id == msg.From
 	})
 	if idx == -1 {
 		s.logger.Error("Unknown sender",
 			zap.String("from", msg.From),
 			zap.String("session_id", msg.SessionID))
-		return fmt.Errorf("unknown sender: %s", msg.From)
+		return fmt.Errorf("%w: unknown sender: %s", p2p.ErrProtocolViolation, msg.From)
 	}
-	fromParty := operation.Participants[idx]
+	fromParty := senders[idx]
+	// Recorded before the message is processed, it may be the one completing the operation
+	operation.recordContributor(msg.From)
 
-	s.logger.Info("Found sender party",
+	s.logger.Debug("Found sender party",
 		zap.String("session_id", msg.SessionID),
 		zap.String("operation_id", operation.ID),
 		zap.String("from", msg.From),
@@ -145,48 +356,44 @@ func (s *Service) HandleMessage(ctx context.Context, msg *p2p.Message) error {
 
 	// Send to party's UpdateFromBytes channel
 	dkcommon.SafeGo(operation.EndCh, func() any {
-		s.logger.Info("Sending message to TSS party",
+		s.logger.Debug("Sending message to TSS party",
 			zap.String("session_id", msg.SessionID),
 			zap.String("operation_id", operation.ID),
 			zap.Bool("isToOldCommittee", msg.IsToOldCommittee),
 			zap.Bool("isToOldAndNewCommittees", msg.IsToOldAndNewCommittees),
 			zap.String("from", msg.From))
 
+		parties := []tss.Party{operation.Party}
 		if operation.Type == OperationResharing {
-			switch {
-			case operation.isNewParticipant() && msg.IsToOldCommittee:
-				s.logger.Info("Skipping message to old participant",
+			// A node in both committees runs a party for each
+			if parties = operation.resharingParties(msg.IsToOldCommittee, msg.IsToOldAndNewCommittees); len(parties) == 0 {
+				s.logger.Debug("Skipping message to the other committee",
 					zap.String("session_id", msg.SessionID),
 					zap.String("operation_id", operation.ID),
 					zap.String("from", msg.From))
 				return nil
-			case !operation.isNewParticipant() && !msg.IsToOldCommittee:
-				s.logger.Info("Skipping message to new participant",
+			}
+		}
+
+		for _, party := range parties {
+			ok, err := party.UpdateFromBytes(msg.Data, fromParty, msg.IsBroadcast)
+			if err != nil {
+				s.logger.Error("Failed to update party with message",
+					zap.Error(err),
 					zap.String("session_id", msg.SessionID),
 					zap.String("operation_id", operation.ID),
 					zap.String("from", msg.From))
-				return nil
-			default:
+				return err
+			} else if !ok {
+				s.logger.Warn("Message was not processed by party",
+					zap.String("session_id", msg.SessionID),
+					zap.String("operation_id", operation.ID),
+					zap.String("from", msg.From))
+				return fmt.Errorf("message was not processed by party")
 			}
 		}
 
-		ok, err := operation.Party.UpdateFromBytes(msg.Data, fromParty, msg.IsBroadcast)
-		if err != nil {
-			s.logger.Error("Failed to update party with message",
-				zap.Error(err),
-				zap.String("session_id", msg.SessionID),
-				zap.String("operation_id", operation.ID),
-				zap.String("from", msg.From))
-			return err
-		} else if !ok {
-			s.logger.Warn("Message was not processed by party",
-				zap.String("session_id", msg.SessionID),
-				zap.String("operation_id", operation.ID),
-				zap.String("from", msg.From))
-			return fmt.Errorf("message was not processed by party")
-		}
-
-		s.logger.Info("Successfully updated TSS party with message",
+		s.logger.Debug("Successfully updated TSS party with message",
 			zap.String("session_id", msg.SessionID),
 			zap.String("operation_id", operation.ID),
 			zap.String("from", msg.From))
@@ -196,6 +403,20 @@ func (s *Service) HandleMessage(ctx context.Context, msg *p2p.Message) error {
 	return nil
 }
 
+// CheckHealth reports whether the service can reach its storage
+func (s *Service) CheckHealth(ctx context.Context) error {
+	if _, err := s.storage.Exists(ctx, "health"); err != nil {
+		return fmt.Errorf("storage unavailable: %w", err)
+	}
+	return nil
+}
+
+// OperationStats returns the number of running operations and of operations queued
+// because the concurrency limit is reached
+func (s *Service) OperationStats() (running, queued int) {
+	return s.admission.stats()
+}
+
 // GetOperation returns an operation by ID
 func (s *Service) GetOperation(operationID string) (*Operation, bool) {
 	// First check active operations in memory
@@ -217,33 +438,19 @@ func (s *Service) GetOperationData(ctx context.Context, operationID string) (*Op
 	s.mutex.RUnlock()
 
 	if exists {
-		// Convert active operation to OperationData
-		opData := &OperationData{
-			ID:           op.ID,
-			Type:         op.Type,
-			SessionID:    op.SessionID,
-			Status:       op.Status,
-			Participants: make([]string, len(op.Participants)),
-			CreatedAt:    op.CreatedAt,
-			CompletedAt:  op.CompletedAt,
-			Result:       op.Result,
-		}
-
-		// Extract participant IDs
-		for i, p := range op.Participants {
-			opData.Participants[i] = p.Id
-		}
-
-		// Set error if present
-		if op.Error != nil {
-			opData.Error = op.Error.Error()
-		}
-
-		return opData, nil
+		return op.Snapshot(), nil
 	}
 
-	// If not found in memory, check persistent storage
-	return s.loadOperation(ctx, operationID)
+	// Finished operations are served from the cache before falling back to storage
+	if data, ok := s.operationCache.get(operationID); ok {
+		return data, nil
+	}
+	data, err := s.loadOperation(ctx, operationID)
+	if err != nil {
+		return nil, err
+	}
+	s.operationCache.put(data)
+	return data, nil
 }
 
 // handleOperationSync handles operation synchronization messages
@@ -252,18 +459,27 @@ func (s *Service) handleOperationSync(ctx context.Context, msg *p2p.Message) err
 	var baseData OperationSyncData
 	if err := json.Unmarshal(msg.Data, &baseData); err != nil {
 		s.logger.Error("Failed to unmarshal operation sync data", zap.Error(err))
-		return fmt.Errorf("failed to unmarshal operation sync data: %w", err)
+		return fmt.Errorf("%w: failed to unmarshal operation sync data: %w", p2p.ErrProtocolViolation, err)
 	}
 
 	s.logger.Info("Received operation sync message",
 		zap.String("operation_id", baseData.OperationID),
 		zap.String("operation_type", string(baseData.OperationType)),
 		zap.String("session_id", baseData.SessionID),
+		zap.String("request_id", baseData.RequestID),
 		zap.String("from", msg.From),
 		zap.Strings("participants", baseData.Participants))
 
-	// Check if we are one of the participants
-	isParticipant := slices.Contains(baseData.Participants, s.nodeID)
+	// Check if we are one of the participants, of either committee for a resharing
+	recipients := baseData.Participants
+	if baseData.OperationType == OperationResharing {
+		var resharingData ResharingSyncData
+		if err := json.Unmarshal(msg.Data, &resharingData); err != nil {
+			return fmt.Errorf("%w: failed to unmarshal resharing sync data: %w", p2p.ErrProtocolViolation, err)
+		}
+		recipients = resharingData.To()
+	}
+	isParticipant := slices.Contains(recipients, s.nodeID)
 
 	if !isParticipant {
 		s.logger.Info("Ignoring operation sync - not a participant",
@@ -280,44 +496,67 @@ func (s *Service) handleOperationSync(ctx context.Context, msg *p2p.Message) err
 	if exists {
 		s.logger.Info("Operation already exists, ignoring sync message",
 			zap.String("operation_id", baseData.OperationID))
+		// The initiator may be retrying because our acknowledgement was lost
+		go s.acknowledgeOperationSync(baseData.OperationID, msg.From)
+		return nil
+	}
+
+	// A participant never queues a synced operation, the initiator learns it is busy instead
+	if !s.admission.reserve(baseData.OperationID) {
+		s.logger.Warn("Rejecting operation sync at the concurrent operation limit",
+			zap.String("operation_id", baseData.OperationID),
+			zap.String("from", msg.From))
+		go s.rejectOperationSync(baseData.OperationID, msg.From)
 		return nil
 	}
 
 	// Create the operation based on the sync message
+	var err error
 	switch baseData.OperationType {
 	case OperationKeygen:
-		return s.createSyncedKeygenOperation(ctx, msg)
+		err = s.createSyncedKeygenOperation(ctx, msg)
 	case OperationSigning:
-		return s.createSyncedSigningOperation(ctx, msg)
+		err = s.createSyncedSigningOperation(ctx, msg)
 	case OperationResharing:
-		return s.createSyncedResharingOperation(ctx, msg)
+		err = s.createSyncedResharingOperation(ctx, msg)
 	default:
-		return fmt.Errorf("unknown operation type: %s", baseData.OperationType)
+		err = fmt.Errorf("%w: unknown operation type: %s", p2p.ErrProtocolViolation, baseData.OperationType)
 	}
+	if err != nil {
+		s.admission.unreserve(baseData.OperationID)
+		return err
+	}
+
+	go s.acknowledgeOperationSync(baseData.OperationID, msg.From)
+	return nil
 }
 
 // handleOutgoingMessages handles outgoing TSS messages
 func (s *Service) handleOutgoingMessages(ctx context.Context, operation *Operation) error {
-	s.logger.Info("Starting outgoing message handler", zap.String("operation_id", operation.ID))
+	logger := s.operationLogger(operation)
+	logger.Info("Starting outgoing message handler")
 
+	round := 0
 	for {
 		select {
 		case msg := <-operation.OutCh:
-			s.logger.Info("Received outgoing TSS message",
-				zap.String("operation_id", operation.ID),
+			logger.Debug("Received outgoing TSS message",
 				zap.String("msg_type", fmt.Sprintf("%T", msg)))
 
+			// The first message of a round shows the party completed the previous one
+			if msgRound := messageRound(msg); msgRound > round {
+				round = msgRound
+				operation.enterRound(round)
+			}
+
 			// Get wire bytes and routing info
 			wireBytes, routing, err := msg.WireBytes()
 			if err != nil {
-				s.logger.Error("Failed to get wire bytes",
-					zap.Error(err),
-					zap.String("operation_id", operation.ID))
+				logger.Error("Failed to get wire bytes", zap.Error(err))
 				return err
 			}
 
-			s.logger.Info("Processing message routing",
-				zap.String("operation_id", operation.ID),
+			logger.Debug("Processing message routing",
 				zap.Bool("is_broadcast", routing.IsBroadcast),
 				zap.Int("wire_bytes_len", len(wireBytes)),
 				zap.Int("routing_to_count", len(routing.To)))
@@ -337,13 +576,16 @@ func (s *Service) handleOutgoingMessages(ctx context.Context, operation *Operati
 
 			to, err := s.toParticipants(operation, msg, routing)
 			if err != nil {
-				s.logger.Error("get participants failed", zap.Error(err))
+				logger.Error("get participants failed", zap.Error(err))
 				return err
 			}
 
 			p2pMsg.To = to
-			s.logger.Info("Sending point-to-point message",
-				zap.String("operation_id", operation.ID),
+			// The parties of a node in both resharing committees reach each other locally
+			if operation.OldParty != nil && slices.Contains(to, s.nodeID) {
+				s.deliverLocally(operation, msg, wireBytes, routing.IsBroadcast)
+			}
+			logger.Debug("Sending point-to-point message",
 				zap.String("session_id", operation.SessionID),
 				zap.Strings("targets", p2pMsg.To),
 				zap.Bool("IsToOldCommittee", p2pMsg.IsToOldCommittee),
@@ -351,31 +593,73 @@ func (s *Service) handleOutgoingMessages(ctx context.Context, operation *Operati
 			)
 
 			if err := s.network.SendMessage(ctx, p2pMsg); err != nil {
-				s.logger.Error("Failed to send message",
+				logger.Error("Failed to send message",
 					zap.Error(err),
-					zap.String("operation_id", operation.ID),
 					zap.Strings("targets", p2pMsg.To))
 				return err
 			}
 		case <-ctx.Done():
-			s.logger.Info("Outgoing message handler stopped",
-				zap.String("operation_id", operation.ID),
-				zap.Error(ctx.Err()))
+			logger.Info("Outgoing message handler stopped", zap.Error(ctx.Err()))
 			return ctx.Err()
 		}
 	}
 }
 
-// loadKeyData loads and decrypts key data from storage
-func (s *Service) loadKeyData(ctx context.Context, keyID string) (*keyData, *keygen.LocalPartySaveData, error) {
-	data, err := s.storage.Load(ctx, keyID)
+// deliverLocally hands a message of one party of a resharing operation to the other party
+// this node runs, if the message is for it
+func (s *Service) deliverLocally(operation *Operation, msg tss.Message, wireBytes []byte, isBroadcast bool) {
+	from := msg.GetFrom()
+	for _, party := range operation.resharingParties(msg.IsToOldCommittee(), msg.IsToOldAndNewCommittees()) {
+		if party.PartyID().KeyInt().Cmp(from.KeyInt()) == 0 {
+			continue
+		}
+		dkcommon.SafeGo(operation.EndCh, func() any {
+			if _, err := party.UpdateFromBytes(wireBytes, from, isBroadcast); err != nil {
+				return err
+			}
+			return nil
+		})
+	}
+}
+
+// fromNewCommittee reports whether a resharing wire message was sent by a party of the new
+// committee, tss-lib tells the committees apart by the message type
+func fromNewCommittee(wireBytes []byte) (bool, error) {
+	wire := new(anypb.Any)
+	if err := proto.Unmarshal(wireBytes, wire); err != nil {
+		return false, err
+	}
+	content, err := wire.UnmarshalNew()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load key data: %w", err)
+		return false, err
 	}
+	switch content.(type) {
+	case *resharing.DGRound2Message1, *resharing.DGRound2Message2, *resharing.DGRound4Message1, *resharing.DGRound4Message2:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
 
-	var keyDataStruct keyData
-	if unmarshalErr := json.Unmarshal(data, &keyDataStruct); unmarshalErr != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal key data struct: %w", unmarshalErr)
+// messageRound returns the protocol round of a message from its type name, such as
+// binance.tsslib.ecdsa.signing.SignRound3Message, or 0 when it has none
+func messageRound(msg tss.Message) int {
+	match := messageRoundPattern.FindStringSubmatch(msg.Type())
+	if match == nil {
+		return 0
+	}
+	round, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return round
+}
+
+// loadKeyData loads and decrypts key data from storage
+func (s *Service) loadKeyData(ctx context.Context, keyID string) (*keyData, *keygen.LocalPartySaveData, error) {
+	keyDataStruct, err := s.loadKeyRecord(ctx, keyID)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Decrypt the key data
@@ -389,35 +673,84 @@ func (s *Service) loadKeyData(ctx context.Context, keyID string) (*keyData, *key
 		return nil, nil, fmt.Errorf("failed to unmarshal save data: %w", err)
 	}
 
+	// Re-encrypt key data written with other key derivation parameters
+	if !s.readOnly && !s.encryption.Current(keyDataStruct.KeyData) {
+		if err := s.reencryptKeyData(ctx, keyID, keyDataStruct, decryptedKeyData); err != nil {
+			s.logger.Warn("Failed to migrate key data encryption", zap.String("key_id", keyID), zap.Error(err))
+		}
+	}
+
 	s.logger.Debug("Successfully loaded and decrypted key data",
 		zap.String("key_id", keyID),
 		zap.Int("encrypted_size", len(keyDataStruct.KeyData)),
 		zap.Int("decrypted_size", len(decryptedKeyData)))
 
-	return &keyDataStruct, &saveData, nil
+	return keyDataStruct, &saveData, nil
+}
+
+// reencryptKeyData encrypts the key data with the current key derivation parameters and
+// stores it again
+func (s *Service) reencryptKeyData(ctx context.Context, keyID string, keyDataStruct *keyData, plaintext []byte) error {
+	encrypted, err := s.encryption.Encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key data: %w", err)
+	}
+
+	migrated := *keyDataStruct
+	migrated.KeyData = encrypted
+	return s.saveKeyRecord(ctx, keyID, &migrated)
 }
 
-// LoadKeyMetadata loads key metadata from storage
+// LoadKeyMetadata loads key metadata from storage and checks its integrity
 func (s *Service) LoadKeyMetadata(ctx context.Context, keyID string) (*keyData, error) {
-	data, err := s.storage.Load(ctx, keyID)
+	return s.loadKeyRecord(ctx, keyID)
+}
+
+// HasKey checks the stored key metadata, without decrypting the share, for whether this
+// node knows a key and is one of its participants. Unknown keys and aliases are not errors.
+func (s *Service) HasKey(ctx context.Context, keyIDOrAlias string) (KeyPresence, error) {
+	keyID, err := s.ResolveKeyID(ctx, keyIDOrAlias)
+	if errors.Is(err, ErrKeyAliasNotFound) {
+		return KeyPresence{}, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to load key data: %w", err)
+		return KeyPresence{}, err
 	}
 
-	var keyDataStruct keyData
-	if unmarshalErr := json.Unmarshal(data, &keyDataStruct); unmarshalErr != nil {
-		return nil, fmt.Errorf("failed to unmarshal key data struct: %w", unmarshalErr)
+	metadata, err := s.LoadKeyMetadata(ctx, keyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return KeyPresence{}, nil
 	}
+	if err != nil {
+		return KeyPresence{}, err
+	}
+
+	return KeyPresence{
+		Known:    true,
+		HasShare: slices.Contains(metadata.Participants, s.nodeID),
+	}, nil
+}
 
-	return &keyDataStruct, nil
+// outChannelSize returns the buffer size of a party's outgoing message channel.
+// In one round a party emits at most one broadcast plus one message per other
+// participant, so the buffer holds two full rounds and the party never blocks
+// on a slow sender regardless of how many participants take part.
+func outChannelSize(parties int) int {
+	return 2 * (parties + 1)
 }
 
-// createParticipantList creates a list of party IDs from peer IDs
-func (s *Service) createParticipantList(peerIDs []string) ([]*tss.PartyID, error) {
+// createParticipantList creates a list of party IDs from peer IDs for shares of the given
+// generation, 0 for shares that were never reshared
+func (s *Service) createParticipantList(peerIDs []string, generation int) ([]*tss.PartyID, error) {
+	// Synced operations skip resolveParticipants, so duplicates are checked here as well
+	if err := checkDuplicateParticipants(peerIDs); err != nil {
+		return nil, err
+	}
+
 	participants := dkcommon.Map(peerIDs, func(peerID string) *tss.PartyID {
 		// Generate a deterministic key based on the peer ID itself
 		// This ensures the same node always gets the same key across different operations
-		key := s.generateDeterministicKey(peerID)
+		key := s.partyKey(peerID, generation)
 
 		// Use empty moniker for remote peers, or actual moniker if it's this node
 		moniker := ""
@@ -430,6 +763,16 @@ func (s *Service) createParticipantList(peerIDs []string) ([]*tss.PartyID, error
 	return tss.SortPartyIDs(participants), nil
 }
 
+// partyKey returns the party key of a peer for shares of the given generation. Every
+// resharing moves the new committee to the next generation, so a node in both committees
+// runs its old and its new party under distinct keys.
+func (s *Service) partyKey(peerID string, generation int) *big.Int {
+	if generation == 0 {
+		return s.generateDeterministicKey(peerID)
+	}
+	return s.generateDeterministicKey(peerID + "/" + strconv.Itoa(generation))
+}
+
 // generateDeterministicKey generates a deterministic big.Int key from a peer ID
 // This ensures the same peer always gets the same key across different operations
 // Uses the same method as bnb-chain/tss library for compatibility
@@ -448,68 +791,66 @@ func (s *Service) generateDeterministicKey(peerID string) *big.Int {
 	return key
 }
 
-// syncOperation broadcasts operation synchronization message to all peers
-func (s *Service) syncOperation(ctx context.Context, syncData Message) error {
+// broadcastOperationMessage sends an operation level message of the given type to
+// every participant except ourselves, retrying delivery to each of them
+func (s *Service) broadcastOperationMessage(ctx context.Context, msgType OperationType, syncData Message) error {
 	// Serialize sync data
 	data, err := json.Marshal(syncData)
 	if err != nil {
 		return fmt.Errorf("failed to marshal sync data: %w", err)
 	}
 
-	// Create a copy of participants to avoid modifying the original syncData
-	participants := syncData.To()
-	to := make([]string, len(participants))
-	copy(to, participants)
-
-	// Remove self from the copied list of participants
-	to = slices.DeleteFunc(to, func(toNode string) bool {
-		return toNode == s.nodeID
-	})
-
+	to := s.remoteParticipants(syncData.To())
 	if len(to) == 0 {
 		s.logger.Warn("No participants to sync with", zap.String("SessionID", syncData.ID()))
 		return nil
 	}
 
+	// Operation messages are addressed to each participant, never broadcast to all peers
 	msg := &p2p.Message{
-		ProtocolID:  p2p.TssPartyProtocolID,
-		SessionID:   syncData.ID(),
-		Type:        string(OperationSync),
-		From:        s.nodeID,
-		To:          to,
-		IsBroadcast: true,
-		Data:        data, // Serialized operation sync data
-		Timestamp:   time.Now(),
+		ProtocolID: p2p.TssPartyProtocolID,
+		SessionID:  syncData.ID(),
+		Type:       string(msgType),
+		From:       s.nodeID,
+		Data:       data, // Serialized operation sync data
+		Timestamp:  time.Now(),
+	}
+
+	// Deliver to each participant separately so one unreachable peer does not
+	// prevent, or exhaust the retries of, the others
+	var wg sync.WaitGroup
+	errs := make([]error, len(to))
+	for i, target := range to {
+		targetMsg := msg.Clone()
+		targetMsg.To = []string{target}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.sendWithRetry(ctx, targetMsg)
+		}()
 	}
-	return s.network.SendMessage(ctx, msg)
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// remoteParticipants returns a copy of participants without ourselves
+func (s *Service) remoteParticipants(participants []string) []string {
+	return slices.DeleteFunc(slices.Clone(participants), func(toNode string) bool {
+		return toNode == s.nodeID
+	})
 }
 
 // saveOperation saves an operation to persistent storage
 func (s *Service) saveOperation(ctx context.Context, operation *Operation) error {
 	// Convert Operation to OperationData for persistence
-	opData := &OperationData{
-		ID:           operation.ID,
-		Type:         operation.Type,
-		SessionID:    operation.SessionID,
-		Status:       operation.Status,
-		Participants: make([]string, len(operation.Participants)),
-		CreatedAt:    operation.CreatedAt,
-		CompletedAt:  operation.CompletedAt,
-		Request:      operation.Request,
-		Result:       operation.Result,
-	}
+	opData := operation.Snapshot()
 
 	if !opData.IsCompleted() {
 		return fmt.Errorf("operation %s is not completed (status: %s)", opData.ID, opData.Status)
 	}
-
-	opData.Participants = dkcommon.Map(operation.Participants, func(p *tss.PartyID) string {
-		return p.Id
-	})
-
-	// Set error if present
-	if operation.Error != nil {
-		opData.Error = operation.Error.Error()
+	if req, ok := opData.Request.(*SigningRequest); ok && !s.persistFullMessage {
+		opData.Request = req.withoutMessage()
 	}
 
 	// Serialize operation data to JSON
@@ -519,14 +860,62 @@ func (s *Service) saveOperation(ctx context.Context, operation *Operation) error
 	}
 
 	// Save to storage with operation key prefix
-	key := fmt.Sprintf("operation:%s", operation.ID)
+	if err := s.saveMetadata(ctx, operationPrefix+operation.ID, data); err != nil {
+		return err
+	}
+	// Replaces an entry of a previously saved version of the operation
+	s.operationCache.put(opData)
+	return s.saveOperationLabels(ctx, operation.ID, operation.Labels)
+}
+
+// saveMetadata stores non-key data, encrypting it first when metadata encryption is enabled
+func (s *Service) saveMetadata(ctx context.Context, key string, data []byte) error {
+	if s.encryptMetadata {
+		encrypted, err := s.encryption.Encrypt(data)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt metadata: %w", err)
+		}
+		data = encrypted
+	}
 	return s.storage.Save(ctx, key, data)
 }
 
+// loadMetadata loads data stored by saveMetadata.
+// Plaintext JSON written before encryption was enabled is still accepted and, when
+// encryption is enabled, rewritten encrypted so existing stores migrate on access.
+// Data encrypted with other key derivation parameters is migrated the same way.
+func (s *Service) loadMetadata(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.storage.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if json.Valid(data) {
+		if s.encryptMetadata && !s.readOnly {
+			if err := s.saveMetadata(ctx, key, data); err != nil {
+				s.logger.Warn("Failed to migrate plaintext metadata", zap.String("key", key), zap.Error(err))
+			}
+		}
+		return data, nil
+	}
+
+	plaintext, err := s.encryption.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt metadata: %w", err)
+	}
+
+	// Re-encrypt metadata written with other key derivation parameters
+	if s.encryptMetadata && !s.readOnly && !s.encryption.Current(data) {
+		if err := s.saveMetadata(ctx, key, plaintext); err != nil {
+			s.logger.Warn("Failed to migrate metadata encryption", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return plaintext, nil
+}
+
 // loadOperation loads an operation from persistent storage
 func (s *Service) loadOperation(ctx context.Context, operationID string) (*OperationData, error) {
-	key := fmt.Sprintf("operation:%s", operationID)
-	data, err := s.storage.Load(ctx, key)
+	data, err := s.loadMetadata(ctx, operationPrefix+operationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load operation data: %w", err)
 	}
@@ -618,15 +1007,21 @@ func (s *Service) checkIdempotency(ctx context.Context, operationID string) (*Op
 	s.mutex.RLock()
 	if existingOp, exists := s.operations[operationID]; exists {
 		s.mutex.RUnlock()
+		existingOp.RLock()
+		status := existingOp.Status
+		existingOp.RUnlock()
 		s.logger.Info("Operation already exists in memory",
 			zap.String("operation_id", operationID),
-			zap.String("status", string(existingOp.Status)))
+			zap.String("status", string(status)))
 		return existingOp, nil
 	}
 	s.mutex.RUnlock()
 
 	// Check if operation exists in persistent storage
 	opData, err := s.loadOperation(ctx, operationID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil // New operation ID, proceed with new operation
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -646,6 +1041,9 @@ func (s *Service) checkIdempotency(ctx context.Context, operationID string) (*Op
 		CompletedAt: opData.CompletedAt,
 		Request:     opData.Request,
 		Result:      opData.Result,
+		Labels:      opData.Labels,
+		Owner:       opData.Owner,
+		RequestID:   opData.RequestID,
 	}
 	if opData.Error != "" {
 		operation.Error = fmt.Errorf("%s", opData.Error)
@@ -698,73 +1096,156 @@ func (s *Service) toParticipants(operation *Operation, msg tss.Message, routing
 }
 
 func (s *Service) watchOperation(ctx context.Context, op *Operation) {
-	s.logger.Info("Waiting for operation completion or cancellation", zap.String("operation_id", op.ID))
+	logger := s.operationLogger(op)
+	logger.Info("Waiting for operation completion or cancellation")
+
+	// Connections to the other parties must survive connection pruning until the end
+	defer s.protectParticipants(op)()
 
 	// Always move completed operation to persistent storage for cleanup
 	defer func() {
 		if err := s.moveCompletedOperationToStorage(ctx, op.ID); err != nil {
-			s.logger.Error("Failed to move operation to persistent storage during cleanup",
+			logger.Error("Failed to move operation to persistent storage during cleanup",
 				zap.Error(err),
-				zap.String("operation_id", op.ID),
 				zap.String("type", string(op.Type)))
 		}
-		s.logger.Info("Operation completed",
-			zap.String("operation_id", op.ID),
+		op.RLock()
+		status := op.Status
+		rounds := formatRoundTimings(op.RoundTimings)
+		op.RUnlock()
+		logger.Info("Operation completed",
 			zap.String("type", string(op.Type)),
-			zap.String("status", string(op.Status)),
+			zap.String("status", string(status)),
+			zap.String("round_timings", rounds),
 		)
+		s.notifyOperation(op)
+		// Release clients waiting for the operation
+		close(op.doneCh())
 	}()
 
-	// Wait for operation completion or cancellation
+	// Wait for operation completion or cancellation. The outcome is recorded under the
+	// operation lock at the end, saving the result takes the lock itself.
+	var (
+		status   OperationStatus
+		opErr    error
+		opDetail string
+	)
 	select {
 	case <-ctx.Done():
-		s.logger.Info("Operation canceled or timed out", zap.String("operation_id", op.ID), zap.Error(ctx.Err()))
-		op.Status = StatusCancelled
-		op.CompletedAt = dkcommon.Now()
+		// The operation context carries the operation timeout, an explicit cancel ends it
+		// with context.Canceled instead
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			logger.Warn("Operation timed out")
+			status = StatusTimedOut
+			opErr = fmt.Errorf("%w: the protocol did not finish in time", ErrOperationTimedOut)
+		} else {
+			logger.Info("Operation canceled", zap.Error(ctx.Err()))
+			status = StatusCancelled
+			opDetail = ctx.Err().Error()
+		}
 	case result := <-op.EndCh:
-		op.CompletedAt = dkcommon.Now()
 		switch r := result.(type) {
 		case error:
-			op.Error = r
-			op.Status = StatusFailed
-			s.logger.Error("Operation failed", zap.String("operation_id", op.ID), zap.Error(r))
+			opErr = r
+			status = StatusFailed
+			logger.Error("Operation failed", zap.Error(r))
 		case *keygen.LocalPartySaveData:
-			op.Status = StatusCompleted
-			if err := s.saveKeygenResult(ctx, op, r); err != nil {
-				s.logger.Error("Failed to save signing result", zap.Error(err))
-				op.Error = err
-				op.Status = StatusFailed
+			status = StatusCompleted
+			save := s.saveKeygenResult
+			if op.Type == OperationResharing {
+				save = s.saveResharingResult
+			}
+			if err := save(ctx, op, r); err != nil {
+				logger.Error("Failed to save keygen result", zap.Error(err))
+				opErr = err
+				status = StatusFailed
 			}
 		case *common.SignatureData:
-			op.Status = StatusCompleted
+			status = StatusCompleted
 			if err := s.saveSigningResult(ctx, op, r); err != nil {
-				s.logger.Error("Failed to save signing result", zap.Error(err))
-				op.Error = err
-				op.Status = StatusFailed
+				logger.Error("Failed to save signing result", zap.Error(err))
+				opErr = err
+				status = StatusFailed
 			}
 		default:
-			s.logger.Error("Unknown operation result type", zap.Any("result", result))
-			op.Status = StatusFailed
+			logger.Error("Unknown operation result type", zap.Any("result", result))
+			status = StatusFailed
 		}
 	}
+
+	if opErr != nil {
+		opDetail = opErr.Error()
+	}
+
+	op.Lock()
+	op.CompletedAt = dkcommon.Now()
+	op.endRoundLocked(*op.CompletedAt)
+	if len(op.RoundTimings) > 0 {
+		op.Events = append(op.Events, OperationEvent{Time: *op.CompletedAt, From: op.Status, To: op.Status,
+			Detail: "round timings: " + formatRoundTimings(op.RoundTimings)})
+	}
+	op.setStatusLocked(status, opDetail)
+	op.Error = opErr
+	op.Unlock()
+}
+
+// protectParticipants keeps the connections to the other parties of op open if the transport
+// prunes connections and returns the function releasing them
+func (s *Service) protectParticipants(op *Operation) func() {
+	protector, ok := s.network.(p2p.PeerProtector)
+	if !ok {
+		return func() {}
+	}
+
+	tag := operationProtectionTag(op.ID)
+	participants := s.remoteParticipants(op.participantIDs())
+	protector.Protect(tag, participants)
+	return func() { protector.Unprotect(tag, participants) }
+}
+
+// operationProtectionTag returns the connection manager tag protecting the parties of an operation
+func operationProtectionTag(operationID string) string {
+	return "dknet-operation:" + operationID
 }
 
 // runOperation runs a TSS operation
 func (s *Service) runOperation(ctx context.Context, operation *Operation) {
-	s.logger.Info("Starting TSS operation goroutine", zap.String("operation_id", operation.ID))
+	logger := s.operationLogger(operation)
+	logger.Info("Starting TSS operation goroutine")
+
+	// Synced operations took their slot when the sync arrived. Operations this node initiated
+	// wait for a free slot when the concurrency limit is reached and stay pending meanwhile.
+	if !s.admission.claim(operation.ID) {
+		if err := s.admission.acquire(ctx, operationPriority(operation.Type)); err != nil {
+			logger.Warn("Operation ended before it was admitted", zap.Error(err))
+			return
+		}
+	}
+	close(operation.admittedCh())
+	go func() {
+		<-operation.Done()
+		s.admission.release()
+	}()
 
 	// Update status
 	operation.Lock()
-	operation.Status = StatusInProgress
+	operation.setStatusLocked(StatusInProgress, "")
 	operation.Unlock()
 
 	// Start the party
 	dkcommon.SafeGo(operation.EndCh, func() any {
-		s.logger.Info("Starting TSS party", zap.String("operation_id", operation.ID))
+		logger.Info("Starting TSS party")
 		if err := operation.Party.Start(); err != nil {
 			return err
 		}
-		s.logger.Info("TSS party started successfully", zap.String("operation_id", operation.ID))
+		// The old party of a node in both resharing committees starts once the new party
+		// runs, which takes the first message of the old party
+		if operation.OldParty != nil {
+			if err := operation.OldParty.Start(); err != nil {
+				return err
+			}
+		}
+		logger.Info("TSS party started successfully")
 		return nil
 	})
 
@@ -772,20 +1253,29 @@ func (s *Service) runOperation(ctx context.Context, operation *Operation) {
 	dkcommon.SafeGo(operation.EndCh, func() any {
 		return s.handleOutgoingMessages(ctx, operation)
 	})
+
+	s.replayEarlyMessages(ctx, operation)
 }
 
-func (s *Service) getOperation(sessionID string) *Operation {
+// getOperation finds the operation of a session. Wire messages can arrive before the
+// sync message created the operation locally, so the lookup is retried with backoff
+// for up to sessionLookupTimeout.
+func (s *Service) getOperation(ctx context.Context, sessionID string) *Operation {
 	find := func() *Operation {
 		s.mutex.RLock()
 		defer s.mutex.RUnlock()
+		return s.findOperationLocked(sessionID)
+	}
 
-		for _, op := range s.operations {
-			if op.SessionID == sessionID {
-				return op
-			}
+	return dkcommon.Retry(ctx, find, sessionLookupInitialDelay, sessionLookupMaxDelay, s.sessionLookupTimeout)
+}
+
+// findOperationLocked returns the operation of a session. The caller must hold the lock.
+func (s *Service) findOperationLocked(sessionID string) *Operation {
+	for _, op := range s.operations {
+		if op.SessionID == sessionID {
+			return op
 		}
-		return nil
 	}
-
-	return dkcommon.Retry(find, 1, 10)
+	return nil
 }
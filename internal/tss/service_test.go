@@ -0,0 +1,628 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/notify"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// testKDF keeps key derivation cheap in tests
+var testKDF = plugin.KDFParams{Algorithm: plugin.KDFArgon2id, Time: 1, Memory: 64, Threads: 1}
+
+func newTestService(t testing.TB, encryptMetadata bool) (*Service, storage.Storage) {
+	t.Helper()
+
+	store := storage.NewMemoryStorage()
+	t.Cleanup(func() { _ = store.Close() })
+
+	cipher, err := plugin.NewKeyCipher("test-password", testKDF)
+	require.NoError(t, err)
+
+	return &Service{
+		logger:          zap.NewNop(),
+		storage:         store,
+		encryption:      cipher,
+		operations:      make(map[string]*Operation),
+		encryptMetadata: encryptMetadata,
+		syncAcks:        make(map[string]*syncAckWaiter),
+		notifier:        notify.Nop{},
+		earlyMessages:   make(map[string][]earlyMessage),
+	}, store
+}
+
+// newTestNetwork starts n services connected by an in-memory transport. Their keygen parties
+// take pre-parameters from the tss-lib fixtures, generating safe primes takes minutes.
+func newTestNetwork(t *testing.T, n int, configure func(*Config)) []*Service {
+	t.Helper()
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(n)
+	require.NoError(t, err)
+
+	hub := p2p.NewMemoryHub()
+	services := make([]*Service, n)
+	for i := range services {
+		transport, err := hub.NewTransport(zap.NewNop())
+		require.NoError(t, err)
+		store := storage.NewMemoryStorage()
+		t.Cleanup(func() { _ = store.Close() })
+
+		cfg := &Config{PeerID: transport.GetHostID(), KDF: testKDF}
+		if configure != nil {
+			configure(cfg)
+		}
+		s, err := NewService(cfg, store, transport, zap.NewNop(), "test-password")
+		require.NoError(t, err)
+		preParams := fixtures[i].LocalPreParams
+		s.preParams = func() (*keygen.LocalPreParams, error) { return &preParams, nil }
+		services[i] = s
+	}
+	return services
+}
+
+func TestOperationEncryptedAtRest(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, true)
+	s.persistFullMessage = true
+
+	op := &Operation{
+		ID:        "op-encrypted",
+		Type:      OperationSigning,
+		Status:    StatusCompleted,
+		CreatedAt: time.Now(),
+		Request:   &SigningRequest{Message: []byte("secret message"), KeyID: "0xabc"},
+		Result:    &SigningResult{Signature: "0x01", V: 27},
+	}
+	require.NoError(t, s.saveOperation(ctx, op))
+
+	raw, err := store.Load(ctx, "operation:op-encrypted")
+	require.NoError(t, err)
+	require.False(t, json.Valid(raw))
+	require.NotContains(t, string(raw), "0xabc")
+
+	loaded, err := s.loadOperation(ctx, "op-encrypted")
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", loaded.Request.(*SigningRequest).KeyID)
+	require.Equal(t, []byte("secret message"), loaded.Request.(*SigningRequest).Message)
+}
+
+func TestOperationPersistedWithoutMessage(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, false)
+	s.operationCache = newOperationCache(16)
+
+	message := []byte("secret message")
+	typedData := []byte(`{"primaryType":"Mail"}`)
+	req := &SigningRequest{Message: message, KeyID: "0xabc"}
+	for _, op := range []*Operation{
+		{ID: "op-message", Type: OperationSigning, Status: StatusCompleted, CreatedAt: time.Now(), Request: req},
+		{ID: "op-typed", Type: OperationSigning, Status: StatusCompleted, CreatedAt: time.Now(),
+			Request: &SigningRequest{TypedData: typedData, KeyID: "0xabc"}},
+	} {
+		require.NoError(t, s.saveOperation(ctx, op))
+	}
+	// The request of the running operation is left alone
+	require.Equal(t, message, req.Message)
+
+	raw, err := store.Load(ctx, "operation:op-message")
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), base64.StdEncoding.EncodeToString(message))
+	raw, err = store.Load(ctx, "operation:op-typed")
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "primaryType")
+
+	messageDigest := sha256.Sum256(message)
+	typedDataDigest := sha256.Sum256(typedData)
+	s.operationCache = nil
+	for id, want := range map[string]*SigningRequest{
+		"op-message": {KeyID: "0xabc", MessageSHA256: hex.EncodeToString(messageDigest[:])},
+		"op-typed":   {KeyID: "0xabc", MessageSHA256: hex.EncodeToString(typedDataDigest[:]), IsTypedData: true},
+	} {
+		loaded, err := s.GetOperationData(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, want, loaded.Request)
+		require.True(t, loaded.Request.(*SigningRequest).MessageOmitted())
+	}
+
+	// With persist_full_message the message is kept
+	s.persistFullMessage = true
+	require.NoError(t, s.saveOperation(ctx, &Operation{
+		ID: "op-full", Type: OperationSigning, Status: StatusCompleted, CreatedAt: time.Now(), Request: req,
+	}))
+	loaded, err := s.loadOperation(ctx, "op-full")
+	require.NoError(t, err)
+	require.Equal(t, message, loaded.Request.(*SigningRequest).Message)
+	require.False(t, loaded.Request.(*SigningRequest).MessageOmitted())
+}
+
+func TestOperationPlaintextMigration(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, true)
+
+	// Written by a node without metadata encryption
+	legacy, err := json.Marshal(&OperationData{
+		ID:        "op-legacy",
+		Type:      OperationKeygen,
+		Status:    StatusCompleted,
+		CreatedAt: time.Now(),
+		Result:    &KeygenResult{PublicKey: "04ab", KeyID: "0xabc"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, "operation:op-legacy", legacy))
+
+	loaded, err := s.loadOperation(ctx, "op-legacy")
+	require.NoError(t, err)
+	require.Equal(t, "0xabc", loaded.Result.(*KeygenResult).KeyID)
+
+	// The entry is rewritten encrypted on first access
+	raw, err := store.Load(ctx, "operation:op-legacy")
+	require.NoError(t, err)
+	require.False(t, json.Valid(raw))
+
+	loaded, err = s.loadOperation(ctx, "op-legacy")
+	require.NoError(t, err)
+	require.Equal(t, "04ab", loaded.Result.(*KeygenResult).PublicKey)
+}
+
+func TestGetOperationWaitsForSyncedOperation(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.sessionLookupTimeout = 5 * time.Second
+
+	// The sync message creating the operation arrives after the first wire message
+	op := &Operation{ID: "op-late", SessionID: "session-late"}
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		s.mutex.Lock()
+		s.operations[op.ID] = op
+		s.mutex.Unlock()
+	}()
+
+	start := time.Now()
+	require.Same(t, op, s.getOperation(context.Background(), "session-late"))
+	require.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestGetOperationGivesUp(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	// Without a lookup timeout the operation is looked up once
+	require.Nil(t, s.getOperation(context.Background(), "session-missing"))
+
+	s.sessionLookupTimeout = 200 * time.Millisecond
+	start := time.Now()
+	require.Nil(t, s.getOperation(context.Background(), "session-missing"))
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	// A done context stops waiting early
+	s.sessionLookupTimeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	require.Nil(t, s.getOperation(ctx, "session-missing"))
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestOperationDoneAfterWatch(t *testing.T) {
+	s, store := newTestService(t, false)
+
+	op := &Operation{ID: "op-done", Type: OperationSigning, EndCh: make(chan any, 1), Status: StatusInProgress}
+	s.operations[op.ID] = op
+	go s.watchOperation(context.Background(), op)
+
+	select {
+	case <-op.Done():
+		t.Fatal("operation done before it finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	op.EndCh <- errors.New("party failed")
+	select {
+	case <-op.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("operation not done after it finished")
+	}
+
+	// Waiters see the final state, already in storage
+	require.Equal(t, StatusFailed, op.Status)
+	_, err := store.Load(context.Background(), "operation:op-done")
+	require.NoError(t, err)
+}
+
+// protectingTransport records the peers protected per tag
+type protectingTransport struct {
+	p2p.Transport
+	mu        sync.Mutex
+	protected map[string][]string
+}
+
+func (p *protectingTransport) Protect(tag string, nodeIDs []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.protected[tag] = nodeIDs
+}
+
+func (p *protectingTransport) Unprotect(tag string, _ []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.protected, tag)
+}
+
+func (p *protectingTransport) protectedPeers(tag string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.protected[tag]
+}
+
+func TestOperationProtectsParticipants(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+	transport := &protectingTransport{protected: make(map[string][]string)}
+	s.network = transport
+
+	op := &Operation{
+		ID:           "op-protected",
+		Type:         OperationSigning,
+		Participants: []*tss.PartyID{tss.NewPartyID("node1", "node1", big.NewInt(1)), tss.NewPartyID("node2", "node2", big.NewInt(2))},
+		EndCh:        make(chan any, 1),
+		Status:       StatusInProgress,
+	}
+	s.operations[op.ID] = op
+	go s.watchOperation(context.Background(), op)
+
+	// The other parties are protected from connection pruning until the operation ends
+	tag := operationProtectionTag(op.ID)
+	require.Eventually(t, func() bool {
+		return slices.Equal([]string{"node2"}, transport.protectedPeers(tag))
+	}, time.Second, 10*time.Millisecond)
+
+	op.EndCh <- errors.New("party failed")
+	<-op.Done()
+	require.Eventually(t, func() bool {
+		return transport.protectedPeers(tag) == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestOperationAwait(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	// Failed operation returns its error, also to callers awaiting after completion
+	failed := &Operation{ID: "op-await-failed", Type: OperationSigning, EndCh: make(chan any, 1), Status: StatusInProgress}
+	s.operations[failed.ID] = failed
+	go s.watchOperation(context.Background(), failed)
+
+	shortCtx, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	_, err := failed.Await(shortCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	partyErr := errors.New("party failed")
+	failed.EndCh <- partyErr
+	for range 2 {
+		result, err := failed.Await(context.Background())
+		require.ErrorIs(t, err, partyErr)
+		require.Nil(t, result)
+	}
+
+	// Canceled operation
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := &Operation{ID: "op-await-canceled", Type: OperationSigning, EndCh: make(chan any, 1), Status: StatusInProgress}
+	s.operations[canceled.ID] = canceled
+	go s.watchOperation(ctx, canceled)
+	cancel()
+	_, err = canceled.Await(context.Background())
+	require.ErrorIs(t, err, ErrOperationCancelled)
+
+	// Completed operation returns its result
+	completed := &Operation{ID: "op-await-completed", Status: StatusCompleted, Result: &SigningResult{Signature: "0x01"}}
+	close(completed.doneCh())
+	result, err := completed.Await(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "0x01", result.(*SigningResult).Signature)
+}
+
+func TestWatchOperationDistinguishesTimeoutFromCancel(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	// An operation running out of time ends timed out with a timeout error
+	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelTimeout()
+	timedOut := &Operation{ID: "op-timed-out", Type: OperationSigning, EndCh: make(chan any, 1), Status: StatusInProgress}
+	s.operations[timedOut.ID] = timedOut
+	go s.watchOperation(timeoutCtx, timedOut)
+	_, err := timedOut.Await(context.Background())
+	require.ErrorIs(t, err, ErrOperationTimedOut)
+	require.NotErrorIs(t, err, ErrOperationCancelled)
+
+	data := timedOut.Snapshot()
+	require.Equal(t, StatusTimedOut, data.Status)
+	require.True(t, data.IsCompleted())
+	require.Contains(t, data.Error, "operation timed out")
+
+	// An explicitly canceled operation ends canceled, even when it also had a deadline
+	cancelCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	canceled := &Operation{ID: "op-canceled", Type: OperationSigning, EndCh: make(chan any, 1), Status: StatusInProgress}
+	s.operations[canceled.ID] = canceled
+	go s.watchOperation(cancelCtx, canceled)
+	cancel()
+	_, err = canceled.Await(context.Background())
+	require.ErrorIs(t, err, ErrOperationCancelled)
+
+	data = canceled.Snapshot()
+	require.Equal(t, StatusCancelled, data.Status)
+	require.Empty(t, data.Error)
+	require.Equal(t, context.Canceled.Error(), data.Events[len(data.Events)-1].Detail)
+}
+
+func TestOperationSnapshotDuringOperation(t *testing.T) {
+	s, _ := newTestService(t, false)
+
+	op := &Operation{
+		ID:        "op-snapshot",
+		Type:      OperationSigning,
+		EndCh:     make(chan any, 1),
+		Status:    StatusInProgress,
+		CreatedAt: time.Now(),
+		Labels:    map[string]string{"team": "payments"},
+	}
+	s.operations[op.ID] = op
+	go s.watchOperation(context.Background(), op)
+
+	// Clients read the operation while it finishes, run with -race
+	readers := make(chan struct{})
+	for range 4 {
+		go func() {
+			defer func() { readers <- struct{}{} }()
+			for {
+				if snapshot := op.Snapshot(); snapshot.ID != op.ID {
+					t.Errorf("unexpected snapshot of %s", snapshot.ID)
+					return
+				}
+				if _, err := s.GetOperationData(context.Background(), op.ID); err != nil {
+					t.Error(err)
+					return
+				}
+				select {
+				case <-op.Done():
+					return
+				default:
+				}
+			}
+		}()
+	}
+
+	op.Lock()
+	op.Result = &SigningResult{Signature: "0x01"}
+	op.Unlock()
+	op.EndCh <- errors.New("party failed")
+	for range 4 {
+		<-readers
+	}
+
+	snapshot := op.Snapshot()
+	require.Equal(t, StatusFailed, snapshot.Status)
+	require.Equal(t, "party failed", snapshot.Error)
+	require.NotNil(t, snapshot.CompletedAt)
+
+	// Snapshots do not share mutable state with the operation
+	snapshot.Labels["team"] = "changed"
+	require.Equal(t, "payments", op.Snapshot().Labels["team"])
+}
+
+func TestOutChannelSizeHoldsFullRound(t *testing.T) {
+	keys, partyIDs, err := keygen.LoadKeygenTestFixtures(5)
+	require.NoError(t, err)
+	parties := len(partyIDs)
+
+	// The first signing round sends a point-to-point message to each of the n-1 other
+	// parties plus a broadcast, the party must get through it without a reader
+	outCh := make(chan tss.Message, outChannelSize(parties))
+	endCh := make(chan *common.SignatureData, 1)
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(partyIDs), partyIDs[0], parties, parties-1)
+	party := signing.NewLocalParty(big.NewInt(42), params, keys[0], outCh, endCh)
+
+	started := make(chan error, 1)
+	go func() {
+		if err := party.Start(); err != nil {
+			started <- err
+			return
+		}
+		started <- nil
+	}()
+	select {
+	case err := <-started:
+		require.NoError(t, err)
+	case <-time.After(time.Minute):
+		t.Fatalf("party blocked with %d of %d buffered messages", len(outCh), cap(outCh))
+	}
+
+	var pointToPoint, broadcasts int
+	for len(outCh) > 0 {
+		if msg := <-outCh; msg.IsBroadcast() {
+			broadcasts++
+		} else {
+			pointToPoint++
+		}
+	}
+	require.Equal(t, parties-1, pointToPoint)
+	require.Equal(t, 1, broadcasts)
+}
+
+func TestKeyDataKDFMigration(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, false)
+
+	// Key data encrypted with other key derivation parameters
+	old, err := plugin.NewKeyCipher("test-password", plugin.KDFParams{Algorithm: plugin.KDFScrypt, N: 1 << 10, R: 8, P: 1})
+	require.NoError(t, err)
+	share, err := json.Marshal(&keygen.LocalPartySaveData{})
+	require.NoError(t, err)
+	encrypted, err := old.Encrypt(share)
+	require.NoError(t, err)
+	record := &keyData{KeyData: encrypted, Threshold: 1, Participants: []string{"a", "b"}}
+	content, err := keyDataMACContent("0xabc", record)
+	require.NoError(t, err)
+	record.MAC = old.MAC(content)
+	stored, err := json.Marshal(record)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, "0xabc", stored))
+
+	_, _, err = s.loadKeyData(ctx, "0xabc")
+	require.NoError(t, err)
+
+	// The key data is rewritten with the current parameters on first access
+	raw, err := store.Load(ctx, "0xabc")
+	require.NoError(t, err)
+	var migrated keyData
+	require.NoError(t, json.Unmarshal(raw, &migrated))
+	require.True(t, s.encryption.Current(migrated.KeyData))
+	require.Equal(t, 1, migrated.Threshold)
+
+	_, _, err = s.loadKeyData(ctx, "0xabc")
+	require.NoError(t, err)
+}
+
+func TestOperationEventHistory(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+
+	op := &Operation{ID: "op-events", Type: OperationSigning, EndCh: make(chan any, 1), Status: StatusPending}
+	s.operations[op.ID] = op
+	go s.watchOperation(ctx, op)
+
+	op.Lock()
+	op.setStatusLocked(StatusInProgress, "")
+	op.Unlock()
+	op.recordEvent("round 1")
+	op.EndCh <- errors.New("party failed")
+	<-op.Done()
+
+	// The history is persisted with the operation
+	loaded, err := s.loadOperation(ctx, op.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Events, 3)
+	require.Equal(t, StatusPending, loaded.Events[0].From)
+	require.Equal(t, StatusInProgress, loaded.Events[0].To)
+	require.Equal(t, OperationEvent{
+		Time: loaded.Events[1].Time, From: StatusInProgress, To: StatusInProgress, Detail: "round 1",
+	}, loaded.Events[1])
+	require.Equal(t, StatusFailed, loaded.Events[2].To)
+	require.Equal(t, "party failed", loaded.Events[2].Detail)
+	require.False(t, loaded.Events[2].Time.Before(loaded.Events[0].Time))
+}
+
+func TestOperationRoundTimings(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+
+	op := &Operation{ID: "op-rounds", Type: OperationKeygen, EndCh: make(chan any, 1), Status: StatusPending}
+	s.operations[op.ID] = op
+	go s.watchOperation(ctx, op)
+
+	op.Lock()
+	op.setStatusLocked(StatusInProgress, "")
+	op.Unlock()
+	op.enterRound(1)
+	time.Sleep(20 * time.Millisecond)
+	op.enterRound(2)
+
+	// The round the party is in has no duration yet
+	running := op.Snapshot()
+	require.Len(t, running.RoundTimings, 2)
+	require.GreaterOrEqual(t, running.RoundTimings[0].Duration, 20*time.Millisecond)
+	require.Zero(t, running.RoundTimings[1].Duration)
+
+	time.Sleep(50 * time.Millisecond)
+	op.EndCh <- errors.New("party failed")
+	<-op.Done()
+
+	// The last round ends with the operation, the timings are persisted with it
+	loaded, err := s.loadOperation(ctx, op.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.RoundTimings, 2)
+	require.Equal(t, 1, loaded.RoundTimings[0].Round)
+	require.Equal(t, 2, loaded.RoundTimings[1].Round)
+	require.GreaterOrEqual(t, loaded.RoundTimings[1].Duration, 50*time.Millisecond)
+	firstRound := loaded.RoundTimings[0]
+	require.WithinDuration(t, loaded.RoundTimings[1].Started, firstRound.Started.Add(firstRound.Duration), time.Millisecond)
+
+	// Each round is an event and the breakdown is recorded before the final transition
+	details := make([]string, 0, len(loaded.Events))
+	for _, event := range loaded.Events {
+		details = append(details, event.Detail)
+	}
+	require.Equal(t, "round 1", details[1])
+	require.Equal(t, "round 2", details[2])
+	require.Contains(t, details[3], "round timings: round 1 ")
+	require.Contains(t, details[3], ", round 2 ")
+	require.Equal(t, "party failed", details[4])
+}
+
+func TestMessageRound(t *testing.T) {
+	routing := tss.MessageRouting{From: tss.NewPartyID("a", "a", big.NewInt(1)), IsBroadcast: true}
+	for content, round := range map[tss.MessageContent]int{
+		&keygen.KGRound1Message{}:     1,
+		&signing.SignRound9Message{}:  9,
+		&resharing.DGRound4Message2{}: 4,
+	} {
+		msg := tss.NewMessage(routing, content, tss.NewMessageWrapper(routing, content))
+		require.Equal(t, round, messageRound(msg), msg.Type())
+	}
+}
+
+// recordingNotifier records the operations it is notified about
+type recordingNotifier struct {
+	notify.Nop
+	failed chan *notify.Operation
+}
+
+func (n *recordingNotifier) OperationFailed(op *notify.Operation) {
+	n.failed <- op
+}
+
+func TestWatchOperationNotifiesFailures(t *testing.T) {
+	s, _ := newTestService(t, false)
+	notifier := &recordingNotifier{failed: make(chan *notify.Operation, 1)}
+	s.notifier = notifier
+
+	op := &Operation{
+		ID:        "op-notify",
+		Type:      OperationSigning,
+		EndCh:     make(chan any, 1),
+		Status:    StatusInProgress,
+		CreatedAt: time.Now(),
+		Request:   &SigningRequest{KeyID: "0x0000000000000000000000000000000000000001"},
+	}
+	s.operations[op.ID] = op
+	go s.watchOperation(context.Background(), op)
+	op.EndCh <- errors.New("party failed")
+
+	select {
+	case event := <-notifier.failed:
+		require.Equal(t, "op-notify", event.ID)
+		require.Equal(t, string(StatusFailed), event.Status)
+		require.Equal(t, "0x0000000000000000000000000000000000000001", event.KeyID)
+		require.Equal(t, "party failed", event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed operation was not notified")
+	}
+}
@@ -2,14 +2,19 @@ package tss
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/google/uuid"
@@ -17,6 +22,7 @@ import (
 
 	dknetCommon "github.com/dreamer-zq/DKNet/internal/common"
 	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/storage"
 )
 
 // signingOperationParams contains parameters for creating a signing operation
@@ -24,18 +30,78 @@ type signingOperationParams struct {
 	OperationID  string
 	SessionID    string
 	Message      []byte
+	TypedData    []byte
 	KeyID        string
 	Participants []string
+	ChainID      uint64
+	Metadata     map[string]string
+	Labels       map[string]string
+	// DerivationPath selects the child key to sign with, empty for the master key
+	DerivationPath string
+	// ContextBound signs a digest bound to the key and session, see ContextBoundDigest
+	ContextBound bool
+	// ParticipantsHash is the participant set hash announced by the initiator, empty when initiating
+	ParticipantsHash string
+	// Threshold is the key threshold announced by the initiator, 0 when initiating
+	Threshold int
+	// Timeout bounds the operation, defaultSigningTimeout when zero
+	Timeout time.Duration
+	// Owner is the authenticated client that started the operation, if any
+	Owner string
+	// RequestID is the ID of the client request that started the operation, if any
+	RequestID string
+	// ValidationBypass explains why the request was signed without being validated, if it was
+	ValidationBypass string
 }
 
-// StartSigning starts a new signing operation
+// StartSigning starts a new signing operation, keyID may also be a key alias
 func (s *Service) StartSigning(
 	ctx context.Context,
 	operationID string,
 	message []byte,
 	keyID string,
 	participants []string,
+	chainID uint64,
+	opts SigningOptions,
 ) (*Operation, error) {
+	return s.startSigning(ctx, &SigningRequest{
+		OperationID:  operationID,
+		Message:      message,
+		KeyID:        keyID,
+		Participants: participants,
+		ChainID:      chainID,
+	}, opts)
+}
+
+// StartTypedDataSigning starts a new signing operation over the EIP-712 hash of typed data
+func (s *Service) StartTypedDataSigning(
+	ctx context.Context,
+	operationID string,
+	typedData []byte,
+	keyID string,
+	participants []string,
+	chainID uint64,
+	opts SigningOptions,
+) (*Operation, error) {
+	return s.startSigning(ctx, &SigningRequest{
+		OperationID:  operationID,
+		TypedData:    typedData,
+		KeyID:        keyID,
+		Participants: participants,
+		ChainID:      chainID,
+	}, opts)
+}
+
+// startSigning starts a signing operation for a message or typed data request
+func (s *Service) startSigning(ctx context.Context, req *SigningRequest, opts SigningOptions) (*Operation, error) {
+	operationID := req.OperationID
+	req.Metadata, req.DerivationPath, req.ContextBound = opts.Metadata, opts.DerivationPath, opts.ContextBound
+
+	// Oversized payloads are rejected before they are hashed, synced and persisted
+	if err := s.validateMessageSize(req); err != nil {
+		return nil, err
+	}
+
 	// Check for existing operation (idempotency)
 	existingOp, err := s.checkIdempotency(ctx, operationID)
 	if err != nil {
@@ -46,16 +112,92 @@ func (s *Service) StartSigning(
 		return existingOp, nil
 	}
 
-	// Create request for validation
-	req := &SigningRequest{
-		OperationID:  operationID,
-		Message:      message,
-		KeyID:        keyID,
-		Participants: participants,
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	// Participants are always told the key ID and peer IDs, names are only known to this node
+	if req.Participants, err = s.resolveParticipants(req.Participants); err != nil {
+		return nil, err
+	}
+	if err = s.checkParticipant(req.Participants); err != nil {
+		return nil, err
+	}
+	if req.KeyID, err = s.ResolveKeyID(ctx, req.KeyID); err != nil {
+		return nil, err
+	}
+
+	// The chain family of the key decides how the message is hashed
+	keyMetadata, err := s.LoadKeyMetadata(ctx, req.KeyID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotHeld, req.KeyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(keyMetadata.Participants, s.nodeID) {
+		return nil, fmt.Errorf("%w: %s is not a participant of %s", ErrKeyNotHeld, s.nodeID, req.KeyID)
+	}
+	// The sync message carries the trimmed set, the other parties never hear of the operation
+	if s.trimSigningQuorum {
+		req.Participants = trimSigningQuorum(req.Participants, keyMetadata.Threshold, s.nodeID)
+	}
+	digest, err := signingDigest(keyMetadata.Family(), req.Message, req.TypedData, req.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if req.DerivationPath, err = normalizeDerivationPath(req.DerivationPath); err != nil {
+		return nil, err
+	}
+
+	// Deduplicate requests without an operation ID by content (if enabled), child keys of a
+	// key are told apart by their derivation path
+	var contentHash string
+	if operationID == "" && s.signingDedupTTL > 0 {
+		// A context-bound request is not a duplicate of a plain one for the same message
+		content := digest
+		if req.ContextBound {
+			content = append([]byte(contextBindingTag), digest...)
+		}
+		contentHash, err = signingContentHash(operationOwner(ctx), req.KeyID+req.DerivationPath, content, req.Participants, req.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		operationID = s.generateOrUseOperationID(operationID)
+		if existingOp, err = s.reserveSigningContent(ctx, contentHash, operationID); existingOp != nil || err != nil {
+			return existingOp, err
+		}
+		defer func() {
+			if err != nil {
+				s.releaseSigningContent(contentHash, operationID)
+			}
+		}()
+	}
+
+	keyID, participants, chainID := req.KeyID, req.Participants, req.ChainID
+	req.OperationID = operationID
+
+	if err = validateChainID(chainID); err != nil {
+		return nil, err
+	}
+	if err = validateSigningMetadata(req.Metadata); err != nil {
+		return nil, err
+	}
+	if err = validateOperationLabels(opts.Labels); err != nil {
+		return nil, err
+	}
+
+	// The key's own policy applies first, then the node's validation service
+	if err = s.enforceKeyPolicy(ctx, keyMetadata, req, true); err != nil {
+		s.logger.Warn("Signing request rejected by key policy",
+			zap.Error(err),
+			zap.String("key_id", keyID))
+		return nil, err
 	}
 
 	// Validate signing request with external validation service (if configured)
-	if err = s.validateSigningRequest(ctx, req); err != nil {
+	var validationBypass string
+	if validationBypass, err = s.validateSigningRequest(ctx, req); err != nil {
 		s.logger.Error("Signing request validation failed",
 			zap.Error(err),
 			zap.String("key_id", keyID))
@@ -68,11 +210,21 @@ func (s *Service) StartSigning(
 
 	// Create the signing operation using common logic
 	operation, threshold, err := s.createSigningOperation(ctx, &signingOperationParams{
-		OperationID:  operationID,
-		SessionID:    sessionID,
-		Message:      message,
-		KeyID:        keyID,
-		Participants: participants,
+		OperationID:      operationID,
+		SessionID:        sessionID,
+		Message:          req.Message,
+		TypedData:        req.TypedData,
+		KeyID:            keyID,
+		Participants:     participants,
+		ChainID:          chainID,
+		Metadata:         req.Metadata,
+		DerivationPath:   req.DerivationPath,
+		Labels:           opts.Labels,
+		ContextBound:     req.ContextBound,
+		Timeout:          s.operationTimeout(opts.Timeout, defaultSigningTimeout),
+		Owner:            operationOwner(ctx),
+		RequestID:        requestID(ctx),
+		ValidationBypass: validationBypass,
 	})
 	if err != nil {
 		return nil, err
@@ -80,10 +232,14 @@ func (s *Service) StartSigning(
 
 	// Broadcast signing operation sync message to other participants
 	dknetCommon.SafeGo(operation.EndCh, func() any {
+		// Participants never queue, the sync waits until the operation may run here
+		if !operation.awaitAdmission() {
+			return nil
+		}
 		return s.syncSigningOperation(
-			operationID, sessionID,
+			operationID, sessionID, operation.RequestID,
 			threshold, len(operation.Participants),
-			participants, keyID, message,
+			participants, keyID, req.Message, req.TypedData, chainID, req.Metadata, req.DerivationPath, req.ContextBound,
 		)
 	})
 
@@ -92,14 +248,31 @@ func (s *Service) StartSigning(
 
 // createSigningOperation creates a signing operation with shared logic
 func (s *Service) createSigningOperation(ctx context.Context, params *signingOperationParams) (*Operation, int, error) {
+	// Every node must build exactly the same party set, otherwise the rounds never complete
+	participantsHash, err := participantSetHash(params.Participants)
+	if err != nil {
+		return nil, 0, err
+	}
+	if params.ParticipantsHash != "" && params.ParticipantsHash != participantsHash {
+		s.logger.Error("Participant set does not match the initiator's",
+			zap.String("operation_id", params.OperationID),
+			zap.String("expected_hash", params.ParticipantsHash),
+			zap.String("actual_hash", participantsHash),
+			zap.Strings("participants", params.Participants))
+		return nil, 0, fmt.Errorf("%w: expected %s, got %s", ErrParticipantSetMismatch, params.ParticipantsHash, participantsHash)
+	}
+
 	// Load key data and metadata
 	keyData, localParty, err := s.loadKeyData(ctx, params.KeyID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to load key data: %w", err)
 	}
+	if err = checkSigningParties(keyData, params.Participants, params.Threshold); err != nil {
+		return nil, 0, err
+	}
 
-	// Create participant list
-	participantList, err := s.createParticipantList(params.Participants)
+	// Create participant list, the party keys changed with every resharing of the key
+	participantList, err := s.createParticipantList(params.Participants, keyData.Generation)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to create participant list: %w", err)
 	}
@@ -119,26 +292,50 @@ func (s *Service) createSigningOperation(ctx context.Context, params *signingOpe
 	tssParams := tss.NewParameters(tss.S256(), ctx2, ourPartyID, len(participantList), threshold)
 
 	// Create channels
-	outCh := make(chan tss.Message, 100)
+	outCh := make(chan tss.Message, outChannelSize(len(participantList)))
 	endCh := make(chan *common.SignatureData, 1)
 
-	// Hash the message to sign - use Ethereum-compatible hash for ecrecover verification
-	hash := hashMessageForEthereum(params.Message)
+	// Create request for storage
+	req := &SigningRequest{
+		OperationID:    params.OperationID,
+		Message:        params.Message,
+		TypedData:      params.TypedData,
+		KeyID:          params.KeyID,
+		Participants:   params.Participants,
+		ChainID:        params.ChainID,
+		Metadata:       params.Metadata,
+		DerivationPath: params.DerivationPath,
+		ChainFamily:    keyData.Family(),
+		ContextBound:   params.ContextBound,
+	}
+
+	// Hash the message to sign as the key's chain expects, e.g. for ecrecover verification
+	hash, err := boundSigningDigest(req, keyData.Family(), params.SessionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Sign with the child key at the derivation path, if any, by adding the key derivation
+	// delta to the shares
+	signingKey := localParty
+	var keyDerivationDelta *big.Int
+	var childKey *crypto.ECPoint
+	if params.DerivationPath != "" {
+		if signingKey, keyDerivationDelta, err = deriveSigningKey(localParty, params.DerivationPath); err != nil {
+			return nil, 0, err
+		}
+		childKey = signingKey.ECDSAPub
+	}
 
 	// Create signing party
-	party := signing.NewLocalParty(new(big.Int).SetBytes(hash), tssParams, *localParty, outCh, endCh)
+	party := signing.NewLocalPartyWithKDD(new(big.Int).SetBytes(hash), tssParams, *signingKey, keyDerivationDelta, outCh, endCh)
 
 	// Create operation context with cancellation - use background context to avoid HTTP timeout
-	// Set a shorter timeout for signing operations (5 minutes)
-	operationCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-
-	// Create request for storage
-	req := &SigningRequest{
-		OperationID:  params.OperationID,
-		Message:      params.Message,
-		KeyID:        params.KeyID,
-		Participants: params.Participants,
+	timeout := params.Timeout
+	if timeout == 0 {
+		timeout = defaultSigningTimeout
 	}
+	operationCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	operation := &Operation{
 		ID:           params.OperationID,
@@ -151,7 +348,15 @@ func (s *Service) createSigningOperation(ctx context.Context, params *signingOpe
 		Status:       StatusPending,
 		CreatedAt:    time.Now(),
 		Request:      req, // Store the request for persistence
+		Labels:       params.Labels,
+		Owner:        params.Owner,
+		RequestID:    params.RequestID,
 		cancel:       cancel,
+		childKey:     childKey,
+	}
+	if params.ValidationBypass != "" {
+		// Keep a trace of the skipped validation for audit
+		operation.recordEvent(params.ValidationBypass)
 	}
 
 	// Store operation
@@ -168,26 +373,42 @@ func (s *Service) createSigningOperation(ctx context.Context, params *signingOpe
 }
 
 func (s *Service) syncSigningOperation(
-	operationID, sessionID string,
+	operationID, sessionID, requestID string,
 	threshold, parties int,
 	participants []string,
 	keyID string,
-	message []byte,
+	message, typedData []byte,
+	chainID uint64,
+	metadata map[string]string,
+	derivationPath string,
+	contextBound bool,
 ) error {
+	participantsHash, err := participantSetHash(participants)
+	if err != nil {
+		return err
+	}
+
 	syncCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	syncData := &SigningSyncData{
 		OperationSyncData: OperationSyncData{
 			OperationID:   operationID,
+			RequestID:     requestID,
 			OperationType: "signing",
 			SessionID:     sessionID,
 			Threshold:     threshold,
 			Parties:       parties,
 			Participants:  participants,
 		},
-		KeyID:   keyID,
-		Message: message,
+		KeyID:            keyID,
+		Message:          message,
+		TypedData:        typedData,
+		ChainID:          chainID,
+		Metadata:         metadata,
+		DerivationPath:   derivationPath,
+		ParticipantsHash: participantsHash,
+		ContextBound:     contextBound,
 	}
 
 	if err := s.syncOperation(syncCtx, syncData); err != nil {
@@ -207,7 +428,7 @@ func (s *Service) createSyncedSigningOperation(ctx context.Context, msg *p2p.Mes
 	var syncData SigningSyncData
 	if err := json.Unmarshal(msg.Data, &syncData); err != nil {
 		s.logger.Error("Failed to unmarshal signing sync data", zap.Error(err))
-		return fmt.Errorf("failed to unmarshal signing sync data: %w", err)
+		return fmt.Errorf("%w: failed to unmarshal signing sync data: %w", p2p.ErrProtocolViolation, err)
 	}
 
 	s.logger.Info("Creating synced signing operation",
@@ -222,19 +443,47 @@ func (s *Service) createSyncedSigningOperation(ctx context.Context, msg *p2p.Mes
 	if syncData.KeyID == "" {
 		return fmt.Errorf("key_id is required for signing operation sync")
 	}
-	if len(syncData.Message) == 0 {
+	if len(syncData.Message) == 0 && len(syncData.TypedData) == 0 {
 		return fmt.Errorf("message is required for signing operation sync")
 	}
+	if err := validateChainID(syncData.ChainID); err != nil {
+		return err
+	}
+	if err := validateSigningMetadata(syncData.Metadata); err != nil {
+		return err
+	}
+	if _, err := normalizeDerivationPath(syncData.DerivationPath); err != nil {
+		return err
+	}
 
 	// Create SigningRequest for validation
 	signingReq := &SigningRequest{
-		Message:      syncData.Message,
-		KeyID:        syncData.KeyID,
-		Participants: syncData.Participants,
+		Message:        syncData.Message,
+		TypedData:      syncData.TypedData,
+		KeyID:          syncData.KeyID,
+		Participants:   syncData.Participants,
+		ChainID:        syncData.ChainID,
+		Metadata:       syncData.Metadata,
+		DerivationPath: syncData.DerivationPath,
+		ContextBound:   syncData.ContextBound,
+	}
+
+	// Enforce this node's copy of the key policy, the initiator checked the client's roles
+	keyMetadata, err := s.LoadKeyMetadata(ctx, syncData.KeyID)
+	if err != nil {
+		return err
+	}
+	if err := s.enforceKeyPolicy(ctx, keyMetadata, signingReq, false); err != nil {
+		s.logger.Error("Synced signing request rejected by key policy",
+			zap.Error(err),
+			zap.String("key_id", syncData.KeyID),
+			zap.String("operation_id", syncData.OperationID))
+		return fmt.Errorf("synced signing request rejected: %w", err)
 	}
 
 	// Validate signing request with external validation service (if configured)
-	if err := s.validateSigningRequest(ctx, signingReq); err != nil {
+	validationBypass, err := s.validateSigningRequest(ctx, signingReq)
+	if err != nil {
 		s.logger.Error("Synced signing request validation failed",
 			zap.Error(err),
 			zap.String("key_id", syncData.KeyID),
@@ -243,12 +492,21 @@ func (s *Service) createSyncedSigningOperation(ctx context.Context, msg *p2p.Mes
 	}
 
 	// Create the signing operation using common logic
-	_, _, err := s.createSigningOperation(ctx, &signingOperationParams{
-		OperationID:  syncData.OperationID,
-		SessionID:    syncData.SessionID,
-		Message:      syncData.Message,
-		KeyID:        syncData.KeyID,
-		Participants: syncData.Participants,
+	_, _, err = s.createSigningOperation(ctx, &signingOperationParams{
+		OperationID:      syncData.OperationID,
+		SessionID:        syncData.SessionID,
+		Message:          syncData.Message,
+		TypedData:        syncData.TypedData,
+		KeyID:            syncData.KeyID,
+		Participants:     syncData.Participants,
+		ChainID:          syncData.ChainID,
+		Metadata:         syncData.Metadata,
+		DerivationPath:   syncData.DerivationPath,
+		ContextBound:     syncData.ContextBound,
+		ParticipantsHash: syncData.ParticipantsHash,
+		Threshold:        syncData.Threshold,
+		RequestID:        syncData.RequestID,
+		ValidationBypass: validationBypass,
 	})
 	if err != nil {
 		s.logger.Error("Failed to create synced signing operation", zap.Error(err))
@@ -288,44 +546,254 @@ func (s *Service) saveSigningResult(_ context.Context, operation *Operation, res
 		sBytes = sBytes[len(sBytes)-32:]
 	}
 
+	var chainID uint64
+	if req, ok := operation.Request.(*SigningRequest); ok {
+		if req.ChainFamily == ChainFamilyBitcoin {
+			return s.saveBitcoinSigningResult(operation, rBytes, sBytes, result.SignatureRecovery)
+		}
+		chainID = req.ChainID
+	}
+
 	// Calculate Ethereum-compatible v value from recovery ID
-	// Ethereum format: v = recovery_id + 27
-	v := 27
+	// Legacy format: v = recovery_id + 27, EIP-155: v = recovery_id + chain_id*2 + 35
+	recoveryID := 0
 	if len(result.SignatureRecovery) > 0 {
-		recoveryID := int(result.SignatureRecovery[0])
+		recoveryID = int(result.SignatureRecovery[0])
 		// Ensure recovery ID is in valid range (0 or 1)
-		if recoveryID >= 0 && recoveryID <= 1 {
-			v = recoveryID + 27
-		} else {
-			s.logger.Warn("Invalid recovery ID, using default v=27",
+		if recoveryID > 1 {
+			s.logger.Warn("Invalid recovery ID, using default recovery ID 0",
 				zap.Int("recovery_id", recoveryID))
+			recoveryID = 0
 		}
 	}
+	v := ethereumV(recoveryID, chainID)
 
-	// Create 65-byte Ethereum signature: R(32) + S(32) + V(1)
-	signature65 := make([]byte, 65)
-	copy(signature65[0:32], rBytes)  // R component
-	copy(signature65[32:64], sBytes) // S component
-	signature65[64] = byte(v)        // V component
+	// Create Ethereum signature: R(32) + S(32) + V.
+	// V takes a single byte (65-byte signature) unless the EIP-155 value exceeds 255,
+	// in which case it is appended big-endian as in RLP-encoded transactions
+	vBytes := new(big.Int).SetInt64(int64(v)).Bytes()
+	signature := make([]byte, 64+len(vBytes))
+	copy(signature[0:32], rBytes)  // R component
+	copy(signature[32:64], sBytes) // S component
+	copy(signature[64:], vBytes)   // V component
 
 	// Create signing result with both individual components and complete signature
 	signingResult := &SigningResult{
-		Signature: "0x" + hex.EncodeToString(signature65), // 65-byte signature for contract verification
-		R:         "0x" + hex.EncodeToString(rBytes),      // R component (32 bytes)
-		S:         "0x" + hex.EncodeToString(sBytes),      // S component (32 bytes)
-		V:         v,                                      // V value (recovery_id + 27)
+		Signature: "0x" + hex.EncodeToString(signature), // R || S || V signature for contract verification
+		R:         "0x" + hex.EncodeToString(rBytes),    // R component (32 bytes)
+		S:         "0x" + hex.EncodeToString(sBytes),    // S component (32 bytes)
+		V:         v,                                    // V value (recovery_id + 27 or EIP-155)
+		Signers:   operation.signerIDs(s.nodeID),
 	}
+	if err := setChildKey(signingResult, operation); err != nil {
+		return err
+	}
+
+	signingResult.MessageDigest, signingResult.HashMode, signingResult.Context = signedDigest(operation)
 
 	operation.Lock()
 	operation.Result = signingResult
 	operation.Unlock()
 
-	s.logger.Info("Saved signing result (Ethereum-compatible 65-byte format)",
-		zap.String("signature_65_bytes", signingResult.Signature),
+	s.logger.Info("Saved signing result (Ethereum-compatible format)",
+		zap.String("signature", signingResult.Signature),
 		zap.String("r", signingResult.R),
 		zap.String("s", signingResult.S),
 		zap.Int("v", signingResult.V),
-		zap.Int("signature_length", len(signature65)))
+		zap.Uint64("chain_id", chainID),
+		zap.Int("signature_length", len(signature)),
+		zap.Strings("signers", signingResult.Signers),
+		zap.String("message_digest", signingResult.MessageDigest),
+		zap.String("hash_mode", string(signingResult.HashMode)))
+
+	return nil
+}
+
+// HashMode names how a signing request is hashed into the digest that is signed
+type HashMode string
+
+const (
+	// HashModeEIP191 is the Keccak-256 of the message with the Ethereum personal message prefix
+	HashModeEIP191 HashMode = "eip191"
+	// HashModeEIP712 is the EIP-712 hash of typed data
+	HashModeEIP712 HashMode = "eip712"
+	// HashModeSHA256d is the double SHA-256 of the message, as signed by Bitcoin keys
+	HashModeSHA256d HashMode = "sha256d"
+)
+
+// signingHashMode returns the hash mode signingDigest applies to a request
+func signingHashMode(family ChainFamily, typedData []byte) HashMode {
+	switch {
+	case family == ChainFamilyBitcoin:
+		return HashModeSHA256d
+	case len(typedData) != 0:
+		return HashModeEIP712
+	}
+	return HashModeEIP191
+}
+
+// signedDigest returns the digest, hash mode and signing context of an operation's signing
+// request, so results record what the signature covers
+func signedDigest(operation *Operation) (string, HashMode, *SigningContext) {
+	req, ok := operation.Request.(*SigningRequest)
+	if !ok {
+		return "", "", nil
+	}
+	digest, err := boundSigningDigest(req, req.ChainFamily, operation.SessionID)
+	if err != nil {
+		// The request was hashed the same way before signing, this cannot happen
+		return "", "", nil
+	}
+	var signingContext *SigningContext
+	if req.ContextBound {
+		signingContext = &SigningContext{KeyID: req.KeyID, SessionID: operation.SessionID}
+	}
+	return "0x" + hex.EncodeToString(digest), signingHashMode(req.ChainFamily, req.TypedData), signingContext
+}
+
+// signingDigest returns the hash signed for a request. Ethereum keys sign the EIP-712 hash
+// of typed data if present, otherwise the EIP-191 personal message hash of message. Bitcoin
+// keys sign the double SHA-256 of message and take neither typed data nor a chain ID.
+func signingDigest(family ChainFamily, message, typedData []byte, chainID uint64) ([]byte, error) {
+	if family == ChainFamilyBitcoin {
+		if len(typedData) != 0 || chainID != 0 {
+			return nil, fmt.Errorf("%w: bitcoin keys sign plain messages without chain ID", ErrChainFamilyMismatch)
+		}
+		if len(message) == 0 {
+			return nil, fmt.Errorf("message is required")
+		}
+		return hashMessageForBitcoin(message), nil
+	}
+
+	if len(typedData) == 0 {
+		if len(message) == 0 {
+			return nil, fmt.Errorf("message is required")
+		}
+		return hashMessageForEthereum(message), nil
+	}
+	if len(message) != 0 {
+		return nil, fmt.Errorf("message and typed data are mutually exclusive")
+	}
+	return hashTypedData(typedData)
+}
+
+// validateMessageSize rejects a message or typed data larger than the configured limit
+func (s *Service) validateMessageSize(req *SigningRequest) error {
+	if s.maxMessageBytes <= 0 {
+		return nil
+	}
+	if len(req.Message) > s.maxMessageBytes {
+		return fmt.Errorf("%w: message is %d bytes, maximum is %d", ErrMessageTooLarge, len(req.Message), s.maxMessageBytes)
+	}
+	if len(req.TypedData) > s.maxMessageBytes {
+		return fmt.Errorf("%w: typed data is %d bytes, maximum is %d",
+			ErrMessageTooLarge, len(req.TypedData), s.maxMessageBytes)
+	}
+	return nil
+}
+
+// maxChainID keeps the EIP-155 v value within the range of an int
+const maxChainID = (math.MaxInt32 - 36) / 2
+
+// validateChainID rejects chain IDs whose EIP-155 v value cannot be represented
+func validateChainID(chainID uint64) error {
+	if chainID > maxChainID {
+		return fmt.Errorf("chain ID %d is too large, maximum is %d", chainID, maxChainID)
+	}
+	return nil
+}
+
+const (
+	// maxSigningMetadataEntries bounds the number of client supplied metadata entries
+	maxSigningMetadataEntries = 16
+	// maxSigningMetadataKeyLen bounds the length of a metadata key
+	maxSigningMetadataKeyLen = 64
+	// maxSigningMetadataValueLen bounds the length of a metadata value
+	maxSigningMetadataValueLen = 256
+)
+
+// validateSigningMetadata bounds client supplied metadata, which is synced to every
+// participant and persisted with the operation
+func validateSigningMetadata(metadata map[string]string) error {
+	if len(metadata) > maxSigningMetadataEntries {
+		return fmt.Errorf("%w: %d entries, maximum is %d",
+			ErrInvalidSigningMetadata, len(metadata), maxSigningMetadataEntries)
+	}
+	for key, value := range metadata {
+		if key == "" || len(key) > maxSigningMetadataKeyLen {
+			return fmt.Errorf("%w: key %q must be 1-%d bytes", ErrInvalidSigningMetadata, key, maxSigningMetadataKeyLen)
+		}
+		if len(value) > maxSigningMetadataValueLen {
+			return fmt.Errorf("%w: value of %q exceeds %d bytes", ErrInvalidSigningMetadata, key, maxSigningMetadataValueLen)
+		}
+	}
+	return nil
+}
+
+// ethereumV returns the Ethereum v value for a recovery ID.
+// A zero chain ID selects the legacy 27/28 encoding.
+func ethereumV(recoveryID int, chainID uint64) int {
+	if chainID == 0 {
+		return recoveryID + 27
+	}
+	return recoveryID + int(chainID)*2 + 35
+}
+
+// participantSetHash returns a canonical, order independent hash of a participant set.
+// Duplicate participants are rejected since they would produce an invalid party set.
+func participantSetHash(participants []string) (string, error) {
+	sorted := slices.Clone(participants)
+	slices.Sort(sorted)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return "", fmt.Errorf("duplicate participant: %s", sorted[i])
+		}
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// saveBitcoinSigningResult saves the signing result of a Bitcoin key: a DER signature with
+// low S followed by the SIGHASH_ALL byte, ready for a script signature or witness. V is
+// the plain recovery ID.
+func (s *Service) saveBitcoinSigningResult(operation *Operation, rBytes, sBytes, recovery []byte) error {
+	signature, lowS, negated, err := bitcoinSignature(rBytes, sBytes)
+	if err != nil {
+		return err
+	}
+
+	recoveryID := 0
+	if len(recovery) > 0 {
+		recoveryID = int(recovery[0]) & 1
+	}
+	if negated {
+		recoveryID ^= 1
+	}
+
+	signingResult := &SigningResult{
+		Signature: "0x" + hex.EncodeToString(signature),
+		R:         "0x" + hex.EncodeToString(rBytes),
+		S:         "0x" + hex.EncodeToString(lowS),
+		V:         recoveryID,
+		Signers:   operation.signerIDs(s.nodeID),
+	}
+	if err := setChildKey(signingResult, operation); err != nil {
+		return err
+	}
+	signingResult.MessageDigest, signingResult.HashMode, signingResult.Context = signedDigest(operation)
+
+	operation.Lock()
+	operation.Result = signingResult
+	operation.Unlock()
+
+	s.logger.Info("Saved signing result (Bitcoin DER format)",
+		zap.String("signature", signingResult.Signature),
+		zap.String("r", signingResult.R),
+		zap.String("s", signingResult.S),
+		zap.Int("recovery_id", recoveryID),
+		zap.Strings("signers", signingResult.Signers),
+		zap.String("message_digest", signingResult.MessageDigest))
 
 	return nil
 }
@@ -0,0 +1,338 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
+)
+
+func TestSaveSigningResultV(t *testing.T) {
+	tests := []struct {
+		name       string
+		chainID    uint64
+		recoveryID byte
+		wantV      int
+		wantVHex   string
+	}{
+		{"legacy recovery 0", 0, 0, 27, "1b"},
+		{"legacy recovery 1", 0, 1, 28, "1c"},
+		{"mainnet recovery 0", 1, 0, 37, "25"},
+		{"mainnet recovery 1", 1, 1, 38, "26"},
+		{"polygon recovery 0", 137, 0, 309, "0135"},
+		{"polygon recovery 1", 137, 1, 310, "0136"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{logger: zap.NewNop()}
+			op := &Operation{
+				ID:      "op",
+				Type:    OperationSigning,
+				Request: &SigningRequest{ChainID: tt.chainID},
+			}
+
+			err := s.saveSigningResult(context.Background(), op, &common.SignatureData{
+				R:                 bytes.Repeat([]byte{0x11}, 32),
+				S:                 bytes.Repeat([]byte{0x22}, 32),
+				SignatureRecovery: []byte{tt.recoveryID},
+			})
+			require.NoError(t, err)
+
+			result, ok := op.Result.(*SigningResult)
+			require.True(t, ok)
+			require.Equal(t, tt.wantV, result.V)
+
+			wantSig := "0x" + hex.EncodeToString(bytes.Repeat([]byte{0x11}, 32)) +
+				hex.EncodeToString(bytes.Repeat([]byte{0x22}, 32)) + tt.wantVHex
+			require.Equal(t, wantSig, result.Signature)
+		})
+	}
+}
+
+func TestSigningResultRecordsSigners(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID = "node2"
+
+	// node4 is a nominal participant that never sent a message
+	operation, party := newRecordingOperation("session-signers", "node1", "node2", "node3", "node4")
+	operation.Type = OperationSigning
+	operation.Request = &SigningRequest{KeyID: "0xabc"}
+	s.operations[operation.ID] = operation
+
+	for _, from := range []string{"node3", "node1", "node3"} {
+		require.NoError(t, s.HandleMessage(context.Background(), &p2p.Message{
+			SessionID: "session-signers", Type: string(OperationSigning), From: from, SenderPeerID: from, Data: []byte("round"),
+		}))
+		<-party.updates
+	}
+
+	// A participant cannot credit another one as a signer
+	err := s.HandleMessage(context.Background(), &p2p.Message{
+		SessionID: "session-signers", Type: string(OperationSigning), From: "node4", SenderPeerID: "node3", Data: []byte("round"),
+	})
+	require.ErrorIs(t, err, p2p.ErrProtocolViolation)
+
+	require.NoError(t, s.saveSigningResult(context.Background(), operation, &common.SignatureData{
+		R:                 bytes.Repeat([]byte{0x11}, 32),
+		S:                 bytes.Repeat([]byte{0x22}, 32),
+		SignatureRecovery: []byte{0},
+	}))
+	require.Equal(t, []string{"node1", "node2", "node3"}, operation.Result.(*SigningResult).Signers)
+}
+
+func TestSigningResultRecordsDigest(t *testing.T) {
+	s, _ := newTestService(t, false)
+	typedDigest, err := hashTypedData([]byte(mailTypedData))
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		req    *SigningRequest
+		digest []byte
+		mode   HashMode
+	}{
+		"personal message": {
+			req:    &SigningRequest{Message: []byte("hello"), ChainID: 1},
+			digest: hashMessageForEthereum([]byte("hello")),
+			mode:   HashModeEIP191,
+		},
+		"typed data": {
+			req:    &SigningRequest{TypedData: []byte(mailTypedData), ChainFamily: ChainFamilyEthereum},
+			digest: typedDigest,
+			mode:   HashModeEIP712,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			op := &Operation{ID: "op-" + string(tc.mode), Type: OperationSigning, Request: tc.req}
+			require.NoError(t, s.saveSigningResult(context.Background(), op, &common.SignatureData{
+				R:                 bytes.Repeat([]byte{0x11}, 32),
+				S:                 bytes.Repeat([]byte{0x22}, 32),
+				SignatureRecovery: []byte{0},
+			}))
+			result := op.Result.(*SigningResult)
+			require.Equal(t, "0x"+hex.EncodeToString(tc.digest), result.MessageDigest)
+			require.Equal(t, tc.mode, result.HashMode)
+		})
+	}
+}
+
+func TestValidateChainID(t *testing.T) {
+	require.NoError(t, validateChainID(0))
+	require.NoError(t, validateChainID(137))
+	require.Error(t, validateChainID(maxChainID+1))
+}
+
+func TestValidateSigningMetadata(t *testing.T) {
+	require.NoError(t, validateSigningMetadata(nil))
+	require.NoError(t, validateSigningMetadata(map[string]string{"purpose": "payout"}))
+
+	tooMany := make(map[string]string)
+	for i := range maxSigningMetadataEntries + 1 {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+	for _, metadata := range []map[string]string{
+		tooMany,
+		{"": "v"},
+		{strings.Repeat("k", maxSigningMetadataKeyLen+1): "v"},
+		{"purpose": strings.Repeat("v", maxSigningMetadataValueLen+1)},
+	} {
+		require.ErrorIs(t, validateSigningMetadata(metadata), ErrInvalidSigningMetadata)
+	}
+}
+
+// recordingValidator approves every request and records the last one
+type recordingValidator struct {
+	req *plugin.ValidationRequest
+}
+
+func (v *recordingValidator) ValidateSigningRequest(
+	_ context.Context, req *plugin.ValidationRequest,
+) (*plugin.ValidationResponse, error) {
+	v.req = req
+	return &plugin.ValidationResponse{Approved: true}, nil
+}
+
+func TestValidateSigningRequestPassesMetadata(t *testing.T) {
+	validator := &recordingValidator{}
+	s := &Service{logger: zap.NewNop(), validationService: validator}
+
+	_, err := s.validateSigningRequest(context.Background(), &SigningRequest{
+		Message:  []byte("hello"),
+		KeyID:    "0xabc",
+		Metadata: map[string]string{"purpose": "payout", "message_length": "0"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "payout", validator.req.Metadata["purpose"])
+	// Node supplied entries cannot be overridden by the client
+	require.Equal(t, 5, validator.req.Metadata["message_length"])
+}
+
+// requireValidated asserts req passes validation without bypassing the validation service
+func requireValidated(t *testing.T, s *Service, req *SigningRequest) {
+	t.Helper()
+	bypass, err := s.validateSigningRequest(context.Background(), req)
+	require.NoError(t, err)
+	require.Empty(t, bypass)
+}
+
+// staticValidator answers every validation request with resp and err
+type staticValidator struct {
+	resp *plugin.ValidationResponse
+	err  error
+}
+
+func (v *staticValidator) ValidateSigningRequest(
+	context.Context, *plugin.ValidationRequest,
+) (*plugin.ValidationResponse, error) {
+	return v.resp, v.err
+}
+
+func TestValidateSigningRequestOnErrorPolicy(t *testing.T) {
+	req := &SigningRequest{Message: []byte("hello"), KeyID: "0xabc"}
+	unreachable := &staticValidator{err: errors.New("connection refused")}
+	rejecting := &staticValidator{resp: &plugin.ValidationResponse{Approved: false, Reason: "denied"}}
+
+	// reject: validator errors fail the request
+	s := &Service{logger: zap.NewNop(), validationService: unreachable}
+	_, err := s.validateSigningRequest(context.Background(), req)
+	require.ErrorContains(t, err, "connection refused")
+
+	// allow: validator errors let the request through with an audit detail
+	s.validationFailOpen = validationFailOpen(&config.ValidationServiceConfig{OnError: config.ValidationOnErrorAllow})
+	require.True(t, s.validationFailOpen)
+	bypass, err := s.validateSigningRequest(context.Background(), req)
+	require.NoError(t, err)
+	require.Contains(t, bypass, "on_error policy")
+	require.Contains(t, bypass, "connection refused")
+
+	// Explicit rejections reject under both policies
+	for _, failOpen := range []bool{false, true} {
+		s := &Service{logger: zap.NewNop(), validationService: rejecting, validationFailOpen: failOpen}
+		bypass, err := s.validateSigningRequest(context.Background(), req)
+		require.ErrorContains(t, err, "denied")
+		require.Empty(t, bypass)
+	}
+}
+
+func TestParticipantSetHash(t *testing.T) {
+	a, err := participantSetHash([]string{"peer-a", "peer-b", "peer-c"})
+	require.NoError(t, err)
+	b, err := participantSetHash([]string{"peer-c", "peer-a", "peer-b"})
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	c, err := participantSetHash([]string{"peer-a", "peer-b", "peer-d"})
+	require.NoError(t, err)
+	require.NotEqual(t, a, c)
+
+	_, err = participantSetHash([]string{"peer-a", "peer-b", "peer-a"})
+	require.Error(t, err)
+}
+
+func TestCreateSigningOperationRejectsParticipantMismatch(t *testing.T) {
+	s := &Service{logger: zap.NewNop()}
+
+	expected, err := participantSetHash([]string{"peer-a", "peer-b"})
+	require.NoError(t, err)
+
+	_, _, err = s.createSigningOperation(context.Background(), &signingOperationParams{
+		OperationID:      "op",
+		KeyID:            "0xabc",
+		Participants:     []string{"peer-a", "peer-c"},
+		ParticipantsHash: expected,
+	})
+	require.ErrorIs(t, err, ErrParticipantSetMismatch)
+}
+
+func TestReloadValidationService(t *testing.T) {
+	s := newTestNode(t, p2p.NewMemoryHub())
+	require.Nil(t, s.validationService)
+
+	validator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"approved": false, "reason": "denied"}`))
+	}))
+	defer validator.Close()
+
+	req := &SigningRequest{Message: []byte("hello"), KeyID: "0xabc"}
+	requireValidated(t, s, req)
+
+	s.ReloadValidationService(&config.ValidationServiceConfig{Enabled: true, URL: validator.URL, TimeoutSeconds: 5})
+	_, err := s.validateSigningRequest(context.Background(), req)
+	require.ErrorContains(t, err, "denied")
+
+	s.ReloadValidationService(&config.ValidationServiceConfig{Enabled: false, URL: validator.URL})
+	requireValidated(t, s, req)
+
+	// Injected validators survive reloads
+	s.validationService, s.customValidator = &recordingValidator{}, true
+	s.ReloadValidationService(nil)
+	requireValidated(t, s, req)
+	require.NotNil(t, s.validationService)
+}
+
+func TestStartSigningMessageSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.maxMessageBytes = 32
+
+	_, err := s.StartSigning(ctx, "op-oversize", bytes.Repeat([]byte{1}, 33), "0xabc", []string{"peer-a"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrMessageTooLarge)
+	_, err = s.StartTypedDataSigning(ctx, "op-oversize-typed", bytes.Repeat([]byte{' '}, 33), "0xabc", []string{"peer-a"}, 0, SigningOptions{})
+	require.ErrorIs(t, err, ErrMessageTooLarge)
+	_, exists := s.GetOperation("op-oversize")
+	require.False(t, exists)
+
+	// A message of exactly the limit passes the size check and fails on the unknown key
+	_, err = s.StartSigning(ctx, "op-boundary", bytes.Repeat([]byte{1}, 32), "0xabc", []string{"peer-a"}, 0, SigningOptions{})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrMessageTooLarge)
+
+	s.maxMessageBytes = 0
+	_, err = s.StartSigning(ctx, "op-unlimited", bytes.Repeat([]byte{1}, 1<<20), "0xabc", []string{"peer-a"}, 0, SigningOptions{})
+	require.NotErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestStartSigningAppliesOptions(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+	s.nodeID = "node1"
+	s.network = &recordingTransport{recipients: make(map[string]bool)}
+
+	result, err := s.ImportKeyShare(ctx, newExternalKeyShare(t, s, 1, "node1", "node2"))
+	require.NoError(t, err)
+
+	op, err := s.StartSigning(ctx, "op-options", []byte("message"), result.KeyID, []string{"node1", "node2"}, 0, SigningOptions{
+		Labels:         map[string]string{"team": "payments"},
+		Metadata:       map[string]string{"invoice": "42"},
+		DerivationPath: "m/0/1",
+		ContextBound:   true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(op.cancel)
+
+	req := op.Request.(*SigningRequest)
+	require.Equal(t, map[string]string{"invoice": "42"}, req.Metadata)
+	require.Equal(t, "m/0/1", req.DerivationPath)
+	require.True(t, req.ContextBound)
+	require.Equal(t, map[string]string{"team": "payments"}, op.Labels)
+	require.NotNil(t, op.childKey)
+
+	// Typed data requests carry their metadata to the validation service the same way
+	op, err = s.StartTypedDataSigning(ctx, "op-typed-options", []byte(mailTypedData), result.KeyID,
+		[]string{"node1", "node2"}, 0, SigningOptions{Metadata: map[string]string{"invoice": "43"}})
+	require.NoError(t, err)
+	t.Cleanup(op.cancel)
+	require.Equal(t, map[string]string{"invoice": "43"}, op.Request.(*SigningRequest).Metadata)
+}
@@ -0,0 +1,92 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// storageStatsTTL is how long computed storage statistics are reused, counting requires
+// listing the storage
+const storageStatsTTL = time.Minute
+
+// StorageStats summarizes what a node keeps in its storage, for capacity planning
+type StorageStats struct {
+	// Keys is the number of stored key shares
+	Keys int
+	// Operations is the number of persisted operations
+	Operations int
+	// Bytes is the approximate space used by all stored data
+	Bytes int64
+	// ComputedAt is when the statistics were computed
+	ComputedAt time.Time
+}
+
+// StorageStats returns statistics about the node's storage. Results are cached for
+// storageStatsTTL, concurrent callers share a single scan.
+func (s *Service) StorageStats(ctx context.Context) (StorageStats, error) {
+	s.storageStatsMutex.Lock()
+	defer s.storageStatsMutex.Unlock()
+
+	if s.storageStats != nil && time.Since(s.storageStats.ComputedAt) < storageStatsTTL {
+		return *s.storageStats, nil
+	}
+
+	stats, err := s.computeStorageStats(ctx)
+	if err != nil {
+		return StorageStats{}, err
+	}
+	s.storageStats = &stats
+	return stats, nil
+}
+
+// computeStorageStats counts keys and operations and measures the storage size
+func (s *Service) computeStorageStats(ctx context.Context) (StorageStats, error) {
+	stats := StorageStats{ComputedAt: time.Now()}
+
+	// Key shares are stored under their key ID, an address or a UUID without common prefix
+	candidates, err := s.storage.List(ctx, "")
+	if err != nil {
+		return StorageStats{}, fmt.Errorf("failed to list keys: %w", err)
+	}
+	for _, key := range candidates {
+		if keyIDPattern.MatchString(key) {
+			stats.Keys++
+		}
+	}
+
+	operations, err := s.storage.List(ctx, operationPrefix)
+	if err != nil {
+		return StorageStats{}, fmt.Errorf("failed to list operations: %w", err)
+	}
+	stats.Operations = len(operations)
+
+	if stats.Bytes, err = storageSize(ctx, s.storage); err != nil {
+		return StorageStats{}, fmt.Errorf("failed to measure storage size: %w", err)
+	}
+	return stats, nil
+}
+
+// storageSize returns the approximate size of store, reading every entry if the
+// backend cannot report it
+func storageSize(ctx context.Context, store storage.Storage) (int64, error) {
+	if sizer, ok := store.(storage.Sizer); ok {
+		return sizer.ApproximateSize(ctx)
+	}
+
+	keys, err := store.List(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, key := range keys {
+		value, err := store.Load(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		size += int64(len(key) + len(value))
+	}
+	return size, nil
+}
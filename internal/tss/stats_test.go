@@ -0,0 +1,49 @@
+package tss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dreamer-zq/DKNet/internal/storage"
+)
+
+// plainStorage hides the Sizer implementation of the wrapped storage
+type plainStorage struct {
+	storage.Storage
+}
+
+func TestStorageStats(t *testing.T) {
+	ctx := context.Background()
+	s, store := newTestService(t, false)
+
+	require.NoError(t, store.Save(ctx, "0x1111111111111111111111111111111111111111", []byte("share")))
+	require.NoError(t, store.Save(ctx, "0x2222222222222222222222222222222222222222", []byte("share")))
+	require.NoError(t, store.Save(ctx, "operation:op-1", []byte("{}")))
+	require.NoError(t, store.Save(ctx, "operation_label:team=a:op-1", []byte{}))
+	require.NoError(t, store.Save(ctx, keyAliasStorageKey("treasury"), []byte(`"0x1111111111111111111111111111111111111111"`)))
+
+	stats, err := s.StorageStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Keys)
+	require.Equal(t, 1, stats.Operations)
+	require.Positive(t, stats.Bytes)
+
+	// Backends without a size estimate are measured by reading every entry
+	size, err := storageSize(ctx, plainStorage{store})
+	require.NoError(t, err)
+	require.Equal(t, stats.Bytes, size)
+
+	// Results are cached until they expire
+	require.NoError(t, store.Save(ctx, "operation:op-2", []byte("{}")))
+	cached, err := s.StorageStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stats, cached)
+
+	s.storageStats.ComputedAt = time.Now().Add(-storageStatsTTL)
+	stats, err = s.StorageStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Operations)
+}
@@ -0,0 +1,404 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+// syncAckWaiter tracks which participants acknowledged an operation sync message
+type syncAckWaiter struct {
+	pending map[string]struct{}
+	acked   []string
+	done    chan struct{}
+
+	// joined is closed once quorum participants acknowledged
+	quorum int
+	joined chan struct{}
+
+	// rejected is closed once a participant rejected the operation, rejection tells why
+	rejected  chan struct{}
+	rejection error
+}
+
+// syncOperation sends the operation synchronization message to the participants. When
+// acknowledgement tracking is enabled it waits until every participant created the
+// operation and fails with ErrSyncNotAcknowledged otherwise.
+func (s *Service) syncOperation(ctx context.Context, syncData Message) error {
+	operationID := syncData.ID()
+
+	// The initiator may already track acknowledgements to confirm the operation was joined
+	s.mutex.RLock()
+	waiter := s.syncAcks[operationID]
+	s.mutex.RUnlock()
+
+	if waiter == nil {
+		if s.syncAckTimeout <= 0 {
+			return s.broadcastOperationMessage(ctx, OperationSync, syncData)
+		}
+		participants := s.remoteParticipants(syncData.To())
+		waiter = s.registerSyncAcks(operationID, participants, len(participants))
+	}
+	defer s.unregisterSyncAcks(operationID)
+
+	if s.syncAckTimeout <= 0 {
+		// Only the join confirmation waits, keep acknowledgements tracked until it is done
+		if err := s.broadcastOperationMessage(ctx, OperationSync, syncData); err != nil {
+			return err
+		}
+		timer := time.NewTimer(s.joinTimeout)
+		defer timer.Stop()
+		select {
+		case <-waiter.joined:
+		case <-waiter.rejected:
+			return s.rejectedSync(operationID, waiter)
+		case <-timer.C:
+		}
+		return nil
+	}
+
+	timer := time.NewTimer(s.syncAckTimeout)
+	defer timer.Stop()
+
+	if err := s.broadcastOperationMessage(ctx, OperationSync, syncData); err != nil {
+		s.abortSyncedOperation(operationID, waiter)
+		return err
+	}
+
+	select {
+	case <-waiter.done:
+		return nil
+	case <-waiter.rejected:
+		return s.rejectedSync(operationID, waiter)
+	case <-timer.C:
+	}
+
+	s.mutex.RLock()
+	missing := make([]string, 0, len(waiter.pending))
+	for id := range waiter.pending {
+		missing = append(missing, id)
+	}
+	s.mutex.RUnlock()
+
+	// Acknowledgements may have completed right as the timer fired
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+
+	s.logger.Error("Participants did not acknowledge operation sync",
+		zap.String("operation_id", operationID),
+		zap.Strings("missing", missing),
+		zap.Duration("timeout", s.syncAckTimeout))
+	s.abortSyncedOperation(operationID, waiter)
+	return fmt.Errorf("%w by %v within %s", ErrSyncNotAcknowledged, missing, s.syncAckTimeout)
+}
+
+// registerSyncAcks starts tracking acknowledgements of an operation sync message, the
+// operation counts as joined once quorum participants acknowledged it
+func (s *Service) registerSyncAcks(operationID string, participants []string, quorum int) *syncAckWaiter {
+	waiter := &syncAckWaiter{
+		pending:  make(map[string]struct{}, len(participants)),
+		done:     make(chan struct{}),
+		quorum:   quorum,
+		joined:   make(chan struct{}),
+		rejected: make(chan struct{}),
+	}
+	for _, id := range participants {
+		waiter.pending[id] = struct{}{}
+	}
+	if len(waiter.pending) == 0 {
+		close(waiter.done)
+	}
+	if quorum <= 0 {
+		close(waiter.joined)
+	}
+
+	s.mutex.Lock()
+	s.syncAcks[operationID] = waiter
+	s.mutex.Unlock()
+	return waiter
+}
+
+// unregisterSyncAcks stops tracking acknowledgements of an operation sync message
+func (s *Service) unregisterSyncAcks(operationID string) {
+	s.mutex.Lock()
+	delete(s.syncAcks, operationID)
+	s.mutex.Unlock()
+}
+
+// abortSyncedOperation asks the participants that already created the operation to cancel it
+func (s *Service) abortSyncedOperation(operationID string, waiter *syncAckWaiter) {
+	s.mutex.RLock()
+	acked := slices.Clone(waiter.acked)
+	s.mutex.RUnlock()
+
+	if len(acked) == 0 {
+		return
+	}
+
+	cancelData := &OperationCancelData{
+		OperationID:  operationID,
+		Reason:       "operation sync failed",
+		Participants: acked,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.broadcastOperationMessage(ctx, OperationCancel, cancelData); err != nil {
+		s.logger.Warn("Failed to cancel operation on participants",
+			zap.String("operation_id", operationID),
+			zap.Strings("participants", acked),
+			zap.Error(err))
+	}
+}
+
+// rejectedSync cancels an operation a participant rejected on the participants that
+// already created it and returns the rejection
+func (s *Service) rejectedSync(operationID string, waiter *syncAckWaiter) error {
+	s.mutex.RLock()
+	rejection := waiter.rejection
+	s.mutex.RUnlock()
+
+	s.logger.Error("Participant rejected operation sync",
+		zap.String("operation_id", operationID),
+		zap.Error(rejection))
+	s.abortSyncedOperation(operationID, waiter)
+	return rejection
+}
+
+// joinQuorum returns how many of the remote participants must acknowledge an operation
+// before it counts as joined, threshold+1 parties including this node or all of them
+func (s *Service) joinQuorum(threshold int, remote []string) int {
+	if s.joinQuorumMode == JoinQuorumThreshold {
+		return min(threshold, len(remote))
+	}
+	return len(remote)
+}
+
+// awaitJoin waits until the quorum of participants acknowledged the operation. When it
+// does not assemble within the join timeout the operation is canceled on every node that
+// created it and ErrJoinQuorumNotReached is returned.
+func (s *Service) awaitJoin(operation *Operation, waiter *syncAckWaiter) error {
+	// The join timeout starts once the operation left the admission queue and was synced
+	if !operation.awaitAdmission() {
+		return nil
+	}
+	timer := time.NewTimer(s.joinTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-waiter.joined:
+		return nil
+	case <-operation.Done():
+		// The operation already failed, e.g. its sync could not be delivered, its status
+		// tells the client why. A busy participant is reported right away, the client
+		// should retry later.
+		operation.RLock()
+		defer operation.RUnlock()
+		if errors.Is(operation.Error, ErrParticipantBusy) {
+			return operation.Error
+		}
+		return nil
+	case <-timer.C:
+	}
+
+	s.mutex.RLock()
+	joined := len(waiter.acked)
+	s.mutex.RUnlock()
+
+	// The quorum may have assembled right as the timer fired
+	if joined >= waiter.quorum {
+		return nil
+	}
+
+	s.logger.Error("Participants did not join operation",
+		zap.String("operation_id", operation.ID),
+		zap.Int("joined", joined),
+		zap.Int("quorum", waiter.quorum),
+		zap.Duration("timeout", s.joinTimeout))
+	operation.cancel()
+	s.abortSyncedOperation(operation.ID, waiter)
+	return fmt.Errorf("%w: %d of %d participants joined within %s",
+		ErrJoinQuorumNotReached, joined, waiter.quorum, s.joinTimeout)
+}
+
+// acknowledgeOperationSync tells the initiator that the synced operation was created
+func (s *Service) acknowledgeOperationSync(operationID, initiator string) {
+	ackData := &OperationSyncAckData{
+		OperationID: operationID,
+		Initiator:   initiator,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.broadcastOperationMessage(ctx, OperationSyncAck, ackData); err != nil {
+		s.logger.Warn("Failed to acknowledge operation sync",
+			zap.String("operation_id", operationID),
+			zap.String("initiator", initiator),
+			zap.Error(err))
+	}
+}
+
+// rejectOperationSync tells the initiator that this node is too busy to take part in
+// the synced operation
+func (s *Service) rejectOperationSync(operationID, initiator string) {
+	ackData := &OperationSyncAckData{
+		OperationID: operationID,
+		Initiator:   initiator,
+		Busy:        true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.broadcastOperationMessage(ctx, OperationSyncAck, ackData); err != nil {
+		s.logger.Warn("Failed to reject operation sync",
+			zap.String("operation_id", operationID),
+			zap.String("initiator", initiator),
+			zap.Error(err))
+	}
+}
+
+// handleOperationSyncAck records the acknowledgement of an operation sync message
+func (s *Service) handleOperationSyncAck(msg *p2p.Message) error {
+	var ackData OperationSyncAckData
+	if err := json.Unmarshal(msg.Data, &ackData); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal operation sync ack data: %w", p2p.ErrProtocolViolation, err)
+	}
+
+	// The transport guarantees SenderPeerID is the peer we received the message from
+	if msg.From != msg.SenderPeerID {
+		return fmt.Errorf("operation sync ack sender mismatch: %s != %s", msg.From, msg.SenderPeerID)
+	}
+	if ackData.Busy {
+		return s.handleOperationSyncRejection(msg.From, ackData.OperationID)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	waiter, exists := s.syncAcks[ackData.OperationID]
+	if !exists {
+		s.logger.Debug("Ignoring ack for operation without pending sync",
+			zap.String("operation_id", ackData.OperationID),
+			zap.String("from", msg.From))
+		return nil
+	}
+
+	if _, pending := waiter.pending[msg.From]; !pending {
+		if slices.Contains(waiter.acked, msg.From) {
+			return nil
+		}
+		return fmt.Errorf("node %s is not a participant of operation %s", msg.From, ackData.OperationID)
+	}
+
+	delete(waiter.pending, msg.From)
+	waiter.acked = append(waiter.acked, msg.From)
+	if len(waiter.pending) == 0 {
+		close(waiter.done)
+	}
+	if len(waiter.acked) == waiter.quorum {
+		close(waiter.joined)
+	}
+
+	s.logger.Debug("Operation sync acknowledged",
+		zap.String("operation_id", ackData.OperationID),
+		zap.String("from", msg.From),
+		zap.Int("pending", len(waiter.pending)))
+	return nil
+}
+
+// handleOperationSyncRejection fails an operation this node initiated that a participant
+// rejected at its concurrency limit
+func (s *Service) handleOperationSyncRejection(from, operationID string) error {
+	s.mutex.Lock()
+	operation := s.operations[operationID]
+	waiter := s.syncAcks[operationID]
+	s.mutex.Unlock()
+
+	if operation == nil {
+		s.logger.Debug("Ignoring rejection of unknown or finished operation",
+			zap.String("operation_id", operationID),
+			zap.String("from", from))
+		return nil
+	}
+	if !slices.Contains(operation.participantIDs(), from) {
+		return fmt.Errorf("node %s is not a participant of operation %s", from, operationID)
+	}
+	rejection := fmt.Errorf("%w: %s rejected operation %s", ErrParticipantBusy, from, operationID)
+
+	// A pending sync returns the rejection and cancels the operation on the participants
+	// that already created it
+	if waiter != nil {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if waiter.rejection == nil {
+			waiter.rejection = rejection
+			close(waiter.rejected)
+		}
+		return nil
+	}
+
+	// Without acknowledgement tracking the operation fails here and every participant is
+	// asked to cancel it
+	go func() {
+		select {
+		case operation.EndCh <- rejection:
+		case <-operation.Done():
+			return
+		}
+		cancelData := &OperationCancelData{
+			OperationID:  operationID,
+			Reason:       rejection.Error(),
+			Participants: operation.participantIDs(),
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.broadcastOperationMessage(ctx, OperationCancel, cancelData); err != nil {
+			s.logger.Warn("Failed to cancel rejected operation on participants",
+				zap.String("operation_id", operationID),
+				zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// sendWithRetry sends a message, retrying failed deliveries with exponential backoff.
+// Retries only go to the recipients that failed, the others already have the message.
+func (s *Service) sendWithRetry(ctx context.Context, msg *p2p.Message) error {
+	interval := s.syncRetryInterval
+	for attempt := 0; ; attempt++ {
+		err := s.network.SendMessage(ctx, msg)
+		if err == nil || attempt >= s.syncRetries {
+			return err
+		}
+
+		var sendErr *p2p.SendError
+		if errors.As(err, &sendErr) {
+			msg = msg.Clone()
+			msg.To = sendErr.FailedPeers()
+		}
+
+		s.logger.Warn("Failed to send operation message, retrying",
+			zap.String("type", msg.Type),
+			zap.String("session_id", msg.SessionID),
+			zap.Strings("targets", msg.To),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", interval),
+			zap.Error(err))
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		}
+		interval *= 2
+	}
+}
@@ -0,0 +1,267 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dreamer-zq/DKNet/internal/p2p"
+)
+
+// connectTestNodes starts two test nodes on one memory hub and enables sync
+// acknowledgements on the initiator
+func connectTestNodes(t *testing.T, ackTimeout time.Duration) (initiator, peer *Service) {
+	t.Helper()
+
+	hub := p2p.NewMemoryHub()
+	initiator = newTestNode(t, hub)
+	peer = newTestNode(t, hub)
+	initiator.syncAckTimeout = ackTimeout
+	return initiator, peer
+}
+
+func newTestSigningSyncData(operationID string, participants ...string) *SigningSyncData {
+	return &SigningSyncData{
+		OperationSyncData: OperationSyncData{
+			OperationID:   operationID,
+			OperationType: OperationSigning,
+			SessionID:     operationID,
+			Participants:  participants,
+		},
+		KeyID:   "missing-key",
+		Message: []byte("message"),
+	}
+}
+
+func TestSyncOperationAcknowledged(t *testing.T) {
+	initiator, peer := connectTestNodes(t, 10*time.Second)
+
+	// The peer already runs the operation, so the sync is acknowledged right away
+	addTestOperation(peer, "op-1", initiator.nodeID, peer.nodeID)
+
+	syncData := newTestSigningSyncData("op-1", initiator.nodeID, peer.nodeID)
+	require.NoError(t, initiator.syncOperation(context.Background(), syncData))
+}
+
+func TestSyncOperationNotAcknowledged(t *testing.T) {
+	initiator, peer := connectTestNodes(t, time.Second)
+
+	// The peer fails to create the operation since it does not hold the key
+	syncData := newTestSigningSyncData("op-1", initiator.nodeID, peer.nodeID)
+	err := initiator.syncOperation(context.Background(), syncData)
+	require.ErrorIs(t, err, ErrSyncNotAcknowledged)
+	require.Contains(t, err.Error(), peer.nodeID)
+}
+
+// recordingHandler records the types of the messages a node receives
+type recordingHandler struct {
+	received chan string
+}
+
+func (r *recordingHandler) HandleMessage(_ context.Context, msg *p2p.Message) error {
+	r.received <- msg.Type
+	return nil
+}
+
+func (r *recordingHandler) Stop() {}
+
+func TestResharingSyncReachesOnlyParticipants(t *testing.T) {
+	hub := p2p.NewMemoryHub()
+	newTransport := func() (*p2p.MemoryTransport, *recordingHandler) {
+		transport, err := hub.NewTransport(zap.NewNop())
+		require.NoError(t, err)
+		handler := &recordingHandler{received: make(chan string, 1)}
+		transport.SetMessageHandler(handler)
+		return transport, handler
+	}
+
+	initiator, _ := newTransport()
+	oldMember, oldReceived := newTransport()
+	newMember, newReceived := newTransport()
+	_, outsiderReceived := newTransport()
+
+	s, _ := newTestService(t, false)
+	s.network, s.nodeID = initiator, initiator.GetHostID()
+
+	syncData := &ResharingSyncData{
+		OperationSyncData: OperationSyncData{
+			OperationID:   "op-reshare",
+			OperationType: OperationResharing,
+			SessionID:     "op-reshare",
+			Participants:  []string{initiator.GetHostID(), newMember.GetHostID()},
+		},
+		OldParticipants: []string{initiator.GetHostID(), oldMember.GetHostID()},
+		NewParticipants: []string{initiator.GetHostID(), newMember.GetHostID()},
+	}
+	require.NoError(t, s.syncOperation(context.Background(), syncData))
+
+	// Both committees receive the sync, the node outside them does not
+	for _, received := range []chan string{oldReceived.received, newReceived.received} {
+		select {
+		case msgType := <-received:
+			require.Equal(t, string(OperationSync), msgType)
+		case <-time.After(5 * time.Second):
+			t.Fatal("participant did not receive the sync")
+		}
+	}
+	select {
+	case msgType := <-outsiderReceived.received:
+		t.Fatalf("non-participant received %s", msgType)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHandleResharingSyncAsOldParticipant(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.nodeID = "node-old"
+
+	data, err := json.Marshal(&ResharingSyncData{
+		OperationSyncData: OperationSyncData{
+			OperationID:   "op-reshare",
+			OperationType: OperationResharing,
+			Parties:       2,
+			Participants:  []string{"node-a", "node-b"},
+		},
+		KeyID:           "missing-key",
+		OldParticipants: []string{"node-a", "node-old"},
+		NewParticipants: []string{"node-a", "node-b"},
+	})
+	require.NoError(t, err)
+
+	// A member of only the old committee takes part, it fails here for lack of the key
+	err = s.handleOperationSync(context.Background(), &p2p.Message{Type: string(OperationSync), From: "node-a", Data: data})
+	require.ErrorContains(t, err, "failed to load key data")
+}
+
+func TestHandleOperationSyncAck(t *testing.T) {
+	s, _ := newTestService(t, false)
+	waiter := s.registerSyncAcks("op-1", []string{"node-a", "node-b"}, 2)
+
+	ackMsg := func(from string) *p2p.Message {
+		return &p2p.Message{
+			Type:         string(OperationSyncAck),
+			From:         from,
+			SenderPeerID: from,
+			Data:         []byte(`{"operation_id":"op-1"}`),
+		}
+	}
+
+	require.Error(t, s.handleOperationSyncAck(ackMsg("node-c")))
+	require.NoError(t, s.handleOperationSyncAck(ackMsg("node-a")))
+	// Duplicate acknowledgements are harmless
+	require.NoError(t, s.handleOperationSyncAck(ackMsg("node-a")))
+
+	select {
+	case <-waiter.done:
+		t.Fatal("sync acknowledged before all participants")
+	default:
+	}
+
+	require.NoError(t, s.handleOperationSyncAck(ackMsg("node-b")))
+	select {
+	case <-waiter.done:
+	default:
+		t.Fatal("sync not acknowledged by all participants")
+	}
+}
+
+func TestMalformedMessagesAreProtocolViolations(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t, false)
+
+	for _, msgType := range []OperationType{OperationSync, OperationSyncAck, OperationCancel} {
+		err := s.HandleMessage(ctx, &p2p.Message{Type: string(msgType), From: "node2", Data: []byte("{not json")})
+		require.ErrorIs(t, err, p2p.ErrProtocolViolation, msgType)
+	}
+
+	// Messages of a session the sender does not take part in
+	participants, err := s.createParticipantList([]string{"node1", "node2"}, 0)
+	require.NoError(t, err)
+	op := &Operation{ID: "op-violation", SessionID: "session-violation", Participants: participants}
+	s.operations[op.ID] = op
+	err = s.HandleMessage(ctx, &p2p.Message{Type: string(OperationKeygen), SessionID: op.SessionID, From: "node3", SenderPeerID: "node3"})
+	require.ErrorIs(t, err, p2p.ErrProtocolViolation)
+	require.ErrorContains(t, err, "unknown sender")
+
+	// or that claim to come from another participant
+	err = s.HandleMessage(ctx, &p2p.Message{Type: string(OperationKeygen), SessionID: op.SessionID, From: "node2", SenderPeerID: "node3"})
+	require.ErrorIs(t, err, p2p.ErrProtocolViolation)
+	require.ErrorContains(t, err, "sender mismatch")
+}
+
+func TestJoinQuorum(t *testing.T) {
+	s, _ := newTestService(t, false)
+	remote := []string{"node-a", "node-b", "node-c"}
+
+	require.Equal(t, 3, s.joinQuorum(1, remote))
+	s.joinQuorumMode = JoinQuorumThreshold
+	require.Equal(t, 1, s.joinQuorum(1, remote))
+	require.Equal(t, 3, s.joinQuorum(5, remote))
+}
+
+func TestAwaitJoin(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.joinTimeout = 200 * time.Millisecond
+
+	ackMsg := func(operationID, from string) *p2p.Message {
+		return &p2p.Message{
+			Type:         string(OperationSyncAck),
+			From:         from,
+			SenderPeerID: from,
+			Data:         []byte(`{"operation_id":"` + operationID + `"}`),
+		}
+	}
+
+	// One of two participants suffices for a quorum of one
+	addTestOperation(s, "op-joined", "node-self", "node-a", "node-b")
+	waiter := s.registerSyncAcks("op-joined", []string{"node-a", "node-b"}, 1)
+	require.NoError(t, s.handleOperationSyncAck(ackMsg("op-joined", "node-a")))
+	require.NoError(t, s.awaitJoin(s.operations["op-joined"], waiter))
+
+	// Without acknowledgements the operation is canceled once the window passed
+	opCtx := addTestOperation(s, "op-alone", "node-self", "node-a")
+	waiter = s.registerSyncAcks("op-alone", []string{"node-a"}, 1)
+	err := s.awaitJoin(s.operations["op-alone"], waiter)
+	require.ErrorIs(t, err, ErrJoinQuorumNotReached)
+	require.ErrorIs(t, opCtx.Err(), context.Canceled)
+}
+
+// flakyTransport fails the first delivery to each recipient in failOnce
+type flakyTransport struct {
+	p2p.Transport
+	failOnce map[string]bool
+	sent     [][]string
+}
+
+func (f *flakyTransport) SendMessage(_ context.Context, msg *p2p.Message) error {
+	f.sent = append(f.sent, msg.To)
+	failed := make(map[string]error)
+	for _, target := range msg.To {
+		if f.failOnce[target] {
+			f.failOnce[target] = false
+			failed[target] = p2p.ErrPeerNotConnected
+		}
+	}
+	if len(failed) > 0 {
+		return &p2p.SendError{Failed: failed}
+	}
+	return nil
+}
+
+func TestSendWithRetryOnlyRetriesFailedPeers(t *testing.T) {
+	s, _ := newTestService(t, false)
+	transport := &flakyTransport{failOnce: map[string]bool{"node-b": true}}
+	s.network = transport
+	s.syncRetries = 2
+	s.syncRetryInterval = time.Millisecond
+
+	msg := &p2p.Message{Type: string(OperationSync), To: []string{"node-a", "node-b", "node-c"}}
+	require.NoError(t, s.sendWithRetry(context.Background(), msg))
+	require.Equal(t, [][]string{{"node-a", "node-b", "node-c"}, {"node-b"}}, transport.sent)
+	// The caller's message is left untouched
+	require.Equal(t, []string{"node-a", "node-b", "node-c"}, msg.To)
+}
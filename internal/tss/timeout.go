@@ -0,0 +1,34 @@
+package tss
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultKeygenTimeout bounds keygen operations unless the client requested a timeout
+	defaultKeygenTimeout = 10 * time.Minute
+	// defaultSigningTimeout bounds signing operations unless the client requested a timeout
+	defaultSigningTimeout = 5 * time.Minute
+	// defaultResharingTimeout bounds resharing operations unless the client requested a timeout
+	defaultResharingTimeout = 15 * time.Minute
+)
+
+// operationTimeout returns the timeout of an operation: the requested timeout if it is
+// within the configured bounds, otherwise defaultTimeout
+func (s *Service) operationTimeout(requested, defaultTimeout time.Duration) time.Duration {
+	if requested <= 0 {
+		return defaultTimeout
+	}
+
+	if requested < s.minOperationTimeout || requested > s.maxOperationTimeout {
+		s.logger.Warn("Ignoring requested operation timeout outside the configured bounds",
+			zap.Duration("requested", requested),
+			zap.Duration("min", s.minOperationTimeout),
+			zap.Duration("max", s.maxOperationTimeout),
+			zap.Duration("default", defaultTimeout))
+		return defaultTimeout
+	}
+	return requested
+}
@@ -0,0 +1,28 @@
+package tss
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationTimeout(t *testing.T) {
+	s, _ := newTestService(t, false)
+	s.minOperationTimeout, s.maxOperationTimeout = 10*time.Second, time.Hour
+
+	require.Equal(t, defaultSigningTimeout, s.operationTimeout(0, defaultSigningTimeout))
+	require.Equal(t, 90*time.Second,
+		s.operationTimeout(90*time.Second, defaultSigningTimeout))
+
+	// Timeouts outside the bounds are ignored
+	require.Equal(t, defaultSigningTimeout,
+		s.operationTimeout(time.Second, defaultSigningTimeout))
+	require.Equal(t, defaultKeygenTimeout,
+		s.operationTimeout(2*time.Hour, defaultKeygenTimeout))
+
+	// A maximum of zero disables overrides
+	s.maxOperationTimeout = 0
+	require.Equal(t, defaultSigningTimeout,
+		s.operationTimeout(90*time.Second, defaultSigningTimeout))
+}
@@ -2,15 +2,23 @@ package tss
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"slices"
 	"sync"
 	"time"
 
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"golang.org/x/crypto/sha3"
 
+	"github.com/dreamer-zq/DKNet/internal/common"
 	"github.com/dreamer-zq/DKNet/internal/config"
+	"github.com/dreamer-zq/DKNet/internal/notify"
+	"github.com/dreamer-zq/DKNet/internal/plugin"
 )
 
 // OperationType defines the type of TSS operation
@@ -25,14 +33,83 @@ const (
 	OperationResharing OperationType = "resharing"
 	// OperationSync is the type for operation broadcast
 	OperationSync OperationType = "operation_sync"
+	// OperationSyncAck is the type for operation sync acknowledgements
+	OperationSyncAck OperationType = "operation_sync_ack"
+	// OperationCancel is the type for operation cancellation broadcast
+	OperationCancel OperationType = "operation_cancel"
+)
+
+// Join quorums of the keygen join confirmation
+const (
+	// JoinQuorumAll requires every participant to join
+	JoinQuorumAll = "all"
+	// JoinQuorumThreshold requires threshold+1 participants, including the initiator, to join
+	JoinQuorumThreshold = "threshold"
 )
 
 // Config holds TSS service configuration
 type Config struct {
 	PeerID  string
 	Moniker string
+	// EncryptMetadata encrypts persisted operation data with the key cipher
+	EncryptMetadata bool
+	// SigningDedupTTL enables content based deduplication of signing requests without
+	// an operation ID, matching operations started within the TTL (0 disables it)
+	SigningDedupTTL time.Duration
+	// SyncRetries is the number of times delivery of a sync message to a participant is retried
+	SyncRetries int
+	// SyncRetryInterval is the delay before the first retry, doubled for every further retry
+	SyncRetryInterval time.Duration
+	// SyncAckTimeout fails an operation when participants have not acknowledged its sync
+	// message within this duration (0 disables acknowledgement tracking)
+	SyncAckTimeout time.Duration
+	// JoinTimeout makes StartKeygen wait this long for participants to acknowledge the
+	// operation before returning, failing it when too few joined (0 returns immediately)
+	JoinTimeout time.Duration
+	// JoinQuorum is how many participants must join, JoinQuorumAll (the default) or
+	// JoinQuorumThreshold for threshold+1 parties
+	JoinQuorum string
+	// KeyIDFormat is how new keys are identified, KeyIDFormatUUID or KeyIDFormatAddress
+	// (the default) to use their Ethereum address as key ID
+	KeyIDFormat string
+	// TrimSigningQuorum makes signing requests naming more than threshold+1 participants
+	// sign with only threshold+1 of them
+	TrimSigningQuorum bool
+	// Mode is ModeFull (the default) or ModeReadOnly for a replica serving queries only
+	Mode string
+	// SessionLookupTimeout is how long an incoming TSS message waits for the operation of
+	// its session to be created (0 looks the operation up once)
+	SessionLookupTimeout time.Duration
+	// EarlyMessageWindow buffers wire messages that arrive before their operation was created
+	// for this long and replays them once it exists (0 waits up to SessionLookupTimeout instead)
+	EarlyMessageWindow time.Duration
+	// MaxConcurrentOperations limits how many operations run at once, further operations this
+	// node initiates are queued and admitted by priority, signing before keygen before
+	// resharing. Synced operations are rejected with ErrParticipantBusy (0 is unlimited)
+	MaxConcurrentOperations int
+	// MaxMessageBytes rejects signing requests whose message or typed data is larger (0 is unlimited)
+	MaxMessageBytes int
+	// PersistFullMessage stores the message or typed data of signing operations with them,
+	// otherwise only its SHA-256 hash is stored
+	PersistFullMessage bool
+	// OperationCacheSize is how many finished operations are kept in memory for GetOperationData
+	// (0 disables the cache)
+	OperationCacheSize int
+	// NodeNames maps node names to peer IDs, requests may name participants by them
+	NodeNames map[string]string
+	// MinOperationTimeout and MaxOperationTimeout bound the timeout clients may request in the
+	// operation options, requests outside are ignored (a zero maximum disables overrides)
+	MinOperationTimeout time.Duration
+	MaxOperationTimeout time.Duration
+	// KDF selects how the key cipher derives its key from the encryption password,
+	// argon2id with default costs when zero
+	KDF plugin.KDFParams
 	// Validation service configuration (optional)
 	ValidationService *config.ValidationServiceConfig `json:"validation_service,omitempty"`
+	// Validator overrides the HTTP validation service built from ValidationService (optional)
+	Validator plugin.ValidationService `json:"-"`
+	// Notifier is told about operations reaching a terminal state (optional)
+	Notifier notify.Notifier `json:"-"`
 }
 
 // Operation represents an active TSS operation
@@ -50,10 +127,144 @@ type Operation struct {
 	Result       any
 	Error        error
 	Request      any // Store the original request (KeygenRequest, SigningRequest, etc.)
+	// OldParticipants are the old committee of a resharing operation
+	OldParticipants []*tss.PartyID
+	// OldParty is the old committee party of a resharing operation when this node belongs to
+	// both committees, Party is its new committee party then
+	OldParty tss.Party
+	// Labels are client supplied key/value pairs for filtering, kept on the initiating node
+	Labels map[string]string
+	// Owner is the authenticated client that started the operation, kept on the initiating node
+	Owner string
+	// RequestID is the ID of the client request that started the operation, synced to participants
+	RequestID string
+	// Events is the history of status transitions and protocol rounds of the operation
+	Events []OperationEvent
+	// RoundTimings is the time spent in each protocol round the party entered, oldest first
+	RoundTimings []RoundTiming
+
+	// childKey is the public key of the derived child key a signing operation signs with
+	childKey *crypto.ECPoint
+
+	// contributors are the remote participants that sent messages for the operation
+	contributors []string
 
 	// Synchronization
-	mutex  sync.RWMutex
-	cancel context.CancelFunc
+	mutex    sync.RWMutex
+	cancel   context.CancelFunc
+	doneOnce sync.Once
+	done     chan struct{}
+
+	// admitted is closed once the operation is admitted to run under the concurrency limit
+	admittedOnce sync.Once
+	admitted     chan struct{}
+}
+
+// Done returns a channel that is closed once the operation reached a terminal
+// state and was moved to persistent storage
+func (o *Operation) Done() <-chan struct{} {
+	return o.doneCh()
+}
+
+// Await blocks until the operation reached a terminal state or ctx is done. It returns
+// the operation result on completion, the operation error if it failed and
+// ErrOperationCancelled if it was canceled and ErrOperationTimedOut if it ran out of time.
+// It may be called after completion.
+func (o *Operation) Await(ctx context.Context) (any, error) {
+	select {
+	case <-o.Done():
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	o.RLock()
+	defer o.RUnlock()
+	switch o.Status {
+	case StatusCompleted:
+		return o.Result, nil
+	case StatusCancelled:
+		return nil, fmt.Errorf("operation %s: %w", o.ID, ErrOperationCancelled)
+	case StatusTimedOut:
+		return nil, fmt.Errorf("operation %s: %w", o.ID, ErrOperationTimedOut)
+	default:
+		if o.Error != nil {
+			return nil, o.Error
+		}
+		return nil, fmt.Errorf("operation %s ended with status %s", o.ID, o.Status)
+	}
+}
+
+// Snapshot returns a copy of the externally visible state of the operation, read
+// under its lock. Request and Result are shared, they are not modified once set.
+func (o *Operation) Snapshot() *OperationData {
+	o.RLock()
+	defer o.RUnlock()
+
+	data := &OperationData{
+		ID:           o.ID,
+		Type:         o.Type,
+		SessionID:    o.SessionID,
+		Status:       o.Status,
+		Participants: make([]string, len(o.Participants)),
+		CreatedAt:    o.CreatedAt,
+		Request:      o.Request,
+		Result:       o.Result,
+		Labels:       maps.Clone(o.Labels),
+		Owner:        o.Owner,
+		RequestID:    o.RequestID,
+		Events:       slices.Clone(o.Events),
+		RoundTimings: slices.Clone(o.RoundTimings),
+	}
+	for i, p := range o.Participants {
+		data.Participants[i] = p.Id
+	}
+	if o.CompletedAt != nil {
+		completedAt := *o.CompletedAt
+		data.CompletedAt = &completedAt
+	}
+	if o.Error != nil {
+		data.Error = o.Error.Error()
+	}
+	return data
+}
+
+// setStatusLocked moves the operation to status and records the transition in its
+// event history. The caller must hold the lock.
+func (o *Operation) setStatusLocked(status OperationStatus, detail string) {
+	o.Events = append(o.Events, OperationEvent{Time: time.Now(), From: o.Status, To: status, Detail: detail})
+	o.Status = status
+}
+
+// recordEvent records progress the operation made without changing its status
+func (o *Operation) recordEvent(detail string) {
+	o.Lock()
+	defer o.Unlock()
+	o.Events = append(o.Events, OperationEvent{Time: time.Now(), From: o.Status, To: o.Status, Detail: detail})
+}
+
+func (o *Operation) doneCh() chan struct{} {
+	o.doneOnce.Do(func() {
+		o.done = make(chan struct{})
+	})
+	return o.done
+}
+
+func (o *Operation) admittedCh() chan struct{} {
+	o.admittedOnce.Do(func() {
+		o.admitted = make(chan struct{})
+	})
+	return o.admitted
+}
+
+// awaitAdmission blocks until the operation is admitted to run under the concurrency
+// limit, it returns false if the operation ended while it was queued
+func (o *Operation) awaitAdmission() bool {
+	select {
+	case <-o.admittedCh():
+		return true
+	case <-o.Done():
+		return false
+	}
 }
 
 // Lock locks the operation
@@ -76,6 +287,45 @@ func (o *Operation) RUnlock() {
 	o.mutex.RUnlock()
 }
 
+// participantIDs returns the node IDs taking part in the operation,
+// including the old committee of a resharing
+func (o *Operation) participantIDs() []string {
+	ids := make([]string, 0, len(o.Participants))
+	for _, p := range o.Participants {
+		ids = append(ids, p.Id)
+	}
+	if req, ok := o.Request.(*ResharingRequest); ok {
+		for _, id := range req.OldParticipants {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// recordContributor notes that a participant sent a message for the operation
+func (o *Operation) recordContributor(nodeID string) {
+	o.Lock()
+	defer o.Unlock()
+	if !slices.Contains(o.contributors, nodeID) {
+		o.contributors = append(o.contributors, nodeID)
+	}
+}
+
+// signerIDs returns the sorted node IDs that contributed to the operation: the
+// participants messages were received from, and self
+func (o *Operation) signerIDs(self string) []string {
+	o.RLock()
+	signers := slices.Clone(o.contributors)
+	o.RUnlock()
+	if !slices.Contains(signers, self) {
+		signers = append(signers, self)
+	}
+	slices.Sort(signers)
+	return signers
+}
+
 func (o *Operation) isNewParticipant() bool {
 	req, ok := o.Request.(*ResharingRequest)
 	if !ok {
@@ -86,6 +336,29 @@ func (o *Operation) isNewParticipant() bool {
 	}) != -1
 }
 
+// resharingParties returns the parties of a resharing operation a message is for: messages
+// to the old committee, to both committees or else to the new committee
+func (o *Operation) resharingParties(toOldCommittee, toOldAndNewCommittees bool) []tss.Party {
+	var oldParty, newParty tss.Party
+	switch {
+	case o.OldParty != nil:
+		oldParty, newParty = o.OldParty, o.Party
+	case o.isNewParticipant():
+		newParty = o.Party
+	default:
+		oldParty = o.Party
+	}
+
+	var parties []tss.Party
+	if oldParty != nil && (toOldCommittee || toOldAndNewCommittees) {
+		parties = append(parties, oldParty)
+	}
+	if newParty != nil && !toOldCommittee {
+		parties = append(parties, newParty)
+	}
+	return parties
+}
+
 // OperationStatus defines operation status
 type OperationStatus string
 
@@ -98,29 +371,82 @@ const (
 	StatusCompleted OperationStatus = "completed"
 	// StatusFailed is the status for failed operations
 	StatusFailed OperationStatus = "failed"
-	// StatusCancelled is the status for canceled operations
+	// StatusCancelled is the status for operations canceled by a client, a participant or
+	// the node shutting down
 	StatusCancelled OperationStatus = "canceled"
+	// StatusTimedOut is the status for operations that did not finish within their timeout
+	StatusTimedOut OperationStatus = "timed_out"
 )
 
 // KeygenRequest represents a keygen request
 type KeygenRequest struct {
 	OperationID  string   `json:"operation_id,omitempty"` // Optional operation ID for idempotency
 	Threshold    int      `json:"threshold"`
-	Participants []string `json:"participants"` // peer IDs
+	Participants []string `json:"participants"`    // peer IDs
+	Alias        string   `json:"alias,omitempty"` // Optional human-readable key name
+	// ChainFamily selects message hashing and signature encoding for the key
+	ChainFamily ChainFamily `json:"chain_family,omitempty"`
+	// Policy is stored with the key, see OperationOptions.Policy
+	Policy *KeyPolicy `json:"policy,omitempty"`
+	// KeyID is the ID chosen by the initiator, empty to store the key under its address
+	KeyID string `json:"key_id,omitempty"`
 }
 
 // KeygenResult represents keygen result
 type KeygenResult struct {
 	PublicKey string `json:"public_key"`
 	KeyID     string `json:"key_id"`
+	Alias     string `json:"alias,omitempty"`
+	// Address is the Ethereum address of the public key, empty in results stored before
+	// key IDs and addresses were separated
+	Address string `json:"address,omitempty"`
 }
 
 // SigningRequest represents a signing request
 type SigningRequest struct {
-	OperationID  string   `json:"operation_id,omitempty"` // Optional operation ID for idempotency
-	Message      []byte   `json:"message"`
-	KeyID        string   `json:"key_id"`
-	Participants []string `json:"participants"` // peer IDs
+	OperationID  string          `json:"operation_id,omitempty"` // Optional operation ID for idempotency
+	Message      []byte          `json:"message"`
+	TypedData    json.RawMessage `json:"typed_data,omitempty"` // EIP-712 typed data, signed instead of Message
+	KeyID        string          `json:"key_id"`
+	Participants []string        `json:"participants"`       // peer IDs
+	ChainID      uint64          `json:"chain_id,omitempty"` // Optional EIP-155 chain ID for the v value
+	// Metadata is client supplied context passed to the validation service and kept for audit
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// DerivationPath selects the non-hardened BIP32 child key of KeyID to sign with, e.g. m/0/1
+	DerivationPath string `json:"derivation_path,omitempty"`
+	// ChainFamily is the chain family of the key, set when the operation is created
+	ChainFamily ChainFamily `json:"chain_family,omitempty"`
+	// ContextBound signs a digest bound to the key and session, see ContextBoundDigest
+	ContextBound bool `json:"context_bound,omitempty"`
+	// MessageSHA256 is the hex encoded SHA-256 hash of Message, or of TypedData for typed
+	// data requests, set instead of them when the operation was persisted without its message
+	MessageSHA256 string `json:"message_sha256,omitempty"`
+	// IsTypedData marks typed data requests persisted without their TypedData
+	IsTypedData bool `json:"is_typed_data,omitempty"`
+}
+
+// MessageOmitted reports whether the request was persisted without its message
+func (r *SigningRequest) MessageOmitted() bool {
+	return r.MessageSHA256 != ""
+}
+
+// withoutMessage returns a copy of the request with its message or typed data replaced by
+// their SHA-256 hash
+func (r *SigningRequest) withoutMessage() *SigningRequest {
+	if r.MessageOmitted() {
+		return r
+	}
+	payload := r.Message
+	if len(r.TypedData) > 0 {
+		payload = r.TypedData
+	}
+	digest := sha256.Sum256(payload)
+
+	stripped := *r
+	stripped.Message, stripped.TypedData = nil, nil
+	stripped.MessageSHA256 = hex.EncodeToString(digest[:])
+	stripped.IsTypedData = len(r.TypedData) > 0
+	return &stripped
 }
 
 // SigningResult represents signing result
@@ -129,6 +455,20 @@ type SigningResult struct {
 	R         string `json:"r"`
 	S         string `json:"s"`
 	V         int    `json:"v"`
+	// DerivationPath, PublicKey and Address describe the child key signed with, set for
+	// requests with a derivation path. Address is in the key's chain family format.
+	DerivationPath string `json:"derivation_path,omitempty"`
+	PublicKey      string `json:"public_key,omitempty"`
+	Address        string `json:"address,omitempty"`
+	// Signers are the participants that contributed messages to the signature
+	Signers []string `json:"signers,omitempty"`
+	// MessageDigest is the hex encoded hash the signature covers
+	MessageDigest string `json:"message_digest,omitempty"`
+	// HashMode is how the request was hashed into MessageDigest
+	HashMode HashMode `json:"hash_mode,omitempty"`
+	// Context is set for context-bound signatures, MessageDigest is then the
+	// ContextBoundDigest of the message digest in HashMode
+	Context *SigningContext `json:"context,omitempty"`
 }
 
 // ResharingRequest represents a resharing request
@@ -139,6 +479,15 @@ type ResharingRequest struct {
 	NewParties      int      `json:"new_parties"`
 	OldParticipants []string `json:"old_participants"`
 	NewParticipants []string `json:"new_participants"`
+	PublicKey       string   `json:"public_key,omitempty"` // Group public key before resharing (hex)
+	Alias           string   `json:"alias,omitempty"`      // Alias of the key, carried over to new participants
+	// ChainFamily of the key, carried over to new participants
+	ChainFamily ChainFamily `json:"chain_family,omitempty"`
+	// Policy of the key, carried over to new participants
+	Policy *KeyPolicy `json:"policy,omitempty"`
+	// OldGeneration is the share generation of the old committee, the new committee's
+	// shares are of the next one
+	OldGeneration int `json:"old_generation,omitempty"`
 }
 
 // Message is the interface for all operation sync data
@@ -155,6 +504,8 @@ type OperationSyncData struct {
 	Threshold     int           `json:"threshold"`
 	Parties       int           `json:"parties"`
 	Participants  []string      `json:"participants"`
+	// RequestID is the ID of the client request that started the operation, for tracing
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // ID implement Message.ID
@@ -162,10 +513,49 @@ func (o *OperationSyncData) ID() string {
 	return o.OperationID
 }
 
+// OperationSyncAckData is sent back to the initiator once a synced operation was created,
+// or with Busy set when the participant rejected it at its concurrency limit
+type OperationSyncAckData struct {
+	OperationID string `json:"operation_id"`
+	Initiator   string `json:"initiator"`
+	Busy        bool   `json:"busy,omitempty"`
+}
+
+// ID implement Message.ID
+func (a *OperationSyncAckData) ID() string {
+	return a.OperationID
+}
+
+// To implement Message.To
+func (a *OperationSyncAckData) To() []string {
+	return []string{a.Initiator}
+}
+
+// OperationCancelData is broadcast to the other participants when an operation is canceled
+type OperationCancelData struct {
+	OperationID  string   `json:"operation_id"`
+	Reason       string   `json:"reason,omitempty"`
+	Participants []string `json:"participants"`
+}
+
+// ID implement Message.ID
+func (c *OperationCancelData) ID() string {
+	return c.OperationID
+}
+
+// To implement Message.To
+func (c *OperationCancelData) To() []string {
+	return c.Participants
+}
+
 // KeygenSyncData contains keygen-specific sync data
 type KeygenSyncData struct {
 	OperationSyncData
-	// Add keygen-specific fields if needed in the future
+	Alias       string      `json:"alias,omitempty"`
+	ChainFamily ChainFamily `json:"chain_family,omitempty"`
+	Policy      *KeyPolicy  `json:"policy,omitempty"`
+	// KeyID is the ID every participant stores the key under, empty for its address
+	KeyID string `json:"key_id,omitempty"`
 }
 
 // To implement Message.To
@@ -176,8 +566,18 @@ func (k *KeygenSyncData) To() []string {
 // SigningSyncData contains signing-specific sync data
 type SigningSyncData struct {
 	OperationSyncData
-	KeyID   string `json:"key_id"`
-	Message []byte `json:"message"`
+	KeyID     string          `json:"key_id"`
+	Message   []byte          `json:"message"`
+	TypedData json.RawMessage `json:"typed_data,omitempty"`
+	ChainID   uint64          `json:"chain_id,omitempty"`
+	// Metadata is the client supplied signing metadata, validated by every participant
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// DerivationPath selects the child key to sign with, empty for the master key
+	DerivationPath string `json:"derivation_path,omitempty"`
+	// ParticipantsHash is the canonical hash of the participant set (see participantSetHash)
+	ParticipantsHash string `json:"participants_hash,omitempty"`
+	// ContextBound signs a digest bound to the key and session, see ContextBoundDigest
+	ContextBound bool `json:"context_bound,omitempty"`
 }
 
 // To implement Message.To
@@ -188,35 +588,56 @@ func (s *SigningSyncData) To() []string {
 // ResharingSyncData contains resharing-specific sync data
 type ResharingSyncData struct {
 	OperationSyncData
-	OldThreshold    int      `json:"old_threshold"`
-	NewThreshold    int      `json:"new_threshold"`
-	OldParticipants []string `json:"old_participants"`
-	NewParticipants []string `json:"new_participants"`
-	KeyID           string   `json:"key_id"`
+	OldThreshold    int         `json:"old_threshold"`
+	NewThreshold    int         `json:"new_threshold"`
+	OldParticipants []string    `json:"old_participants"`
+	NewParticipants []string    `json:"new_participants"`
+	OldGeneration   int         `json:"old_generation,omitempty"`
+	KeyID           string      `json:"key_id"`
+	PublicKey       string      `json:"public_key,omitempty"`
+	Alias           string      `json:"alias,omitempty"`
+	ChainFamily     ChainFamily `json:"chain_family,omitempty"`
+	Policy          *KeyPolicy  `json:"policy,omitempty"`
 }
 
 // To implement Message.To
 func (r *ResharingSyncData) To() []string {
-	return r.NewParticipants
+	// The old committee takes part in the resharing as well
+	return common.Distinct(slices.Concat(r.OldParticipants, r.NewParticipants))
 }
 
 // OperationData represents operation data for persistence
 type OperationData struct {
-	ID           string          `json:"id"`
-	Type         OperationType   `json:"type"`
-	SessionID    string          `json:"session_id"`
-	Status       OperationStatus `json:"status"`
-	Participants []string        `json:"participants"` // peer IDs
-	Request      interface{}     `json:"request"`      // KeygenRequest, SigningRequest, or ResharingRequest
-	Result       interface{}     `json:"result"`       // KeygenResult, SigningResult, etc.
-	Error        string          `json:"error,omitempty"`
-	CreatedAt    time.Time       `json:"created_at"`
-	CompletedAt  *time.Time      `json:"completed_at,omitempty"`
+	ID           string            `json:"id"`
+	Type         OperationType     `json:"type"`
+	SessionID    string            `json:"session_id"`
+	Status       OperationStatus   `json:"status"`
+	Participants []string          `json:"participants"` // peer IDs
+	Request      interface{}       `json:"request"`      // KeygenRequest, SigningRequest, or ResharingRequest
+	Result       interface{}       `json:"result"`       // KeygenResult, SigningResult, etc.
+	Error        string            `json:"error,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	CompletedAt  *time.Time        `json:"completed_at,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Owner        string            `json:"owner,omitempty"`
+	RequestID    string            `json:"request_id,omitempty"`
+	Events       []OperationEvent  `json:"events,omitempty"`
+	RoundTimings []RoundTiming     `json:"round_timings,omitempty"`
+}
+
+// OperationEvent is an entry of the event history of an operation. From and To differ
+// for status transitions and are equal for progress within a status, such as a new round.
+type OperationEvent struct {
+	Time   time.Time       `json:"time"`
+	From   OperationStatus `json:"from"`
+	To     OperationStatus `json:"to"`
+	Detail string          `json:"detail,omitempty"`
 }
 
 // IsCompleted returns true if the operation has completed (success, failure, or cancellation)
 func (o *OperationData) IsCompleted() bool {
-	return o.Status == StatusCompleted || o.Status == StatusFailed || o.Status == StatusCancelled
+	return o.Status == StatusCompleted || o.Status == StatusFailed || o.Status == StatusCancelled ||
+		o.Status == StatusTimedOut
 }
 
 // IsActive returns true if the operation is still active (pending or in progress)
@@ -224,12 +645,37 @@ func (o *OperationData) IsActive() bool {
 	return o.Status == StatusPending || o.Status == StatusInProgress
 }
 
+// KeyMetadata is the stored metadata of a key, as returned by LoadKeyMetadata
+type KeyMetadata = keyData
+
+// KeyPresence describes what a node holds of a key
+type KeyPresence struct {
+	// Known is set when the key's metadata is stored on the node
+	Known bool
+	// HasShare is set when the node is also listed among the key's participants
+	HasShare bool
+}
+
 // keyData represents the TSS key data that needs to be stored
 type keyData struct {
 	Moniker      string   `json:"moniker"`
 	KeyData      []byte   `json:"key_data"`
 	Threshold    int      `json:"threshold"`
 	Participants []string `json:"participants"` // peer IDs
+	Alias        string   `json:"alias,omitempty"`
+	// Generation counts the resharings of the key, the party keys of the participants
+	// depend on it, see partyKey
+	Generation int `json:"generation,omitempty"`
+	// ChainFamily is empty for keys stored before chain families existed, see Family
+	ChainFamily ChainFamily `json:"chain_family,omitempty"`
+	// Policy restricts how the key signs, nil when it has none
+	Policy *KeyPolicy `json:"policy,omitempty"`
+	// Address is the Ethereum address of the group public key, empty for keys stored
+	// before key IDs and addresses were separated, their key ID is the address
+	Address string `json:"address,omitempty"`
+	// MAC authenticates the key ID and the rest of the record, empty for keys stored before
+	// records were authenticated
+	MAC []byte `json:"mac,omitempty"`
 }
 
 // hashMessageForEthereum creates an Ethereum-compatible hash that can be verified with ecrecover
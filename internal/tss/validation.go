@@ -6,46 +6,127 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/dreamer-zq/DKNet/internal/config"
 	"github.com/dreamer-zq/DKNet/internal/plugin"
 )
 
-// validateSigningRequest validates a signing request using external validation service
-func (s *Service) validateSigningRequest(ctx context.Context, req *SigningRequest) error {
-	if s.validationService == nil {
-		s.logger.Debug("Validation service not configured, skipping validation")
-		return nil
+// ReloadValidationService replaces the HTTP validation service with one built from cfg,
+// disabling validation when cfg is nil or not enabled. An injected validator is kept.
+func (s *Service) ReloadValidationService(cfg *config.ValidationServiceConfig) {
+	if s.customValidator {
+		s.logger.Warn("Validation service is provided by the application, ignoring reloaded config")
+		return
+	}
+
+	var validationService plugin.ValidationService
+	if cfg != nil && cfg.Enabled {
+		validationService = plugin.NewHTTPValidationService(cfg, s.nodeID, s.network.PrivateKey(), s.logger)
+	}
+
+	s.validationMutex.Lock()
+	s.validationService = validationService
+	s.validationFailOpen = validationFailOpen(cfg)
+	s.validationConfig = cfg
+	s.keyValidators = nil
+	s.validationMutex.Unlock()
+
+	if validationService == nil {
+		s.logger.Info("Validation service disabled")
+		return
 	}
+	s.logger.Info("Validation service reloaded",
+		zap.String("url", cfg.URL),
+		zap.Int("timeout_seconds", cfg.TimeoutSeconds),
+		zap.String("on_error", cfg.OnError))
+}
+
+// validationFailOpen reports whether cfg allows signing requests the validation service
+// could not answer
+func validationFailOpen(cfg *config.ValidationServiceConfig) bool {
+	return cfg != nil && cfg.OnError == config.ValidationOnErrorAllow
+}
+
+// ValidationEnabled reports whether signing requests are checked by a validation service
+func (s *Service) ValidationEnabled() bool {
+	s.validationMutex.RLock()
+	defer s.validationMutex.RUnlock()
+	return s.validationService != nil
+}
+
+// ValidationBreakerState returns the circuit breaker state of the validation service, empty
+// when validation is disabled or the validator has no circuit breaker
+func (s *Service) ValidationBreakerState() plugin.BreakerState {
+	s.validationMutex.RLock()
+	validationService := s.validationService
+	s.validationMutex.RUnlock()
 
-	// Prepare validation request
-	validationReq := &plugin.ValidationRequest{
+	if breaker, ok := validationService.(interface{ BreakerState() plugin.BreakerState }); ok {
+		return breaker.BreakerState()
+	}
+	return ""
+}
+
+// validationRequest returns the request sent to validation services for a signing request
+func validationRequest(req *SigningRequest) *plugin.ValidationRequest {
+	// Client metadata is passed through, message_length is always set by the node and
+	// derivation_path for requests signing with a child key
+	metadata := make(map[string]interface{}, len(req.Metadata)+2)
+	for key, value := range req.Metadata {
+		metadata[key] = value
+	}
+	metadata["message_length"] = len(req.Message)
+	if req.DerivationPath != "" {
+		metadata["derivation_path"] = req.DerivationPath
+	}
+
+	return &plugin.ValidationRequest{
 		Message:      req.Message,
+		TypedData:    req.TypedData,
 		KeyID:        req.KeyID,
 		Participants: req.Participants,
-		Metadata: map[string]interface{}{
-			"message_length": len(req.Message),
-		},
+		Metadata:     metadata,
+	}
+}
+
+// validateSigningRequest validates a signing request using external validation service.
+// When the service fails and the on_error policy allows the request, the returned detail
+// explains why it was not validated and is recorded in the operation's event history.
+func (s *Service) validateSigningRequest(ctx context.Context, req *SigningRequest) (string, error) {
+	s.validationMutex.RLock()
+	validationService, failOpen := s.validationService, s.validationFailOpen
+	s.validationMutex.RUnlock()
+
+	if validationService == nil {
+		s.logger.Debug("Validation service not configured, skipping validation")
+		return "", nil
 	}
 
 	// Call validation service
-	validationResp, err := s.validationService.ValidateSigningRequest(ctx, validationReq)
+	validationResp, err := validationService.ValidateSigningRequest(ctx, validationRequest(req))
 	if err != nil {
+		if failOpen {
+			s.logger.Warn("Validation service call failed, allowing signing request by on_error policy",
+				zap.Error(err),
+				zap.String("key_id", req.KeyID))
+			return fmt.Sprintf("not validated, allowed by on_error policy: %v", err), nil
+		}
 		s.logger.Error("Validation service call failed",
 			zap.Error(err),
 			zap.String("key_id", req.KeyID))
-		return fmt.Errorf("validation service call failed: %w", err)
+		return "", fmt.Errorf("validation service call failed: %w", err)
 	}
 
-	// Check if request is approved
+	// Check if request is approved, rejections are final whatever the on_error policy
 	if !validationResp.Approved {
 		s.logger.Warn("Signing request rejected by validation service",
 			zap.String("key_id", req.KeyID),
 			zap.String("reason", validationResp.Reason))
-		return fmt.Errorf("signing request rejected by validation service: %s", validationResp.Reason)
+		return "", fmt.Errorf("signing request rejected by validation service: %s", validationResp.Reason)
 	}
 
 	s.logger.Info("Signing request approved by validation service",
 		zap.String("key_id", req.KeyID),
 		zap.String("reason", validationResp.Reason))
 
-	return nil
+	return "", nil
 }
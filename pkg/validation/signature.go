@@ -0,0 +1,93 @@
+// Package validation holds what external validation services need to authenticate the
+// signing validation requests DKNet nodes send them.
+//
+// A signed request carries three headers: X-DKNet-Node-ID with the peer ID of the node,
+// X-DKNet-Signature-Algorithm with the algorithm and X-DKNet-Signature with the base64
+// encoded signature of the raw request body. With hmac-sha256 the signature is
+// HMAC-SHA256(secret, body). With libp2p it is the signature of the body by the node's P2P
+// identity key, whose public key is embedded in the peer ID.
+package validation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Headers attached to signed validation requests
+const (
+	// HeaderNodeID carries the peer ID of the requesting node
+	HeaderNodeID = "X-DKNet-Node-ID"
+	// HeaderSignature carries the base64 encoded signature of the request body
+	HeaderSignature = "X-DKNet-Signature"
+	// HeaderSignatureAlgorithm names the algorithm used to produce the signature
+	HeaderSignatureAlgorithm = "X-DKNet-Signature-Algorithm"
+)
+
+// Supported validation request signature algorithms
+const (
+	// SignatureAlgorithmLibp2p signs with the node's P2P identity key
+	SignatureAlgorithmLibp2p = "libp2p"
+	// SignatureAlgorithmHMACSHA256 signs with a shared secret
+	SignatureAlgorithmHMACSHA256 = "hmac-sha256"
+)
+
+// ErrInvalidRequestSignature is returned when a validation request signature does not verify
+var ErrInvalidRequestSignature = errors.New("invalid validation request signature")
+
+// VerifyRequest verifies the signature headers of a validation request against its raw
+// body and returns the authenticated node ID. secret is required only for HMAC signed
+// requests; libp2p signatures are verified with the public key embedded in the node's
+// peer ID. Validators should also check that the returned node ID matches the node_id in
+// the body and that the timestamp is recent.
+func VerifyRequest(header http.Header, body, secret []byte) (string, error) {
+	nodeID := header.Get(HeaderNodeID)
+	encoded := header.Get(HeaderSignature)
+	if nodeID == "" || encoded == "" {
+		return "", fmt.Errorf("%w: missing signature headers", ErrInvalidRequestSignature)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed signature: %v", ErrInvalidRequestSignature, err)
+	}
+
+	switch algorithm := header.Get(HeaderSignatureAlgorithm); algorithm {
+	case SignatureAlgorithmHMACSHA256:
+		if len(secret) == 0 {
+			return "", fmt.Errorf("%w: no secret configured for HMAC signatures", ErrInvalidRequestSignature)
+		}
+		if !hmac.Equal(signature, HMACSHA256(secret, body)) {
+			return "", fmt.Errorf("%w: signature mismatch", ErrInvalidRequestSignature)
+		}
+	case SignatureAlgorithmLibp2p:
+		peerID, err := peer.Decode(nodeID)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid node ID: %v", ErrInvalidRequestSignature, err)
+		}
+		pubKey, err := peerID.ExtractPublicKey()
+		if err != nil {
+			return "", fmt.Errorf("%w: cannot extract public key: %v", ErrInvalidRequestSignature, err)
+		}
+		ok, err := pubKey.Verify(body, signature)
+		if err != nil || !ok {
+			return "", fmt.Errorf("%w: signature mismatch", ErrInvalidRequestSignature)
+		}
+	default:
+		return "", fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidRequestSignature, algorithm)
+	}
+
+	return nodeID, nil
+}
+
+// HMACSHA256 returns the hmac-sha256 signature of a request body
+func HMACSHA256(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRequestHMACTestVector(t *testing.T) {
+	// Reference values for validation services implementing the check in other languages
+	body := []byte(`{"message":"aGVsbG8=","key_id":"key"}`)
+	header := http.Header{}
+	header.Set(HeaderNodeID, "node1")
+	header.Set(HeaderSignatureAlgorithm, SignatureAlgorithmHMACSHA256)
+	header.Set(HeaderSignature, "CcRWVwPhqOCX6TDzEfOv13HF+4ujA43Muh33gM9RWRY=")
+
+	nodeID, err := VerifyRequest(header, body, []byte("shared-secret"))
+	require.NoError(t, err)
+	require.Equal(t, "node1", nodeID)
+
+	_, err = VerifyRequest(header, body, []byte("wrong-secret"))
+	require.ErrorIs(t, err, ErrInvalidRequestSignature)
+	_, err = VerifyRequest(header, append(body, ' '), []byte("shared-secret"))
+	require.ErrorIs(t, err, ErrInvalidRequestSignature)
+}
+
+func TestVerifyRequestLibp2p(t *testing.T) {
+	privKey, _, err := crypto.GenerateKeyPairWithReader(crypto.Secp256k1, 2048, rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	require.NoError(t, err)
+
+	body := []byte(`{"key_id":"key"}`)
+	signature, err := privKey.Sign(body)
+	require.NoError(t, err)
+	header := http.Header{}
+	header.Set(HeaderNodeID, peerID.String())
+	header.Set(HeaderSignatureAlgorithm, SignatureAlgorithmLibp2p)
+	header.Set(HeaderSignature, base64.StdEncoding.EncodeToString(signature))
+
+	nodeID, err := VerifyRequest(header, body, nil)
+	require.NoError(t, err)
+	require.Equal(t, peerID.String(), nodeID)
+
+	header.Set(HeaderSignatureAlgorithm, "none")
+	_, err = VerifyRequest(header, body, nil)
+	require.ErrorIs(t, err, ErrInvalidRequestSignature)
+	header.Del(HeaderSignature)
+	_, err = VerifyRequest(header, body, nil)
+	require.ErrorIs(t, err, ErrInvalidRequestSignature)
+}
@@ -32,6 +32,7 @@ const (
 	OperationStatus_OPERATION_STATUS_COMPLETED   OperationStatus = 3
 	OperationStatus_OPERATION_STATUS_FAILED      OperationStatus = 4
 	OperationStatus_OPERATION_STATUS_CANCELED    OperationStatus = 5
+	OperationStatus_OPERATION_STATUS_TIMED_OUT   OperationStatus = 6
 )
 
 // Enum value maps for OperationStatus.
@@ -43,6 +44,7 @@ var (
 		3: "OPERATION_STATUS_COMPLETED",
 		4: "OPERATION_STATUS_FAILED",
 		5: "OPERATION_STATUS_CANCELED",
+		6: "OPERATION_STATUS_TIMED_OUT",
 	}
 	OperationStatus_value = map[string]int32{
 		"OPERATION_STATUS_UNSPECIFIED": 0,
@@ -51,6 +53,7 @@ var (
 		"OPERATION_STATUS_COMPLETED":   3,
 		"OPERATION_STATUS_FAILED":      4,
 		"OPERATION_STATUS_CANCELED":    5,
+		"OPERATION_STATUS_TIMED_OUT":   6,
 	}
 )
 
@@ -143,7 +146,21 @@ type StartKeygenRequest struct {
 	// Max number of parties that can fail. Minimum signers required = t+1
 	Threshold int32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
 	// List of participant peer IDs (n = len(participants))
-	Participants  []string `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	Participants []string `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Optional human-readable alias, usable instead of the key ID once the key exists
+	Alias string `protobuf:"bytes,4,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Chain family of the key: "ethereum" (default) or "bitcoin". It selects how
+	// messages are hashed and how signatures are encoded.
+	ChainFamily string `protobuf:"bytes,5,opt,name=chain_family,json=chainFamily,proto3" json:"chain_family,omitempty"`
+	// Optional labels for filtering with ListOperations, e.g. team=payments.
+	// At most 16 labels, see ListOperationsRequest for the allowed characters.
+	Labels map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Signature algorithm of the key: "ecdsa" (default) or "schnorr" for BIP340
+	// signatures with x-only public keys. Schnorr keys are rejected until the TSS
+	// library provides threshold Schnorr over secp256k1.
+	Algorithm string `protobuf:"bytes,7,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Optional signing policy stored with the key on every participant
+	Policy        *KeyPolicy `protobuf:"bytes,8,opt,name=policy,proto3" json:"policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -199,6 +216,116 @@ func (x *StartKeygenRequest) GetParticipants() []string {
 	return nil
 }
 
+func (x *StartKeygenRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *StartKeygenRequest) GetChainFamily() string {
+	if x != nil {
+		return x.ChainFamily
+	}
+	return ""
+}
+
+func (x *StartKeygenRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *StartKeygenRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *StartKeygenRequest) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// KeyPolicy restricts how a key signs. Signing requests must pass it before the node's
+// validation service is called. Unset fields do not restrict anything.
+type KeyPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum size of the message or typed data in bytes, 0 for no key specific limit
+	MaxMessageBytes int32 `protobuf:"varint,1,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty"`
+	// Hash modes the key signs with: "eip191", "eip712" or "sha256d", empty for all
+	AllowedHashModes []string `protobuf:"bytes,2,rep,name=allowed_hash_modes,json=allowedHashModes,proto3" json:"allowed_hash_modes,omitempty"`
+	// Roles of which the client starting a signing operation needs at least one, checked
+	// by the node the request is sent to. Empty when any client may sign.
+	RequiredRoles []string `protobuf:"bytes,3,rep,name=required_roles,json=requiredRoles,proto3" json:"required_roles,omitempty"`
+	// Validation service every participant consults for this key in addition to its own
+	ValidationUrl string `protobuf:"bytes,4,opt,name=validation_url,json=validationUrl,proto3" json:"validation_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyPolicy) Reset() {
+	*x = KeyPolicy{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPolicy) ProtoMessage() {}
+
+func (x *KeyPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPolicy.ProtoReflect.Descriptor instead.
+func (*KeyPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeyPolicy) GetMaxMessageBytes() int32 {
+	if x != nil {
+		return x.MaxMessageBytes
+	}
+	return 0
+}
+
+func (x *KeyPolicy) GetAllowedHashModes() []string {
+	if x != nil {
+		return x.AllowedHashModes
+	}
+	return nil
+}
+
+func (x *KeyPolicy) GetRequiredRoles() []string {
+	if x != nil {
+		return x.RequiredRoles
+	}
+	return nil
+}
+
+func (x *KeyPolicy) GetValidationUrl() string {
+	if x != nil {
+		return x.ValidationUrl
+	}
+	return ""
+}
+
 // StartKeygenResponse represents the response when starting keygen operation
 type StartKeygenResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -207,14 +334,22 @@ type StartKeygenResponse struct {
 	// Current status of the operation
 	Status OperationStatus `protobuf:"varint,2,opt,name=status,proto3,enum=tss.v1.OperationStatus" json:"status,omitempty"`
 	// Timestamp when operation was created
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set when the operation ID of the request named an operation that already finished:
+	// no operation was started and the response describes the finished one, with its
+	// result or error, so retrying clients need not poll
+	AlreadyCompleted bool `protobuf:"varint,4,opt,name=already_completed,json=alreadyCompleted,proto3" json:"already_completed,omitempty"`
+	// Result of the finished operation, set with already_completed when it succeeded
+	Result *KeygenResult `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// Error of the finished operation, set with already_completed when it did not succeed
+	Error         *string `protobuf:"bytes,6,opt,name=error,proto3,oneof" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StartKeygenResponse) Reset() {
 	*x = StartKeygenResponse{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[1]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -226,7 +361,7 @@ func (x *StartKeygenResponse) String() string {
 func (*StartKeygenResponse) ProtoMessage() {}
 
 func (x *StartKeygenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[1]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +374,7 @@ func (x *StartKeygenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartKeygenResponse.ProtoReflect.Descriptor instead.
 func (*StartKeygenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{1}
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *StartKeygenResponse) GetOperationId() string {
@@ -263,20 +398,46 @@ func (x *StartKeygenResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *StartKeygenResponse) GetAlreadyCompleted() bool {
+	if x != nil {
+		return x.AlreadyCompleted
+	}
+	return false
+}
+
+func (x *StartKeygenResponse) GetResult() *KeygenResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *StartKeygenResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
 // KeygenResult represents the result of key generation
 type KeygenResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Generated public key in hex format
 	PublicKey string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
 	// Unique identifier for the generated key
-	KeyId         string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Alias of the key, empty if it has none
+	Alias string `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Ethereum address of the public key. It is the key ID only for keys identified by
+	// their address, empty for results recorded before addresses were reported.
+	Address       string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *KeygenResult) Reset() {
 	*x = KeygenResult{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[2]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +449,7 @@ func (x *KeygenResult) String() string {
 func (*KeygenResult) ProtoMessage() {}
 
 func (x *KeygenResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[2]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +462,7 @@ func (x *KeygenResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeygenResult.ProtoReflect.Descriptor instead.
 func (*KeygenResult) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{2}
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *KeygenResult) GetPublicKey() string {
@@ -318,6 +479,20 @@ func (x *KeygenResult) GetKeyId() string {
 	return ""
 }
 
+func (x *KeygenResult) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *KeygenResult) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
 // StartSigningRequest represents a signing request
 type StartSigningRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -325,17 +500,33 @@ type StartSigningRequest struct {
 	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 	// Message to be signed (bytes)
 	Message []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	// Key ID to use for signing
+	// Key ID or key alias to use for signing
 	KeyId string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
 	// List of participant peer IDs
-	Participants  []string `protobuf:"bytes,4,rep,name=participants,proto3" json:"participants,omitempty"`
+	Participants []string `protobuf:"bytes,4,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Optional EIP-155 chain ID. When set, v = recovery_id + chain_id*2 + 35,
+	// otherwise the legacy v = recovery_id + 27 is used
+	ChainId *uint64 `protobuf:"varint,5,opt,name=chain_id,json=chainId,proto3,oneof" json:"chain_id,omitempty"`
+	// Optional client context (e.g. a transaction purpose tag) passed to the
+	// validation service and stored with the operation. At most 16 entries,
+	// keys up to 64 bytes and values up to 256 bytes.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional non-hardened BIP32 derivation path (e.g. m/0/1). When set, the
+	// message is signed with the child key at this path of the key
+	DerivationPath string `protobuf:"bytes,7,opt,name=derivation_path,json=derivationPath,proto3" json:"derivation_path,omitempty"`
+	// Optional labels for filtering with ListOperations, e.g. team=payments.
+	// At most 16 labels, see ListOperationsRequest for the allowed characters.
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Sign a context-bound digest instead of the message digest. The signature is
+	// only valid for the key and session it was produced in, see SigningContext.
+	ContextBound  bool `protobuf:"varint,9,opt,name=context_bound,json=contextBound,proto3" json:"context_bound,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StartSigningRequest) Reset() {
 	*x = StartSigningRequest{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[3]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +538,7 @@ func (x *StartSigningRequest) String() string {
 func (*StartSigningRequest) ProtoMessage() {}
 
 func (x *StartSigningRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[3]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +551,7 @@ func (x *StartSigningRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartSigningRequest.ProtoReflect.Descriptor instead.
 func (*StartSigningRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{3}
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StartSigningRequest) GetOperationId() string {
@@ -391,6 +582,161 @@ func (x *StartSigningRequest) GetParticipants() []string {
 	return nil
 }
 
+func (x *StartSigningRequest) GetChainId() uint64 {
+	if x != nil && x.ChainId != nil {
+		return *x.ChainId
+	}
+	return 0
+}
+
+func (x *StartSigningRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *StartSigningRequest) GetDerivationPath() string {
+	if x != nil {
+		return x.DerivationPath
+	}
+	return ""
+}
+
+func (x *StartSigningRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *StartSigningRequest) GetContextBound() bool {
+	if x != nil {
+		return x.ContextBound
+	}
+	return false
+}
+
+// SignTypedDataRequest represents an EIP-712 typed data signing request
+type SignTypedDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional operation ID provided by client for idempotency
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// EIP-712 typed data JSON with types, primaryType, domain and message.
+	// The typed data hash is computed by the nodes
+	TypedData string `protobuf:"bytes,2,opt,name=typed_data,json=typedData,proto3" json:"typed_data,omitempty"`
+	// Key ID or key alias to use for signing
+	KeyId string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// List of participant peer IDs
+	Participants []string `protobuf:"bytes,4,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Optional EIP-155 chain ID for the v value, see StartSigningRequest
+	ChainId *uint64 `protobuf:"varint,5,opt,name=chain_id,json=chainId,proto3,oneof" json:"chain_id,omitempty"`
+	// Optional derivation path of the child key to sign with, see StartSigningRequest
+	DerivationPath string `protobuf:"bytes,6,opt,name=derivation_path,json=derivationPath,proto3" json:"derivation_path,omitempty"`
+	// Optional labels for filtering with ListOperations, e.g. team=payments.
+	// At most 16 labels, see ListOperationsRequest for the allowed characters.
+	Labels map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Sign a context-bound digest, see StartSigningRequest
+	ContextBound bool `protobuf:"varint,8,opt,name=context_bound,json=contextBound,proto3" json:"context_bound,omitempty"`
+	// Optional client context passed to the validation service, see StartSigningRequest
+	Metadata      map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTypedDataRequest) Reset() {
+	*x = SignTypedDataRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTypedDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTypedDataRequest) ProtoMessage() {}
+
+func (x *SignTypedDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTypedDataRequest.ProtoReflect.Descriptor instead.
+func (*SignTypedDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SignTypedDataRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *SignTypedDataRequest) GetTypedData() string {
+	if x != nil {
+		return x.TypedData
+	}
+	return ""
+}
+
+func (x *SignTypedDataRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SignTypedDataRequest) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *SignTypedDataRequest) GetChainId() uint64 {
+	if x != nil && x.ChainId != nil {
+		return *x.ChainId
+	}
+	return 0
+}
+
+func (x *SignTypedDataRequest) GetDerivationPath() string {
+	if x != nil {
+		return x.DerivationPath
+	}
+	return ""
+}
+
+func (x *SignTypedDataRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SignTypedDataRequest) GetContextBound() bool {
+	if x != nil {
+		return x.ContextBound
+	}
+	return false
+}
+
+func (x *SignTypedDataRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 // StartSigningResponse represents the response when starting signing operation
 type StartSigningResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -399,14 +745,22 @@ type StartSigningResponse struct {
 	// Current status of the operation
 	Status OperationStatus `protobuf:"varint,2,opt,name=status,proto3,enum=tss.v1.OperationStatus" json:"status,omitempty"`
 	// Timestamp when operation was created
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set when the operation ID of the request named an operation that already finished:
+	// no operation was started and the response describes the finished one, with its
+	// result or error, so retrying clients need not poll
+	AlreadyCompleted bool `protobuf:"varint,4,opt,name=already_completed,json=alreadyCompleted,proto3" json:"already_completed,omitempty"`
+	// Result of the finished operation, set with already_completed when it succeeded
+	Result *SigningResult `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// Error of the finished operation, set with already_completed when it did not succeed
+	Error         *string `protobuf:"bytes,6,opt,name=error,proto3,oneof" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StartSigningResponse) Reset() {
 	*x = StartSigningResponse{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[4]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -418,7 +772,7 @@ func (x *StartSigningResponse) String() string {
 func (*StartSigningResponse) ProtoMessage() {}
 
 func (x *StartSigningResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[4]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,7 +785,7 @@ func (x *StartSigningResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartSigningResponse.ProtoReflect.Descriptor instead.
 func (*StartSigningResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{4}
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *StartSigningResponse) GetOperationId() string {
@@ -455,6 +809,27 @@ func (x *StartSigningResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *StartSigningResponse) GetAlreadyCompleted() bool {
+	if x != nil {
+		return x.AlreadyCompleted
+	}
+	return false
+}
+
+func (x *StartSigningResponse) GetResult() *SigningResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *StartSigningResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
 // SigningResult represents the result of signing operation
 type SigningResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -465,14 +840,32 @@ type SigningResult struct {
 	// S component of the signature
 	S string `protobuf:"bytes,3,opt,name=s,proto3" json:"s,omitempty"`
 	// V component (recovery ID) for Ethereum compatibility
-	V             int32 `protobuf:"varint,4,opt,name=v,proto3" json:"v,omitempty"`
+	V int32 `protobuf:"varint,4,opt,name=v,proto3" json:"v,omitempty"`
+	// Derivation path, public key and address of the child key signed with, set
+	// for requests with a derivation path. The address is the Ethereum address,
+	// or the btc_p2wpkh address for bitcoin keys.
+	DerivationPath string `protobuf:"bytes,5,opt,name=derivation_path,json=derivationPath,proto3" json:"derivation_path,omitempty"`
+	PublicKey      string `protobuf:"bytes,6,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Address        string `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	// Node IDs of the participants that contributed messages to the signature, as
+	// observed by the queried node. Empty for operations signed before it was recorded.
+	Signers []string `protobuf:"bytes,8,rep,name=signers,proto3" json:"signers,omitempty"`
+	// Hex encoded digest the signature covers
+	MessageDigest string `protobuf:"bytes,9,opt,name=message_digest,json=messageDigest,proto3" json:"message_digest,omitempty"`
+	// How the request was hashed into message_digest: eip191, eip712 or sha256d
+	HashMode string `protobuf:"bytes,10,opt,name=hash_mode,json=hashMode,proto3" json:"hash_mode,omitempty"`
+	// Set for context-bound signatures. message_digest is then
+	// SHA-256("DKNet context-bound signature v1" || len(key_id) || key_id ||
+	// len(session_id) || session_id || digest), with 4 byte big endian lengths and
+	// digest the hash of the request in hash_mode.
+	Context       *SigningContext `protobuf:"bytes,11,opt,name=context,proto3" json:"context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SigningResult) Reset() {
 	*x = SigningResult{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[5]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -484,7 +877,7 @@ func (x *SigningResult) String() string {
 func (*SigningResult) ProtoMessage() {}
 
 func (x *SigningResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[5]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,7 +890,7 @@ func (x *SigningResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SigningResult.ProtoReflect.Descriptor instead.
 func (*SigningResult) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{5}
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SigningResult) GetSignature() string {
@@ -528,24 +921,129 @@ func (x *SigningResult) GetV() int32 {
 	return 0
 }
 
-// StartResharingRequest represents a resharing request
-type StartResharingRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Optional operation ID provided by client for idempotency
-	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
-	// Key ID to reshare
-	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
-	// New fault tolerance threshold (t in (t+1)-of-n scheme)
-	NewThreshold int32 `protobuf:"varint,3,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty"`
-	// List of new participant peer IDs (new_parties = len(new_participants))
+func (x *SigningResult) GetDerivationPath() string {
+	if x != nil {
+		return x.DerivationPath
+	}
+	return ""
+}
+
+func (x *SigningResult) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *SigningResult) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SigningResult) GetSigners() []string {
+	if x != nil {
+		return x.Signers
+	}
+	return nil
+}
+
+func (x *SigningResult) GetMessageDigest() string {
+	if x != nil {
+		return x.MessageDigest
+	}
+	return ""
+}
+
+func (x *SigningResult) GetHashMode() string {
+	if x != nil {
+		return x.HashMode
+	}
+	return ""
+}
+
+func (x *SigningResult) GetContext() *SigningContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// SigningContext is the key and session a context-bound signature is bound to
+type SigningContext struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SigningContext) Reset() {
+	*x = SigningContext{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SigningContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningContext) ProtoMessage() {}
+
+func (x *SigningContext) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningContext.ProtoReflect.Descriptor instead.
+func (*SigningContext) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SigningContext) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SigningContext) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// StartResharingRequest represents a resharing request
+type StartResharingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional operation ID provided by client for idempotency
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Key ID or key alias to reshare
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// New fault tolerance threshold (t in (t+1)-of-n scheme)
+	NewThreshold int32 `protobuf:"varint,3,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty"`
+	// List of new participant peer IDs (new_parties = len(new_participants))
 	NewParticipants []string `protobuf:"bytes,4,rep,name=new_participants,json=newParticipants,proto3" json:"new_participants,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Optional labels for filtering with ListOperations, e.g. team=payments.
+	// At most 16 labels, see ListOperationsRequest for the allowed characters.
+	Labels        map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StartResharingRequest) Reset() {
 	*x = StartResharingRequest{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[6]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -557,7 +1055,7 @@ func (x *StartResharingRequest) String() string {
 func (*StartResharingRequest) ProtoMessage() {}
 
 func (x *StartResharingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[6]
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +1068,7 @@ func (x *StartResharingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartResharingRequest.ProtoReflect.Descriptor instead.
 func (*StartResharingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{6}
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *StartResharingRequest) GetOperationId() string {
@@ -601,6 +1099,79 @@ func (x *StartResharingRequest) GetNewParticipants() []string {
 	return nil
 }
 
+func (x *StartResharingRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// RefreshSharesRequest represents a request to refresh the shares of a key. It runs a
+// resharing to the key's current participants and threshold, the public key is unchanged.
+type RefreshSharesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional operation ID provided by client for idempotency
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Key ID or key alias to refresh
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Optional labels for filtering with ListOperations, e.g. team=payments.
+	// At most 16 labels, see ListOperationsRequest for the allowed characters.
+	Labels        map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSharesRequest) Reset() {
+	*x = RefreshSharesRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSharesRequest) ProtoMessage() {}
+
+func (x *RefreshSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSharesRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSharesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RefreshSharesRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *RefreshSharesRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RefreshSharesRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // StartResharingResponse represents the response when starting resharing operation
 type StartResharingResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -609,26 +1180,1744 @@ type StartResharingResponse struct {
 	// Current status of the operation
 	Status OperationStatus `protobuf:"varint,2,opt,name=status,proto3,enum=tss.v1.OperationStatus" json:"status,omitempty"`
 	// Timestamp when operation was created
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set when the operation ID of the request named an operation that already finished:
+	// no operation was started and the response describes the finished one, with its
+	// result or error, so retrying clients need not poll
+	AlreadyCompleted bool `protobuf:"varint,4,opt,name=already_completed,json=alreadyCompleted,proto3" json:"already_completed,omitempty"`
+	// Result of the finished operation, set with already_completed when it succeeded
+	Result *KeygenResult `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// Error of the finished operation, set with already_completed when it did not succeed
+	Error         *string `protobuf:"bytes,6,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartResharingResponse) Reset() {
+	*x = StartResharingResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartResharingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartResharingResponse) ProtoMessage() {}
+
+func (x *StartResharingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartResharingResponse.ProtoReflect.Descriptor instead.
+func (*StartResharingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StartResharingResponse) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *StartResharingResponse) GetStatus() OperationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+}
+
+func (x *StartResharingResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StartResharingResponse) GetAlreadyCompleted() bool {
+	if x != nil {
+		return x.AlreadyCompleted
+	}
+	return false
+}
+
+func (x *StartResharingResponse) GetResult() *KeygenResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *StartResharingResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetKeyMetadataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key ID or key alias to query
+	KeyId         string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyMetadataRequest) Reset() {
+	*x = GetKeyMetadataRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyMetadataRequest) ProtoMessage() {}
+
+func (x *GetKeyMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetKeyMetadataRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// GetKeyMetadataResponse represents the response to a key metadata request
+type GetKeyMetadataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key ID
+	Moniker string `protobuf:"bytes,1,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	// Threshold
+	Threshold int32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Participants
+	Participants []string `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Key ID the metadata belongs to
+	KeyId string `protobuf:"bytes,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Alias of the key, empty if it has none
+	Alias string `protobuf:"bytes,5,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Chain family of the key: "ethereum" or "bitcoin"
+	ChainFamily string `protobuf:"bytes,6,opt,name=chain_family,json=chainFamily,proto3" json:"chain_family,omitempty"`
+	// Addresses derived from the public key by format: ethereum, btc_p2pkh, btc_p2wpkh
+	Addresses map[string]string `protobuf:"bytes,7,rep,name=addresses,proto3" json:"addresses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Signing policy of the key on this node, unset if it has none
+	Policy        *KeyPolicy `protobuf:"bytes,8,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyMetadataResponse) Reset() {
+	*x = GetKeyMetadataResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyMetadataResponse) ProtoMessage() {}
+
+func (x *GetKeyMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetKeyMetadataResponse) GetMoniker() string {
+	if x != nil {
+		return x.Moniker
+	}
+	return ""
+}
+
+func (x *GetKeyMetadataResponse) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *GetKeyMetadataResponse) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *GetKeyMetadataResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *GetKeyMetadataResponse) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *GetKeyMetadataResponse) GetChainFamily() string {
+	if x != nil {
+		return x.ChainFamily
+	}
+	return ""
+}
+
+func (x *GetKeyMetadataResponse) GetAddresses() map[string]string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *GetKeyMetadataResponse) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// HasKeyRequest represents a request to check whether this node holds a key
+type HasKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key ID or key alias to check
+	KeyId         string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasKeyRequest) Reset() {
+	*x = HasKeyRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasKeyRequest) ProtoMessage() {}
+
+func (x *HasKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasKeyRequest.ProtoReflect.Descriptor instead.
+func (*HasKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HasKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// HasKeyResponse reports what this node holds of a key
+type HasKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the key's metadata is stored on this node
+	Known bool `protobuf:"varint,1,opt,name=known,proto3" json:"known,omitempty"`
+	// Whether this node is listed among the key's participants, i.e. holds a share
+	HasShare      bool `protobuf:"varint,2,opt,name=has_share,json=hasShare,proto3" json:"has_share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasKeyResponse) Reset() {
+	*x = HasKeyResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasKeyResponse) ProtoMessage() {}
+
+func (x *HasKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasKeyResponse.ProtoReflect.Descriptor instead.
+func (*HasKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HasKeyResponse) GetKnown() bool {
+	if x != nil {
+		return x.Known
+	}
+	return false
+}
+
+func (x *HasKeyResponse) GetHasShare() bool {
+	if x != nil {
+		return x.HasShare
+	}
+	return false
+}
+
+// FindKeyHoldersRequest represents a request to find the nodes holding a key
+type FindKeyHoldersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key ID or key alias to look up
+	KeyId         string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindKeyHoldersRequest) Reset() {
+	*x = FindKeyHoldersRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindKeyHoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindKeyHoldersRequest) ProtoMessage() {}
+
+func (x *FindKeyHoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindKeyHoldersRequest.ProtoReflect.Descriptor instead.
+func (*FindKeyHoldersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FindKeyHoldersRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// FindKeyHoldersResponse lists the reachable nodes holding a share of a key
+type FindKeyHoldersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the key, empty when no reachable node knows it
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Threshold of the key, threshold+1 holders are needed to sign
+	Threshold int32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Participants of the key
+	Participants []string `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Reachable participants holding a share
+	Holders []string `protobuf:"bytes,4,rep,name=holders,proto3" json:"holders,omitempty"`
+	// Participants that did not answer
+	Unreachable []string `protobuf:"bytes,5,rep,name=unreachable,proto3" json:"unreachable,omitempty"`
+	// Whether enough holders are reachable to sign with the key
+	QuorumAvailable bool `protobuf:"varint,6,opt,name=quorum_available,json=quorumAvailable,proto3" json:"quorum_available,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FindKeyHoldersResponse) Reset() {
+	*x = FindKeyHoldersResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindKeyHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindKeyHoldersResponse) ProtoMessage() {}
+
+func (x *FindKeyHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindKeyHoldersResponse.ProtoReflect.Descriptor instead.
+func (*FindKeyHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FindKeyHoldersResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *FindKeyHoldersResponse) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *FindKeyHoldersResponse) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *FindKeyHoldersResponse) GetHolders() []string {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+func (x *FindKeyHoldersResponse) GetUnreachable() []string {
+	if x != nil {
+		return x.Unreachable
+	}
+	return nil
+}
+
+func (x *FindKeyHoldersResponse) GetQuorumAvailable() bool {
+	if x != nil {
+		return x.QuorumAvailable
+	}
+	return false
+}
+
+// GetOperationRequest represents a request to get operation status
+type GetOperationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operation ID to query
+	OperationId   string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationRequest) Reset() {
+	*x = GetOperationRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationRequest) ProtoMessage() {}
+
+func (x *GetOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetOperationRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+// GetOperationResponse represents the detailed status of an operation
+type GetOperationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operation identifier
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Type of operation
+	Type OperationType `protobuf:"varint,2,opt,name=type,proto3,enum=tss.v1.OperationType" json:"type,omitempty"`
+	// Session ID for the operation
+	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Current status
+	Status OperationStatus `protobuf:"varint,4,opt,name=status,proto3,enum=tss.v1.OperationStatus" json:"status,omitempty"`
+	// List of participants
+	Participants []string `protobuf:"bytes,5,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Timestamp when operation was created
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Timestamp when operation completed (if applicable)
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
+	// Error message if operation failed
+	Error *string `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	// Operation result (one of the result types)
+	//
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetOperationResponse_KeygenResult
+	//	*GetOperationResponse_SigningResult
+	//	*GetOperationResponse_ResharingResult
+	Result isGetOperationResponse_Result `protobuf_oneof:"result"`
+	// Original request (one of the request types)
+	//
+	// Types that are valid to be assigned to Request:
+	//
+	//	*GetOperationResponse_KeygenRequest
+	//	*GetOperationResponse_SigningRequest
+	//	*GetOperationResponse_ResharingRequest
+	//	*GetOperationResponse_TypedDataRequest
+	Request isGetOperationResponse_Request `protobuf_oneof:"request"`
+	// Labels the operation was started with
+	Labels map[string]string `protobuf:"bytes,16,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// History of status transitions and protocol rounds, oldest first
+	Events []*OperationEvent `protobuf:"bytes,17,rep,name=events,proto3" json:"events,omitempty"`
+	// Time spent in each protocol round the node entered, oldest first
+	RoundTimings []*RoundTiming `protobuf:"bytes,18,rep,name=round_timings,json=roundTimings,proto3" json:"round_timings,omitempty"`
+	// Set when the node did not persist the message or typed data of a signing request:
+	// message_omitted is true and message_sha256 is the hex SHA-256 hash of the omitted bytes
+	MessageSha256  string `protobuf:"bytes,19,opt,name=message_sha256,json=messageSha256,proto3" json:"message_sha256,omitempty"`
+	MessageOmitted bool   `protobuf:"varint,20,opt,name=message_omitted,json=messageOmitted,proto3" json:"message_omitted,omitempty"`
+	// Participants joined with their names from this node's address book, in the order of
+	// participants
+	ParticipantDetails []*ParticipantInfo `protobuf:"bytes,21,rep,name=participant_details,json=participantDetails,proto3" json:"participant_details,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetOperationResponse) Reset() {
+	*x = GetOperationResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationResponse) ProtoMessage() {}
+
+func (x *GetOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetOperationResponse) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *GetOperationResponse) GetType() OperationType {
+	if x != nil {
+		return x.Type
+	}
+	return OperationType_OPERATION_TYPE_UNSPECIFIED
+}
+
+func (x *GetOperationResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GetOperationResponse) GetStatus() OperationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+}
+
+func (x *GetOperationResponse) GetParticipants() []string {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *GetOperationResponse) GetResult() isGetOperationResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetKeygenResult() *KeygenResult {
+	if x != nil {
+		if x, ok := x.Result.(*GetOperationResponse_KeygenResult); ok {
+			return x.KeygenResult
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetSigningResult() *SigningResult {
+	if x != nil {
+		if x, ok := x.Result.(*GetOperationResponse_SigningResult); ok {
+			return x.SigningResult
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetResharingResult() *KeygenResult {
+	if x != nil {
+		if x, ok := x.Result.(*GetOperationResponse_ResharingResult); ok {
+			return x.ResharingResult
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetRequest() isGetOperationResponse_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetKeygenRequest() *StartKeygenRequest {
+	if x != nil {
+		if x, ok := x.Request.(*GetOperationResponse_KeygenRequest); ok {
+			return x.KeygenRequest
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetSigningRequest() *StartSigningRequest {
+	if x != nil {
+		if x, ok := x.Request.(*GetOperationResponse_SigningRequest); ok {
+			return x.SigningRequest
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetResharingRequest() *StartResharingRequest {
+	if x != nil {
+		if x, ok := x.Request.(*GetOperationResponse_ResharingRequest); ok {
+			return x.ResharingRequest
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetTypedDataRequest() *SignTypedDataRequest {
+	if x != nil {
+		if x, ok := x.Request.(*GetOperationResponse_TypedDataRequest); ok {
+			return x.TypedDataRequest
+		}
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetEvents() []*OperationEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetRoundTimings() []*RoundTiming {
+	if x != nil {
+		return x.RoundTimings
+	}
+	return nil
+}
+
+func (x *GetOperationResponse) GetMessageSha256() string {
+	if x != nil {
+		return x.MessageSha256
+	}
+	return ""
+}
+
+func (x *GetOperationResponse) GetMessageOmitted() bool {
+	if x != nil {
+		return x.MessageOmitted
+	}
+	return false
+}
+
+func (x *GetOperationResponse) GetParticipantDetails() []*ParticipantInfo {
+	if x != nil {
+		return x.ParticipantDetails
+	}
+	return nil
+}
+
+type isGetOperationResponse_Result interface {
+	isGetOperationResponse_Result()
+}
+
+type GetOperationResponse_KeygenResult struct {
+	KeygenResult *KeygenResult `protobuf:"bytes,9,opt,name=keygen_result,json=keygenResult,proto3,oneof"`
+}
+
+type GetOperationResponse_SigningResult struct {
+	SigningResult *SigningResult `protobuf:"bytes,10,opt,name=signing_result,json=signingResult,proto3,oneof"`
+}
+
+type GetOperationResponse_ResharingResult struct {
+	ResharingResult *KeygenResult `protobuf:"bytes,11,opt,name=resharing_result,json=resharingResult,proto3,oneof"` // Resharing produces a new key
+}
+
+func (*GetOperationResponse_KeygenResult) isGetOperationResponse_Result() {}
+
+func (*GetOperationResponse_SigningResult) isGetOperationResponse_Result() {}
+
+func (*GetOperationResponse_ResharingResult) isGetOperationResponse_Result() {}
+
+type isGetOperationResponse_Request interface {
+	isGetOperationResponse_Request()
+}
+
+type GetOperationResponse_KeygenRequest struct {
+	KeygenRequest *StartKeygenRequest `protobuf:"bytes,12,opt,name=keygen_request,json=keygenRequest,proto3,oneof"`
+}
+
+type GetOperationResponse_SigningRequest struct {
+	SigningRequest *StartSigningRequest `protobuf:"bytes,13,opt,name=signing_request,json=signingRequest,proto3,oneof"`
+}
+
+type GetOperationResponse_ResharingRequest struct {
+	ResharingRequest *StartResharingRequest `protobuf:"bytes,14,opt,name=resharing_request,json=resharingRequest,proto3,oneof"`
+}
+
+type GetOperationResponse_TypedDataRequest struct {
+	TypedDataRequest *SignTypedDataRequest `protobuf:"bytes,15,opt,name=typed_data_request,json=typedDataRequest,proto3,oneof"`
+}
+
+func (*GetOperationResponse_KeygenRequest) isGetOperationResponse_Request() {}
+
+func (*GetOperationResponse_SigningRequest) isGetOperationResponse_Request() {}
+
+func (*GetOperationResponse_ResharingRequest) isGetOperationResponse_Request() {}
+
+func (*GetOperationResponse_TypedDataRequest) isGetOperationResponse_Request() {}
+
+// ParticipantInfo names a participant of an operation
+type ParticipantInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer ID the participant takes part in the protocol with
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Node ID as used by the network endpoints, the same as peer_id
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Moniker of this node, or the node name configured in tss.node_names for other
+	// nodes. Empty when this node has no name for the participant.
+	Moniker       string `protobuf:"bytes,3,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantInfo) Reset() {
+	*x = ParticipantInfo{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantInfo) ProtoMessage() {}
+
+func (x *ParticipantInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantInfo.ProtoReflect.Descriptor instead.
+func (*ParticipantInfo) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ParticipantInfo) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *ParticipantInfo) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *ParticipantInfo) GetMoniker() string {
+	if x != nil {
+		return x.Moniker
+	}
+	return ""
+}
+
+// GetOperationsRequest represents a request to get the status of several operations
+type GetOperationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operation IDs to query, at most 100
+	OperationIds  []string `protobuf:"bytes,1,rep,name=operation_ids,json=operationIds,proto3" json:"operation_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationsRequest) Reset() {
+	*x = GetOperationsRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationsRequest) ProtoMessage() {}
+
+func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationsRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetOperationsRequest) GetOperationIds() []string {
+	if x != nil {
+		return x.OperationIds
+	}
+	return nil
+}
+
+// GetOperationsResponse holds one result per requested operation ID, in request order
+type GetOperationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*OperationLookup     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationsResponse) Reset() {
+	*x = GetOperationsResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationsResponse) ProtoMessage() {}
+
+func (x *GetOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationsResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetOperationsResponse) GetResults() []*OperationLookup {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// OperationLookup is the result of looking up a single operation of a GetOperationsRequest
+type OperationLookup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Requested operation ID
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Whether the operation exists and is visible to the caller
+	Found bool `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	// The operation, set when found
+	Operation     *GetOperationResponse `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationLookup) Reset() {
+	*x = OperationLookup{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationLookup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationLookup) ProtoMessage() {}
+
+func (x *OperationLookup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationLookup.ProtoReflect.Descriptor instead.
+func (*OperationLookup) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OperationLookup) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *OperationLookup) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *OperationLookup) GetOperation() *GetOperationResponse {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+// RoundTiming is the time an operation spent in one protocol round, from entering it until
+// entering the next one or the end of the operation, including waiting for other participants
+type RoundTiming struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Protocol round, starting at 1
+	Round int32 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// Timestamp when the node entered the round
+	Started *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started,proto3" json:"started,omitempty"`
+	// Time spent in the round in milliseconds, so far for the round the node is in
+	DurationMs    int64 `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundTiming) Reset() {
+	*x = RoundTiming{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundTiming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundTiming) ProtoMessage() {}
+
+func (x *RoundTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundTiming.ProtoReflect.Descriptor instead.
+func (*RoundTiming) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RoundTiming) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RoundTiming) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *RoundTiming) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// OperationEvent is an entry of the event history of an operation. from_status and
+// to_status differ for status transitions and are equal for progress within a status.
+type OperationEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timestamp of the event
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Status before the event
+	FromStatus OperationStatus `protobuf:"varint,2,opt,name=from_status,json=fromStatus,proto3,enum=tss.v1.OperationStatus" json:"from_status,omitempty"`
+	// Status after the event
+	ToStatus OperationStatus `protobuf:"varint,3,opt,name=to_status,json=toStatus,proto3,enum=tss.v1.OperationStatus" json:"to_status,omitempty"`
+	// Optional detail, such as the round the party entered or why the operation ended
+	Detail        string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationEvent) Reset() {
+	*x = OperationEvent{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationEvent) ProtoMessage() {}
+
+func (x *OperationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationEvent.ProtoReflect.Descriptor instead.
+func (*OperationEvent) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OperationEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *OperationEvent) GetFromStatus() OperationStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+}
+
+func (x *OperationEvent) GetToStatus() OperationStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+}
+
+func (x *OperationEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// ListOperationsRequest represents a request to list operations by label
+type ListOperationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Label selector, only operations with every key/value pair are listed. An empty
+	// selector lists all operations. Keys are 1-63 letters, digits, '.', '_', '-' or '/',
+	// values up to 63 letters, digits, '.', '_' or '-', both start and end alphanumeric.
+	LabelSelector map[string]string `protobuf:"bytes,1,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Maximum number of operations returned, 0 for the default of 100. Larger values are
+	// capped at 1000.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response, empty for the first page
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsRequest) ProtoMessage() {}
+
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListOperationsRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
+func (x *ListOperationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOperationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListOperationsResponse lists a page of active and persisted operations in ID order
+type ListOperationsResponse struct {
+	state      protoimpl.MessageState  `protogen:"open.v1"`
+	Operations []*GetOperationResponse `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// Token requesting the next page, empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsResponse) ProtoMessage() {}
+
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListOperationsResponse) GetOperations() []*GetOperationResponse {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *ListOperationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SyncPeersRequest represents a request to trigger peer discovery
+type SyncPeersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncPeersRequest) Reset() {
+	*x = SyncPeersRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPeersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPeersRequest) ProtoMessage() {}
+
+func (x *SyncPeersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPeersRequest.ProtoReflect.Descriptor instead.
+func (*SyncPeersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{28}
+}
+
+// SyncPeersResponse represents the response to a peer sync request
+type SyncPeersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of peers connected when the sync was triggered
+	ConnectedPeers int32 `protobuf:"varint,1,opt,name=connected_peers,json=connectedPeers,proto3" json:"connected_peers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SyncPeersResponse) Reset() {
+	*x = SyncPeersResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPeersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPeersResponse) ProtoMessage() {}
+
+func (x *SyncPeersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPeersResponse.ProtoReflect.Descriptor instead.
+func (*SyncPeersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SyncPeersResponse) GetConnectedPeers() int32 {
+	if x != nil {
+		return x.ConnectedPeers
+	}
+	return 0
+}
+
+// GetNodeAddressRequest represents a request to look up a node's addresses
+type GetNodeAddressRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Node ID (peer ID) to look up
+	NodeId        string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeAddressRequest) Reset() {
+	*x = GetNodeAddressRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeAddressRequest) ProtoMessage() {}
+
+func (x *GetNodeAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetNodeAddressRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+// GetNodeAddressResponse represents the known addresses of a node
+type GetNodeAddressResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Node ID (peer ID)
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Multiaddresses known for the node
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// Whether this node currently has a connection to it
+	Connected     bool `protobuf:"varint,3,opt,name=connected,proto3" json:"connected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNodeAddressResponse) Reset() {
+	*x = GetNodeAddressResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNodeAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeAddressResponse) ProtoMessage() {}
+
+func (x *GetNodeAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeAddressResponse.ProtoReflect.Descriptor instead.
+func (*GetNodeAddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetNodeAddressResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *GetNodeAddressResponse) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *GetNodeAddressResponse) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+// GetNetworkAddressesRequest represents a request to list the known nodes
+type GetNetworkAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetworkAddressesRequest) Reset() {
+	*x = GetNetworkAddressesRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetworkAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkAddressesRequest) ProtoMessage() {}
+
+func (x *GetNetworkAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkAddressesRequest.ProtoReflect.Descriptor instead.
+func (*GetNetworkAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{32}
+}
+
+// GetNetworkAddressesResponse lists this node followed by its connected peers
+type GetNetworkAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*NodeAddress         `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNetworkAddressesResponse) Reset() {
+	*x = GetNetworkAddressesResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNetworkAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetworkAddressesResponse) ProtoMessage() {}
+
+func (x *GetNetworkAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetworkAddressesResponse.ProtoReflect.Descriptor instead.
+func (*GetNetworkAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetNetworkAddressesResponse) GetNodes() []*NodeAddress {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// NodeAddress represents a node known to this node
+type NodeAddress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Node ID (peer ID)
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Human readable node name, only known for this node
+	Moniker string `protobuf:"bytes,2,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	// Multiaddresses known for the node
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// Whether this node currently has a connection to it
+	Connected bool `protobuf:"varint,4,opt,name=connected,proto3" json:"connected,omitempty"`
+	// Whether the entry describes this node
+	Self          bool `protobuf:"varint,5,opt,name=self,proto3" json:"self,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeAddress) Reset() {
+	*x = NodeAddress{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeAddress) ProtoMessage() {}
+
+func (x *NodeAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeAddress.ProtoReflect.Descriptor instead.
+func (*NodeAddress) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NodeAddress) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *NodeAddress) GetMoniker() string {
+	if x != nil {
+		return x.Moniker
+	}
+	return ""
+}
+
+func (x *NodeAddress) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *NodeAddress) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *NodeAddress) GetSelf() bool {
+	if x != nil {
+		return x.Self
+	}
+	return false
+}
+
+// SetMaintenanceModeRequest represents a request to pause or resume new operations
+type SetMaintenanceModeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether new keygen, signing and resharing operations are rejected
+	Enabled       bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeRequest) Reset() {
+	*x = SetMaintenanceModeRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetMaintenanceModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// SetMaintenanceModeResponse represents the maintenance mode after the change
+type SetMaintenanceModeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether new operations are rejected
+	Enabled       bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartResharingResponse) Reset() {
-	*x = StartResharingResponse{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[7]
+func (x *SetMaintenanceModeResponse) Reset() {
+	*x = SetMaintenanceModeResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartResharingResponse) String() string {
+func (x *SetMaintenanceModeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartResharingResponse) ProtoMessage() {}
+func (*SetMaintenanceModeResponse) ProtoMessage() {}
 
-func (x *StartResharingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[7]
+func (x *SetMaintenanceModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -639,55 +2928,45 @@ func (x *StartResharingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartResharingResponse.ProtoReflect.Descriptor instead.
-func (*StartResharingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *StartResharingResponse) GetOperationId() string {
-	if x != nil {
-		return x.OperationId
-	}
-	return ""
-}
-
-func (x *StartResharingResponse) GetStatus() OperationStatus {
-	if x != nil {
-		return x.Status
-	}
-	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+// Deprecated: Use SetMaintenanceModeResponse.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *StartResharingResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SetMaintenanceModeResponse) GetEnabled() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.Enabled
 	}
-	return nil
+	return false
 }
 
-type GetKeyMetadataRequest struct {
+// UpdateKeyPolicyRequest represents a request to replace the signing policy of a key. Each
+// participant stores its own copy of the policy, the request must be sent to every one.
+type UpdateKeyPolicyRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Key ID to query
-	KeyId         string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Key ID or key alias
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// New policy of the key, unset or empty to remove it
+	Policy        *KeyPolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetKeyMetadataRequest) Reset() {
-	*x = GetKeyMetadataRequest{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[8]
+func (x *UpdateKeyPolicyRequest) Reset() {
+	*x = UpdateKeyPolicyRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetKeyMetadataRequest) String() string {
+func (x *UpdateKeyPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetKeyMetadataRequest) ProtoMessage() {}
+func (*UpdateKeyPolicyRequest) ProtoMessage() {}
 
-func (x *GetKeyMetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[8]
+func (x *UpdateKeyPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,46 +2977,51 @@ func (x *GetKeyMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetKeyMetadataRequest.ProtoReflect.Descriptor instead.
-func (*GetKeyMetadataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use UpdateKeyPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateKeyPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetKeyMetadataRequest) GetKeyId() string {
+func (x *UpdateKeyPolicyRequest) GetKeyId() string {
 	if x != nil {
 		return x.KeyId
 	}
 	return ""
 }
 
-// GetKeyMetadataResponse represents the response to a key metadata request
-type GetKeyMetadataResponse struct {
+func (x *UpdateKeyPolicyRequest) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// UpdateKeyPolicyResponse represents the policy of a key after the change
+type UpdateKeyPolicyResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Key ID
-	Moniker string `protobuf:"bytes,1,opt,name=moniker,proto3" json:"moniker,omitempty"`
-	// Threshold
-	Threshold int32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	// Participants
-	Participants  []string `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	// Key ID the policy belongs to
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Policy of the key, unset if it has none
+	Policy        *KeyPolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetKeyMetadataResponse) Reset() {
-	*x = GetKeyMetadataResponse{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[9]
+func (x *UpdateKeyPolicyResponse) Reset() {
+	*x = UpdateKeyPolicyResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetKeyMetadataResponse) String() string {
+func (x *UpdateKeyPolicyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetKeyMetadataResponse) ProtoMessage() {}
+func (*UpdateKeyPolicyResponse) ProtoMessage() {}
 
-func (x *GetKeyMetadataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[9]
+func (x *UpdateKeyPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,56 +3032,47 @@ func (x *GetKeyMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetKeyMetadataResponse.ProtoReflect.Descriptor instead.
-func (*GetKeyMetadataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UpdateKeyPolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateKeyPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetKeyMetadataResponse) GetMoniker() string {
+func (x *UpdateKeyPolicyResponse) GetKeyId() string {
 	if x != nil {
-		return x.Moniker
+		return x.KeyId
 	}
 	return ""
 }
 
-func (x *GetKeyMetadataResponse) GetThreshold() int32 {
-	if x != nil {
-		return x.Threshold
-	}
-	return 0
-}
-
-func (x *GetKeyMetadataResponse) GetParticipants() []string {
+func (x *UpdateKeyPolicyResponse) GetPolicy() *KeyPolicy {
 	if x != nil {
-		return x.Participants
+		return x.Policy
 	}
 	return nil
 }
 
-// GetOperationRequest represents a request to get operation status
-type GetOperationRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Operation ID to query
-	OperationId   string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+// GetNodeInfoRequest represents a request for the identity and capabilities of this node
+type GetNodeInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOperationRequest) Reset() {
-	*x = GetOperationRequest{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[10]
+func (x *GetNodeInfoRequest) Reset() {
+	*x = GetNodeInfoRequest{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOperationRequest) String() string {
+func (x *GetNodeInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOperationRequest) ProtoMessage() {}
+func (*GetNodeInfoRequest) ProtoMessage() {}
 
-func (x *GetOperationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[10]
+func (x *GetNodeInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -808,72 +3083,62 @@ func (x *GetOperationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOperationRequest.ProtoReflect.Descriptor instead.
-func (*GetOperationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *GetOperationRequest) GetOperationId() string {
-	if x != nil {
-		return x.OperationId
-	}
-	return ""
+// Deprecated: Use GetNodeInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{39}
 }
 
-// GetOperationResponse represents the detailed status of an operation
-type GetOperationResponse struct {
+// GetNodeInfoResponse describes this node, from its configuration and build information
+type GetNodeInfoResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Operation identifier
-	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
-	// Type of operation
-	Type OperationType `protobuf:"varint,2,opt,name=type,proto3,enum=tss.v1.OperationType" json:"type,omitempty"`
-	// Session ID for the operation
-	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	// Current status
-	Status OperationStatus `protobuf:"varint,4,opt,name=status,proto3,enum=tss.v1.OperationStatus" json:"status,omitempty"`
-	// List of participants
-	Participants []string `protobuf:"bytes,5,rep,name=participants,proto3" json:"participants,omitempty"`
-	// Timestamp when operation was created
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// Timestamp when operation completed (if applicable)
-	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
-	// Error message if operation failed
-	Error *string `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
-	// Operation result (one of the result types)
-	//
-	// Types that are valid to be assigned to Result:
-	//
-	//	*GetOperationResponse_KeygenResult
-	//	*GetOperationResponse_SigningResult
-	//	*GetOperationResponse_ResharingResult
-	Result isGetOperationResponse_Result `protobuf_oneof:"result"`
-	// Original request (one of the request types)
-	//
-	// Types that are valid to be assigned to Request:
-	//
-	//	*GetOperationResponse_KeygenRequest
-	//	*GetOperationResponse_SigningRequest
-	//	*GetOperationResponse_ResharingRequest
-	Request       isGetOperationResponse_Request `protobuf_oneof:"request"`
+	// Node ID, the libp2p peer ID other nodes know this node by
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Human readable name of the node
+	Moniker string `protobuf:"bytes,2,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	// Version and git commit the node was built from, empty for development builds
+	Version   string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	GitCommit string `protobuf:"bytes,4,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	// Elliptic curves of the keys the node generates
+	Curves []string `protobuf:"bytes,5,rep,name=curves,proto3" json:"curves,omitempty"`
+	// Signature algorithms keygen accepts
+	Algorithms []string `protobuf:"bytes,6,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+	// Chain families keygen accepts: "ethereum", "bitcoin"
+	ChainFamilies []string `protobuf:"bytes,7,rep,name=chain_families,json=chainFamilies,proto3" json:"chain_families,omitempty"`
+	// Address formats derived from public keys: ethereum, btc_p2pkh, btc_p2wpkh
+	AddressFormats []string `protobuf:"bytes,8,rep,name=address_formats,json=addressFormats,proto3" json:"address_formats,omitempty"`
+	// Format of the IDs of new keys: "uuid" or "address"
+	KeyIdFormat string `protobuf:"bytes,9,opt,name=key_id_format,json=keyIdFormat,proto3" json:"key_id_format,omitempty"`
+	// Whether API requests must be authenticated
+	AuthEnabled bool `protobuf:"varint,10,opt,name=auth_enabled,json=authEnabled,proto3" json:"auth_enabled,omitempty"`
+	// Whether only allowed peers may connect to the node
+	AccessControlEnabled bool `protobuf:"varint,11,opt,name=access_control_enabled,json=accessControlEnabled,proto3" json:"access_control_enabled,omitempty"`
+	// Whether signing requests are checked by a validation service
+	ValidationEnabled bool `protobuf:"varint,12,opt,name=validation_enabled,json=validationEnabled,proto3" json:"validation_enabled,omitempty"`
+	// Whether the API is served over TLS
+	TlsEnabled bool `protobuf:"varint,13,opt,name=tls_enabled,json=tlsEnabled,proto3" json:"tls_enabled,omitempty"`
+	// Whether new operations are rejected
+	Maintenance bool `protobuf:"varint,14,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	// Multiaddresses the node listens on
+	Addresses     []string `protobuf:"bytes,15,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOperationResponse) Reset() {
-	*x = GetOperationResponse{}
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[11]
+func (x *GetNodeInfoResponse) Reset() {
+	*x = GetNodeInfoResponse{}
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOperationResponse) String() string {
+func (x *GetNodeInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOperationResponse) ProtoMessage() {}
+func (*GetNodeInfoResponse) ProtoMessage() {}
 
-func (x *GetOperationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_tss_v1_tss_proto_msgTypes[11]
+func (x *GetNodeInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tss_v1_tss_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,230 +3149,271 @@ func (x *GetOperationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOperationResponse.ProtoReflect.Descriptor instead.
-func (*GetOperationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetNodeInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetNodeInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tss_v1_tss_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetOperationResponse) GetOperationId() string {
+func (x *GetNodeInfoResponse) GetNodeId() string {
 	if x != nil {
-		return x.OperationId
+		return x.NodeId
 	}
 	return ""
 }
 
-func (x *GetOperationResponse) GetType() OperationType {
+func (x *GetNodeInfoResponse) GetMoniker() string {
 	if x != nil {
-		return x.Type
+		return x.Moniker
 	}
-	return OperationType_OPERATION_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *GetOperationResponse) GetSessionId() string {
+func (x *GetNodeInfoResponse) GetVersion() string {
 	if x != nil {
-		return x.SessionId
+		return x.Version
 	}
 	return ""
 }
 
-func (x *GetOperationResponse) GetStatus() OperationStatus {
+func (x *GetNodeInfoResponse) GetGitCommit() string {
 	if x != nil {
-		return x.Status
+		return x.GitCommit
 	}
-	return OperationStatus_OPERATION_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (x *GetOperationResponse) GetParticipants() []string {
+func (x *GetNodeInfoResponse) GetCurves() []string {
 	if x != nil {
-		return x.Participants
+		return x.Curves
 	}
 	return nil
 }
 
-func (x *GetOperationResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *GetNodeInfoResponse) GetAlgorithms() []string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Algorithms
 	}
 	return nil
 }
 
-func (x *GetOperationResponse) GetCompletedAt() *timestamppb.Timestamp {
+func (x *GetNodeInfoResponse) GetChainFamilies() []string {
 	if x != nil {
-		return x.CompletedAt
+		return x.ChainFamilies
 	}
 	return nil
 }
 
-func (x *GetOperationResponse) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
-	}
-	return ""
-}
-
-func (x *GetOperationResponse) GetResult() isGetOperationResponse_Result {
+func (x *GetNodeInfoResponse) GetAddressFormats() []string {
 	if x != nil {
-		return x.Result
+		return x.AddressFormats
 	}
 	return nil
 }
 
-func (x *GetOperationResponse) GetKeygenResult() *KeygenResult {
+func (x *GetNodeInfoResponse) GetKeyIdFormat() string {
 	if x != nil {
-		if x, ok := x.Result.(*GetOperationResponse_KeygenResult); ok {
-			return x.KeygenResult
-		}
+		return x.KeyIdFormat
 	}
-	return nil
+	return ""
 }
 
-func (x *GetOperationResponse) GetSigningResult() *SigningResult {
+func (x *GetNodeInfoResponse) GetAuthEnabled() bool {
 	if x != nil {
-		if x, ok := x.Result.(*GetOperationResponse_SigningResult); ok {
-			return x.SigningResult
-		}
+		return x.AuthEnabled
 	}
-	return nil
+	return false
 }
 
-func (x *GetOperationResponse) GetResharingResult() *KeygenResult {
+func (x *GetNodeInfoResponse) GetAccessControlEnabled() bool {
 	if x != nil {
-		if x, ok := x.Result.(*GetOperationResponse_ResharingResult); ok {
-			return x.ResharingResult
-		}
+		return x.AccessControlEnabled
 	}
-	return nil
+	return false
 }
 
-func (x *GetOperationResponse) GetRequest() isGetOperationResponse_Request {
+func (x *GetNodeInfoResponse) GetValidationEnabled() bool {
 	if x != nil {
-		return x.Request
+		return x.ValidationEnabled
 	}
-	return nil
+	return false
 }
 
-func (x *GetOperationResponse) GetKeygenRequest() *StartKeygenRequest {
+func (x *GetNodeInfoResponse) GetTlsEnabled() bool {
 	if x != nil {
-		if x, ok := x.Request.(*GetOperationResponse_KeygenRequest); ok {
-			return x.KeygenRequest
-		}
+		return x.TlsEnabled
 	}
-	return nil
+	return false
 }
 
-func (x *GetOperationResponse) GetSigningRequest() *StartSigningRequest {
+func (x *GetNodeInfoResponse) GetMaintenance() bool {
 	if x != nil {
-		if x, ok := x.Request.(*GetOperationResponse_SigningRequest); ok {
-			return x.SigningRequest
-		}
+		return x.Maintenance
 	}
-	return nil
+	return false
 }
 
-func (x *GetOperationResponse) GetResharingRequest() *StartResharingRequest {
+func (x *GetNodeInfoResponse) GetAddresses() []string {
 	if x != nil {
-		if x, ok := x.Request.(*GetOperationResponse_ResharingRequest); ok {
-			return x.ResharingRequest
-		}
+		return x.Addresses
 	}
 	return nil
 }
 
-type isGetOperationResponse_Result interface {
-	isGetOperationResponse_Result()
-}
-
-type GetOperationResponse_KeygenResult struct {
-	KeygenResult *KeygenResult `protobuf:"bytes,9,opt,name=keygen_result,json=keygenResult,proto3,oneof"`
-}
-
-type GetOperationResponse_SigningResult struct {
-	SigningResult *SigningResult `protobuf:"bytes,10,opt,name=signing_result,json=signingResult,proto3,oneof"`
-}
-
-type GetOperationResponse_ResharingResult struct {
-	ResharingResult *KeygenResult `protobuf:"bytes,11,opt,name=resharing_result,json=resharingResult,proto3,oneof"` // Resharing produces a new key
-}
-
-func (*GetOperationResponse_KeygenResult) isGetOperationResponse_Result() {}
-
-func (*GetOperationResponse_SigningResult) isGetOperationResponse_Result() {}
-
-func (*GetOperationResponse_ResharingResult) isGetOperationResponse_Result() {}
-
-type isGetOperationResponse_Request interface {
-	isGetOperationResponse_Request()
-}
-
-type GetOperationResponse_KeygenRequest struct {
-	KeygenRequest *StartKeygenRequest `protobuf:"bytes,12,opt,name=keygen_request,json=keygenRequest,proto3,oneof"`
-}
-
-type GetOperationResponse_SigningRequest struct {
-	SigningRequest *StartSigningRequest `protobuf:"bytes,13,opt,name=signing_request,json=signingRequest,proto3,oneof"`
-}
-
-type GetOperationResponse_ResharingRequest struct {
-	ResharingRequest *StartResharingRequest `protobuf:"bytes,14,opt,name=resharing_request,json=resharingRequest,proto3,oneof"`
-}
-
-func (*GetOperationResponse_KeygenRequest) isGetOperationResponse_Request() {}
-
-func (*GetOperationResponse_SigningRequest) isGetOperationResponse_Request() {}
-
-func (*GetOperationResponse_ResharingRequest) isGetOperationResponse_Request() {}
-
 var File_proto_tss_v1_tss_proto protoreflect.FileDescriptor
 
 const file_proto_tss_v1_tss_proto_rawDesc = "" +
 	"\n" +
-	"\x16proto/tss/v1/tss.proto\x12\x06tss.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"y\n" +
+	"\x16proto/tss/v1/tss.proto\x12\x06tss.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf6\x02\n" +
 	"\x12StartKeygenRequest\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x1c\n" +
 	"\tthreshold\x18\x02 \x01(\x05R\tthreshold\x12\"\n" +
-	"\fparticipants\x18\x03 \x03(\tR\fparticipants\"\xa4\x01\n" +
+	"\fparticipants\x18\x03 \x03(\tR\fparticipants\x12\x14\n" +
+	"\x05alias\x18\x04 \x01(\tR\x05alias\x12!\n" +
+	"\fchain_family\x18\x05 \x01(\tR\vchainFamily\x12>\n" +
+	"\x06labels\x18\x06 \x03(\v2&.tss.v1.StartKeygenRequest.LabelsEntryR\x06labels\x12\x1c\n" +
+	"\talgorithm\x18\a \x01(\tR\talgorithm\x12)\n" +
+	"\x06policy\x18\b \x01(\v2\x11.tss.v1.KeyPolicyR\x06policy\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x01\n" +
+	"\tKeyPolicy\x12*\n" +
+	"\x11max_message_bytes\x18\x01 \x01(\x05R\x0fmaxMessageBytes\x12,\n" +
+	"\x12allowed_hash_modes\x18\x02 \x03(\tR\x10allowedHashModes\x12%\n" +
+	"\x0erequired_roles\x18\x03 \x03(\tR\rrequiredRoles\x12%\n" +
+	"\x0evalidation_url\x18\x04 \x01(\tR\rvalidationUrl\"\xa4\x02\n" +
 	"\x13StartKeygenResponse\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12/\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x17.tss.v1.OperationStatusR\x06status\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"D\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12+\n" +
+	"\x11already_completed\x18\x04 \x01(\bR\x10alreadyCompleted\x12,\n" +
+	"\x06result\x18\x05 \x01(\v2\x14.tss.v1.KeygenResultR\x06result\x12\x19\n" +
+	"\x05error\x18\x06 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"t\n" +
 	"\fKeygenResult\x12\x1d\n" +
 	"\n" +
 	"public_key\x18\x01 \x01(\tR\tpublicKey\x12\x15\n" +
-	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"\x8d\x01\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12\x14\n" +
+	"\x05alias\x18\x03 \x01(\tR\x05alias\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\"\x88\x04\n" +
 	"\x13StartSigningRequest\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\fR\amessage\x12\x15\n" +
 	"\x06key_id\x18\x03 \x01(\tR\x05keyId\x12\"\n" +
-	"\fparticipants\x18\x04 \x03(\tR\fparticipants\"\xa5\x01\n" +
+	"\fparticipants\x18\x04 \x03(\tR\fparticipants\x12\x1e\n" +
+	"\bchain_id\x18\x05 \x01(\x04H\x00R\achainId\x88\x01\x01\x12E\n" +
+	"\bmetadata\x18\x06 \x03(\v2).tss.v1.StartSigningRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fderivation_path\x18\a \x01(\tR\x0ederivationPath\x12?\n" +
+	"\x06labels\x18\b \x03(\v2'.tss.v1.StartSigningRequest.LabelsEntryR\x06labels\x12#\n" +
+	"\rcontext_bound\x18\t \x01(\bR\fcontextBound\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_chain_id\"\x90\x04\n" +
+	"\x14SignTypedDataRequest\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x1d\n" +
+	"\n" +
+	"typed_data\x18\x02 \x01(\tR\ttypedData\x12\x15\n" +
+	"\x06key_id\x18\x03 \x01(\tR\x05keyId\x12\"\n" +
+	"\fparticipants\x18\x04 \x03(\tR\fparticipants\x12\x1e\n" +
+	"\bchain_id\x18\x05 \x01(\x04H\x00R\achainId\x88\x01\x01\x12'\n" +
+	"\x0fderivation_path\x18\x06 \x01(\tR\x0ederivationPath\x12@\n" +
+	"\x06labels\x18\a \x03(\v2(.tss.v1.SignTypedDataRequest.LabelsEntryR\x06labels\x12#\n" +
+	"\rcontext_bound\x18\b \x01(\bR\fcontextBound\x12F\n" +
+	"\bmetadata\x18\t \x03(\v2*.tss.v1.SignTypedDataRequest.MetadataEntryR\bmetadata\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_chain_id\"\xa6\x02\n" +
 	"\x14StartSigningResponse\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12/\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x17.tss.v1.OperationStatusR\x06status\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"W\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12+\n" +
+	"\x11already_completed\x18\x04 \x01(\bR\x10alreadyCompleted\x12-\n" +
+	"\x06result\x18\x05 \x01(\v2\x15.tss.v1.SigningResultR\x06result\x12\x19\n" +
+	"\x05error\x18\x06 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\xc9\x02\n" +
 	"\rSigningResult\x12\x1c\n" +
 	"\tsignature\x18\x01 \x01(\tR\tsignature\x12\f\n" +
 	"\x01r\x18\x02 \x01(\tR\x01r\x12\f\n" +
 	"\x01s\x18\x03 \x01(\tR\x01s\x12\f\n" +
-	"\x01v\x18\x04 \x01(\x05R\x01v\"\xa1\x01\n" +
+	"\x01v\x18\x04 \x01(\x05R\x01v\x12'\n" +
+	"\x0fderivation_path\x18\x05 \x01(\tR\x0ederivationPath\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x06 \x01(\tR\tpublicKey\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\x12\x18\n" +
+	"\asigners\x18\b \x03(\tR\asigners\x12%\n" +
+	"\x0emessage_digest\x18\t \x01(\tR\rmessageDigest\x12\x1b\n" +
+	"\thash_mode\x18\n" +
+	" \x01(\tR\bhashMode\x120\n" +
+	"\acontext\x18\v \x01(\v2\x16.tss.v1.SigningContextR\acontext\"F\n" +
+	"\x0eSigningContext\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"\x9f\x02\n" +
 	"\x15StartResharingRequest\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x15\n" +
 	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12#\n" +
 	"\rnew_threshold\x18\x03 \x01(\x05R\fnewThreshold\x12)\n" +
-	"\x10new_participants\x18\x04 \x03(\tR\x0fnewParticipants\"\xa7\x01\n" +
+	"\x10new_participants\x18\x04 \x03(\tR\x0fnewParticipants\x12A\n" +
+	"\x06labels\x18\x05 \x03(\v2).tss.v1.StartResharingRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcd\x01\n" +
+	"\x14RefreshSharesRequest\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12@\n" +
+	"\x06labels\x18\x03 \x03(\v2(.tss.v1.RefreshSharesRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x02\n" +
 	"\x16StartResharingResponse\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12/\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x17.tss.v1.OperationStatusR\x06status\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\".\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12+\n" +
+	"\x11already_completed\x18\x04 \x01(\bR\x10alreadyCompleted\x12,\n" +
+	"\x06result\x18\x05 \x01(\v2\x14.tss.v1.KeygenResultR\x06result\x12\x19\n" +
+	"\x05error\x18\x06 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\".\n" +
 	"\x15GetKeyMetadataRequest\x12\x15\n" +
-	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"t\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"\xfa\x02\n" +
 	"\x16GetKeyMetadataResponse\x12\x18\n" +
 	"\amoniker\x18\x01 \x01(\tR\amoniker\x12\x1c\n" +
 	"\tthreshold\x18\x02 \x01(\x05R\tthreshold\x12\"\n" +
-	"\fparticipants\x18\x03 \x03(\tR\fparticipants\"8\n" +
+	"\fparticipants\x18\x03 \x03(\tR\fparticipants\x12\x15\n" +
+	"\x06key_id\x18\x04 \x01(\tR\x05keyId\x12\x14\n" +
+	"\x05alias\x18\x05 \x01(\tR\x05alias\x12!\n" +
+	"\fchain_family\x18\x06 \x01(\tR\vchainFamily\x12K\n" +
+	"\taddresses\x18\a \x03(\v2-.tss.v1.GetKeyMetadataResponse.AddressesEntryR\taddresses\x12)\n" +
+	"\x06policy\x18\b \x01(\v2\x11.tss.v1.KeyPolicyR\x06policy\x1a<\n" +
+	"\x0eAddressesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"&\n" +
+	"\rHasKeyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"C\n" +
+	"\x0eHasKeyResponse\x12\x14\n" +
+	"\x05known\x18\x01 \x01(\bR\x05known\x12\x1b\n" +
+	"\thas_share\x18\x02 \x01(\bR\bhasShare\".\n" +
+	"\x15FindKeyHoldersRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"\xd8\x01\n" +
+	"\x16FindKeyHoldersResponse\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x05R\tthreshold\x12\"\n" +
+	"\fparticipants\x18\x03 \x03(\tR\fparticipants\x12\x18\n" +
+	"\aholders\x18\x04 \x03(\tR\aholders\x12 \n" +
+	"\vunreachable\x18\x05 \x03(\tR\vunreachable\x12)\n" +
+	"\x10quorum_available\x18\x06 \x01(\bR\x0fquorumAvailable\"8\n" +
 	"\x13GetOperationRequest\x12!\n" +
-	"\foperation_id\x18\x01 \x01(\tR\voperationId\"\xbd\x06\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\"\x8c\n" +
+	"\n" +
 	"\x14GetOperationResponse\x12!\n" +
 	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12)\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x15.tss.v1.OperationTypeR\x04type\x12\x1d\n" +
@@ -1125,30 +3431,140 @@ const file_proto_tss_v1_tss_proto_rawDesc = "" +
 	"\x10resharing_result\x18\v \x01(\v2\x14.tss.v1.KeygenResultH\x00R\x0fresharingResult\x12C\n" +
 	"\x0ekeygen_request\x18\f \x01(\v2\x1a.tss.v1.StartKeygenRequestH\x01R\rkeygenRequest\x12F\n" +
 	"\x0fsigning_request\x18\r \x01(\v2\x1b.tss.v1.StartSigningRequestH\x01R\x0esigningRequest\x12L\n" +
-	"\x11resharing_request\x18\x0e \x01(\v2\x1d.tss.v1.StartResharingRequestH\x01R\x10resharingRequestB\b\n" +
+	"\x11resharing_request\x18\x0e \x01(\v2\x1d.tss.v1.StartResharingRequestH\x01R\x10resharingRequest\x12L\n" +
+	"\x12typed_data_request\x18\x0f \x01(\v2\x1c.tss.v1.SignTypedDataRequestH\x01R\x10typedDataRequest\x12@\n" +
+	"\x06labels\x18\x10 \x03(\v2(.tss.v1.GetOperationResponse.LabelsEntryR\x06labels\x12.\n" +
+	"\x06events\x18\x11 \x03(\v2\x16.tss.v1.OperationEventR\x06events\x128\n" +
+	"\rround_timings\x18\x12 \x03(\v2\x13.tss.v1.RoundTimingR\froundTimings\x12%\n" +
+	"\x0emessage_sha256\x18\x13 \x01(\tR\rmessageSha256\x12'\n" +
+	"\x0fmessage_omitted\x18\x14 \x01(\bR\x0emessageOmitted\x12H\n" +
+	"\x13participant_details\x18\x15 \x03(\v2\x17.tss.v1.ParticipantInfoR\x12participantDetails\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
 	"\x06resultB\t\n" +
 	"\arequestB\x0f\n" +
 	"\r_completed_atB\b\n" +
-	"\x06_error*\xcf\x01\n" +
+	"\x06_error\"]\n" +
+	"\x0fParticipantInfo\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\amoniker\x18\x03 \x01(\tR\amoniker\";\n" +
+	"\x14GetOperationsRequest\x12#\n" +
+	"\roperation_ids\x18\x01 \x03(\tR\foperationIds\"J\n" +
+	"\x15GetOperationsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.tss.v1.OperationLookupR\aresults\"\x86\x01\n" +
+	"\x0fOperationLookup\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12:\n" +
+	"\toperation\x18\x03 \x01(\v2\x1c.tss.v1.GetOperationResponseR\toperation\"z\n" +
+	"\vRoundTiming\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x124\n" +
+	"\astarted\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\astarted\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\"\xc8\x01\n" +
+	"\x0eOperationEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x128\n" +
+	"\vfrom_status\x18\x02 \x01(\x0e2\x17.tss.v1.OperationStatusR\n" +
+	"fromStatus\x124\n" +
+	"\tto_status\x18\x03 \x01(\x0e2\x17.tss.v1.OperationStatusR\btoStatus\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\xee\x01\n" +
+	"\x15ListOperationsRequest\x12W\n" +
+	"\x0elabel_selector\x18\x01 \x03(\v20.tss.v1.ListOperationsRequest.LabelSelectorEntryR\rlabelSelector\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"~\n" +
+	"\x16ListOperationsResponse\x12<\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x1c.tss.v1.GetOperationResponseR\n" +
+	"operations\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x12\n" +
+	"\x10SyncPeersRequest\"<\n" +
+	"\x11SyncPeersResponse\x12'\n" +
+	"\x0fconnected_peers\x18\x01 \x01(\x05R\x0econnectedPeers\"0\n" +
+	"\x15GetNodeAddressRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"m\n" +
+	"\x16GetNodeAddressResponse\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x1c\n" +
+	"\taddresses\x18\x02 \x03(\tR\taddresses\x12\x1c\n" +
+	"\tconnected\x18\x03 \x01(\bR\tconnected\"\x1c\n" +
+	"\x1aGetNetworkAddressesRequest\"H\n" +
+	"\x1bGetNetworkAddressesResponse\x12)\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x13.tss.v1.NodeAddressR\x05nodes\"\x90\x01\n" +
+	"\vNodeAddress\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\amoniker\x18\x02 \x01(\tR\amoniker\x12\x1c\n" +
+	"\taddresses\x18\x03 \x03(\tR\taddresses\x12\x1c\n" +
+	"\tconnected\x18\x04 \x01(\bR\tconnected\x12\x12\n" +
+	"\x04self\x18\x05 \x01(\bR\x04self\"5\n" +
+	"\x19SetMaintenanceModeRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"6\n" +
+	"\x1aSetMaintenanceModeResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"Z\n" +
+	"\x16UpdateKeyPolicyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12)\n" +
+	"\x06policy\x18\x02 \x01(\v2\x11.tss.v1.KeyPolicyR\x06policy\"[\n" +
+	"\x17UpdateKeyPolicyResponse\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12)\n" +
+	"\x06policy\x18\x02 \x01(\v2\x11.tss.v1.KeyPolicyR\x06policy\"\x14\n" +
+	"\x12GetNodeInfoRequest\"\x96\x04\n" +
+	"\x13GetNodeInfoResponse\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x18\n" +
+	"\amoniker\x18\x02 \x01(\tR\amoniker\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x04 \x01(\tR\tgitCommit\x12\x16\n" +
+	"\x06curves\x18\x05 \x03(\tR\x06curves\x12\x1e\n" +
+	"\n" +
+	"algorithms\x18\x06 \x03(\tR\n" +
+	"algorithms\x12%\n" +
+	"\x0echain_families\x18\a \x03(\tR\rchainFamilies\x12'\n" +
+	"\x0faddress_formats\x18\b \x03(\tR\x0eaddressFormats\x12\"\n" +
+	"\rkey_id_format\x18\t \x01(\tR\vkeyIdFormat\x12!\n" +
+	"\fauth_enabled\x18\n" +
+	" \x01(\bR\vauthEnabled\x124\n" +
+	"\x16access_control_enabled\x18\v \x01(\bR\x14accessControlEnabled\x12-\n" +
+	"\x12validation_enabled\x18\f \x01(\bR\x11validationEnabled\x12\x1f\n" +
+	"\vtls_enabled\x18\r \x01(\bR\n" +
+	"tlsEnabled\x12 \n" +
+	"\vmaintenance\x18\x0e \x01(\bR\vmaintenance\x12\x1c\n" +
+	"\taddresses\x18\x0f \x03(\tR\taddresses*\xef\x01\n" +
 	"\x0fOperationStatus\x12 \n" +
 	"\x1cOPERATION_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18OPERATION_STATUS_PENDING\x10\x01\x12 \n" +
 	"\x1cOPERATION_STATUS_IN_PROGRESS\x10\x02\x12\x1e\n" +
 	"\x1aOPERATION_STATUS_COMPLETED\x10\x03\x12\x1b\n" +
 	"\x17OPERATION_STATUS_FAILED\x10\x04\x12\x1d\n" +
-	"\x19OPERATION_STATUS_CANCELED\x10\x05*\x84\x01\n" +
+	"\x19OPERATION_STATUS_CANCELED\x10\x05\x12\x1e\n" +
+	"\x1aOPERATION_STATUS_TIMED_OUT\x10\x06*\x84\x01\n" +
 	"\rOperationType\x12\x1e\n" +
 	"\x1aOPERATION_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15OPERATION_TYPE_KEYGEN\x10\x01\x12\x1a\n" +
 	"\x16OPERATION_TYPE_SIGNING\x10\x02\x12\x1c\n" +
-	"\x18OPERATION_TYPE_RESHARING\x10\x032\x8c\x03\n" +
+	"\x18OPERATION_TYPE_RESHARING\x10\x032\xbd\n" +
+	"\n" +
 	"\n" +
 	"TSSService\x12F\n" +
 	"\vStartKeygen\x12\x1a.tss.v1.StartKeygenRequest\x1a\x1b.tss.v1.StartKeygenResponse\x12I\n" +
-	"\fStartSigning\x12\x1b.tss.v1.StartSigningRequest\x1a\x1c.tss.v1.StartSigningResponse\x12O\n" +
-	"\x0eStartResharing\x12\x1d.tss.v1.StartResharingRequest\x1a\x1e.tss.v1.StartResharingResponse\x12I\n" +
-	"\fGetOperation\x12\x1b.tss.v1.GetOperationRequest\x1a\x1c.tss.v1.GetOperationResponse\x12O\n" +
-	"\x0eGetKeyMetadata\x12\x1d.tss.v1.GetKeyMetadataRequest\x1a\x1e.tss.v1.GetKeyMetadataResponseB0Z.github.com/dreamer-zq/DKNet/proto/tss/v1;tssv1b\x06proto3"
+	"\fStartSigning\x12\x1b.tss.v1.StartSigningRequest\x1a\x1c.tss.v1.StartSigningResponse\x12K\n" +
+	"\rSignTypedData\x12\x1c.tss.v1.SignTypedDataRequest\x1a\x1c.tss.v1.StartSigningResponse\x12O\n" +
+	"\x0eStartResharing\x12\x1d.tss.v1.StartResharingRequest\x1a\x1e.tss.v1.StartResharingResponse\x12M\n" +
+	"\rRefreshShares\x12\x1c.tss.v1.RefreshSharesRequest\x1a\x1e.tss.v1.StartResharingResponse\x12I\n" +
+	"\fGetOperation\x12\x1b.tss.v1.GetOperationRequest\x1a\x1c.tss.v1.GetOperationResponse\x12L\n" +
+	"\rGetOperations\x12\x1c.tss.v1.GetOperationsRequest\x1a\x1d.tss.v1.GetOperationsResponse\x12O\n" +
+	"\x0eListOperations\x12\x1d.tss.v1.ListOperationsRequest\x1a\x1e.tss.v1.ListOperationsResponse\x12O\n" +
+	"\x0eGetKeyMetadata\x12\x1d.tss.v1.GetKeyMetadataRequest\x1a\x1e.tss.v1.GetKeyMetadataResponse\x127\n" +
+	"\x06HasKey\x12\x15.tss.v1.HasKeyRequest\x1a\x16.tss.v1.HasKeyResponse\x12O\n" +
+	"\x0eFindKeyHolders\x12\x1d.tss.v1.FindKeyHoldersRequest\x1a\x1e.tss.v1.FindKeyHoldersResponse\x12@\n" +
+	"\tSyncPeers\x12\x18.tss.v1.SyncPeersRequest\x1a\x19.tss.v1.SyncPeersResponse\x12O\n" +
+	"\x0eGetNodeAddress\x12\x1d.tss.v1.GetNodeAddressRequest\x1a\x1e.tss.v1.GetNodeAddressResponse\x12^\n" +
+	"\x13GetNetworkAddresses\x12\".tss.v1.GetNetworkAddressesRequest\x1a#.tss.v1.GetNetworkAddressesResponse\x12[\n" +
+	"\x12SetMaintenanceMode\x12!.tss.v1.SetMaintenanceModeRequest\x1a\".tss.v1.SetMaintenanceModeResponse\x12R\n" +
+	"\x0fUpdateKeyPolicy\x12\x1e.tss.v1.UpdateKeyPolicyRequest\x1a\x1f.tss.v1.UpdateKeyPolicyResponse\x12F\n" +
+	"\vGetNodeInfo\x12\x1a.tss.v1.GetNodeInfoRequest\x1a\x1b.tss.v1.GetNodeInfoResponseB0Z.github.com/dreamer-zq/DKNet/proto/tss/v1;tssv1b\x06proto3"
 
 var (
 	file_proto_tss_v1_tss_proto_rawDescOnce sync.Once
@@ -1163,56 +3579,149 @@ func file_proto_tss_v1_tss_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_tss_v1_tss_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_tss_v1_tss_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_tss_v1_tss_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_proto_tss_v1_tss_proto_goTypes = []any{
-	(OperationStatus)(0),           // 0: tss.v1.OperationStatus
-	(OperationType)(0),             // 1: tss.v1.OperationType
-	(*StartKeygenRequest)(nil),     // 2: tss.v1.StartKeygenRequest
-	(*StartKeygenResponse)(nil),    // 3: tss.v1.StartKeygenResponse
-	(*KeygenResult)(nil),           // 4: tss.v1.KeygenResult
-	(*StartSigningRequest)(nil),    // 5: tss.v1.StartSigningRequest
-	(*StartSigningResponse)(nil),   // 6: tss.v1.StartSigningResponse
-	(*SigningResult)(nil),          // 7: tss.v1.SigningResult
-	(*StartResharingRequest)(nil),  // 8: tss.v1.StartResharingRequest
-	(*StartResharingResponse)(nil), // 9: tss.v1.StartResharingResponse
-	(*GetKeyMetadataRequest)(nil),  // 10: tss.v1.GetKeyMetadataRequest
-	(*GetKeyMetadataResponse)(nil), // 11: tss.v1.GetKeyMetadataResponse
-	(*GetOperationRequest)(nil),    // 12: tss.v1.GetOperationRequest
-	(*GetOperationResponse)(nil),   // 13: tss.v1.GetOperationResponse
-	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
+	(OperationStatus)(0),                // 0: tss.v1.OperationStatus
+	(OperationType)(0),                  // 1: tss.v1.OperationType
+	(*StartKeygenRequest)(nil),          // 2: tss.v1.StartKeygenRequest
+	(*KeyPolicy)(nil),                   // 3: tss.v1.KeyPolicy
+	(*StartKeygenResponse)(nil),         // 4: tss.v1.StartKeygenResponse
+	(*KeygenResult)(nil),                // 5: tss.v1.KeygenResult
+	(*StartSigningRequest)(nil),         // 6: tss.v1.StartSigningRequest
+	(*SignTypedDataRequest)(nil),        // 7: tss.v1.SignTypedDataRequest
+	(*StartSigningResponse)(nil),        // 8: tss.v1.StartSigningResponse
+	(*SigningResult)(nil),               // 9: tss.v1.SigningResult
+	(*SigningContext)(nil),              // 10: tss.v1.SigningContext
+	(*StartResharingRequest)(nil),       // 11: tss.v1.StartResharingRequest
+	(*RefreshSharesRequest)(nil),        // 12: tss.v1.RefreshSharesRequest
+	(*StartResharingResponse)(nil),      // 13: tss.v1.StartResharingResponse
+	(*GetKeyMetadataRequest)(nil),       // 14: tss.v1.GetKeyMetadataRequest
+	(*GetKeyMetadataResponse)(nil),      // 15: tss.v1.GetKeyMetadataResponse
+	(*HasKeyRequest)(nil),               // 16: tss.v1.HasKeyRequest
+	(*HasKeyResponse)(nil),              // 17: tss.v1.HasKeyResponse
+	(*FindKeyHoldersRequest)(nil),       // 18: tss.v1.FindKeyHoldersRequest
+	(*FindKeyHoldersResponse)(nil),      // 19: tss.v1.FindKeyHoldersResponse
+	(*GetOperationRequest)(nil),         // 20: tss.v1.GetOperationRequest
+	(*GetOperationResponse)(nil),        // 21: tss.v1.GetOperationResponse
+	(*ParticipantInfo)(nil),             // 22: tss.v1.ParticipantInfo
+	(*GetOperationsRequest)(nil),        // 23: tss.v1.GetOperationsRequest
+	(*GetOperationsResponse)(nil),       // 24: tss.v1.GetOperationsResponse
+	(*OperationLookup)(nil),             // 25: tss.v1.OperationLookup
+	(*RoundTiming)(nil),                 // 26: tss.v1.RoundTiming
+	(*OperationEvent)(nil),              // 27: tss.v1.OperationEvent
+	(*ListOperationsRequest)(nil),       // 28: tss.v1.ListOperationsRequest
+	(*ListOperationsResponse)(nil),      // 29: tss.v1.ListOperationsResponse
+	(*SyncPeersRequest)(nil),            // 30: tss.v1.SyncPeersRequest
+	(*SyncPeersResponse)(nil),           // 31: tss.v1.SyncPeersResponse
+	(*GetNodeAddressRequest)(nil),       // 32: tss.v1.GetNodeAddressRequest
+	(*GetNodeAddressResponse)(nil),      // 33: tss.v1.GetNodeAddressResponse
+	(*GetNetworkAddressesRequest)(nil),  // 34: tss.v1.GetNetworkAddressesRequest
+	(*GetNetworkAddressesResponse)(nil), // 35: tss.v1.GetNetworkAddressesResponse
+	(*NodeAddress)(nil),                 // 36: tss.v1.NodeAddress
+	(*SetMaintenanceModeRequest)(nil),   // 37: tss.v1.SetMaintenanceModeRequest
+	(*SetMaintenanceModeResponse)(nil),  // 38: tss.v1.SetMaintenanceModeResponse
+	(*UpdateKeyPolicyRequest)(nil),      // 39: tss.v1.UpdateKeyPolicyRequest
+	(*UpdateKeyPolicyResponse)(nil),     // 40: tss.v1.UpdateKeyPolicyResponse
+	(*GetNodeInfoRequest)(nil),          // 41: tss.v1.GetNodeInfoRequest
+	(*GetNodeInfoResponse)(nil),         // 42: tss.v1.GetNodeInfoResponse
+	nil,                                 // 43: tss.v1.StartKeygenRequest.LabelsEntry
+	nil,                                 // 44: tss.v1.StartSigningRequest.MetadataEntry
+	nil,                                 // 45: tss.v1.StartSigningRequest.LabelsEntry
+	nil,                                 // 46: tss.v1.SignTypedDataRequest.LabelsEntry
+	nil,                                 // 47: tss.v1.SignTypedDataRequest.MetadataEntry
+	nil,                                 // 48: tss.v1.StartResharingRequest.LabelsEntry
+	nil,                                 // 49: tss.v1.RefreshSharesRequest.LabelsEntry
+	nil,                                 // 50: tss.v1.GetKeyMetadataResponse.AddressesEntry
+	nil,                                 // 51: tss.v1.GetOperationResponse.LabelsEntry
+	nil,                                 // 52: tss.v1.ListOperationsRequest.LabelSelectorEntry
+	(*timestamppb.Timestamp)(nil),       // 53: google.protobuf.Timestamp
 }
 var file_proto_tss_v1_tss_proto_depIdxs = []int32{
-	0,  // 0: tss.v1.StartKeygenResponse.status:type_name -> tss.v1.OperationStatus
-	14, // 1: tss.v1.StartKeygenResponse.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: tss.v1.StartSigningResponse.status:type_name -> tss.v1.OperationStatus
-	14, // 3: tss.v1.StartSigningResponse.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: tss.v1.StartResharingResponse.status:type_name -> tss.v1.OperationStatus
-	14, // 5: tss.v1.StartResharingResponse.created_at:type_name -> google.protobuf.Timestamp
-	1,  // 6: tss.v1.GetOperationResponse.type:type_name -> tss.v1.OperationType
-	0,  // 7: tss.v1.GetOperationResponse.status:type_name -> tss.v1.OperationStatus
-	14, // 8: tss.v1.GetOperationResponse.created_at:type_name -> google.protobuf.Timestamp
-	14, // 9: tss.v1.GetOperationResponse.completed_at:type_name -> google.protobuf.Timestamp
-	4,  // 10: tss.v1.GetOperationResponse.keygen_result:type_name -> tss.v1.KeygenResult
-	7,  // 11: tss.v1.GetOperationResponse.signing_result:type_name -> tss.v1.SigningResult
-	4,  // 12: tss.v1.GetOperationResponse.resharing_result:type_name -> tss.v1.KeygenResult
-	2,  // 13: tss.v1.GetOperationResponse.keygen_request:type_name -> tss.v1.StartKeygenRequest
-	5,  // 14: tss.v1.GetOperationResponse.signing_request:type_name -> tss.v1.StartSigningRequest
-	8,  // 15: tss.v1.GetOperationResponse.resharing_request:type_name -> tss.v1.StartResharingRequest
-	2,  // 16: tss.v1.TSSService.StartKeygen:input_type -> tss.v1.StartKeygenRequest
-	5,  // 17: tss.v1.TSSService.StartSigning:input_type -> tss.v1.StartSigningRequest
-	8,  // 18: tss.v1.TSSService.StartResharing:input_type -> tss.v1.StartResharingRequest
-	12, // 19: tss.v1.TSSService.GetOperation:input_type -> tss.v1.GetOperationRequest
-	10, // 20: tss.v1.TSSService.GetKeyMetadata:input_type -> tss.v1.GetKeyMetadataRequest
-	3,  // 21: tss.v1.TSSService.StartKeygen:output_type -> tss.v1.StartKeygenResponse
-	6,  // 22: tss.v1.TSSService.StartSigning:output_type -> tss.v1.StartSigningResponse
-	9,  // 23: tss.v1.TSSService.StartResharing:output_type -> tss.v1.StartResharingResponse
-	13, // 24: tss.v1.TSSService.GetOperation:output_type -> tss.v1.GetOperationResponse
-	11, // 25: tss.v1.TSSService.GetKeyMetadata:output_type -> tss.v1.GetKeyMetadataResponse
-	21, // [21:26] is the sub-list for method output_type
-	16, // [16:21] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	43, // 0: tss.v1.StartKeygenRequest.labels:type_name -> tss.v1.StartKeygenRequest.LabelsEntry
+	3,  // 1: tss.v1.StartKeygenRequest.policy:type_name -> tss.v1.KeyPolicy
+	0,  // 2: tss.v1.StartKeygenResponse.status:type_name -> tss.v1.OperationStatus
+	53, // 3: tss.v1.StartKeygenResponse.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 4: tss.v1.StartKeygenResponse.result:type_name -> tss.v1.KeygenResult
+	44, // 5: tss.v1.StartSigningRequest.metadata:type_name -> tss.v1.StartSigningRequest.MetadataEntry
+	45, // 6: tss.v1.StartSigningRequest.labels:type_name -> tss.v1.StartSigningRequest.LabelsEntry
+	46, // 7: tss.v1.SignTypedDataRequest.labels:type_name -> tss.v1.SignTypedDataRequest.LabelsEntry
+	47, // 8: tss.v1.SignTypedDataRequest.metadata:type_name -> tss.v1.SignTypedDataRequest.MetadataEntry
+	0,  // 9: tss.v1.StartSigningResponse.status:type_name -> tss.v1.OperationStatus
+	53, // 10: tss.v1.StartSigningResponse.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 11: tss.v1.StartSigningResponse.result:type_name -> tss.v1.SigningResult
+	10, // 12: tss.v1.SigningResult.context:type_name -> tss.v1.SigningContext
+	48, // 13: tss.v1.StartResharingRequest.labels:type_name -> tss.v1.StartResharingRequest.LabelsEntry
+	49, // 14: tss.v1.RefreshSharesRequest.labels:type_name -> tss.v1.RefreshSharesRequest.LabelsEntry
+	0,  // 15: tss.v1.StartResharingResponse.status:type_name -> tss.v1.OperationStatus
+	53, // 16: tss.v1.StartResharingResponse.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 17: tss.v1.StartResharingResponse.result:type_name -> tss.v1.KeygenResult
+	50, // 18: tss.v1.GetKeyMetadataResponse.addresses:type_name -> tss.v1.GetKeyMetadataResponse.AddressesEntry
+	3,  // 19: tss.v1.GetKeyMetadataResponse.policy:type_name -> tss.v1.KeyPolicy
+	1,  // 20: tss.v1.GetOperationResponse.type:type_name -> tss.v1.OperationType
+	0,  // 21: tss.v1.GetOperationResponse.status:type_name -> tss.v1.OperationStatus
+	53, // 22: tss.v1.GetOperationResponse.created_at:type_name -> google.protobuf.Timestamp
+	53, // 23: tss.v1.GetOperationResponse.completed_at:type_name -> google.protobuf.Timestamp
+	5,  // 24: tss.v1.GetOperationResponse.keygen_result:type_name -> tss.v1.KeygenResult
+	9,  // 25: tss.v1.GetOperationResponse.signing_result:type_name -> tss.v1.SigningResult
+	5,  // 26: tss.v1.GetOperationResponse.resharing_result:type_name -> tss.v1.KeygenResult
+	2,  // 27: tss.v1.GetOperationResponse.keygen_request:type_name -> tss.v1.StartKeygenRequest
+	6,  // 28: tss.v1.GetOperationResponse.signing_request:type_name -> tss.v1.StartSigningRequest
+	11, // 29: tss.v1.GetOperationResponse.resharing_request:type_name -> tss.v1.StartResharingRequest
+	7,  // 30: tss.v1.GetOperationResponse.typed_data_request:type_name -> tss.v1.SignTypedDataRequest
+	51, // 31: tss.v1.GetOperationResponse.labels:type_name -> tss.v1.GetOperationResponse.LabelsEntry
+	27, // 32: tss.v1.GetOperationResponse.events:type_name -> tss.v1.OperationEvent
+	26, // 33: tss.v1.GetOperationResponse.round_timings:type_name -> tss.v1.RoundTiming
+	22, // 34: tss.v1.GetOperationResponse.participant_details:type_name -> tss.v1.ParticipantInfo
+	25, // 35: tss.v1.GetOperationsResponse.results:type_name -> tss.v1.OperationLookup
+	21, // 36: tss.v1.OperationLookup.operation:type_name -> tss.v1.GetOperationResponse
+	53, // 37: tss.v1.RoundTiming.started:type_name -> google.protobuf.Timestamp
+	53, // 38: tss.v1.OperationEvent.time:type_name -> google.protobuf.Timestamp
+	0,  // 39: tss.v1.OperationEvent.from_status:type_name -> tss.v1.OperationStatus
+	0,  // 40: tss.v1.OperationEvent.to_status:type_name -> tss.v1.OperationStatus
+	52, // 41: tss.v1.ListOperationsRequest.label_selector:type_name -> tss.v1.ListOperationsRequest.LabelSelectorEntry
+	21, // 42: tss.v1.ListOperationsResponse.operations:type_name -> tss.v1.GetOperationResponse
+	36, // 43: tss.v1.GetNetworkAddressesResponse.nodes:type_name -> tss.v1.NodeAddress
+	3,  // 44: tss.v1.UpdateKeyPolicyRequest.policy:type_name -> tss.v1.KeyPolicy
+	3,  // 45: tss.v1.UpdateKeyPolicyResponse.policy:type_name -> tss.v1.KeyPolicy
+	2,  // 46: tss.v1.TSSService.StartKeygen:input_type -> tss.v1.StartKeygenRequest
+	6,  // 47: tss.v1.TSSService.StartSigning:input_type -> tss.v1.StartSigningRequest
+	7,  // 48: tss.v1.TSSService.SignTypedData:input_type -> tss.v1.SignTypedDataRequest
+	11, // 49: tss.v1.TSSService.StartResharing:input_type -> tss.v1.StartResharingRequest
+	12, // 50: tss.v1.TSSService.RefreshShares:input_type -> tss.v1.RefreshSharesRequest
+	20, // 51: tss.v1.TSSService.GetOperation:input_type -> tss.v1.GetOperationRequest
+	23, // 52: tss.v1.TSSService.GetOperations:input_type -> tss.v1.GetOperationsRequest
+	28, // 53: tss.v1.TSSService.ListOperations:input_type -> tss.v1.ListOperationsRequest
+	14, // 54: tss.v1.TSSService.GetKeyMetadata:input_type -> tss.v1.GetKeyMetadataRequest
+	16, // 55: tss.v1.TSSService.HasKey:input_type -> tss.v1.HasKeyRequest
+	18, // 56: tss.v1.TSSService.FindKeyHolders:input_type -> tss.v1.FindKeyHoldersRequest
+	30, // 57: tss.v1.TSSService.SyncPeers:input_type -> tss.v1.SyncPeersRequest
+	32, // 58: tss.v1.TSSService.GetNodeAddress:input_type -> tss.v1.GetNodeAddressRequest
+	34, // 59: tss.v1.TSSService.GetNetworkAddresses:input_type -> tss.v1.GetNetworkAddressesRequest
+	37, // 60: tss.v1.TSSService.SetMaintenanceMode:input_type -> tss.v1.SetMaintenanceModeRequest
+	39, // 61: tss.v1.TSSService.UpdateKeyPolicy:input_type -> tss.v1.UpdateKeyPolicyRequest
+	41, // 62: tss.v1.TSSService.GetNodeInfo:input_type -> tss.v1.GetNodeInfoRequest
+	4,  // 63: tss.v1.TSSService.StartKeygen:output_type -> tss.v1.StartKeygenResponse
+	8,  // 64: tss.v1.TSSService.StartSigning:output_type -> tss.v1.StartSigningResponse
+	8,  // 65: tss.v1.TSSService.SignTypedData:output_type -> tss.v1.StartSigningResponse
+	13, // 66: tss.v1.TSSService.StartResharing:output_type -> tss.v1.StartResharingResponse
+	13, // 67: tss.v1.TSSService.RefreshShares:output_type -> tss.v1.StartResharingResponse
+	21, // 68: tss.v1.TSSService.GetOperation:output_type -> tss.v1.GetOperationResponse
+	24, // 69: tss.v1.TSSService.GetOperations:output_type -> tss.v1.GetOperationsResponse
+	29, // 70: tss.v1.TSSService.ListOperations:output_type -> tss.v1.ListOperationsResponse
+	15, // 71: tss.v1.TSSService.GetKeyMetadata:output_type -> tss.v1.GetKeyMetadataResponse
+	17, // 72: tss.v1.TSSService.HasKey:output_type -> tss.v1.HasKeyResponse
+	19, // 73: tss.v1.TSSService.FindKeyHolders:output_type -> tss.v1.FindKeyHoldersResponse
+	31, // 74: tss.v1.TSSService.SyncPeers:output_type -> tss.v1.SyncPeersResponse
+	33, // 75: tss.v1.TSSService.GetNodeAddress:output_type -> tss.v1.GetNodeAddressResponse
+	35, // 76: tss.v1.TSSService.GetNetworkAddresses:output_type -> tss.v1.GetNetworkAddressesResponse
+	38, // 77: tss.v1.TSSService.SetMaintenanceMode:output_type -> tss.v1.SetMaintenanceModeResponse
+	40, // 78: tss.v1.TSSService.UpdateKeyPolicy:output_type -> tss.v1.UpdateKeyPolicyResponse
+	42, // 79: tss.v1.TSSService.GetNodeInfo:output_type -> tss.v1.GetNodeInfoResponse
+	63, // [63:80] is the sub-list for method output_type
+	46, // [46:63] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_proto_tss_v1_tss_proto_init() }
@@ -1220,13 +3729,19 @@ func file_proto_tss_v1_tss_proto_init() {
 	if File_proto_tss_v1_tss_proto != nil {
 		return
 	}
-	file_proto_tss_v1_tss_proto_msgTypes[11].OneofWrappers = []any{
+	file_proto_tss_v1_tss_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_tss_v1_tss_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_tss_v1_tss_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_tss_v1_tss_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_tss_v1_tss_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_tss_v1_tss_proto_msgTypes[19].OneofWrappers = []any{
 		(*GetOperationResponse_KeygenResult)(nil),
 		(*GetOperationResponse_SigningResult)(nil),
 		(*GetOperationResponse_ResharingResult)(nil),
 		(*GetOperationResponse_KeygenRequest)(nil),
 		(*GetOperationResponse_SigningRequest)(nil),
 		(*GetOperationResponse_ResharingRequest)(nil),
+		(*GetOperationResponse_TypedDataRequest)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1234,7 +3749,7 @@ func file_proto_tss_v1_tss_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tss_v1_tss_proto_rawDesc), len(file_proto_tss_v1_tss_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   12,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
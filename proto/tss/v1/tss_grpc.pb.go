@@ -19,11 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TSSService_StartKeygen_FullMethodName    = "/tss.v1.TSSService/StartKeygen"
-	TSSService_StartSigning_FullMethodName   = "/tss.v1.TSSService/StartSigning"
-	TSSService_StartResharing_FullMethodName = "/tss.v1.TSSService/StartResharing"
-	TSSService_GetOperation_FullMethodName   = "/tss.v1.TSSService/GetOperation"
-	TSSService_GetKeyMetadata_FullMethodName = "/tss.v1.TSSService/GetKeyMetadata"
+	TSSService_StartKeygen_FullMethodName         = "/tss.v1.TSSService/StartKeygen"
+	TSSService_StartSigning_FullMethodName        = "/tss.v1.TSSService/StartSigning"
+	TSSService_SignTypedData_FullMethodName       = "/tss.v1.TSSService/SignTypedData"
+	TSSService_StartResharing_FullMethodName      = "/tss.v1.TSSService/StartResharing"
+	TSSService_RefreshShares_FullMethodName       = "/tss.v1.TSSService/RefreshShares"
+	TSSService_GetOperation_FullMethodName        = "/tss.v1.TSSService/GetOperation"
+	TSSService_GetOperations_FullMethodName       = "/tss.v1.TSSService/GetOperations"
+	TSSService_ListOperations_FullMethodName      = "/tss.v1.TSSService/ListOperations"
+	TSSService_GetKeyMetadata_FullMethodName      = "/tss.v1.TSSService/GetKeyMetadata"
+	TSSService_HasKey_FullMethodName              = "/tss.v1.TSSService/HasKey"
+	TSSService_FindKeyHolders_FullMethodName      = "/tss.v1.TSSService/FindKeyHolders"
+	TSSService_SyncPeers_FullMethodName           = "/tss.v1.TSSService/SyncPeers"
+	TSSService_GetNodeAddress_FullMethodName      = "/tss.v1.TSSService/GetNodeAddress"
+	TSSService_GetNetworkAddresses_FullMethodName = "/tss.v1.TSSService/GetNetworkAddresses"
+	TSSService_SetMaintenanceMode_FullMethodName  = "/tss.v1.TSSService/SetMaintenanceMode"
+	TSSService_UpdateKeyPolicy_FullMethodName     = "/tss.v1.TSSService/UpdateKeyPolicy"
+	TSSService_GetNodeInfo_FullMethodName         = "/tss.v1.TSSService/GetNodeInfo"
 )
 
 // TSSServiceClient is the client API for TSSService service.
@@ -36,11 +48,35 @@ type TSSServiceClient interface {
 	StartKeygen(ctx context.Context, in *StartKeygenRequest, opts ...grpc.CallOption) (*StartKeygenResponse, error)
 	// StartSigning starts a new signing operation
 	StartSigning(ctx context.Context, in *StartSigningRequest, opts ...grpc.CallOption) (*StartSigningResponse, error)
+	// SignTypedData starts a new signing operation over the EIP-712 hash of typed data
+	SignTypedData(ctx context.Context, in *SignTypedDataRequest, opts ...grpc.CallOption) (*StartSigningResponse, error)
 	// StartResharing starts a new resharing operation
 	StartResharing(ctx context.Context, in *StartResharingRequest, opts ...grpc.CallOption) (*StartResharingResponse, error)
+	// RefreshShares refreshes the shares of a key, resharing to the same participants and threshold
+	RefreshShares(ctx context.Context, in *RefreshSharesRequest, opts ...grpc.CallOption) (*StartResharingResponse, error)
 	// GetOperation gets the status and result of an operation
 	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error)
+	// GetOperations gets the status and result of several operations in one request
+	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error)
+	// ListOperations lists the operations of this node, optionally filtered by labels
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
 	GetKeyMetadata(ctx context.Context, in *GetKeyMetadataRequest, opts ...grpc.CallOption) (*GetKeyMetadataResponse, error)
+	// HasKey reports whether this node knows a key and holds a share of it
+	HasKey(ctx context.Context, in *HasKeyRequest, opts ...grpc.CallOption) (*HasKeyResponse, error)
+	// FindKeyHolders asks the nodes reachable from this node which of them hold a share of a key
+	FindKeyHolders(ctx context.Context, in *FindKeyHoldersRequest, opts ...grpc.CallOption) (*FindKeyHoldersResponse, error)
+	// SyncPeers triggers an immediate peer discovery round (rate limited)
+	SyncPeers(ctx context.Context, in *SyncPeersRequest, opts ...grpc.CallOption) (*SyncPeersResponse, error)
+	// GetNodeAddress returns the known addresses of a single node
+	GetNodeAddress(ctx context.Context, in *GetNodeAddressRequest, opts ...grpc.CallOption) (*GetNodeAddressResponse, error)
+	// GetNetworkAddresses lists this node and the peers it is connected to
+	GetNetworkAddresses(ctx context.Context, in *GetNetworkAddressesRequest, opts ...grpc.CallOption) (*GetNetworkAddressesResponse, error)
+	// SetMaintenanceMode pauses or resumes accepting new operations on this node
+	SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*SetMaintenanceModeResponse, error)
+	// UpdateKeyPolicy replaces the signing policy of a key on this node
+	UpdateKeyPolicy(ctx context.Context, in *UpdateKeyPolicyRequest, opts ...grpc.CallOption) (*UpdateKeyPolicyResponse, error)
+	// GetNodeInfo returns the identity and capabilities of this node
+	GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoResponse, error)
 }
 
 type tSSServiceClient struct {
@@ -71,6 +107,16 @@ func (c *tSSServiceClient) StartSigning(ctx context.Context, in *StartSigningReq
 	return out, nil
 }
 
+func (c *tSSServiceClient) SignTypedData(ctx context.Context, in *SignTypedDataRequest, opts ...grpc.CallOption) (*StartSigningResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartSigningResponse)
+	err := c.cc.Invoke(ctx, TSSService_SignTypedData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tSSServiceClient) StartResharing(ctx context.Context, in *StartResharingRequest, opts ...grpc.CallOption) (*StartResharingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartResharingResponse)
@@ -81,6 +127,16 @@ func (c *tSSServiceClient) StartResharing(ctx context.Context, in *StartResharin
 	return out, nil
 }
 
+func (c *tSSServiceClient) RefreshShares(ctx context.Context, in *RefreshSharesRequest, opts ...grpc.CallOption) (*StartResharingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartResharingResponse)
+	err := c.cc.Invoke(ctx, TSSService_RefreshShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tSSServiceClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetOperationResponse)
@@ -91,6 +147,26 @@ func (c *tSSServiceClient) GetOperation(ctx context.Context, in *GetOperationReq
 	return out, nil
 }
 
+func (c *tSSServiceClient) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOperationsResponse)
+	err := c.cc.Invoke(ctx, TSSService_GetOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, TSSService_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tSSServiceClient) GetKeyMetadata(ctx context.Context, in *GetKeyMetadataRequest, opts ...grpc.CallOption) (*GetKeyMetadataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetKeyMetadataResponse)
@@ -101,6 +177,86 @@ func (c *tSSServiceClient) GetKeyMetadata(ctx context.Context, in *GetKeyMetadat
 	return out, nil
 }
 
+func (c *tSSServiceClient) HasKey(ctx context.Context, in *HasKeyRequest, opts ...grpc.CallOption) (*HasKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HasKeyResponse)
+	err := c.cc.Invoke(ctx, TSSService_HasKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) FindKeyHolders(ctx context.Context, in *FindKeyHoldersRequest, opts ...grpc.CallOption) (*FindKeyHoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindKeyHoldersResponse)
+	err := c.cc.Invoke(ctx, TSSService_FindKeyHolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) SyncPeers(ctx context.Context, in *SyncPeersRequest, opts ...grpc.CallOption) (*SyncPeersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncPeersResponse)
+	err := c.cc.Invoke(ctx, TSSService_SyncPeers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) GetNodeAddress(ctx context.Context, in *GetNodeAddressRequest, opts ...grpc.CallOption) (*GetNodeAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNodeAddressResponse)
+	err := c.cc.Invoke(ctx, TSSService_GetNodeAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) GetNetworkAddresses(ctx context.Context, in *GetNetworkAddressesRequest, opts ...grpc.CallOption) (*GetNetworkAddressesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNetworkAddressesResponse)
+	err := c.cc.Invoke(ctx, TSSService_GetNetworkAddresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*SetMaintenanceModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMaintenanceModeResponse)
+	err := c.cc.Invoke(ctx, TSSService_SetMaintenanceMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) UpdateKeyPolicy(ctx context.Context, in *UpdateKeyPolicyRequest, opts ...grpc.CallOption) (*UpdateKeyPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateKeyPolicyResponse)
+	err := c.cc.Invoke(ctx, TSSService_UpdateKeyPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tSSServiceClient) GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNodeInfoResponse)
+	err := c.cc.Invoke(ctx, TSSService_GetNodeInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TSSServiceServer is the server API for TSSService service.
 // All implementations must embed UnimplementedTSSServiceServer
 // for forward compatibility.
@@ -111,11 +267,35 @@ type TSSServiceServer interface {
 	StartKeygen(context.Context, *StartKeygenRequest) (*StartKeygenResponse, error)
 	// StartSigning starts a new signing operation
 	StartSigning(context.Context, *StartSigningRequest) (*StartSigningResponse, error)
+	// SignTypedData starts a new signing operation over the EIP-712 hash of typed data
+	SignTypedData(context.Context, *SignTypedDataRequest) (*StartSigningResponse, error)
 	// StartResharing starts a new resharing operation
 	StartResharing(context.Context, *StartResharingRequest) (*StartResharingResponse, error)
+	// RefreshShares refreshes the shares of a key, resharing to the same participants and threshold
+	RefreshShares(context.Context, *RefreshSharesRequest) (*StartResharingResponse, error)
 	// GetOperation gets the status and result of an operation
 	GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error)
+	// GetOperations gets the status and result of several operations in one request
+	GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error)
+	// ListOperations lists the operations of this node, optionally filtered by labels
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
 	GetKeyMetadata(context.Context, *GetKeyMetadataRequest) (*GetKeyMetadataResponse, error)
+	// HasKey reports whether this node knows a key and holds a share of it
+	HasKey(context.Context, *HasKeyRequest) (*HasKeyResponse, error)
+	// FindKeyHolders asks the nodes reachable from this node which of them hold a share of a key
+	FindKeyHolders(context.Context, *FindKeyHoldersRequest) (*FindKeyHoldersResponse, error)
+	// SyncPeers triggers an immediate peer discovery round (rate limited)
+	SyncPeers(context.Context, *SyncPeersRequest) (*SyncPeersResponse, error)
+	// GetNodeAddress returns the known addresses of a single node
+	GetNodeAddress(context.Context, *GetNodeAddressRequest) (*GetNodeAddressResponse, error)
+	// GetNetworkAddresses lists this node and the peers it is connected to
+	GetNetworkAddresses(context.Context, *GetNetworkAddressesRequest) (*GetNetworkAddressesResponse, error)
+	// SetMaintenanceMode pauses or resumes accepting new operations on this node
+	SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*SetMaintenanceModeResponse, error)
+	// UpdateKeyPolicy replaces the signing policy of a key on this node
+	UpdateKeyPolicy(context.Context, *UpdateKeyPolicyRequest) (*UpdateKeyPolicyResponse, error)
+	// GetNodeInfo returns the identity and capabilities of this node
+	GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoResponse, error)
 	mustEmbedUnimplementedTSSServiceServer()
 }
 
@@ -132,15 +312,51 @@ func (UnimplementedTSSServiceServer) StartKeygen(context.Context, *StartKeygenRe
 func (UnimplementedTSSServiceServer) StartSigning(context.Context, *StartSigningRequest) (*StartSigningResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartSigning not implemented")
 }
+func (UnimplementedTSSServiceServer) SignTypedData(context.Context, *SignTypedDataRequest) (*StartSigningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignTypedData not implemented")
+}
 func (UnimplementedTSSServiceServer) StartResharing(context.Context, *StartResharingRequest) (*StartResharingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartResharing not implemented")
 }
+func (UnimplementedTSSServiceServer) RefreshShares(context.Context, *RefreshSharesRequest) (*StartResharingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshShares not implemented")
+}
 func (UnimplementedTSSServiceServer) GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
 }
+func (UnimplementedTSSServiceServer) GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperations not implemented")
+}
+func (UnimplementedTSSServiceServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
 func (UnimplementedTSSServiceServer) GetKeyMetadata(context.Context, *GetKeyMetadataRequest) (*GetKeyMetadataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKeyMetadata not implemented")
 }
+func (UnimplementedTSSServiceServer) HasKey(context.Context, *HasKeyRequest) (*HasKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasKey not implemented")
+}
+func (UnimplementedTSSServiceServer) FindKeyHolders(context.Context, *FindKeyHoldersRequest) (*FindKeyHoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindKeyHolders not implemented")
+}
+func (UnimplementedTSSServiceServer) SyncPeers(context.Context, *SyncPeersRequest) (*SyncPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncPeers not implemented")
+}
+func (UnimplementedTSSServiceServer) GetNodeAddress(context.Context, *GetNodeAddressRequest) (*GetNodeAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeAddress not implemented")
+}
+func (UnimplementedTSSServiceServer) GetNetworkAddresses(context.Context, *GetNetworkAddressesRequest) (*GetNetworkAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkAddresses not implemented")
+}
+func (UnimplementedTSSServiceServer) SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*SetMaintenanceModeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenanceMode not implemented")
+}
+func (UnimplementedTSSServiceServer) UpdateKeyPolicy(context.Context, *UpdateKeyPolicyRequest) (*UpdateKeyPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateKeyPolicy not implemented")
+}
+func (UnimplementedTSSServiceServer) GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
+}
 func (UnimplementedTSSServiceServer) mustEmbedUnimplementedTSSServiceServer() {}
 func (UnimplementedTSSServiceServer) testEmbeddedByValue()                    {}
 
@@ -198,6 +414,24 @@ func _TSSService_StartSigning_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TSSService_SignTypedData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignTypedDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).SignTypedData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_SignTypedData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).SignTypedData(ctx, req.(*SignTypedDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TSSService_StartResharing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartResharingRequest)
 	if err := dec(in); err != nil {
@@ -216,6 +450,24 @@ func _TSSService_StartResharing_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TSSService_RefreshShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).RefreshShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_RefreshShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).RefreshShares(ctx, req.(*RefreshSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TSSService_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOperationRequest)
 	if err := dec(in); err != nil {
@@ -234,6 +486,42 @@ func _TSSService_GetOperation_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TSSService_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).GetOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_GetOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).GetOperations(ctx, req.(*GetOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TSSService_GetKeyMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetKeyMetadataRequest)
 	if err := dec(in); err != nil {
@@ -252,6 +540,150 @@ func _TSSService_GetKeyMetadata_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TSSService_HasKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).HasKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_HasKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).HasKey(ctx, req.(*HasKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_FindKeyHolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindKeyHoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).FindKeyHolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_FindKeyHolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).FindKeyHolders(ctx, req.(*FindKeyHoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_SyncPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).SyncPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_SyncPeers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).SyncPeers(ctx, req.(*SyncPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_GetNodeAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).GetNodeAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_GetNodeAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).GetNodeAddress(ctx, req.(*GetNodeAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_GetNetworkAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).GetNetworkAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_GetNetworkAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).GetNetworkAddresses(ctx, req.(*GetNetworkAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_SetMaintenanceMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).SetMaintenanceMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_SetMaintenanceMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).SetMaintenanceMode(ctx, req.(*SetMaintenanceModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_UpdateKeyPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateKeyPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).UpdateKeyPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_UpdateKeyPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).UpdateKeyPolicy(ctx, req.(*UpdateKeyPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TSSService_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TSSServiceServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TSSService_GetNodeInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TSSServiceServer).GetNodeInfo(ctx, req.(*GetNodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TSSService_ServiceDesc is the grpc.ServiceDesc for TSSService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,18 +699,66 @@ var TSSService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartSigning",
 			Handler:    _TSSService_StartSigning_Handler,
 		},
+		{
+			MethodName: "SignTypedData",
+			Handler:    _TSSService_SignTypedData_Handler,
+		},
 		{
 			MethodName: "StartResharing",
 			Handler:    _TSSService_StartResharing_Handler,
 		},
+		{
+			MethodName: "RefreshShares",
+			Handler:    _TSSService_RefreshShares_Handler,
+		},
 		{
 			MethodName: "GetOperation",
 			Handler:    _TSSService_GetOperation_Handler,
 		},
+		{
+			MethodName: "GetOperations",
+			Handler:    _TSSService_GetOperations_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _TSSService_ListOperations_Handler,
+		},
 		{
 			MethodName: "GetKeyMetadata",
 			Handler:    _TSSService_GetKeyMetadata_Handler,
 		},
+		{
+			MethodName: "HasKey",
+			Handler:    _TSSService_HasKey_Handler,
+		},
+		{
+			MethodName: "FindKeyHolders",
+			Handler:    _TSSService_FindKeyHolders_Handler,
+		},
+		{
+			MethodName: "SyncPeers",
+			Handler:    _TSSService_SyncPeers_Handler,
+		},
+		{
+			MethodName: "GetNodeAddress",
+			Handler:    _TSSService_GetNodeAddress_Handler,
+		},
+		{
+			MethodName: "GetNetworkAddresses",
+			Handler:    _TSSService_GetNetworkAddresses_Handler,
+		},
+		{
+			MethodName: "SetMaintenanceMode",
+			Handler:    _TSSService_SetMaintenanceMode_Handler,
+		},
+		{
+			MethodName: "UpdateKeyPolicy",
+			Handler:    _TSSService_UpdateKeyPolicy_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _TSSService_GetNodeInfo_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/tss/v1/tss.proto",
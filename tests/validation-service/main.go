@@ -4,10 +4,21 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 	"time"
+
+	"github.com/dreamer-zq/DKNet/pkg/validation"
+)
+
+var (
+	// requireSignature rejects requests without a valid node signature
+	requireSignature = os.Getenv("VALIDATION_REQUIRE_SIGNATURE") == "true"
+	// signingSecret verifies HMAC signed requests (libp2p signatures need no secret)
+	signingSecret = []byte(os.Getenv("VALIDATION_SIGNING_SECRET"))
 )
 
 // ValidationRequest represents the request from TSS node
@@ -120,13 +131,35 @@ func validateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the raw body, the signature covers the exact bytes sent by the node
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to read body: %v", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parse request body
 	var req ValidationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
 		return
 	}
 
+	// Verify the request came from the node it claims to come from
+	if requireSignature || r.Header.Get(validation.HeaderSignature) != "" {
+		nodeID, err := validation.VerifyRequest(r.Header, body, signingSecret)
+		if err != nil {
+			log.Printf("REJECTED unauthenticated request: %v", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if nodeID != req.NodeID {
+			log.Printf("REJECTED request: signed by %s but claims node %s", nodeID, req.NodeID)
+			http.Error(w, "node ID does not match signature", http.StatusUnauthorized)
+			return
+		}
+	}
+
 	// Log the incoming request
 	log.Printf("Received validation request from node %s for key %s", req.NodeID, req.KeyID)
 